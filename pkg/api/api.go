@@ -529,6 +529,7 @@ func (hs *HTTPServer) registerRoutes() {
 				liveRoute.Post("/write-configs", routing.Wrap(hs.Live.HandleWriteConfigsPostHTTP), reqOrgAdmin)
 				liveRoute.Put("/write-configs", routing.Wrap(hs.Live.HandleWriteConfigsPutHTTP), reqOrgAdmin)
 				liveRoute.Delete("/write-configs", routing.Wrap(hs.Live.HandleWriteConfigsDeleteHTTP), reqOrgAdmin)
+				liveRoute.Get("/input-configs", routing.Wrap(hs.Live.HandleInputConfigsListHTTP), reqOrgAdmin)
 			}
 		})
 
@@ -94,6 +94,10 @@ func (hs *HTTPServer) GetDashboard(c *models.ReqContext) response.Response {
 	if canView, err := guardian.CanView(); err != nil || !canView {
 		return dashboardGuardianResponse(err)
 	}
+	if hs.searchV2Service != nil {
+		// The views rank the search results
+		hs.searchV2Service.RecordDashboardView(c.OrgId, c.UserId, dash.Uid)
+	}
 	canEdit, _ := guardian.CanEdit()
 	canSave, _ := guardian.CanSave()
 	canAdmin, _ := guardian.CanAdmin()
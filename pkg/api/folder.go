@@ -120,6 +120,7 @@ func (hs *HTTPServer) DeleteFolder(c *models.ReqContext) response.Response { //
 	if err != nil {
 		return apierrors.ToFolderErrorResponse(err)
 	}
+	// The search index removes the alert rules deleted with the folder on this event as well.
 	if hs.entityEventsService != nil {
 		if err := hs.entityEventsService.SaveEvent(c.Req.Context(), store.SaveEventCmd{
 			EntityId:  store.CreateDatabaseEntityId(uid, c.OrgId, store.EntityTypeFolder),
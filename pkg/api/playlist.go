@@ -7,6 +7,7 @@ import (
 
 	"github.com/grafana/grafana/pkg/api/response"
 	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/store"
 	"github.com/grafana/grafana/pkg/web"
 )
 
@@ -152,6 +153,7 @@ func (hs *HTTPServer) DeletePlaylist(c *models.ReqContext) response.Response {
 	if err := hs.SQLStore.DeletePlaylist(c.Req.Context(), &cmd); err != nil {
 		return response.Error(500, "Failed to delete playlist", err)
 	}
+	hs.savePlaylistEntityEvent(c.Req.Context(), c.OrgId, id, store.EntityEventTypeDelete)
 
 	return response.JSON(http.StatusOK, "")
 }
@@ -166,6 +168,7 @@ func (hs *HTTPServer) CreatePlaylist(c *models.ReqContext) response.Response {
 	if err := hs.SQLStore.CreatePlaylist(c.Req.Context(), &cmd); err != nil {
 		return response.Error(500, "Failed to create playlist", err)
 	}
+	hs.savePlaylistEntityEvent(c.Req.Context(), c.OrgId, cmd.Result.Id, store.EntityEventTypeCreate)
 
 	return response.JSON(http.StatusOK, cmd.Result)
 }
@@ -185,6 +188,7 @@ func (hs *HTTPServer) UpdatePlaylist(c *models.ReqContext) response.Response {
 	if err := hs.SQLStore.UpdatePlaylist(c.Req.Context(), &cmd); err != nil {
 		return response.Error(500, "Failed to save playlist", err)
 	}
+	hs.savePlaylistEntityEvent(c.Req.Context(), c.OrgId, cmd.Id, store.EntityEventTypeUpdate)
 
 	playlistDTOs, err := hs.LoadPlaylistItemDTOs(c.Req.Context(), cmd.Id)
 	if err != nil {
@@ -194,3 +198,18 @@ func (hs *HTTPServer) UpdatePlaylist(c *models.ReqContext) response.Response {
 	cmd.Result.Items = playlistDTOs
 	return response.JSON(http.StatusOK, cmd.Result)
 }
+
+// savePlaylistEntityEvent records a change of a playlist so that the search index can be updated.
+// Playlists do not have UIDs, so they are identified by their ID.
+func (hs *HTTPServer) savePlaylistEntityEvent(ctx context.Context, orgID int64, id int64, eventType store.EntityEventType) {
+	if hs.entityEventsService == nil {
+		return
+	}
+	uid := strconv.FormatInt(id, 10)
+	if err := hs.entityEventsService.SaveEvent(ctx, store.SaveEventCmd{
+		EntityId:  store.CreateDatabaseEntityId(uid, orgID, store.EntityTypePlaylist),
+		EventType: eventType,
+	}); err != nil {
+		hs.log.Warn("failed to save playlist entity event", "id", id, "error", err)
+	}
+}
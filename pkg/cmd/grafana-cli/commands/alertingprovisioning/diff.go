@@ -0,0 +1,53 @@
+package alertingprovisioning
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+
+	"github.com/grafana/grafana/pkg/cmd/grafana-cli/logger"
+	"github.com/grafana/grafana/pkg/cmd/grafana-cli/runner"
+	"github.com/grafana/grafana/pkg/cmd/grafana-cli/utils"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/provisioning"
+	"github.com/grafana/grafana/pkg/services/ngalert/store"
+)
+
+// Diff prints the changes that the alerting provisioning files would make as JSON, without persisting anything.
+// If the exit-code flag is set, it fails when there are changes.
+func Diff(c utils.CommandLine, runner runner.Runner) error {
+	path := c.String("path")
+	if path == "" {
+		path = filepath.Join(runner.Cfg.ProvisioningPath, "alerting")
+	}
+	file, err := provisioning.ReadProvisioningFiles(path)
+	if err != nil {
+		return err
+	}
+
+	l := log.New("alerting.provisioning")
+	dbStore := &store.DBstore{
+		BaseInterval:    runner.Cfg.UnifiedAlerting.BaseInterval,
+		DefaultInterval: runner.Cfg.UnifiedAlerting.DefaultRuleEvaluationInterval,
+		SQLStore:        runner.SQLStore,
+		Logger:          l,
+	}
+	contactPoints := provisioning.NewContactPointService(dbStore, runner.SecretsService, dbStore, dbStore, l)
+	dryRun := provisioning.NewDryRunService(dbStore, contactPoints, int64(runner.Cfg.UnifiedAlerting.DefaultRuleEvaluationInterval.Seconds()), l)
+
+	diff, err := dryRun.Diff(context.Background(), file)
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(diff, "", "  ")
+	if err != nil {
+		return err
+	}
+	logger.Info(string(b) + "\n")
+
+	if c.Bool("exit-code") && diff.HasChanges() {
+		return errors.New("the alerting provisioning files would change the alerting resources")
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/fatih/color"
+	"github.com/grafana/grafana/pkg/cmd/grafana-cli/commands/alertingprovisioning"
 	"github.com/grafana/grafana/pkg/cmd/grafana-cli/commands/datamigrations"
 	"github.com/grafana/grafana/pkg/cmd/grafana-cli/commands/secretsmigrations"
 	"github.com/grafana/grafana/pkg/cmd/grafana-cli/logger"
@@ -189,6 +190,28 @@ var adminCommands = []*cli.Command{
 			},
 		},
 	},
+	{
+		Name:  "alerting-provisioning",
+		Usage: "Runs commands for alerting provisioning files. Grafana does not apply these files, they can only be compared with the stored resources",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "diff",
+				Usage:  "Prints the changes that the alerting provisioning files would make. Nothing is applied. Fails on errors, and with --exit-code also when there are changes.",
+				Action: runRunnerCommand(alertingprovisioning.Diff),
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "path",
+						Usage: "Path to the directory of the alerting provisioning files. Defaults to the alerting directory in the provisioning path",
+					},
+					&cli.BoolFlag{
+						Name:  "exit-code",
+						Usage: "Fail if the provisioning files would change anything",
+						Value: false,
+					},
+				},
+			},
+		},
+	},
 }
 
 var Commands = []*cli.Command{
@@ -286,6 +286,12 @@ const (
 	TypeResample
 	// TypeClassicConditions is the CMDType for the classic condition operation.
 	TypeClassicConditions
+	// TypeThreshold is the CMDType for checking if a threshold has been crossed.
+	TypeThreshold
+	// TypeLabels is the CMDType for changing the labels of a result.
+	TypeLabels
+	// TypeJoin is the CMDType for a binary operation between two results matched by labels.
+	TypeJoin
 )
 
 func (gt CommandType) String() string {
@@ -298,6 +304,12 @@ func (gt CommandType) String() string {
 		return "resample"
 	case TypeClassicConditions:
 		return "classic_conditions"
+	case TypeThreshold:
+		return "threshold"
+	case TypeLabels:
+		return "labels"
+	case TypeJoin:
+		return "join"
 	default:
 		return "unknown"
 	}
@@ -314,6 +326,12 @@ func ParseCommandType(s string) (CommandType, error) {
 		return TypeResample, nil
 	case "classic_conditions":
 		return TypeClassicConditions, nil
+	case "threshold":
+		return TypeThreshold, nil
+	case "labels":
+		return TypeLabels, nil
+	case "join":
+		return TypeJoin, nil
 	default:
 		return TypeUnknown, fmt.Errorf("'%v' is not a recognized expression type", s)
 	}
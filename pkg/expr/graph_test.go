@@ -225,6 +225,80 @@ func TestServicebuildPipeLine(t *testing.T) {
 			},
 			expectedOrder: []string{"B", "A"},
 		},
+		{
+			name: "threshold requires reduce requires query",
+			req: &Request{
+				Queries: []Query{
+					{
+						RefID:      "A",
+						DataSource: DataSourceModel(),
+						JSON: json.RawMessage(`{
+							"expression": "$B",
+							"type": "threshold",
+							"conditions": [
+								{
+									"evaluator": {
+										"params": [80],
+										"type": "gt"
+									}
+								}
+							]
+						}`),
+					},
+					{
+						RefID:      "B",
+						DataSource: DataSourceModel(),
+						JSON: json.RawMessage(`{
+							"expression": "C",
+							"reducer": "mean",
+							"type": "reduce"
+						}`),
+					},
+					{
+						RefID: "C",
+						DataSource: &models.DataSource{
+							Uid: "Fake",
+						},
+					},
+				},
+			},
+			expectedOrder: []string{"C", "B", "A"},
+		},
+		{
+			name: "join requires both inputs",
+			req: &Request{
+				Queries: []Query{
+					{
+						RefID:      "A",
+						DataSource: DataSourceModel(),
+						JSON: json.RawMessage(`{
+							"left": "$B",
+							"right": "$C",
+							"operator": "+",
+							"matching": "on",
+							"labels": ["host"],
+							"type": "join"
+						}`),
+					},
+					{
+						RefID:      "B",
+						DataSource: DataSourceModel(),
+						JSON: json.RawMessage(`{
+							"expression": "$C",
+							"operations": [{"action": "drop", "labels": ["job"]}],
+							"type": "labels"
+						}`),
+					},
+					{
+						RefID: "C",
+						DataSource: &models.DataSource{
+							Uid: "Fake",
+						},
+					},
+				},
+			},
+			expectedOrder: []string{"C", "B", "A"},
+		},
 	}
 	s := Service{}
 	for _, tt := range tests {
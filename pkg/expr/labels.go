@@ -0,0 +1,175 @@
+package expr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+)
+
+// LabelsCommand is an expression command that renames, copies, replaces or drops labels of its input.
+type LabelsCommand struct {
+	ReferenceVar string
+	Operations   []mathexp.LabelOperation
+	refID        string
+}
+
+// NewLabelsCommand creates a new LabelsCommand.
+func NewLabelsCommand(refID, referenceVar string, operations []mathexp.LabelOperation) (*LabelsCommand, error) {
+	if len(operations) == 0 {
+		return nil, fmt.Errorf("no label operations specified")
+	}
+	for i := range operations {
+		if err := operations[i].Validate(); err != nil {
+			return nil, fmt.Errorf("label operation %v: %w", i+1, err)
+		}
+	}
+	return &LabelsCommand{
+		ReferenceVar: referenceVar,
+		Operations:   operations,
+		refID:        refID,
+	}, nil
+}
+
+// UnmarshalLabelsCommand creates a LabelsCommand from Grafana's frontend query.
+func UnmarshalLabelsCommand(rn *rawNode) (*LabelsCommand, error) {
+	referenceVar, err := getReferenceVar(rn, "expression")
+	if err != nil {
+		return nil, err
+	}
+
+	jsonFromM, err := json.Marshal(rn.Query["operations"])
+	if err != nil {
+		return nil, fmt.Errorf("failed to remarshal label operations: %w", err)
+	}
+	var operations []mathexp.LabelOperation
+	if err = json.Unmarshal(jsonFromM, &operations); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal remarshaled label operations: %w", err)
+	}
+
+	cmd, err := NewLabelsCommand(rn.RefID, referenceVar, operations)
+	if err != nil {
+		return nil, fmt.Errorf("invalid labels command in '%v': %w", rn.RefID, err)
+	}
+	return cmd, nil
+}
+
+// NeedsVars returns the variable names (refIds) that are dependencies
+// to execute the command and allows the command to fulfill the Command interface.
+func (lc *LabelsCommand) NeedsVars() []string {
+	return []string{lc.ReferenceVar}
+}
+
+// Execute runs the command and returns the results or an error if the command
+// failed to execute.
+func (lc *LabelsCommand) Execute(_ context.Context, vars mathexp.Vars) (mathexp.Results, error) {
+	return mathexp.Relabel(lc.refID, vars[lc.ReferenceVar], lc.Operations)
+}
+
+const (
+	joinMatchingOn       = "on"
+	joinMatchingIgnoring = "ignoring"
+)
+
+// JoinCommand is an expression command that applies a binary operator between two inputs,
+// pairing their values explicitly by a subset of labels instead of by the implicit label union
+// used by math expressions.
+type JoinCommand struct {
+	Left     string
+	Right    string
+	Operator string
+	Matching mathexp.LabelMatching
+	refID    string
+}
+
+// NewJoinCommand creates a new JoinCommand.
+func NewJoinCommand(refID, left, right, operator string, matching mathexp.LabelMatching) (*JoinCommand, error) {
+	if !mathexp.IsBinaryOperator(operator) {
+		return nil, fmt.Errorf("operator '%v' is not supported", operator)
+	}
+	return &JoinCommand{
+		Left:     left,
+		Right:    right,
+		Operator: operator,
+		Matching: matching,
+		refID:    refID,
+	}, nil
+}
+
+// UnmarshalJoinCommand creates a JoinCommand from Grafana's frontend query.
+func UnmarshalJoinCommand(rn *rawNode) (*JoinCommand, error) {
+	left, err := getReferenceVar(rn, "left")
+	if err != nil {
+		return nil, err
+	}
+	right, err := getReferenceVar(rn, "right")
+	if err != nil {
+		return nil, err
+	}
+
+	rawOperator, ok := rn.Query["operator"]
+	if !ok {
+		return nil, fmt.Errorf("no operator specified for refId %v", rn.RefID)
+	}
+	operator, ok := rawOperator.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected operator to be a string, got %T for refId %v", rawOperator, rn.RefID)
+	}
+
+	matching := mathexp.LabelMatching{}
+	if rawMatching, ok := rn.Query["matching"]; ok {
+		switch rawMatching {
+		case joinMatchingOn:
+			matching.On = true
+		case joinMatchingIgnoring, "":
+		default:
+			return nil, fmt.Errorf("label matching %v is not supported for refId %v. Supported only: [%s,%s]", rawMatching, rn.RefID, joinMatchingOn, joinMatchingIgnoring)
+		}
+	}
+	if rawLabels, ok := rn.Query["labels"]; ok {
+		labels, ok := rawLabels.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected labels to be a list of strings, got %T for refId %v", rawLabels, rn.RefID)
+		}
+		for _, l := range labels {
+			name, ok := l.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected labels to be a list of strings, got %T in the list for refId %v", l, rn.RefID)
+			}
+			matching.Labels = append(matching.Labels, name)
+		}
+	}
+
+	cmd, err := NewJoinCommand(rn.RefID, left, right, operator, matching)
+	if err != nil {
+		return nil, fmt.Errorf("invalid join command in '%v': %w", rn.RefID, err)
+	}
+	return cmd, nil
+}
+
+// NeedsVars returns the variable names (refIds) that are dependencies
+// to execute the command and allows the command to fulfill the Command interface.
+func (jc *JoinCommand) NeedsVars() []string {
+	return []string{jc.Left, jc.Right}
+}
+
+// Execute runs the command and returns the results or an error if the command
+// failed to execute.
+func (jc *JoinCommand) Execute(_ context.Context, vars mathexp.Vars) (mathexp.Results, error) {
+	return mathexp.Join(jc.refID, jc.Operator, vars[jc.Left], vars[jc.Right], jc.Matching)
+}
+
+// getReferenceVar returns the refID in the given field of the query, without a leading $.
+func getReferenceVar(rn *rawNode, field string) (string, error) {
+	rawVar, ok := rn.Query[field]
+	if !ok {
+		return "", fmt.Errorf("no variable specified in %v for refId %v", field, rn.RefID)
+	}
+	referenceVar, ok := rawVar.(string)
+	if !ok {
+		return "", fmt.Errorf("expected %v to be a string, got %T for refId %v", field, rawVar, rn.RefID)
+	}
+	return strings.TrimPrefix(referenceVar, "$"), nil
+}
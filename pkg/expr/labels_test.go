@@ -0,0 +1,120 @@
+package expr
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+)
+
+func TestUnmarshalLabelsCommand(t *testing.T) {
+	var tests = []struct {
+		name    string
+		query   string
+		isError bool
+	}{
+		{
+			name:  "valid operations",
+			query: `{"expression": "$B", "operations": [{"action": "rename", "sourceLabel": "instance", "targetLabel": "host"}, {"action": "drop", "labels": ["job"]}]}`,
+		},
+		{
+			name:    "error when operations are missing",
+			query:   `{"expression": "$B"}`,
+			isError: true,
+		},
+		{
+			name:    "error when an operation is invalid",
+			query:   `{"expression": "$B", "operations": [{"action": "replace", "targetLabel": "host", "regex": "("}]}`,
+			isError: true,
+		},
+		{
+			name:    "error when expression is missing",
+			query:   `{"operations": [{"action": "drop", "labels": ["job"]}]}`,
+			isError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var qmap = make(map[string]interface{})
+			require.NoError(t, json.Unmarshal([]byte(test.query), &qmap))
+
+			cmd, err := UnmarshalLabelsCommand(&rawNode{RefID: "A", Query: qmap})
+			if test.isError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, []string{"B"}, cmd.NeedsVars())
+		})
+	}
+}
+
+func TestUnmarshalJoinCommand(t *testing.T) {
+	var tests = []struct {
+		name     string
+		query    string
+		isError  bool
+		expected *JoinCommand
+	}{
+		{
+			name:  "on labels",
+			query: `{"left": "$B", "right": "C", "operator": "/", "matching": "on", "labels": ["host"]}`,
+			expected: &JoinCommand{
+				Left:     "B",
+				Right:    "C",
+				Operator: "/",
+				Matching: mathexp.LabelMatching{On: true, Labels: []string{"host"}},
+				refID:    "A",
+			},
+		},
+		{
+			name:  "ignoring is the default",
+			query: `{"left": "$B", "right": "$C", "operator": ">", "labels": ["job"]}`,
+			expected: &JoinCommand{
+				Left:     "B",
+				Right:    "C",
+				Operator: ">",
+				Matching: mathexp.LabelMatching{Labels: []string{"job"}},
+				refID:    "A",
+			},
+		},
+		{
+			name:    "error when operator is unknown",
+			query:   `{"left": "$B", "right": "$C", "operator": "<>"}`,
+			isError: true,
+		},
+		{
+			name:    "error when matching is unknown",
+			query:   `{"left": "$B", "right": "$C", "operator": "+", "matching": "group_left"}`,
+			isError: true,
+		},
+		{
+			name:    "error when labels are not strings",
+			query:   `{"left": "$B", "right": "$C", "operator": "+", "labels": [1]}`,
+			isError: true,
+		},
+		{
+			name:    "error when right is missing",
+			query:   `{"left": "$B", "operator": "+"}`,
+			isError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var qmap = make(map[string]interface{})
+			require.NoError(t, json.Unmarshal([]byte(test.query), &qmap))
+
+			cmd, err := UnmarshalJoinCommand(&rawNode{RefID: "A", Query: qmap})
+			if test.isError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expected, cmd)
+		})
+	}
+}
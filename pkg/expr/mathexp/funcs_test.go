@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/grafana/grafana-plugin-sdk-go/data"
 	"github.com/stretchr/testify/require"
 )
 
@@ -159,3 +160,107 @@ func TestIsNumberFunc(t *testing.T) {
 		})
 	}
 }
+
+func TestMinMaxClampFuncs(t *testing.T) {
+	var tests = []struct {
+		name      string
+		expr      string
+		vars      Vars
+		newErrIs  require.ErrorAssertionFunc
+		execErrIs require.ErrorAssertionFunc
+		results   Results
+	}{
+		{
+			name:      "min on scalars",
+			expr:      "min(1, 2)",
+			vars:      Vars{},
+			newErrIs:  require.NoError,
+			execErrIs: require.NoError,
+			results:   Results{[]Value{NewScalar("", float64Pointer(1))}},
+		},
+		{
+			name: "max on numbers uses label union",
+			expr: "max($A, $B)",
+			vars: Vars{
+				"A": Results{[]Value{
+					makeNumber("", data.Labels{"host": "a"}, float64Pointer(1)),
+					makeNumber("", data.Labels{"host": "b"}, float64Pointer(5)),
+				}},
+				"B": Results{[]Value{
+					makeNumber("", data.Labels{"host": "a"}, float64Pointer(3)),
+					makeNumber("", data.Labels{"host": "b"}, float64Pointer(4)),
+				}},
+			},
+			newErrIs:  require.NoError,
+			execErrIs: require.NoError,
+			results: Results{[]Value{
+				makeNumber("", data.Labels{"host": "a"}, float64Pointer(3)),
+				makeNumber("", data.Labels{"host": "b"}, float64Pointer(5)),
+			}},
+		},
+		{
+			name: "min of scalar and series returns series",
+			expr: "min(2, $A)",
+			vars: Vars{
+				"A": Results{[]Value{
+					makeSeries("", nil,
+						tp{time.Unix(5, 0), float64Pointer(1)},
+						tp{time.Unix(10, 0), float64Pointer(3)}),
+				}},
+			},
+			newErrIs:  require.NoError,
+			execErrIs: require.NoError,
+			results: Results{[]Value{
+				makeSeries("", nil,
+					tp{time.Unix(5, 0), float64Pointer(1)},
+					tp{time.Unix(10, 0), float64Pointer(2)}),
+			}},
+		},
+		{
+			name: "clamp on series",
+			expr: "clamp($A, -1, 1)",
+			vars: Vars{
+				"A": Results{[]Value{
+					makeSeries("", nil,
+						tp{time.Unix(5, 0), float64Pointer(-3)},
+						tp{time.Unix(10, 0), float64Pointer(0.5)},
+						tp{time.Unix(15, 0), float64Pointer(7)}),
+				}},
+			},
+			newErrIs:  require.NoError,
+			execErrIs: require.NoError,
+			results: Results{[]Value{
+				makeSeries("", nil,
+					tp{time.Unix(5, 0), float64Pointer(-1)},
+					tp{time.Unix(10, 0), float64Pointer(0.5)},
+					tp{time.Unix(15, 0), float64Pointer(1)}),
+			}},
+		},
+		{
+			name:      "clamp with inverted bounds should error",
+			expr:      "clamp(5, 1, 0)",
+			vars:      Vars{},
+			newErrIs:  require.NoError,
+			execErrIs: require.Error,
+		},
+		{
+			name:     "clamp with series bound should error",
+			expr:     "clamp(5, $A, 1)",
+			vars:     Vars{},
+			newErrIs: require.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := New(tt.expr)
+			tt.newErrIs(t, err)
+			if e != nil {
+				res, err := e.Execute("", tt.vars)
+				tt.execErrIs(t, err)
+				if err == nil {
+					require.Equal(t, tt.results, res)
+				}
+			}
+		})
+	}
+}
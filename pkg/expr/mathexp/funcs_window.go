@@ -0,0 +1,206 @@
+package mathexp
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend/gtime"
+)
+
+// rate returns the per-second rate of increase between consecutive points of each Series in the SeriesSet.
+// The values are treated as a counter, so a decrease is considered a counter reset.
+// The first point of each series has no previous point and is set to null.
+func rate(e *State, varSet Results) (Results, error) {
+	return perSeries(e, "rate", varSet, func(s Series) (Series, error) {
+		return pairwise(e.RefID, s, func(prevT time.Time, prev float64, t time.Time, cur float64) float64 {
+			secs := t.Sub(prevT).Seconds()
+			if secs <= 0 {
+				return math.NaN()
+			}
+			return counterIncrease(prev, cur) / secs
+		}), nil
+	})
+}
+
+// delta returns a Number for each Series in the SeriesSet, with the difference between the last
+// and the first non-null point. Unlike rate, the values are not treated as a counter, so a
+// decreasing series has a negative delta. Series with less than two non-null points have a null delta.
+func delta(e *State, varSet Results) (Results, error) {
+	newRes := Results{}
+	for _, res := range varSet.Values {
+		s, ok := res.(Series)
+		if !ok {
+			return newRes, fmt.Errorf("delta: can only be applied to a series, got %v", res.Type())
+		}
+		sorted := sortedByTime(e.RefID, s)
+
+		var first, last *float64
+		points := 0
+		for i := 0; i < sorted.Len(); i++ {
+			_, f := sorted.GetPoint(i)
+			if f == nil {
+				continue
+			}
+			if first == nil {
+				first = f
+			}
+			last = f
+			points++
+		}
+
+		n := NewNumber(e.RefID, s.GetLabels())
+		if points > 1 {
+			d := *last - *first
+			n.SetValue(&d)
+		} else {
+			n.SetValue(nil)
+		}
+		newRes.Values = append(newRes.Values, n)
+	}
+	return newRes, nil
+}
+
+// diff returns the difference between consecutive points of each Series in the SeriesSet.
+// The first point of each series has no previous point and is set to null.
+func diff(e *State, varSet Results) (Results, error) {
+	return perSeries(e, "diff", varSet, func(s Series) (Series, error) {
+		return pairwise(e.RefID, s, func(_ time.Time, prev float64, _ time.Time, cur float64) float64 {
+			return cur - prev
+		}), nil
+	})
+}
+
+// cumsum returns the running total of each Series in the SeriesSet.
+// Null points remain null and do not contribute to the total.
+func cumsum(e *State, varSet Results) (Results, error) {
+	return perSeries(e, "cumsum", varSet, func(s Series) (Series, error) {
+		newSeries := NewSeries(e.RefID, s.GetLabels(), s.Len())
+		sum := float64(0)
+		for i := 0; i < s.Len(); i++ {
+			t, f := s.GetPoint(i)
+			if f == nil {
+				newSeries.SetPoint(i, t, nil)
+				continue
+			}
+			sum += *f
+			nF := sum
+			newSeries.SetPoint(i, t, &nF)
+		}
+		return newSeries, nil
+	})
+}
+
+// movingAvg returns the average of the last n points (including the current one) for each point of each
+// Series in the SeriesSet. Null points are excluded from the average, and if all points in the window are
+// null the result is null.
+func movingAvg(e *State, varSet Results, window Results) (Results, error) {
+	w, err := scalarArg("moving_avg", window)
+	if err != nil {
+		return Results{}, err
+	}
+	if w < 1 || w != math.Trunc(w) {
+		return Results{}, fmt.Errorf("moving_avg: window must be a positive integer, got %v", w)
+	}
+	n := int(w)
+	return perSeries(e, "moving_avg", varSet, func(s Series) (Series, error) {
+		newSeries := NewSeries(e.RefID, s.GetLabels(), s.Len())
+		for i := 0; i < s.Len(); i++ {
+			sum := float64(0)
+			count := 0
+			for j := i - n + 1; j <= i; j++ {
+				if j < 0 {
+					continue
+				}
+				if f := s.GetValue(j); f != nil {
+					sum += *f
+					count++
+				}
+			}
+			if count == 0 {
+				newSeries.SetPoint(i, s.GetTime(i), nil)
+				continue
+			}
+			nF := sum / float64(count)
+			newSeries.SetPoint(i, s.GetTime(i), &nF)
+		}
+		return newSeries, nil
+	})
+}
+
+// shift moves each point of each Series in the SeriesSet forward in time by the duration (e.g. "1h" or "7d"),
+// so that a series can be compared against its own past values, e.g. $A - shift($A, "1d").
+func shift(e *State, varSet Results, duration string) (Results, error) {
+	d, err := gtime.ParseDuration(duration)
+	if err != nil {
+		return Results{}, fmt.Errorf("shift: failed to parse duration %q: %w", duration, err)
+	}
+	return perSeries(e, "shift", varSet, func(s Series) (Series, error) {
+		newSeries := NewSeries(e.RefID, s.GetLabels(), s.Len())
+		for i := 0; i < s.Len(); i++ {
+			t, f := s.GetPoint(i)
+			newSeries.SetPoint(i, t.Add(d), f)
+		}
+		return newSeries, nil
+	})
+}
+
+// perSeries passes a copy of each Series in varSet, sorted from oldest to newest, to seriesF.
+// It returns an error if varSet contains anything other than Series, since windowed functions
+// have no meaning for a Number or Scalar.
+func perSeries(e *State, funcName string, varSet Results, seriesF func(s Series) (Series, error)) (Results, error) {
+	newRes := Results{}
+	for _, res := range varSet.Values {
+		s, ok := res.(Series)
+		if !ok {
+			return newRes, fmt.Errorf("%s: can only be applied to a series, got %v", funcName, res.Type())
+		}
+		newSeries, err := seriesF(sortedByTime(e.RefID, s))
+		if err != nil {
+			return newRes, err
+		}
+		newRes.Values = append(newRes.Values, newSeries)
+	}
+	return newRes, nil
+}
+
+// sortedByTime returns a copy of s sorted from oldest to newest.
+func sortedByTime(refID string, s Series) Series {
+	sorted := NewSeries(refID, s.GetLabels(), s.Len())
+	for i := 0; i < s.Len(); i++ {
+		t, f := s.GetPoint(i)
+		sorted.SetPoint(i, t, f)
+	}
+	sorted.SortByTime(false)
+	return sorted
+}
+
+// pairwise returns a Series where each point is the result of pointF on that point and the point before it.
+// The first point, and any point where either value is null, is set to null.
+func pairwise(refID string, s Series, pointF func(prevT time.Time, prev float64, t time.Time, cur float64) float64) Series {
+	newSeries := NewSeries(refID, s.GetLabels(), s.Len())
+	for i := 0; i < s.Len(); i++ {
+		t, f := s.GetPoint(i)
+		if i == 0 || f == nil {
+			newSeries.SetPoint(i, t, nil)
+			continue
+		}
+		prevT, prev := s.GetPoint(i - 1)
+		if prev == nil {
+			newSeries.SetPoint(i, t, nil)
+			continue
+		}
+		nF := pointF(prevT, *prev, t, *f)
+		newSeries.SetPoint(i, t, &nF)
+	}
+	return newSeries
+}
+
+// counterIncrease returns the increase of a counter from prev to cur. If the counter
+// went down it is assumed to have been reset to zero in between.
+func counterIncrease(prev, cur float64) float64 {
+	if cur < prev {
+		return cur
+	}
+	return cur - prev
+}
@@ -0,0 +1,190 @@
+package mathexp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWindowFuncs(t *testing.T) {
+	counter := Vars{
+		"A": Results{
+			[]Value{
+				makeSeries("", data.Labels{"host": "a"},
+					tp{time.Unix(10, 0), float64Pointer(20)},
+					tp{time.Unix(0, 0), float64Pointer(0)},
+					tp{time.Unix(20, 0), float64Pointer(50)},
+					tp{time.Unix(30, 0), nil},
+					tp{time.Unix(40, 0), float64Pointer(10)},
+					tp{time.Unix(50, 0), float64Pointer(5)}),
+			},
+		},
+	}
+
+	var tests = []struct {
+		name      string
+		expr      string
+		vars      Vars
+		newErrIs  require.ErrorAssertionFunc
+		execErrIs require.ErrorAssertionFunc
+		results   Results
+	}{
+		{
+			name:      "diff sorts by time and drops first point",
+			expr:      "diff($A)",
+			vars:      counter,
+			newErrIs:  require.NoError,
+			execErrIs: require.NoError,
+			results: Results{[]Value{
+				makeSeries("", data.Labels{"host": "a"},
+					tp{time.Unix(0, 0), nil},
+					tp{time.Unix(10, 0), float64Pointer(20)},
+					tp{time.Unix(20, 0), float64Pointer(30)},
+					tp{time.Unix(30, 0), nil},
+					tp{time.Unix(40, 0), nil},
+					tp{time.Unix(50, 0), float64Pointer(-5)}),
+			}},
+		},
+		{
+			name:      "delta is last minus first non-null point",
+			expr:      "delta($A)",
+			vars:      counter,
+			newErrIs:  require.NoError,
+			execErrIs: require.NoError,
+			results: Results{[]Value{
+				makeNumber("", data.Labels{"host": "a"}, float64Pointer(5)),
+			}},
+		},
+		{
+			name: "delta of a decreasing gauge is negative",
+			expr: "delta($A)",
+			vars: Vars{
+				"A": Results{
+					[]Value{
+						makeSeries("", data.Labels{"host": "a"},
+							tp{time.Unix(0, 0), float64Pointer(50)},
+							tp{time.Unix(10, 0), float64Pointer(20)},
+							tp{time.Unix(20, 0), float64Pointer(30)}),
+						makeSeries("", data.Labels{"host": "b"},
+							tp{time.Unix(0, 0), float64Pointer(50)},
+							tp{time.Unix(10, 0), nil}),
+					},
+				},
+			},
+			newErrIs:  require.NoError,
+			execErrIs: require.NoError,
+			results: Results{[]Value{
+				makeNumber("", data.Labels{"host": "a"}, float64Pointer(-20)),
+				makeNumber("", data.Labels{"host": "b"}, nil),
+			}},
+		},
+		{
+			name:      "rate is per second",
+			expr:      "rate($A)",
+			vars:      counter,
+			newErrIs:  require.NoError,
+			execErrIs: require.NoError,
+			results: Results{[]Value{
+				makeSeries("", data.Labels{"host": "a"},
+					tp{time.Unix(0, 0), nil},
+					tp{time.Unix(10, 0), float64Pointer(2)},
+					tp{time.Unix(20, 0), float64Pointer(3)},
+					tp{time.Unix(30, 0), nil},
+					tp{time.Unix(40, 0), nil},
+					tp{time.Unix(50, 0), float64Pointer(0.5)}),
+			}},
+		},
+		{
+			name:      "cumsum skips nulls",
+			expr:      "cumsum($A)",
+			vars:      counter,
+			newErrIs:  require.NoError,
+			execErrIs: require.NoError,
+			results: Results{[]Value{
+				makeSeries("", data.Labels{"host": "a"},
+					tp{time.Unix(0, 0), float64Pointer(0)},
+					tp{time.Unix(10, 0), float64Pointer(20)},
+					tp{time.Unix(20, 0), float64Pointer(70)},
+					tp{time.Unix(30, 0), nil},
+					tp{time.Unix(40, 0), float64Pointer(80)},
+					tp{time.Unix(50, 0), float64Pointer(85)}),
+			}},
+		},
+		{
+			name:      "moving_avg over two points",
+			expr:      "moving_avg($A, 2)",
+			vars:      counter,
+			newErrIs:  require.NoError,
+			execErrIs: require.NoError,
+			results: Results{[]Value{
+				makeSeries("", data.Labels{"host": "a"},
+					tp{time.Unix(0, 0), float64Pointer(0)},
+					tp{time.Unix(10, 0), float64Pointer(10)},
+					tp{time.Unix(20, 0), float64Pointer(35)},
+					tp{time.Unix(30, 0), float64Pointer(50)},
+					tp{time.Unix(40, 0), float64Pointer(10)},
+					tp{time.Unix(50, 0), float64Pointer(7.5)}),
+			}},
+		},
+		{
+			name:      "moving_avg with invalid window",
+			expr:      "moving_avg($A, 0.5)",
+			vars:      counter,
+			newErrIs:  require.NoError,
+			execErrIs: require.Error,
+		},
+		{
+			name:      "shift moves points forward",
+			expr:      `shift($A, "1m")`,
+			vars:      counter,
+			newErrIs:  require.NoError,
+			execErrIs: require.NoError,
+			results: Results{[]Value{
+				makeSeries("", data.Labels{"host": "a"},
+					tp{time.Unix(60, 0), float64Pointer(0)},
+					tp{time.Unix(70, 0), float64Pointer(20)},
+					tp{time.Unix(80, 0), float64Pointer(50)},
+					tp{time.Unix(90, 0), nil},
+					tp{time.Unix(100, 0), float64Pointer(10)},
+					tp{time.Unix(110, 0), float64Pointer(5)}),
+			}},
+		},
+		{
+			name:      "shift with invalid duration",
+			expr:      `shift($A, "soon")`,
+			vars:      counter,
+			newErrIs:  require.NoError,
+			execErrIs: require.Error,
+		},
+		{
+			name: "window function on a number should error",
+			expr: "rate($A)",
+			vars: Vars{
+				"A": Results{[]Value{makeNumber("", nil, float64Pointer(1))}},
+			},
+			newErrIs:  require.NoError,
+			execErrIs: require.Error,
+		},
+		{
+			name:     "window function on a scalar should error",
+			expr:     "rate(1)",
+			vars:     Vars{},
+			newErrIs: require.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := New(tt.expr)
+			tt.newErrIs(t, err)
+			if e != nil {
+				res, err := e.Execute("", tt.vars)
+				tt.execErrIs(t, err)
+				if err == nil {
+					require.Equal(t, tt.results, res)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package mathexp
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// LabelAction is the kind of change a LabelOperation makes to the labels of a value.
+type LabelAction string
+
+const (
+	// LabelReplace sets TargetLabel to Replacement if the value of SourceLabel matches Regex,
+	// with the same semantics as Prometheus' label_replace.
+	LabelReplace LabelAction = "replace"
+	// LabelCopy sets TargetLabel to the value of SourceLabel.
+	LabelCopy LabelAction = "copy"
+	// LabelRename moves the value of SourceLabel to TargetLabel.
+	LabelRename LabelAction = "rename"
+	// LabelDrop removes Labels.
+	LabelDrop LabelAction = "drop"
+	// LabelKeep removes all labels except Labels.
+	LabelKeep LabelAction = "keep"
+)
+
+// LabelOperation is a single change to the labels of each value in a Results.
+type LabelOperation struct {
+	Action      LabelAction `json:"action"`
+	SourceLabel string      `json:"sourceLabel,omitempty"`
+	TargetLabel string      `json:"targetLabel,omitempty"`
+	Regex       string      `json:"regex,omitempty"`
+	Replacement string      `json:"replacement,omitempty"`
+	Labels      []string    `json:"labels,omitempty"`
+
+	regex *regexp.Regexp
+}
+
+// Validate checks the operation has the fields its action requires and compiles its regular expression.
+func (op *LabelOperation) Validate() error {
+	switch op.Action {
+	case LabelReplace:
+		if op.TargetLabel == "" {
+			return fmt.Errorf("label operation %v requires a target label", op.Action)
+		}
+		regex := op.Regex
+		if regex == "" {
+			regex = "(.*)"
+		}
+		// Anchored, like Prometheus
+		re, err := regexp.Compile("^(?:" + regex + ")$")
+		if err != nil {
+			return fmt.Errorf("invalid regular expression %q in label operation: %w", op.Regex, err)
+		}
+		op.regex = re
+	case LabelCopy, LabelRename:
+		if op.SourceLabel == "" || op.TargetLabel == "" {
+			return fmt.Errorf("label operation %v requires a source and a target label", op.Action)
+		}
+	case LabelDrop, LabelKeep:
+		if len(op.Labels) == 0 && op.Action == LabelDrop {
+			return fmt.Errorf("label operation %v requires at least one label", op.Action)
+		}
+	default:
+		return fmt.Errorf("label action '%v' is not supported. Supported only: [%s, %s, %s, %s, %s]", op.Action,
+			LabelReplace, LabelCopy, LabelRename, LabelDrop, LabelKeep)
+	}
+	return nil
+}
+
+// Apply returns a copy of labels with the operation applied. Validate must have been called first.
+func (op *LabelOperation) Apply(labels data.Labels) data.Labels {
+	l := data.Labels{}
+	for k, v := range labels {
+		l[k] = v
+	}
+
+	switch op.Action {
+	case LabelReplace:
+		src := l[op.SourceLabel]
+		idx := op.regex.FindStringSubmatchIndex(src)
+		if idx == nil {
+			break
+		}
+		res := op.regex.ExpandString([]byte{}, op.Replacement, src, idx)
+		if len(res) == 0 {
+			delete(l, op.TargetLabel)
+		} else {
+			l[op.TargetLabel] = string(res)
+		}
+	case LabelCopy:
+		if v, ok := l[op.SourceLabel]; ok {
+			l[op.TargetLabel] = v
+		}
+	case LabelRename:
+		if v, ok := l[op.SourceLabel]; ok {
+			delete(l, op.SourceLabel)
+			l[op.TargetLabel] = v
+		}
+	case LabelDrop:
+		for _, name := range op.Labels {
+			delete(l, name)
+		}
+	case LabelKeep:
+		keep := make(map[string]struct{}, len(op.Labels))
+		for _, name := range op.Labels {
+			keep[name] = struct{}{}
+		}
+		for k := range l {
+			if _, ok := keep[k]; !ok {
+				delete(l, k)
+			}
+		}
+	}
+	return l
+}
+
+// Relabel returns a copy of each value in res with the operations applied to its labels in order.
+// It returns an error if two values end up with the same labels, since they could no longer be told apart.
+func Relabel(refID string, res Results, ops []LabelOperation) (Results, error) {
+	newRes := Results{}
+	seen := make(map[string]struct{}, len(res.Values))
+	for _, val := range res.Values {
+		labels := val.GetLabels()
+		for i := range ops {
+			labels = ops[i].Apply(labels)
+		}
+		if len(labels) == 0 {
+			labels = nil
+		}
+
+		if _, isScalar := val.(Scalar); !isScalar {
+			key := labels.String()
+			if _, ok := seen[key]; ok {
+				return newRes, fmt.Errorf("relabeling resulted in more than one value with the labels {%s}", key)
+			}
+			seen[key] = struct{}{}
+		}
+
+		newVal, err := copyValue(refID, val, labels)
+		if err != nil {
+			return newRes, err
+		}
+		newRes.Values = append(newRes.Values, newVal)
+	}
+	return newRes, nil
+}
+
+// LabelMatching controls which labels must be equal for two values to be joined.
+// If On is true only Labels are compared, otherwise all labels except Labels are compared
+// (the equivalent of PromQL's on(...) and ignoring(...)).
+type LabelMatching struct {
+	On     bool
+	Labels []string
+}
+
+// signature returns a string that is equal for all label sets that match.
+func (m LabelMatching) signature(labels data.Labels) string {
+	return m.resultLabels(labels).String()
+}
+
+// resultLabels returns the labels that are compared, which are also the labels of the joined value.
+func (m LabelMatching) resultLabels(labels data.Labels) data.Labels {
+	l := data.Labels{}
+	if m.On {
+		for _, name := range m.Labels {
+			if v, ok := labels[name]; ok {
+				l[name] = v
+			}
+		}
+		return l
+	}
+
+	ignored := make(map[string]struct{}, len(m.Labels))
+	for _, name := range m.Labels {
+		ignored[name] = struct{}{}
+	}
+	for k, v := range labels {
+		if _, ok := ignored[k]; !ok {
+			l[k] = v
+		}
+	}
+	return l
+}
+
+// Join pairs each value of a with the value of b that has matching labels and applies the binary
+// operator op (e.g. "+" or ">") to each pair. Values without a match are dropped. The result has the
+// compared labels, so the remaining labels of a and b may be different. As with PromQL only one-to-one
+// matching is supported, so it is an error if more than one value on either side has the same signature.
+func Join(refID, op string, a, b Results, matching LabelMatching) (Results, error) {
+	if !IsBinaryOperator(op) {
+		return Results{}, fmt.Errorf("expr: unknown operator %s", op)
+	}
+
+	bySignature := make(map[string]Value, len(b.Values))
+	for _, bv := range b.Values {
+		sig := matching.signature(bv.GetLabels())
+		if _, ok := bySignature[sig]; ok {
+			return Results{}, fmt.Errorf("found more than one value on the right side of the join with the labels {%s}", sig)
+		}
+		bySignature[sig] = bv
+	}
+
+	seen := make(map[string]struct{}, len(a.Values))
+	unions := make([]*Union, 0, len(a.Values))
+	for _, av := range a.Values {
+		sig := matching.signature(av.GetLabels())
+		if _, ok := seen[sig]; ok {
+			return Results{}, fmt.Errorf("found more than one value on the left side of the join with the labels {%s}", sig)
+		}
+		seen[sig] = struct{}{}
+
+		bv, ok := bySignature[sig]
+		if !ok {
+			continue
+		}
+		labels := matching.resultLabels(av.GetLabels())
+		if len(labels) == 0 {
+			labels = nil
+		}
+		unions = append(unions, &Union{
+			Labels: labels,
+			A:      av,
+			B:      bv,
+		})
+	}
+
+	s := &State{RefID: refID}
+	return s.binaryUnions(op, unions)
+}
+
+// IsBinaryOperator returns true if op is an operator that can be used between two values.
+func IsBinaryOperator(op string) bool {
+	_, err := binaryOp(op, 1, 1)
+	return err == nil
+}
+
+// copyValue returns a copy of the value with the given refID and labels.
+func copyValue(refID string, val Value, labels data.Labels) (Value, error) {
+	switch v := val.(type) {
+	case Scalar:
+		return NewScalar(refID, v.GetFloat64Value()), nil
+	case Number:
+		n := NewNumber(refID, labels)
+		n.SetValue(v.GetFloat64Value())
+		return n, nil
+	case Series:
+		s := NewSeries(refID, labels, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			t, f := v.GetPoint(i)
+			s.SetPoint(i, t, f)
+		}
+		return s, nil
+	default:
+		return nil, fmt.Errorf("can not change the labels of type %v", val.Type())
+	}
+}
@@ -0,0 +1,182 @@
+package mathexp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelabel(t *testing.T) {
+	var tests = []struct {
+		name     string
+		ops      []LabelOperation
+		input    Results
+		expected Results
+		errIs    require.ErrorAssertionFunc
+	}{
+		{
+			name: "replace with capture group",
+			ops: []LabelOperation{
+				{Action: LabelReplace, SourceLabel: "instance", TargetLabel: "host", Regex: "(.*):\\d+", Replacement: "$1"},
+			},
+			input: Results{[]Value{makeNumber("", data.Labels{"instance": "web1:9090"}, float64Pointer(1))}},
+			expected: Results{[]Value{
+				makeNumber("B", data.Labels{"instance": "web1:9090", "host": "web1"}, float64Pointer(1)),
+			}},
+			errIs: require.NoError,
+		},
+		{
+			name: "replace is anchored and leaves labels alone when not matching",
+			ops: []LabelOperation{
+				{Action: LabelReplace, SourceLabel: "instance", TargetLabel: "host", Regex: "web", Replacement: "x"},
+			},
+			input:    Results{[]Value{makeNumber("", data.Labels{"instance": "web1"}, float64Pointer(1))}},
+			expected: Results{[]Value{makeNumber("B", data.Labels{"instance": "web1"}, float64Pointer(1))}},
+			errIs:    require.NoError,
+		},
+		{
+			name: "replace with empty replacement removes the target",
+			ops: []LabelOperation{
+				{Action: LabelReplace, SourceLabel: "instance", TargetLabel: "instance", Regex: ".*"},
+			},
+			input:    Results{[]Value{makeNumber("", data.Labels{"instance": "web1", "job": "a"}, float64Pointer(1))}},
+			expected: Results{[]Value{makeNumber("B", data.Labels{"job": "a"}, float64Pointer(1))}},
+			errIs:    require.NoError,
+		},
+		{
+			name: "rename copy and drop on a series",
+			ops: []LabelOperation{
+				{Action: LabelRename, SourceLabel: "hostname", TargetLabel: "host"},
+				{Action: LabelCopy, SourceLabel: "host", TargetLabel: "server"},
+				{Action: LabelDrop, Labels: []string{"job"}},
+			},
+			input: Results{[]Value{
+				makeSeries("", data.Labels{"hostname": "a", "job": "node"}, tp{time.Unix(5, 0), float64Pointer(1)}),
+			}},
+			expected: Results{[]Value{
+				makeSeries("B", data.Labels{"host": "a", "server": "a"}, tp{time.Unix(5, 0), float64Pointer(1)}),
+			}},
+			errIs: require.NoError,
+		},
+		{
+			name: "keep only listed labels",
+			ops: []LabelOperation{
+				{Action: LabelKeep, Labels: []string{"host"}},
+			},
+			input:    Results{[]Value{makeNumber("", data.Labels{"host": "a", "job": "node"}, float64Pointer(1))}},
+			expected: Results{[]Value{makeNumber("B", data.Labels{"host": "a"}, float64Pointer(1))}},
+			errIs:    require.NoError,
+		},
+		{
+			name: "duplicate labels after relabeling should error",
+			ops: []LabelOperation{
+				{Action: LabelDrop, Labels: []string{"host"}},
+			},
+			input: Results{[]Value{
+				makeNumber("", data.Labels{"host": "a"}, float64Pointer(1)),
+				makeNumber("", data.Labels{"host": "b"}, float64Pointer(2)),
+			}},
+			errIs: require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := range tt.ops {
+				require.NoError(t, tt.ops[i].Validate())
+			}
+			res, err := Relabel("B", tt.input, tt.ops)
+			tt.errIs(t, err)
+			if err == nil {
+				require.Equal(t, tt.expected, res)
+			}
+		})
+	}
+
+	t.Run("input is not modified", func(t *testing.T) {
+		input := Results{[]Value{makeNumber("", data.Labels{"host": "a"}, float64Pointer(1))}}
+		ops := []LabelOperation{{Action: LabelDrop, Labels: []string{"host"}}}
+		require.NoError(t, ops[0].Validate())
+		_, err := Relabel("B", input, ops)
+		require.NoError(t, err)
+		require.Equal(t, data.Labels{"host": "a"}, input.Values[0].GetLabels())
+	})
+
+	t.Run("invalid operations", func(t *testing.T) {
+		for _, op := range []LabelOperation{
+			{Action: "explode"},
+			{Action: LabelReplace, SourceLabel: "a"},
+			{Action: LabelReplace, TargetLabel: "a", Regex: "("},
+			{Action: LabelCopy, SourceLabel: "a"},
+			{Action: LabelRename, TargetLabel: "a"},
+			{Action: LabelDrop},
+		} {
+			require.Error(t, op.Validate(), op.Action)
+		}
+	})
+}
+
+func TestJoin(t *testing.T) {
+	prom := Results{[]Value{
+		makeNumber("", data.Labels{"host": "a", "job": "node"}, float64Pointer(10)),
+		makeNumber("", data.Labels{"host": "b", "job": "node"}, float64Pointer(20)),
+		makeNumber("", data.Labels{"host": "c", "job": "node"}, float64Pointer(30)),
+	}}
+	influx := Results{[]Value{
+		makeNumber("", data.Labels{"host": "a", "measurement": "cpu"}, float64Pointer(1)),
+		makeNumber("", data.Labels{"host": "b", "measurement": "cpu"}, float64Pointer(2)),
+	}}
+
+	t.Run("on matches only the listed labels", func(t *testing.T) {
+		res, err := Join("C", "+", prom, influx, LabelMatching{On: true, Labels: []string{"host"}})
+		require.NoError(t, err)
+		require.Equal(t, Results{[]Value{
+			makeNumber("C", data.Labels{"host": "a"}, float64Pointer(11)),
+			makeNumber("C", data.Labels{"host": "b"}, float64Pointer(22)),
+		}}, res)
+	})
+
+	t.Run("ignoring matches all labels except the listed ones", func(t *testing.T) {
+		res, err := Join("C", ">", prom, influx, LabelMatching{Labels: []string{"job", "measurement"}})
+		require.NoError(t, err)
+		require.Equal(t, Results{[]Value{
+			makeNumber("C", data.Labels{"host": "a"}, float64Pointer(1)),
+			makeNumber("C", data.Labels{"host": "b"}, float64Pointer(1)),
+		}}, res)
+	})
+
+	t.Run("without matching labels nothing is joined", func(t *testing.T) {
+		res, err := Join("C", "+", prom, influx, LabelMatching{})
+		require.NoError(t, err)
+		require.Empty(t, res.Values)
+	})
+
+	t.Run("series and numbers can be joined", func(t *testing.T) {
+		series := Results{[]Value{
+			makeSeries("", data.Labels{"host": "a", "job": "node"},
+				tp{time.Unix(5, 0), float64Pointer(1)},
+				tp{time.Unix(10, 0), float64Pointer(2)}),
+		}}
+		res, err := Join("C", "*", series, influx, LabelMatching{On: true, Labels: []string{"host"}})
+		require.NoError(t, err)
+		require.Equal(t, Results{[]Value{
+			makeSeries("C", data.Labels{"host": "a"},
+				tp{time.Unix(5, 0), float64Pointer(1)},
+				tp{time.Unix(10, 0), float64Pointer(2)}),
+		}}, res)
+	})
+
+	t.Run("many to one matching should error", func(t *testing.T) {
+		_, err := Join("C", "+", prom, influx, LabelMatching{On: true, Labels: []string{"job"}})
+		require.Error(t, err)
+		_, err = Join("C", "+", influx, prom, LabelMatching{On: true, Labels: []string{"job"}})
+		require.Error(t, err)
+	})
+
+	t.Run("unknown operator should error", func(t *testing.T) {
+		_, err := Join("C", "<>", prom, influx, LabelMatching{On: true, Labels: []string{"host"}})
+		require.Error(t, err)
+	})
+}
@@ -320,7 +320,9 @@ func (t *Tree) Func() (f *FuncNode) {
 			t.backup()
 			node := t.O()
 			f.append(node)
-			if len(f.Args) == 1 && f.F.VariantReturn {
+			// Variant functions return the widest type of their arguments,
+			// the same way a BinaryNode does (e.g. min(1, $A) returns a series).
+			if f.F.VariantReturn && (len(f.Args) == 1 || node.Return() > f.F.Return) {
 				f.F.Return = node.Return()
 			}
 		case itemString:
@@ -329,6 +331,10 @@ func (t *Tree) Func() (f *FuncNode) {
 				t.errorf("Unquoting error: %s", err)
 			}
 			f.append(newString(token.pos, token.val, s))
+		case itemComma:
+			if len(f.Args) == 0 {
+				t.unexpected(token, "func")
+			}
 		case itemRightParen:
 			return
 		}
@@ -514,3 +514,95 @@ func TestSeriesReduceReplaceNN(t *testing.T) {
 		})
 	}
 }
+
+var fivePointSeries = Vars{
+	"A": Results{
+		[]Value{
+			makeSeries("temp", nil,
+				tp{time.Unix(5, 0), float64Pointer(4)},
+				tp{time.Unix(10, 0), float64Pointer(1)},
+				tp{time.Unix(15, 0), float64Pointer(3)},
+				tp{time.Unix(20, 0), float64Pointer(5)},
+				tp{time.Unix(25, 0), float64Pointer(2)}),
+		},
+	},
+}
+
+var fivePointSeriesWithNil = Vars{
+	"A": Results{
+		[]Value{
+			makeSeries("temp", nil,
+				tp{time.Unix(5, 0), float64Pointer(4)},
+				tp{time.Unix(10, 0), float64Pointer(1)},
+				tp{time.Unix(15, 0), nil},
+				tp{time.Unix(20, 0), float64Pointer(5)},
+				tp{time.Unix(25, 0), float64Pointer(2)}),
+		},
+	},
+}
+
+func TestSeriesReduceStatistical(t *testing.T) {
+	var tests = []struct {
+		name   string
+		red    string
+		vars   Vars
+		mapper ReduceMapper
+		result *float64
+	}{
+		{name: "first", red: "first", vars: fivePointSeries, result: float64Pointer(4)},
+		{name: "first empty series", red: "first", vars: seriesEmpty, result: NaN},
+		{name: "median", red: "median", vars: fivePointSeries, result: float64Pointer(3)},
+		{name: "median with nil", red: "median", vars: fivePointSeriesWithNil, result: NaN},
+		{name: "median with nil dropped", red: "median", vars: fivePointSeriesWithNil, mapper: DropNonNumber{}, result: float64Pointer(3)},
+		{name: "median empty series", red: "median", vars: seriesEmpty, result: NaN},
+		{name: "p0", red: "p0", vars: fivePointSeries, result: float64Pointer(1)},
+		{name: "p100", red: "p100", vars: fivePointSeries, result: float64Pointer(5)},
+		{name: "p90 is interpolated", red: "p90", vars: fivePointSeries, result: float64Pointer(4.6)},
+		{name: "P25 is case insensitive", red: "P25", vars: fivePointSeries, result: float64Pointer(2)},
+		{name: "variance", red: "variance", vars: fivePointSeries, result: float64Pointer(2)},
+		{name: "stddev", red: "stddev", vars: fivePointSeries, result: float64Pointer(math.Sqrt(2))},
+		{name: "stddev with nil", red: "stddev", vars: fivePointSeriesWithNil, result: NaN},
+		{name: "stddev with nil replaced", red: "stddev", vars: fivePointSeriesWithNil, mapper: ReplaceNonNumberWithValue{Value: 3}, result: float64Pointer(math.Sqrt(2))},
+		{name: "range", red: "range", vars: fivePointSeries, result: float64Pointer(4)},
+		{name: "range empty series", red: "range", vars: seriesEmpty, result: NaN},
+		{name: "diff", red: "diff", vars: fivePointSeries, result: float64Pointer(-2)},
+		{name: "diff with null last value", red: "diff", vars: seriesWithNil, result: NaN},
+		{name: "diff with null last value dropped", red: "diff", vars: seriesWithNil, mapper: DropNonNumber{}, result: float64Pointer(0)},
+		{name: "count_non_null", red: "count_non_null", vars: fivePointSeriesWithNil, result: float64Pointer(4)},
+		{name: "count_non_null empty series", red: "count_non_null", vars: seriesEmpty, result: float64Pointer(0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := Results{}
+			for _, series := range tt.vars["A"].Values {
+				ns, err := series.Value().(*Series).Reduce("", tt.red, tt.mapper)
+				require.NoError(t, err)
+				results.Values = append(results.Values, ns)
+			}
+			expected := Results{[]Value{makeNumber("", nil, tt.result)}}
+			opt := cmp.Comparer(func(x, y float64) bool {
+				return (math.IsNaN(x) && math.IsNaN(y)) || math.Abs(x-y) < 1e-9
+			})
+			options := append([]cmp.Option{opt}, data.FrameTestCompareOptions()...)
+			if diff := cmp.Diff(expected, results, options...); diff != "" {
+				t.Errorf("Result mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetReduceFunc(t *testing.T) {
+	for _, name := range GetSupportedReduceFuncs() {
+		_, err := GetReduceFunc(name)
+		require.NoError(t, err, name)
+	}
+	for _, name := range []string{"p99.9", "p1"} {
+		_, err := GetReduceFunc(name)
+		require.NoError(t, err, name)
+	}
+	for _, name := range []string{"p", "p101", "p-1", "pfoo", "percentile", "pnan", "pinf", "p-inf"} {
+		_, err := GetReduceFunc(name)
+		require.Error(t, err, name)
+	}
+}
@@ -0,0 +1,224 @@
+package expr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+)
+
+const (
+	ThresholdIsAbove        = "gt"
+	ThresholdIsBelow        = "lt"
+	ThresholdIsWithinRange  = "within_range"
+	ThresholdIsOutsideRange = "outside_range"
+)
+
+// ThresholdCommand is an expression command that compares each value of its input against a threshold
+// and outputs 1 if the condition is met and 0 if it is not. Labels of the input are preserved.
+//
+// If UnloadEvaluator is set the command has hysteresis: a dimension that is currently firing (loaded)
+// keeps returning 1 until the UnloadEvaluator condition is met, instead of as soon as Evaluator no
+// longer matches. For series the state is carried from one point to the next, for numbers the loaded
+// dimensions have to be provided by the caller.
+type ThresholdCommand struct {
+	ReferenceVar     string
+	Evaluator        ThresholdEvaluator
+	UnloadEvaluator  *ThresholdEvaluator
+	LoadedDimensions []data.Labels
+	refID            string
+}
+
+// ThresholdEvaluator is a single threshold condition, e.g. gt with params [80].
+type ThresholdEvaluator struct {
+	Type   string    `json:"type"`
+	Params []float64 `json:"params"`
+}
+
+type thresholdConditionJSON struct {
+	Evaluator       ThresholdEvaluator  `json:"evaluator"`
+	UnloadEvaluator *ThresholdEvaluator `json:"unloadEvaluator"`
+}
+
+// NewThresholdCommand creates a new ThresholdCommand.
+func NewThresholdCommand(refID, referenceVar string, evaluator ThresholdEvaluator, unloadEvaluator *ThresholdEvaluator, loaded []data.Labels) (*ThresholdCommand, error) {
+	if err := evaluator.validate(); err != nil {
+		return nil, err
+	}
+	if unloadEvaluator != nil {
+		if err := unloadEvaluator.validate(); err != nil {
+			return nil, fmt.Errorf("invalid unload evaluator: %w", err)
+		}
+	}
+	return &ThresholdCommand{
+		ReferenceVar:     referenceVar,
+		Evaluator:        evaluator,
+		UnloadEvaluator:  unloadEvaluator,
+		LoadedDimensions: loaded,
+		refID:            refID,
+	}, nil
+}
+
+// UnmarshalThresholdCommand creates a ThresholdCommand from Grafana's frontend query.
+func UnmarshalThresholdCommand(rn *rawNode) (*ThresholdCommand, error) {
+	rawVar, ok := rn.Query["expression"]
+	if !ok {
+		return nil, fmt.Errorf("no variable specified to reference for refId %v", rn.RefID)
+	}
+	referenceVar, ok := rawVar.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected threshold variable to be a string, got %T for refId %v", rawVar, rn.RefID)
+	}
+	referenceVar = strings.TrimPrefix(referenceVar, "$")
+
+	jsonFromM, err := json.Marshal(rn.Query["conditions"])
+	if err != nil {
+		return nil, fmt.Errorf("failed to remarshal threshold expression body: %w", err)
+	}
+	var conditions []thresholdConditionJSON
+	if err = json.Unmarshal(jsonFromM, &conditions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal remarshaled threshold expression body: %w", err)
+	}
+	if len(conditions) != 1 {
+		return nil, fmt.Errorf("threshold expression requires exactly one condition for refId %v, got %v", rn.RefID, len(conditions))
+	}
+
+	var loaded []data.Labels
+	if rawLoaded, ok := rn.Query["loadedDimensions"]; ok {
+		jsonFromM, err := json.Marshal(rawLoaded)
+		if err != nil {
+			return nil, fmt.Errorf("failed to remarshal threshold loaded dimensions: %w", err)
+		}
+		if err = json.Unmarshal(jsonFromM, &loaded); err != nil {
+			return nil, fmt.Errorf("expected loadedDimensions to be a list of label sets for refId %v: %w", rn.RefID, err)
+		}
+	}
+
+	cmd, err := NewThresholdCommand(rn.RefID, referenceVar, conditions[0].Evaluator, conditions[0].UnloadEvaluator, loaded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid threshold command in '%v': %w", rn.RefID, err)
+	}
+	return cmd, nil
+}
+
+// NeedsVars returns the variable names (refIds) that are dependencies
+// to execute the command and allows the command to fulfill the Command interface.
+func (tc *ThresholdCommand) NeedsVars() []string {
+	return []string{tc.ReferenceVar}
+}
+
+// Execute runs the command and returns the results or an error if the command
+// failed to execute.
+func (tc *ThresholdCommand) Execute(_ context.Context, vars mathexp.Vars) (mathexp.Results, error) {
+	newRes := mathexp.Results{}
+	for _, val := range vars[tc.ReferenceVar].Values {
+		switch v := val.(type) {
+		case mathexp.Number:
+			n := mathexp.NewNumber(tc.refID, copyLabels(v.GetLabels()))
+			n.SetValue(tc.next(tc.isLoaded(v.GetLabels()), v.GetFloat64Value()))
+			newRes.Values = append(newRes.Values, n)
+		case mathexp.Scalar:
+			newRes.Values = append(newRes.Values, mathexp.NewScalar(tc.refID, tc.next(tc.isLoaded(nil), v.GetFloat64Value())))
+		case mathexp.Series:
+			s := mathexp.NewSeries(tc.refID, copyLabels(v.GetLabels()), v.Len())
+			for i := 0; i < v.Len(); i++ {
+				t, f := v.GetPoint(i)
+				s.SetPoint(i, t, f)
+			}
+			s.SortByTime(false)
+
+			loaded := tc.isLoaded(v.GetLabels())
+			for i := 0; i < s.Len(); i++ {
+				t, f := s.GetPoint(i)
+				out := tc.next(loaded, f)
+				if out != nil && !math.IsNaN(*out) {
+					loaded = *out == 1
+				}
+				s.SetPoint(i, t, out)
+			}
+			newRes.Values = append(newRes.Values, s)
+		default:
+			return newRes, fmt.Errorf("can not apply a threshold to type %v", val.Type())
+		}
+	}
+	return newRes, nil
+}
+
+// next returns 1 if the value meets the threshold, taking into account whether it was
+// previously loaded, or 0 if it does not. Null and NaN values are passed through unchanged.
+func (tc *ThresholdCommand) next(loaded bool, f *float64) *float64 {
+	if f == nil {
+		return nil
+	}
+	if math.IsNaN(*f) {
+		nan := math.NaN()
+		return &nan
+	}
+	var match bool
+	if loaded && tc.UnloadEvaluator != nil {
+		match = !tc.UnloadEvaluator.Eval(*f)
+	} else {
+		match = tc.Evaluator.Eval(*f)
+	}
+	r := float64(0)
+	if match {
+		r = 1
+	}
+	return &r
+}
+
+func (tc *ThresholdCommand) isLoaded(labels data.Labels) bool {
+	for _, l := range tc.LoadedDimensions {
+		if l.Equals(labels) || (len(l) == 0 && len(labels) == 0) {
+			return true
+		}
+	}
+	return false
+}
+
+// Eval returns true if the value meets the condition of the evaluator.
+func (e ThresholdEvaluator) Eval(f float64) bool {
+	switch e.Type {
+	case ThresholdIsAbove:
+		return f > e.Params[0]
+	case ThresholdIsBelow:
+		return f < e.Params[0]
+	case ThresholdIsWithinRange:
+		return f > e.Params[0] && f < e.Params[1]
+	case ThresholdIsOutsideRange:
+		return f < e.Params[0] || f > e.Params[1]
+	}
+	return false
+}
+
+func (e ThresholdEvaluator) validate() error {
+	switch e.Type {
+	case ThresholdIsAbove, ThresholdIsBelow:
+		if len(e.Params) != 1 {
+			return fmt.Errorf("threshold type %v requires exactly one parameter, got %v", e.Type, len(e.Params))
+		}
+	case ThresholdIsWithinRange, ThresholdIsOutsideRange:
+		if len(e.Params) != 2 {
+			return fmt.Errorf("threshold type %v requires exactly two parameters, got %v", e.Type, len(e.Params))
+		}
+		if e.Params[0] > e.Params[1] {
+			return fmt.Errorf("threshold type %v requires the first parameter to be less than the second", e.Type)
+		}
+	default:
+		return fmt.Errorf("threshold type '%v' is not supported. Supported only: [%s, %s, %s, %s]", e.Type,
+			ThresholdIsAbove, ThresholdIsBelow, ThresholdIsWithinRange, ThresholdIsOutsideRange)
+	}
+	return nil
+}
+
+func copyLabels(l data.Labels) data.Labels {
+	if l == nil {
+		return nil
+	}
+	return l.Copy()
+}
@@ -0,0 +1,176 @@
+package expr
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+	ptr "github.com/xorcare/pointer"
+
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+)
+
+func TestUnmarshalThresholdCommand(t *testing.T) {
+	var tests = []struct {
+		name     string
+		query    string
+		isError  bool
+		expected *ThresholdCommand
+	}{
+		{
+			name:  "gt threshold",
+			query: `{"expression": "$B", "conditions": [{"evaluator": {"type": "gt", "params": [80]}}]}`,
+			expected: &ThresholdCommand{
+				ReferenceVar: "B",
+				Evaluator:    ThresholdEvaluator{Type: ThresholdIsAbove, Params: []float64{80}},
+				refID:        "A",
+			},
+		},
+		{
+			name: "range threshold with hysteresis and loaded dimensions",
+			query: `{"expression": "B", "conditions": [{"evaluator": {"type": "outside_range", "params": [10, 20]},
+				"unloadEvaluator": {"type": "within_range", "params": [12, 18]}}], "loadedDimensions": [{"host": "a"}]}`,
+			expected: &ThresholdCommand{
+				ReferenceVar:     "B",
+				Evaluator:        ThresholdEvaluator{Type: ThresholdIsOutsideRange, Params: []float64{10, 20}},
+				UnloadEvaluator:  &ThresholdEvaluator{Type: ThresholdIsWithinRange, Params: []float64{12, 18}},
+				LoadedDimensions: []data.Labels{{"host": "a"}},
+				refID:            "A",
+			},
+		},
+		{
+			name:    "error when expression is missing",
+			query:   `{"conditions": [{"evaluator": {"type": "gt", "params": [80]}}]}`,
+			isError: true,
+		},
+		{
+			name:    "error when conditions are missing",
+			query:   `{"expression": "$B"}`,
+			isError: true,
+		},
+		{
+			name:    "error when type is unknown",
+			query:   `{"expression": "$B", "conditions": [{"evaluator": {"type": "eq", "params": [80]}}]}`,
+			isError: true,
+		},
+		{
+			name:    "error when range has one param",
+			query:   `{"expression": "$B", "conditions": [{"evaluator": {"type": "within_range", "params": [80]}}]}`,
+			isError: true,
+		},
+		{
+			name:    "error when range is inverted",
+			query:   `{"expression": "$B", "conditions": [{"evaluator": {"type": "within_range", "params": [80, 10]}}]}`,
+			isError: true,
+		},
+		{
+			name: "error when unload evaluator is invalid",
+			query: `{"expression": "$B", "conditions": [{"evaluator": {"type": "gt", "params": [80]},
+				"unloadEvaluator": {"type": "lt", "params": []}}]}`,
+			isError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var qmap = make(map[string]interface{})
+			require.NoError(t, json.Unmarshal([]byte(test.query), &qmap))
+
+			cmd, err := UnmarshalThresholdCommand(&rawNode{
+				RefID: "A",
+				Query: qmap,
+			})
+
+			if test.isError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expected, cmd)
+		})
+	}
+}
+
+func TestThresholdCommandExecute(t *testing.T) {
+	number := func(labels data.Labels, f *float64) mathexp.Number {
+		n := mathexp.NewNumber("A", labels)
+		n.SetValue(f)
+		return n
+	}
+
+	t.Run("numbers keep labels and return 1 or 0", func(t *testing.T) {
+		cmd, err := NewThresholdCommand("A", "B", ThresholdEvaluator{Type: ThresholdIsAbove, Params: []float64{5}}, nil, nil)
+		require.NoError(t, err)
+
+		vars := mathexp.Vars{
+			"B": mathexp.Results{Values: []mathexp.Value{
+				number(data.Labels{"host": "a"}, ptr.Float64(10)),
+				number(data.Labels{"host": "b"}, ptr.Float64(1)),
+				number(data.Labels{"host": "c"}, nil),
+			}},
+		}
+		res, err := cmd.Execute(context.Background(), vars)
+		require.NoError(t, err)
+		require.Equal(t, mathexp.Results{Values: []mathexp.Value{
+			number(data.Labels{"host": "a"}, ptr.Float64(1)),
+			number(data.Labels{"host": "b"}, ptr.Float64(0)),
+			number(data.Labels{"host": "c"}, nil),
+		}}, res)
+	})
+
+	t.Run("loaded numbers use the unload evaluator", func(t *testing.T) {
+		cmd, err := NewThresholdCommand("A", "B",
+			ThresholdEvaluator{Type: ThresholdIsAbove, Params: []float64{80}},
+			&ThresholdEvaluator{Type: ThresholdIsBelow, Params: []float64{70}},
+			[]data.Labels{{"host": "a"}})
+		require.NoError(t, err)
+
+		vars := mathexp.Vars{
+			"B": mathexp.Results{Values: []mathexp.Value{
+				number(data.Labels{"host": "a"}, ptr.Float64(75)),
+				number(data.Labels{"host": "b"}, ptr.Float64(75)),
+			}},
+		}
+		res, err := cmd.Execute(context.Background(), vars)
+		require.NoError(t, err)
+		require.Equal(t, mathexp.Results{Values: []mathexp.Value{
+			number(data.Labels{"host": "a"}, ptr.Float64(1)),
+			number(data.Labels{"host": "b"}, ptr.Float64(0)),
+		}}, res)
+	})
+
+	t.Run("series carry hysteresis state between points", func(t *testing.T) {
+		cmd, err := NewThresholdCommand("A", "B",
+			ThresholdEvaluator{Type: ThresholdIsAbove, Params: []float64{80}},
+			&ThresholdEvaluator{Type: ThresholdIsBelow, Params: []float64{70}},
+			nil)
+		require.NoError(t, err)
+
+		in := mathexp.NewSeries("B", data.Labels{"host": "a"}, 0)
+		out := mathexp.NewSeries("A", data.Labels{"host": "a"}, 0)
+		for i, p := range []struct{ in, out float64 }{
+			{75, 0}, {85, 1}, {75, 1}, {65, 0}, {75, 0},
+		} {
+			ts := time.Unix(int64(i*10), 0)
+			in.AppendPoint(ts, ptr.Float64(p.in))
+			out.AppendPoint(ts, ptr.Float64(p.out))
+		}
+
+		res, err := cmd.Execute(context.Background(), mathexp.Vars{"B": mathexp.Results{Values: []mathexp.Value{in}}})
+		require.NoError(t, err)
+		require.Equal(t, mathexp.Results{Values: []mathexp.Value{out}}, res)
+	})
+
+	t.Run("range evaluators", func(t *testing.T) {
+		within := ThresholdEvaluator{Type: ThresholdIsWithinRange, Params: []float64{1, 3}}
+		outside := ThresholdEvaluator{Type: ThresholdIsOutsideRange, Params: []float64{1, 3}}
+		require.True(t, within.Eval(2))
+		require.False(t, within.Eval(3))
+		require.False(t, outside.Eval(2))
+		require.True(t, outside.Eval(0))
+		require.True(t, outside.Eval(4))
+	})
+}
@@ -134,6 +134,9 @@ type JSONData struct {
 
 	// Backend (Datasource + Renderer)
 	Executable string `json:"executable,omitempty"`
+
+	// Notifier is set by backend plugins that provide a contact point type for Grafana Alerting.
+	Notifier bool `json:"notifier,omitempty"`
 }
 
 func (d JSONData) DashboardIncludes() []*Includes {
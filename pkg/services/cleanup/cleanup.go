@@ -9,6 +9,7 @@ import (
 	"time"
 
 	dashver "github.com/grafana/grafana/pkg/services/dashboardversion"
+	ngstore "github.com/grafana/grafana/pkg/services/ngalert/store"
 	"github.com/grafana/grafana/pkg/services/queryhistory"
 	"github.com/grafana/grafana/pkg/services/shorturls"
 	"github.com/grafana/grafana/pkg/services/sqlstore"
@@ -22,27 +23,32 @@ import (
 
 func ProvideService(cfg *setting.Cfg, serverLockService *serverlock.ServerLockService,
 	shortURLService shorturls.Service, store sqlstore.Store, queryHistoryService queryhistory.Service,
-	dashboardVersionService dashver.Service) *CleanUpService {
+	dashboardVersionService dashver.Service, alertStateHistoryStore ngstore.StateHistoryStore,
+	notificationDeliveryStore ngstore.NotificationDeliveryStore) *CleanUpService {
 	s := &CleanUpService{
-		Cfg:                     cfg,
-		ServerLockService:       serverLockService,
-		ShortURLService:         shortURLService,
-		QueryHistoryService:     queryHistoryService,
-		store:                   store,
-		log:                     log.New("cleanup"),
-		dashboardVersionService: dashboardVersionService,
+		Cfg:                       cfg,
+		ServerLockService:         serverLockService,
+		ShortURLService:           shortURLService,
+		QueryHistoryService:       queryHistoryService,
+		store:                     store,
+		log:                       log.New("cleanup"),
+		dashboardVersionService:   dashboardVersionService,
+		alertStateHistoryStore:    alertStateHistoryStore,
+		notificationDeliveryStore: notificationDeliveryStore,
 	}
 	return s
 }
 
 type CleanUpService struct {
-	log                     log.Logger
-	store                   sqlstore.Store
-	Cfg                     *setting.Cfg
-	ServerLockService       *serverlock.ServerLockService
-	ShortURLService         shorturls.Service
-	QueryHistoryService     queryhistory.Service
-	dashboardVersionService dashver.Service
+	log                       log.Logger
+	store                     sqlstore.Store
+	Cfg                       *setting.Cfg
+	ServerLockService         *serverlock.ServerLockService
+	ShortURLService           shorturls.Service
+	QueryHistoryService       queryhistory.Service
+	dashboardVersionService   dashver.Service
+	alertStateHistoryStore    ngstore.StateHistoryStore
+	notificationDeliveryStore ngstore.NotificationDeliveryStore
 }
 
 func (srv *CleanUpService) Run(ctx context.Context) error {
@@ -62,6 +68,8 @@ func (srv *CleanUpService) Run(ctx context.Context) error {
 			srv.expireOldUserInvites(ctx)
 			srv.deleteStaleShortURLs(ctx)
 			srv.deleteStaleQueryHistory(ctx)
+			srv.deleteExpiredAlertStateHistory(ctx)
+			srv.deleteExpiredNotificationDeliveries(ctx)
 			err := srv.ServerLockService.LockAndExecute(ctx, "delete old login attempts",
 				time.Minute*10, func(context.Context) {
 					srv.deleteOldLoginAttempts(ctx)
@@ -153,6 +161,34 @@ func (srv *CleanUpService) deleteExpiredDashboardVersions(ctx context.Context) {
 	}
 }
 
+func (srv *CleanUpService) deleteExpiredAlertStateHistory(ctx context.Context) {
+	retention := srv.Cfg.UnifiedAlerting.StateHistory.Retention
+	if retention == 0 {
+		return
+	}
+
+	deleted, err := srv.alertStateHistoryStore.DeleteAlertStateHistoryOlderThan(ctx, time.Now().Add(-retention))
+	if err != nil {
+		srv.log.Error("Problem deleting expired alert state history", "error", err.Error())
+	} else {
+		srv.log.Debug("Deleted expired alert state history", "rows affected", deleted)
+	}
+}
+
+func (srv *CleanUpService) deleteExpiredNotificationDeliveries(ctx context.Context) {
+	retention := srv.Cfg.UnifiedAlerting.DeliveryLog.Retention
+	if retention == 0 {
+		return
+	}
+
+	deleted, err := srv.notificationDeliveryStore.DeleteNotificationDeliveriesOlderThan(ctx, time.Now().Add(-retention))
+	if err != nil {
+		srv.log.Error("Problem deleting expired notification deliveries", "error", err.Error())
+	} else {
+		srv.log.Debug("Deleted expired notification deliveries", "rows affected", deleted)
+	}
+}
+
 func (srv *CleanUpService) deleteOldLoginAttempts(ctx context.Context) {
 	if srv.Cfg.DisableBruteForceLoginProtection {
 		return
@@ -1,9 +1,13 @@
 package cleanup
 
 import (
+	"context"
 	"testing"
 	"time"
 
+	"github.com/grafana/grafana/pkg/infra/log"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	ngstore "github.com/grafana/grafana/pkg/services/ngalert/store"
 	"github.com/grafana/grafana/pkg/setting"
 	"github.com/stretchr/testify/require"
 )
@@ -35,3 +39,59 @@ func TestCleanUpTmpFiles(t *testing.T) {
 		require.False(t, service.shouldCleanupTempFile(weekAgo, now))
 	})
 }
+
+func TestDeleteExpiredAlertStateHistory(t *testing.T) {
+	cfg := setting.NewCfg()
+	historyStore := &ngstore.FakeStateHistoryStore{}
+	service := CleanUpService{
+		Cfg:                    cfg,
+		log:                    log.New("cleanup"),
+		alertStateHistoryStore: historyStore,
+	}
+	now := time.Now()
+	require.NoError(t, historyStore.SaveAlertStateHistory(context.Background(), []*ngmodels.AlertStateHistory{
+		{RuleUID: "old", EvaluatedAt: now.Add(-48 * time.Hour).UnixMilli()},
+		{RuleUID: "new", EvaluatedAt: now.UnixMilli()},
+	}))
+
+	t.Run("If retention is 0, history should never be deleted", func(t *testing.T) {
+		cfg.UnifiedAlerting.StateHistory.Retention = 0
+		service.deleteExpiredAlertStateHistory(context.Background())
+		require.Len(t, historyStore.GetHistory(), 2)
+	})
+
+	t.Run("Should delete history older than the retention", func(t *testing.T) {
+		cfg.UnifiedAlerting.StateHistory.Retention = 24 * time.Hour
+		service.deleteExpiredAlertStateHistory(context.Background())
+		history := historyStore.GetHistory()
+		require.Len(t, history, 1)
+		require.Equal(t, "new", history[0].RuleUID)
+	})
+}
+
+func TestDeleteExpiredNotificationDeliveries(t *testing.T) {
+	cfg := setting.NewCfg()
+	deliveryStore := &ngstore.FakeNotificationDeliveryStore{}
+	service := CleanUpService{
+		Cfg:                       cfg,
+		log:                       log.New("cleanup"),
+		notificationDeliveryStore: deliveryStore,
+	}
+	now := time.Now()
+	require.NoError(t, deliveryStore.SaveNotificationDelivery(context.Background(), &ngmodels.NotificationDelivery{Receiver: "old", CreatedAt: now.Add(-48 * time.Hour).UnixMilli()}))
+	require.NoError(t, deliveryStore.SaveNotificationDelivery(context.Background(), &ngmodels.NotificationDelivery{Receiver: "new", CreatedAt: now.UnixMilli()}))
+
+	t.Run("If retention is 0, deliveries should never be deleted", func(t *testing.T) {
+		cfg.UnifiedAlerting.DeliveryLog.Retention = 0
+		service.deleteExpiredNotificationDeliveries(context.Background())
+		require.Len(t, deliveryStore.GetDeliveries(), 2)
+	})
+
+	t.Run("Should delete deliveries older than the retention", func(t *testing.T) {
+		cfg.UnifiedAlerting.DeliveryLog.Retention = 24 * time.Hour
+		service.deleteExpiredNotificationDeliveries(context.Background())
+		deliveries := deliveryStore.GetDeliveries()
+		require.Len(t, deliveries, 1)
+		require.Equal(t, "new", deliveries[0].Receiver)
+	})
+}
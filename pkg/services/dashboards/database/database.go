@@ -763,6 +763,7 @@ func (d *DashboardStore) deleteDashboard(cmd *models.DeleteDashboardCommand, ses
 				"DELETE FROM annotation WHERE dashboard_id IN (SELECT id FROM dashboard WHERE org_id = ? AND folder_id = ?)",
 				"DELETE FROM dashboard_provisioning WHERE dashboard_id IN (SELECT id FROM dashboard WHERE org_id = ? AND folder_id = ?)",
 				"DELETE FROM dashboard_acl WHERE dashboard_id IN (SELECT id FROM dashboard WHERE org_id = ? AND folder_id = ?)",
+				"DELETE FROM dashboard_view WHERE org_id = ? AND dashboard_uid IN (SELECT uid FROM dashboard WHERE folder_id = ?)",
 			}
 			for _, sql := range childrenDeletes {
 				_, err := sess.Exec(sql, dashboard.OrgId, dashboard.Id)
@@ -806,6 +807,10 @@ func (d *DashboardStore) deleteDashboard(cmd *models.DeleteDashboardCommand, ses
 		return err
 	}
 
+	if _, err := sess.Exec("DELETE FROM dashboard_view WHERE org_id = ? AND dashboard_uid = ?", dashboard.OrgId, dashboard.Uid); err != nil {
+		return err
+	}
+
 	for _, sql := range deletes {
 		_, err := sess.Exec(sql, dashboard.Id)
 		if err != nil {
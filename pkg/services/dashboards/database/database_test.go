@@ -139,12 +139,14 @@ func TestIntegrationDashboardDataAccess(t *testing.T) {
 	t.Run("Should be able to delete dashboard", func(t *testing.T) {
 		setup()
 		dash := insertTestDashboard(t, dashboardStore, "delete me", 1, 0, false, "delete this")
+		insertTestDashboardView(t, sqlStore, dash)
 
 		err := dashboardStore.DeleteDashboard(context.Background(), &models.DeleteDashboardCommand{
 			Id:    dash.Id,
 			OrgId: 1,
 		})
 		require.NoError(t, err)
+		require.Equal(t, int64(0), countTestDashboardViews(t, sqlStore, dash))
 	})
 
 	t.Run("Should be able to create dashboard", func(t *testing.T) {
@@ -228,9 +230,13 @@ func TestIntegrationDashboardDataAccess(t *testing.T) {
 
 	t.Run("Should be able to delete a dashboard folder and its children if force delete rules is enabled", func(t *testing.T) {
 		setup()
+		insertTestDashboardView(t, sqlStore, savedDash)
+		insertTestDashboardView(t, sqlStore, savedDash2)
 		deleteCmd := &models.DeleteDashboardCommand{Id: savedFolder.Id, ForceDeleteFolderRules: true}
 		err := dashboardStore.DeleteDashboard(context.Background(), deleteCmd)
 		require.NoError(t, err)
+		require.Equal(t, int64(0), countTestDashboardViews(t, sqlStore, savedDash))
+		require.Equal(t, int64(1), countTestDashboardViews(t, sqlStore, savedDash2))
 
 		query := models.FindPersistedDashboardsQuery{
 			OrgId:        1,
@@ -581,6 +587,26 @@ func TestIntegrationDashboard_Filter(t *testing.T) {
 	assert.Equal(t, dashB.Id, results[0].ID)
 }
 
+func insertTestDashboardView(t *testing.T, sqlStore *sqlstore.SQLStore, dash *models.Dashboard) {
+	err := sqlStore.WithDbSession(context.Background(), func(sess *sqlstore.DBSession) error {
+		_, err := sess.Exec("INSERT INTO dashboard_view (org_id, dashboard_uid, user_id, views, last_viewed) VALUES (?, ?, ?, ?, ?)",
+			dash.OrgId, dash.Uid, 1, 1, time.Now().Unix())
+		return err
+	})
+	require.NoError(t, err)
+}
+
+func countTestDashboardViews(t *testing.T, sqlStore *sqlstore.SQLStore, dash *models.Dashboard) int64 {
+	var count int64
+	err := sqlStore.WithDbSession(context.Background(), func(sess *sqlstore.DBSession) error {
+		var err error
+		count, err = sess.Table("dashboard_view").Where("org_id = ? AND dashboard_uid = ?", dash.OrgId, dash.Uid).Count()
+		return err
+	})
+	require.NoError(t, err)
+	return count
+}
+
 func insertTestRule(t *testing.T, sqlStore *sqlstore.SQLStore, foderOrgID int64, folderUID string) {
 	err := sqlStore.WithDbSession(context.Background(), func(sess *sqlstore.DBSession) error {
 		type alertQuery struct {
@@ -0,0 +1,187 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/grafana/grafana/pkg/services/sqlstore"
+)
+
+type commitHelper struct {
+	ctx     context.Context
+	repo    *git.Repository
+	work    *git.Worktree
+	workDir string // absolute path to the repository root
+	orgID   int64
+	users   map[int64]*userInfo
+
+	// called after each commit with the last path written
+	onCommit func(fpath string)
+}
+
+type commitBody struct {
+	fpath string // relative to the repository root
+	body  []byte
+}
+
+type commitOptions struct {
+	body    []commitBody
+	when    time.Time
+	userID  int64
+	comment string
+}
+
+type userInfo struct {
+	ID    int64  `xorm:"id"`
+	Login string `xorm:"login"`
+	Email string `xorm:"email"`
+	Name  string `xorm:"name"`
+}
+
+// grafanaUser is the author of the commits of resources that don't record who saved them,
+// such as alert rules and data sources, and the committer of all commits.
+var grafanaUser = &userInfo{
+	Login: "grafana",
+	Name:  "Grafana",
+	Email: "grafana@localhost",
+}
+
+func newCommitHelper(ctx context.Context, sql *sqlstore.SQLStore, orgID int64, workDir string) (*commitHelper, error) {
+	if err := os.MkdirAll(workDir, 0750); err != nil {
+		return nil, err
+	}
+
+	repo, err := git.PlainOpen(workDir)
+	if err != nil {
+		if err != git.ErrRepositoryNotExists {
+			return nil, err
+		}
+		repo, err = git.PlainInit(workDir, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	work, err := repo.Worktree()
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := loadUsers(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+
+	return &commitHelper{
+		ctx:      ctx,
+		repo:     repo,
+		work:     work,
+		workDir:  workDir,
+		orgID:    orgID,
+		users:    users,
+		onCommit: func(string) {},
+	}, nil
+}
+
+func (ch *commitHelper) add(opts commitOptions) error {
+	if len(opts.body) < 1 {
+		return nil
+	}
+
+	for _, b := range opts.body {
+		if err := ch.ctx.Err(); err != nil {
+			return err
+		}
+
+		fpath := path.Clean(filepath.ToSlash(b.fpath))
+		if strings.HasPrefix(fpath, "../") || path.IsAbs(fpath) {
+			return fmt.Errorf("path outside of the repository: %s", b.fpath)
+		}
+
+		full := filepath.Join(ch.workDir, filepath.FromSlash(fpath))
+		if err := os.MkdirAll(filepath.Dir(full), 0750); err != nil {
+			return err
+		}
+		if err := os.WriteFile(full, b.body, 0600); err != nil {
+			return err
+		}
+		if _, err := ch.work.Add(fpath); err != nil {
+			return err
+		}
+	}
+
+	user := ch.getUser(opts.userID)
+	when := opts.when
+	if when.IsZero() {
+		when = time.Now()
+	}
+
+	comment := opts.comment
+	if comment == "" {
+		comment = "exported from grafana"
+	}
+
+	_, err := ch.work.Commit(comment, &git.CommitOptions{
+		Author: &object.Signature{
+			Name:  user.Name,
+			Email: user.Email,
+			When:  when,
+		},
+		Committer: &object.Signature{
+			Name:  grafanaUser.Name,
+			Email: grafanaUser.Email,
+			When:  time.Now(),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	ch.onCommit(opts.body[len(opts.body)-1].fpath)
+	return nil
+}
+
+func (ch *commitHelper) getUser(id int64) *userInfo {
+	if id == 0 {
+		return grafanaUser
+	}
+	if u, ok := ch.users[id]; ok {
+		return u
+	}
+	return &userInfo{
+		ID:    id,
+		Login: "unknown",
+		Name:  "Unknown",
+		Email: "unknown@localhost",
+	}
+}
+
+func loadUsers(ctx context.Context, sql *sqlstore.SQLStore) (map[int64]*userInfo, error) {
+	users := make(map[int64]*userInfo)
+	err := sql.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		rows := make([]*userInfo, 0)
+		sess.Table("user").Cols("id", "login", "email", "name")
+		if err := sess.Find(&rows); err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			if row.Name == "" {
+				row.Name = row.Login
+			}
+			if row.Email == "" {
+				row.Email = row.Login + "@localhost"
+			}
+			users[row.ID] = row
+		}
+		return nil
+	})
+	return users, err
+}
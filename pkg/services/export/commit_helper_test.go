@@ -0,0 +1,90 @@
+package export
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitHelper(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	work, err := repo.Worktree()
+	require.NoError(t, err)
+
+	committed := []string{}
+	helper := &commitHelper{
+		ctx:     context.Background(),
+		repo:    repo,
+		work:    work,
+		workDir: dir,
+		orgID:   1,
+		users: map[int64]*userInfo{
+			2: {ID: 2, Login: "jdoe", Name: "Jane Doe", Email: "jane@example.com"},
+		},
+		onCommit: func(fpath string) {
+			committed = append(committed, fpath)
+		},
+	}
+
+	when := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	err = helper.add(commitOptions{
+		body:    []commitBody{{fpath: "dashboards/a/b-dash.json", body: []byte("{}\n")}},
+		when:    when,
+		userID:  2,
+		comment: "first",
+	})
+	require.NoError(t, err)
+
+	err = helper.add(commitOptions{
+		body:    []commitBody{{fpath: "dashboards/a/b-dash.json", body: []byte("{\"a\":1}\n")}},
+		userID:  99,
+		comment: "second",
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"dashboards/a/b-dash.json", "dashboards/a/b-dash.json"}, committed)
+
+	body, err := os.ReadFile(filepath.Join(dir, "dashboards", "a", "b-dash.json"))
+	require.NoError(t, err)
+	require.Equal(t, "{\"a\":1}\n", string(body))
+
+	iter, err := repo.Log(&git.LogOptions{})
+	require.NoError(t, err)
+	first, err := iter.Next()
+	require.NoError(t, err)
+	require.Equal(t, "second", first.Message)
+	require.Equal(t, "Unknown", first.Author.Name)
+	second, err := iter.Next()
+	require.NoError(t, err)
+	require.Equal(t, "first", second.Message)
+	require.Equal(t, "Jane Doe", second.Author.Name)
+	require.Equal(t, "jane@example.com", second.Author.Email)
+	require.True(t, when.Equal(second.Author.When))
+
+	t.Run("attributes resources without a user to Grafana", func(t *testing.T) {
+		err := helper.add(commitOptions{
+			body:    []commitBody{{fpath: "alerting/rules/a/b/c-rule.json", body: []byte("{}\n")}},
+			comment: "rule",
+		})
+		require.NoError(t, err)
+		head, err := repo.Head()
+		require.NoError(t, err)
+		commit, err := repo.CommitObject(head.Hash())
+		require.NoError(t, err)
+		require.Equal(t, "Grafana", commit.Author.Name)
+		require.Equal(t, "grafana@localhost", commit.Author.Email)
+	})
+
+	t.Run("rejects paths outside of the repository", func(t *testing.T) {
+		err := helper.add(commitOptions{
+			body: []commitBody{{fpath: "../escape.json", body: []byte("{}")}},
+		})
+		require.Error(t, err)
+	})
+}
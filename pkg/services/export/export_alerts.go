@@ -0,0 +1,46 @@
+package export
+
+import (
+	"fmt"
+	"path/filepath"
+
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/sqlstore"
+)
+
+func exportAlertRules(helper *commitHelper, job *gitExportJob) error {
+	rows := make([]*ngmodels.AlertRule, 0)
+	err := job.sql.WithDbSession(helper.ctx, func(sess *sqlstore.DBSession) error {
+		sess.Table("alert_rule").
+			Where("org_id = ?", helper.orgID).
+			OrderBy("id ASC")
+		return sess.Find(&rows)
+	})
+	if err != nil {
+		return err
+	}
+
+	job.addCount(int64(len(rows)))
+	for _, rule := range rows {
+		rule.ID = 0
+		rule.OrgID = 0
+
+		body, err := prettyJSON(rule)
+		if err != nil {
+			return err
+		}
+
+		err = helper.add(commitOptions{
+			body: []commitBody{{
+				fpath: filepath.Join("alerting", "rules", rule.NamespaceUID, rule.RuleGroup, rule.UID+"-rule.json"),
+				body:  body,
+			}},
+			when:    rule.Updated,
+			comment: fmt.Sprintf("Alert rule: %s", rule.Title),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+package export
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/sqlstore"
+)
+
+type dashboardRow struct {
+	ID        int64  `xorm:"id"`
+	UID       string `xorm:"uid"`
+	IsFolder  bool   `xorm:"is_folder"`
+	FolderID  int64  `xorm:"folder_id"`
+	Slug      string `xorm:"slug"`
+	Title     string `xorm:"title"`
+	Data      []byte `xorm:"data"`
+	Created   time.Time
+	Updated   time.Time
+	CreatedBy int64 `xorm:"created_by"`
+	UpdatedBy int64 `xorm:"updated_by"`
+}
+
+type dashboardVersionRow struct {
+	DashboardID int64 `xorm:"dashboard_id"`
+	Version     int   `xorm:"version"`
+	Created     time.Time
+	CreatedBy   int64  `xorm:"created_by"`
+	Message     string `xorm:"message"`
+	Data        []byte `xorm:"data"`
+}
+
+const dashboardVersionPageSize = 100
+
+func exportDashboards(helper *commitHelper, job *gitExportJob) error {
+	rows := make([]*dashboardRow, 0)
+	err := job.sql.WithDbSession(helper.ctx, func(sess *sqlstore.DBSession) error {
+		sess.Table("dashboard").
+			Where("org_id = ?", helper.orgID).
+			Cols("id", "uid", "is_folder", "folder_id", "slug", "title", "data", "created", "updated", "created_by", "updated_by").
+			OrderBy("id ASC")
+		return sess.Find(&rows)
+	})
+	if err != nil {
+		return err
+	}
+
+	root := "dashboards"
+	folders := make(map[int64]string)
+	dashboards := make(map[int64]*dashboardRow)
+	for _, row := range rows {
+		if row.IsFolder {
+			folders[row.ID] = filepath.Join(root, fileName(row.Slug, row.UID))
+		} else {
+			dashboards[row.ID] = row
+		}
+	}
+
+	dashboardPath := func(row *dashboardRow) string {
+		dir, ok := folders[row.FolderID]
+		if !ok {
+			dir = job.generalFolderPath(root)
+		}
+		return filepath.Join(dir, fileName(row.Slug, row.UID)+"-dash.json")
+	}
+
+	// Folders first, so the history reads naturally
+	for _, row := range rows {
+		if !row.IsFolder {
+			continue
+		}
+		body, err := prettyJSON(map[string]string{
+			"uid":   row.UID,
+			"title": row.Title,
+		})
+		if err != nil {
+			return err
+		}
+		job.addCount(1)
+		err = helper.add(commitOptions{
+			body: []commitBody{{
+				fpath: filepath.Join(folders[row.ID], "__folder.json"),
+				body:  body,
+			}},
+			when:    row.Created,
+			userID:  row.CreatedBy,
+			comment: fmt.Sprintf("Folder: %s", row.Title),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	addCurrent := func(row *dashboardRow) error {
+		body, err := cleanDashboardJSON(row.Data)
+		if err != nil {
+			return err
+		}
+		return helper.add(commitOptions{
+			body: []commitBody{{
+				fpath: dashboardPath(row),
+				body:  body,
+			}},
+			when:    row.Updated,
+			userID:  row.UpdatedBy,
+			comment: fmt.Sprintf("Dashboard: %s", row.Title),
+		})
+	}
+
+	if job.cfg.Git.ExcludeHistory {
+		job.addCount(int64(len(dashboards)))
+		for _, row := range rows {
+			if row.IsFolder {
+				continue
+			}
+			if err := addCurrent(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// Folders may have versions too, they are exported without history
+	var total int64
+	err = job.sql.WithDbSession(helper.ctx, func(sess *sqlstore.DBSession) error {
+		total, err = sess.Table("dashboard_version").
+			Join("INNER", "dashboard", "dashboard.id = dashboard_version.dashboard_id").
+			Where("dashboard.org_id = ? AND dashboard.is_folder = ?", helper.orgID, job.sql.Dialect.BooleanStr(false)).
+			Count()
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	job.addCount(total)
+
+	// One commit per dashboard version, in the order they were saved
+	versioned := make(map[int64]bool, len(dashboards))
+	for offset := 0; ; offset += dashboardVersionPageSize {
+		versions := make([]*dashboardVersionRow, 0, dashboardVersionPageSize)
+		err = job.sql.WithDbSession(helper.ctx, func(sess *sqlstore.DBSession) error {
+			sess.Table("dashboard_version").
+				Join("INNER", "dashboard", "dashboard.id = dashboard_version.dashboard_id").
+				Where("dashboard.org_id = ? AND dashboard.is_folder = ?", helper.orgID, job.sql.Dialect.BooleanStr(false)).
+				Cols("dashboard_version.dashboard_id",
+					"dashboard_version.version",
+					"dashboard_version.created",
+					"dashboard_version.created_by",
+					"dashboard_version.message",
+					"dashboard_version.data").
+				OrderBy("dashboard_version.created ASC, dashboard_version.id ASC").
+				Limit(dashboardVersionPageSize, offset)
+			return sess.Find(&versions)
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, v := range versions {
+			row, ok := dashboards[v.DashboardID]
+			if !ok {
+				continue
+			}
+			versioned[v.DashboardID] = true
+			body, err := cleanDashboardJSON(v.Data)
+			if err != nil {
+				return err
+			}
+			comment := v.Message
+			if comment == "" {
+				comment = fmt.Sprintf("Dashboard: %s (version %d)", row.Title, v.Version)
+			}
+			err = helper.add(commitOptions{
+				body: []commitBody{{
+					fpath: dashboardPath(row),
+					body:  body,
+				}},
+				when:    v.Created,
+				userID:  v.CreatedBy,
+				comment: comment,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(versions) < dashboardVersionPageSize {
+			break
+		}
+	}
+
+	// Dashboards without versions, such as ones created before versioning or
+	// whose versions were cleaned up, are exported as they are now
+	for _, row := range rows {
+		if row.IsFolder || versioned[row.ID] {
+			continue
+		}
+		job.addCount(1)
+		if err := addCurrent(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cleanDashboardJSON removes the instance specific internal id and formats the model
+func cleanDashboardJSON(data []byte) ([]byte, error) {
+	dash, err := simplejson.NewJson(data)
+	if err != nil {
+		return nil, err
+	}
+	dash.Del("id")
+	return prettyJSON(dash)
+}
+
+// fileName returns the name of the file of a resource, or the fallback if the resource has no name.
+func fileName(name string, fallback string) string {
+	if name == "" {
+		return fallback
+	}
+	return name
+}
@@ -0,0 +1,109 @@
+package export
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/sqlstore"
+	"github.com/stretchr/testify/require"
+)
+
+type testDashboardRow struct {
+	ID       int64  `xorm:"pk autoincr 'id'"`
+	OrgID    int64  `xorm:"org_id"`
+	UID      string `xorm:"uid"`
+	Slug     string
+	Title    string
+	Data     string
+	IsFolder bool
+	FolderID int64 `xorm:"folder_id"`
+	Version  int
+	Created  time.Time
+	Updated  time.Time
+}
+
+func (testDashboardRow) TableName() string {
+	return "dashboard"
+}
+
+type testDashboardVersionRow struct {
+	ID            int64 `xorm:"pk autoincr 'id'"`
+	DashboardID   int64 `xorm:"dashboard_id"`
+	ParentVersion int
+	RestoredFrom  int
+	Version       int
+	Created       time.Time
+	CreatedBy     int64
+	Message       string
+	Data          string
+}
+
+func (testDashboardVersionRow) TableName() string {
+	return "dashboard_version"
+}
+
+func TestIntegrationExportDashboards(t *testing.T) {
+	sql := sqlstore.InitTestDB(t)
+	created := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	folder := &testDashboardRow{OrgID: 1, UID: "f", Slug: "folder", Title: "Folder", Data: `{"title":"Folder"}`, IsFolder: true, Created: created, Updated: created}
+	versioned := &testDashboardRow{OrgID: 1, UID: "a", Slug: "versioned", Title: "Versioned", Data: `{"title":"Versioned v2"}`, Created: created, Updated: created}
+	unversioned := &testDashboardRow{OrgID: 1, UID: "b", Slug: "unversioned", Title: "Unversioned", Data: `{"id":3,"title":"Unversioned"}`, Created: created, Updated: created}
+	err := sql.WithDbSession(context.Background(), func(sess *sqlstore.DBSession) error {
+		if _, err := sess.Insert(folder, versioned, unversioned); err != nil {
+			return err
+		}
+		_, err := sess.Insert(
+			&testDashboardVersionRow{DashboardID: folder.ID, Version: 1, Created: created, Data: `{"title":"Folder"}`},
+			&testDashboardVersionRow{DashboardID: versioned.ID, Version: 1, Created: created, Data: `{"title":"Versioned v1"}`},
+			&testDashboardVersionRow{DashboardID: versioned.ID, Version: 2, Created: created.Add(time.Minute), Data: `{"title":"Versioned v2"}`},
+		)
+		return err
+	})
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	job := &gitExportJob{
+		logger:  log.New("test"),
+		sql:     sql,
+		orgID:   1,
+		rootDir: dir,
+	}
+	helper, err := newCommitHelper(context.Background(), sql, 1, dir)
+	require.NoError(t, err)
+
+	require.NoError(t, exportDashboards(helper, job))
+
+	// folder, two versions and the current unversioned dashboard
+	require.Equal(t, int64(4), job.status.Count)
+
+	body, err := os.ReadFile(filepath.Join(dir, "dashboards", "General", "versioned-dash.json"))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"title":"Versioned v2"}`, string(body))
+
+	body, err = os.ReadFile(filepath.Join(dir, "dashboards", "General", "unversioned-dash.json"))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"title":"Unversioned"}`, string(body))
+
+	repo, err := git.PlainOpen(dir)
+	require.NoError(t, err)
+	iter, err := repo.Log(&git.LogOptions{})
+	require.NoError(t, err)
+	messages := []string{}
+	require.NoError(t, iter.ForEach(func(c *object.Commit) error {
+		messages = append(messages, c.Message)
+		return nil
+	}))
+	require.Equal(t, []string{
+		"Dashboard: Unversioned",
+		"Dashboard: Versioned (version 2)",
+		"Dashboard: Versioned (version 1)",
+		"Folder: Folder",
+	}, messages)
+}
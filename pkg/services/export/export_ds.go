@@ -0,0 +1,50 @@
+package export
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/sqlstore"
+)
+
+func exportDataSources(helper *commitHelper, job *gitExportJob) error {
+	rows := make([]*models.DataSource, 0)
+	err := job.sql.WithDbSession(helper.ctx, func(sess *sqlstore.DBSession) error {
+		sess.Table("data_source").
+			Where("org_id = ?", helper.orgID).
+			OrderBy("id ASC")
+		return sess.Find(&rows)
+	})
+	if err != nil {
+		return err
+	}
+
+	job.addCount(int64(len(rows)))
+	for _, ds := range rows {
+		// Never write secrets to the repository
+		ds.Id = 0
+		ds.OrgId = 0
+		ds.Password = ""
+		ds.BasicAuthPassword = ""
+		ds.SecureJsonData = nil
+
+		body, err := prettyJSON(ds)
+		if err != nil {
+			return err
+		}
+
+		err = helper.add(commitOptions{
+			body: []commitBody{{
+				fpath: filepath.Join("datasources", fileName(ds.Uid, ds.Name)+"-ds.json"),
+				body:  body,
+			}},
+			when:    ds.Updated,
+			comment: fmt.Sprintf("Data source: %s", ds.Name),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
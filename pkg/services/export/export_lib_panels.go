@@ -0,0 +1,57 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/libraryelements"
+	"github.com/grafana/grafana/pkg/services/sqlstore"
+)
+
+func exportLibraryPanels(helper *commitHelper, job *gitExportJob) error {
+	rows := make([]*libraryelements.LibraryElement, 0)
+	err := job.sql.WithDbSession(helper.ctx, func(sess *sqlstore.DBSession) error {
+		sess.Table("library_element").
+			Where("org_id = ? AND kind = ?", helper.orgID, int64(models.PanelElement)).
+			OrderBy("id ASC")
+		return sess.Find(&rows)
+	})
+	if err != nil {
+		return err
+	}
+
+	job.addCount(int64(len(rows)))
+	for _, element := range rows {
+		var model map[string]interface{}
+		if err := json.Unmarshal(element.Model, &model); err != nil {
+			return err
+		}
+
+		body, err := prettyJSON(map[string]interface{}{
+			"uid":         element.UID,
+			"name":        element.Name,
+			"type":        element.Type,
+			"description": element.Description,
+			"model":       model,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = helper.add(commitOptions{
+			body: []commitBody{{
+				fpath: filepath.Join("library-panels", element.UID+"-panel.json"),
+				body:  body,
+			}},
+			when:    element.Updated,
+			userID:  element.UpdatedBy,
+			comment: fmt.Sprintf("Library panel: %s", element.Name),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/sqlstore"
+)
+
+var _ Job = new(gitExportJob)
+
+// simpleExporter writes one kind of entity into the repository
+type simpleExporter = func(helper *commitHelper, job *gitExportJob) error
+
+type gitExportJob struct {
+	logger  log.Logger
+	sql     *sqlstore.SQLStore
+	orgID   int64
+	rootDir string
+
+	statusMu    sync.Mutex
+	status      ExportStatus
+	cfg         ExportConfig
+	broadcaster statusBroadcaster
+}
+
+func startGitExportJob(cfg ExportConfig, sql *sqlstore.SQLStore, orgID int64, rootDir string, broadcaster statusBroadcaster) (Job, error) {
+	if cfg.Format != "git" {
+		return nil, errors.New("only git format is supported")
+	}
+
+	job := &gitExportJob{
+		logger:      log.New("git_export_job"),
+		sql:         sql,
+		orgID:       orgID,
+		rootDir:     rootDir,
+		cfg:         cfg,
+		broadcaster: broadcaster,
+		status: ExportStatus{
+			Running: true,
+			Target:  "git export",
+			Started: time.Now().UnixMilli(),
+			Current: 0,
+		},
+	}
+
+	broadcaster(job.status)
+	go job.start()
+	return job, nil
+}
+
+func (e *gitExportJob) getStatus() ExportStatus {
+	e.statusMu.Lock()
+	defer e.statusMu.Unlock()
+
+	return e.status
+}
+
+func (e *gitExportJob) getConfig() ExportConfig {
+	e.statusMu.Lock()
+	defer e.statusMu.Unlock()
+
+	return e.cfg
+}
+
+func (e *gitExportJob) start() {
+	defer func() {
+		e.logger.Info("Finished git export job")
+
+		e.statusMu.Lock()
+		defer e.statusMu.Unlock()
+		s := e.status
+		if err := recover(); err != nil {
+			e.logger.Error("export panic", "error", err)
+			s.Status = fmt.Sprintf("ERROR: %v", err)
+		}
+		// Make sure it finishes OK
+		if s.Finished < 10 {
+			s.Finished = time.Now().UnixMilli()
+		}
+		s.Running = false
+		if s.Status == "" {
+			s.Status = "done"
+		}
+		e.status = s
+		e.broadcaster(s)
+	}()
+
+	e.logger.Info("Starting git export job", "dir", e.rootDir)
+
+	err := e.doExportWithHistory()
+	if err != nil {
+		e.logger.Error("git export failed", "error", err)
+		e.statusMu.Lock()
+		e.status.Status = fmt.Sprintf("ERROR: %v", err)
+		e.statusMu.Unlock()
+	}
+}
+
+func (e *gitExportJob) doExportWithHistory() error {
+	ctx := context.Background()
+	helper, err := newCommitHelper(ctx, e.sql, e.orgID, e.rootDir)
+	if err != nil {
+		return err
+	}
+	helper.onCommit = e.progress
+
+	exporters := []simpleExporter{
+		exportDataSources,
+		exportDashboards,
+		exportAlertRules,
+		exportLibraryPanels,
+	}
+
+	for _, exporter := range exporters {
+		if err := exporter(helper, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *gitExportJob) progress(fpath string) {
+	e.statusMu.Lock()
+	e.status.Changed = time.Now().UnixMilli()
+	e.status.Current++
+	e.status.Last = fpath
+	s := e.status
+	e.statusMu.Unlock()
+
+	e.broadcaster(s)
+}
+
+// addCount increases the expected number of commits reported in the status
+func (e *gitExportJob) addCount(count int64) {
+	e.statusMu.Lock()
+	e.status.Count += count
+	e.statusMu.Unlock()
+}
+
+// generalFolderPath returns the directory used for items that are not in a folder
+func (e *gitExportJob) generalFolderPath(root string) string {
+	if e.cfg.Git.GeneralAtRoot {
+		return root
+	}
+	return filepath.Join(root, "General")
+}
+
+func prettyJSON(v interface{}) ([]byte, error) {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
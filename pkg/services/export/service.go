@@ -2,8 +2,11 @@ package export
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/grafana/grafana/pkg/api/response"
 	"github.com/grafana/grafana/pkg/infra/log"
@@ -11,6 +14,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	"github.com/grafana/grafana/pkg/services/live"
 	"github.com/grafana/grafana/pkg/services/sqlstore"
+	"github.com/grafana/grafana/pkg/setting"
 )
 
 type ExportService interface {
@@ -22,16 +26,17 @@ type ExportService interface {
 }
 
 type StandardExport struct {
-	logger log.Logger
-	sql    *sqlstore.SQLStore
-	glive  *live.GrafanaLive
-	mutex  sync.Mutex
+	logger  log.Logger
+	sql     *sqlstore.SQLStore
+	glive   *live.GrafanaLive
+	mutex   sync.Mutex
+	dataDir string
 
 	// updated with mutex
 	exportJob Job
 }
 
-func ProvideService(sql *sqlstore.SQLStore, features featuremgmt.FeatureToggles, gl *live.GrafanaLive) ExportService {
+func ProvideService(sql *sqlstore.SQLStore, features featuremgmt.FeatureToggles, gl *live.GrafanaLive, cfg *setting.Cfg) ExportService {
 	if !features.IsEnabled(featuremgmt.FlagExport) {
 		return &StubExport{}
 	}
@@ -41,6 +46,7 @@ func ProvideService(sql *sqlstore.SQLStore, features featuremgmt.FeatureToggles,
 		glive:     gl,
 		logger:    log.New("export_service"),
 		exportJob: &stoppedJob{},
+		dataDir:   filepath.Join(cfg.DataPath, "export"),
 	}
 }
 
@@ -67,7 +73,8 @@ func (ex *StandardExport) HandleRequestExport(c *models.ReqContext) response.Res
 		return response.Error(http.StatusLocked, "export already running", nil)
 	}
 
-	job, err := startDummyExportJob(cfg, func(s ExportStatus) {
+	dir := filepath.Join(ex.dataDir, fmt.Sprintf("org_%d", c.OrgId), fmt.Sprintf("git_%d", time.Now().Unix()))
+	job, err := startGitExportJob(cfg, ex.sql, c.OrgId, dir, func(s ExportStatus) {
 		ex.broadcastStatus(c.OrgId, s)
 	})
 	if err != nil {
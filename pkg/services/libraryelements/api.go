@@ -8,6 +8,7 @@ import (
 	"github.com/grafana/grafana/pkg/api/routing"
 	"github.com/grafana/grafana/pkg/middleware"
 	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/store"
 	"github.com/grafana/grafana/pkg/web"
 )
 
@@ -46,6 +47,7 @@ func (l *LibraryElementService) createHandler(c *models.ReqContext) response.Res
 	if err != nil {
 		return toLibraryElementError(err, "Failed to create library element")
 	}
+	l.saveEntityEvent(c.Req.Context(), c.OrgId, element.UID, store.EntityEventTypeCreate)
 
 	if element.FolderID != 0 {
 		folder, err := l.folderService.GetFolderByID(c.Req.Context(), c.SignedInUser, element.FolderID, c.OrgId)
@@ -62,10 +64,12 @@ func (l *LibraryElementService) createHandler(c *models.ReqContext) response.Res
 
 // deleteHandler handles DELETE /api/library-elements/:uid.
 func (l *LibraryElementService) deleteHandler(c *models.ReqContext) response.Response {
-	id, err := l.deleteLibraryElement(c.Req.Context(), c.SignedInUser, web.Params(c.Req)[":uid"])
+	uid := web.Params(c.Req)[":uid"]
+	id, err := l.deleteLibraryElement(c.Req.Context(), c.SignedInUser, uid)
 	if err != nil {
 		return toLibraryElementError(err, "Failed to delete library element")
 	}
+	l.saveEntityEvent(c.Req.Context(), c.OrgId, uid, store.EntityEventTypeDelete)
 
 	return response.JSON(http.StatusOK, DeleteLibraryElementResponse{
 		Message: "Library element deleted",
@@ -126,6 +130,7 @@ func (l *LibraryElementService) patchHandler(c *models.ReqContext) response.Resp
 	if err != nil {
 		return toLibraryElementError(err, "Failed to update library element")
 	}
+	l.saveEntityEvent(c.Req.Context(), c.OrgId, element.UID, store.EntityEventTypeUpdate)
 
 	if element.FolderID != 0 {
 		folder, err := l.folderService.GetFolderByID(c.Req.Context(), c.SignedInUser, element.FolderID, c.OrgId)
@@ -8,16 +8,19 @@ import (
 	"github.com/grafana/grafana/pkg/models"
 	"github.com/grafana/grafana/pkg/services/dashboards"
 	"github.com/grafana/grafana/pkg/services/sqlstore"
+	"github.com/grafana/grafana/pkg/services/store"
 	"github.com/grafana/grafana/pkg/setting"
 )
 
-func ProvideService(cfg *setting.Cfg, sqlStore *sqlstore.SQLStore, routeRegister routing.RouteRegister, folderService dashboards.FolderService) *LibraryElementService {
+func ProvideService(cfg *setting.Cfg, sqlStore *sqlstore.SQLStore, routeRegister routing.RouteRegister, folderService dashboards.FolderService,
+	entityEventsService store.EntityEventsService) *LibraryElementService {
 	l := &LibraryElementService{
-		Cfg:           cfg,
-		SQLStore:      sqlStore,
-		RouteRegister: routeRegister,
-		folderService: folderService,
-		log:           log.New("library-elements"),
+		Cfg:                 cfg,
+		SQLStore:            sqlStore,
+		RouteRegister:       routeRegister,
+		folderService:       folderService,
+		entityEventsService: entityEventsService,
+		log:                 log.New("library-elements"),
 	}
 	l.registerAPIEndpoints()
 	return l
@@ -39,7 +42,9 @@ type LibraryElementService struct {
 	SQLStore      *sqlstore.SQLStore
 	RouteRegister routing.RouteRegister
 	folderService dashboards.FolderService
-	log           log.Logger
+	// entityEventsService is optional and used to notify the search index about changed elements.
+	entityEventsService store.EntityEventsService
+	log                 log.Logger
 }
 
 // CreateElement creates a Library Element.
@@ -59,7 +64,14 @@ func (l *LibraryElementService) GetElementsForDashboard(c context.Context, dashb
 
 // ConnectElementsToDashboard connects elements to a specific dashboard.
 func (l *LibraryElementService) ConnectElementsToDashboard(c context.Context, signedInUser *models.SignedInUser, elementUIDs []string, dashboardID int64) error {
-	return l.connectElementsToDashboardID(c, signedInUser, elementUIDs, dashboardID)
+	if err := l.connectElementsToDashboardID(c, signedInUser, elementUIDs, dashboardID); err != nil {
+		return err
+	}
+	// The connected dashboards are part of the indexed library panels.
+	for _, uid := range elementUIDs {
+		l.saveEntityEvent(c, signedInUser.OrgId, uid, store.EntityEventTypeUpdate)
+	}
+	return nil
 }
 
 // DisconnectElementsFromDashboard disconnects elements from a specific dashboard.
@@ -71,3 +83,16 @@ func (l *LibraryElementService) DisconnectElementsFromDashboard(c context.Contex
 func (l *LibraryElementService) DeleteLibraryElementsInFolder(c context.Context, signedInUser *models.SignedInUser, folderUID string) error {
 	return l.deleteLibraryElementsInFolderUID(c, signedInUser, folderUID)
 }
+
+// saveEntityEvent records a change of a library element so that the search index can be updated.
+func (l *LibraryElementService) saveEntityEvent(c context.Context, orgID int64, uid string, eventType store.EntityEventType) {
+	if l.entityEventsService == nil {
+		return
+	}
+	if err := l.entityEventsService.SaveEvent(c, store.SaveEventCmd{
+		EntityId:  store.CreateDatabaseEntityId(uid, orgID, store.EntityTypeLibraryPanel),
+		EventType: eventType,
+	}); err != nil {
+		l.log.Warn("failed to save library element entity event", "uid", uid, "error", err)
+	}
+}
@@ -226,6 +226,29 @@ func ProvideService(plugCtxProvider *plugincontext.Provider, cfg *setting.Cfg, r
 		if err != nil {
 			return nil, err
 		}
+
+		if g.pipelineStorage != nil {
+			inputBuilder := &pipeline.InputBuilder{
+				Processor:      g.Pipeline,
+				SecretsService: g.SecretsService,
+			}
+			for _, org := range orgQuery.Result {
+				inputConfigs, err := g.pipelineStorage.ListInputConfigs(context.Background(), org.Id)
+				if err != nil {
+					logger.Error("Error listing pipeline inputs, skipping them", "orgId", org.Id, "error", err)
+					continue
+				}
+				for _, inputConfig := range inputConfigs {
+					input, err := inputBuilder.BuildInput(context.Background(), inputConfig)
+					if err != nil {
+						// Inputs are independent, one that can't be built must not stop the others.
+						logger.Error("Error building pipeline input, skipping it", "orgId", org.Id, "uid", inputConfig.UID, "error", err)
+						continue
+					}
+					g.pipelineInputs = append(g.pipelineInputs, input)
+				}
+			}
+		}
 	}
 
 	g.contextGetter = liveplugin.NewContextGetter(g.PluginContextProvider, g.DataSourceCache)
@@ -428,6 +451,7 @@ type GrafanaLive struct {
 	ManagedStreamRunner *managedstream.Runner
 	Pipeline            *pipeline.Pipeline
 	pipelineStorage     pipeline.Storage
+	pipelineInputs      []pipeline.Input
 
 	contextGetter    *liveplugin.ContextGetter
 	runStreamManager *runstream.Manager
@@ -472,6 +496,13 @@ func (g *GrafanaLive) Run(ctx context.Context) error {
 		})
 	}
 
+	for _, input := range g.pipelineInputs {
+		input := input
+		eGroup.Go(func() error {
+			return input.Run(eCtx)
+		})
+	}
+
 	return eGroup.Wait()
 }
 
@@ -1117,6 +1148,10 @@ func (s *DryRunRuleStorage) ListWriteConfigs(_ context.Context, _ int64) ([]pipe
 	return nil, nil
 }
 
+func (s *DryRunRuleStorage) ListInputConfigs(_ context.Context, _ int64) ([]pipeline.InputConfig, error) {
+	return nil, nil
+}
+
 func (s *DryRunRuleStorage) ListChannelRules(_ context.Context, _ int64) ([]pipeline.ChannelRule, error) {
 	return s.ChannelRules, nil
 }
@@ -1238,6 +1273,22 @@ func (g *GrafanaLive) HandlePipelineEntitiesListHTTP(_ *models.ReqContext) respo
 		"converters":      pipeline.ConvertersRegistry,
 		"frameProcessors": pipeline.FrameProcessorsRegistry,
 		"frameOutputs":    pipeline.FrameOutputsRegistry,
+		"inputs":          pipeline.InputsRegistry,
+	})
+}
+
+// HandleInputConfigsListHTTP ...
+func (g *GrafanaLive) HandleInputConfigsListHTTP(c *models.ReqContext) response.Response {
+	inputConfigs, err := g.pipelineStorage.ListInputConfigs(c.Req.Context(), c.OrgId)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to get input configs", err)
+	}
+	result := make([]pipeline.InputConfigDto, 0, len(inputConfigs))
+	for _, inputConfig := range inputConfigs {
+		result = append(result, pipeline.InputConfigToDto(inputConfig))
+	}
+	return response.JSON(http.StatusOK, util.DynMap{
+		"inputConfigs": result,
 	})
 }
 
@@ -0,0 +1,116 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/grafana/grafana/pkg/services/secrets"
+
+	liveDto "github.com/grafana/grafana-plugin-sdk-go/live"
+)
+
+// InputBuilder constructs inputs from their configuration.
+type InputBuilder struct {
+	Processor      InputProcessor
+	SecretsService secrets.Service
+}
+
+func (b *InputBuilder) BuildInput(ctx context.Context, config InputConfig) (Input, error) {
+	ok, reason := config.Valid()
+	if !ok {
+		return nil, fmt.Errorf("invalid input config %s: %s", config.UID, reason)
+	}
+	password, err := b.inputPassword(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid input config %s: %w", config.UID, err)
+	}
+	channels := newTopicChannels(b.Processor, config)
+	switch config.Settings.Type {
+	case InputTypeKafka:
+		return NewKafkaInput(*config.Settings.Kafka, password, channels), nil
+	case InputTypeMQTT:
+		return NewMQTTInput(*config.Settings.MQTT, password, channels), nil
+	default:
+		return nil, fmt.Errorf("unknown input type: %s", config.Settings.Type)
+	}
+}
+
+func (b *InputBuilder) inputPassword(ctx context.Context, config InputConfig) (string, error) {
+	if len(config.SecureSettings["password"]) > 0 {
+		passwordBytes, err := b.SecretsService.Decrypt(ctx, config.SecureSettings["password"])
+		if err != nil {
+			return "", fmt.Errorf("password can't be decrypted: %w", err)
+		}
+		return string(passwordBytes), nil
+	}
+	// Use plain text password (should be removed upon database integration).
+	switch config.Settings.Type {
+	case InputTypeKafka:
+		return config.Settings.Kafka.Password, nil
+	case InputTypeMQTT:
+		return config.Settings.MQTT.Password, nil
+	}
+	return "", nil
+}
+
+// topicChannels passes the messages received on topics to the channels the
+// topics are mapped to.
+type topicChannels struct {
+	processor InputProcessor
+	orgID     int64
+	inputUID  string
+	channels  map[string]string
+}
+
+func newTopicChannels(processor InputProcessor, config InputConfig) *topicChannels {
+	channels := make(map[string]string, len(config.Settings.Topics))
+	for _, t := range config.Settings.Topics {
+		channels[t.Topic] = t.Channel
+	}
+	return &topicChannels{
+		processor: processor,
+		orgID:     config.OrgId,
+		inputUID:  config.UID,
+		channels:  channels,
+	}
+}
+
+func (t *topicChannels) topics() []string {
+	topics := make([]string, 0, len(t.channels))
+	for topic := range t.channels {
+		topics = append(topics, topic)
+	}
+	return topics
+}
+
+// processMessage processes a message received on a topic. Errors are logged
+// rather than returned, so that a bad message does not stop the input.
+func (t *topicChannels) processMessage(ctx context.Context, topic string, body []byte) {
+	channelID, ok := t.channels[topic]
+	if !ok {
+		logger.Warn("Message received on unknown input topic", "input", t.inputUID, "topic", topic)
+		return
+	}
+	logger.Debug("Input message",
+		"input", t.inputUID,
+		"topic", topic,
+		"channel", channelID,
+		"bodyLength", len(body),
+	)
+	ruleFound, err := t.processor.ProcessInput(ctx, t.orgID, channelID, body)
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return
+		}
+		if errors.Is(err, liveDto.ErrInvalidChannelID) {
+			logger.Error("Invalid input channel", "input", t.inputUID, "topic", topic, "channel", channelID)
+			return
+		}
+		logger.Error("Pipeline input processing error", "error", err, "input", t.inputUID, "topic", topic, "channel", channelID)
+		return
+	}
+	if !ruleFound {
+		logger.Warn("No conversion rule for a channel", "input", t.inputUID, "topic", topic, "channel", channelID)
+	}
+}
@@ -0,0 +1,101 @@
+package pipeline
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl/plain"
+)
+
+const InputTypeKafka = "kafka"
+
+const (
+	defaultKafkaGroupID = "grafana-live"
+	kafkaRetryInterval  = 5 * time.Second
+)
+
+// KafkaInput consumes messages from Kafka topics as a member of a consumer
+// group, so that Grafana instances sharing the group process each message once.
+type KafkaInput struct {
+	settings KafkaInputSettings
+	password string
+	channels *topicChannels
+}
+
+func NewKafkaInput(settings KafkaInputSettings, password string, channels *topicChannels) *KafkaInput {
+	return &KafkaInput{
+		settings: settings,
+		password: password,
+		channels: channels,
+	}
+}
+
+func (in *KafkaInput) Type() string {
+	return InputTypeKafka
+}
+
+func (in *KafkaInput) readerConfig() kafka.ReaderConfig {
+	groupID := in.settings.GroupID
+	if groupID == "" {
+		groupID = defaultKafkaGroupID
+	}
+	dialer := &kafka.Dialer{
+		Timeout:   10 * time.Second,
+		DualStack: true,
+	}
+	if in.settings.TLS {
+		dialer.TLS = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	if in.settings.User != "" {
+		dialer.SASLMechanism = plain.Mechanism{
+			Username: in.settings.User,
+			Password: in.password,
+		}
+	}
+	return kafka.ReaderConfig{
+		Brokers:     in.settings.Brokers,
+		GroupID:     groupID,
+		GroupTopics: in.channels.topics(),
+		Dialer:      dialer,
+		// Only new messages are relevant for streaming.
+		StartOffset: kafka.LastOffset,
+		ErrorLogger: kafka.LoggerFunc(func(msg string, args ...interface{}) {
+			logger.Error("Kafka input error", "input", in.channels.inputUID, "error", fmt.Sprintf(msg, args...))
+		}),
+	}
+}
+
+func (in *KafkaInput) Run(ctx context.Context) error {
+	reader := kafka.NewReader(in.readerConfig())
+	defer func() {
+		if err := reader.Close(); err != nil {
+			logger.Error("Error closing Kafka input", "input", in.channels.inputUID, "error", err)
+		}
+	}()
+
+	for {
+		msg, err := reader.ReadMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if errors.Is(err, io.EOF) {
+				// Reader was closed.
+				return nil
+			}
+			logger.Error("Error reading Kafka message", "input", in.channels.inputUID, "error", err)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(kafkaRetryInterval):
+			}
+			continue
+		}
+		in.channels.processMessage(ctx, msg.Topic, msg.Value)
+	}
+}
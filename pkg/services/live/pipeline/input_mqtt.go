@@ -0,0 +1,86 @@
+package pipeline
+
+import (
+	"context"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+const InputTypeMQTT = "mqtt"
+
+const mqttDisconnectQuiesce = 250 // milliseconds
+
+// MQTTInput subscribes to MQTT topics. Every Grafana instance receives all the
+// messages, topics may use MQTT wildcards.
+type MQTTInput struct {
+	settings MQTTInputSettings
+	password string
+	channels *topicChannels
+}
+
+func NewMQTTInput(settings MQTTInputSettings, password string, channels *topicChannels) *MQTTInput {
+	return &MQTTInput{
+		settings: settings,
+		password: password,
+		channels: channels,
+	}
+}
+
+func (in *MQTTInput) Type() string {
+	return InputTypeMQTT
+}
+
+func (in *MQTTInput) clientOptions(ctx context.Context) *mqtt.ClientOptions {
+	clientID := in.settings.ClientID
+	if clientID == "" {
+		clientID = "grafana-" + util.GenerateShortUID()
+	}
+	opts := mqtt.NewClientOptions().
+		AddBroker(in.settings.Broker).
+		SetClientID(clientID).
+		SetAutoReconnect(true).
+		SetConnectRetry(true).
+		SetConnectRetryInterval(5 * time.Second).
+		SetConnectionLostHandler(func(_ mqtt.Client, err error) {
+			logger.Warn("MQTT input connection lost", "input", in.channels.inputUID, "error", err)
+		}).
+		// Subscribe on every connect, as subscriptions of a clean session do
+		// not survive reconnects.
+		SetOnConnectHandler(func(client mqtt.Client) {
+			in.subscribe(ctx, client)
+		})
+	if in.settings.User != "" {
+		opts.SetUsername(in.settings.User)
+		opts.SetPassword(in.password)
+	}
+	return opts
+}
+
+func (in *MQTTInput) subscribe(ctx context.Context, client mqtt.Client) {
+	for _, topic := range in.channels.topics() {
+		topic := topic
+		token := client.Subscribe(topic, in.settings.QoS, func(_ mqtt.Client, msg mqtt.Message) {
+			// Messages of wildcard subscriptions have the actual topic, they are
+			// processed in the channel of the subscription.
+			in.channels.processMessage(ctx, topic, msg.Payload())
+		})
+		go func() {
+			<-token.Done()
+			if err := token.Error(); err != nil {
+				logger.Error("MQTT input subscribe error", "input", in.channels.inputUID, "topic", topic, "error", err)
+			}
+		}()
+	}
+}
+
+func (in *MQTTInput) Run(ctx context.Context) error {
+	client := mqtt.NewClient(in.clientOptions(ctx))
+	// With connect retry the token only completes once connected, or when
+	// the client is disconnected.
+	client.Connect()
+	<-ctx.Done()
+	client.Disconnect(mqttDisconnectQuiesce)
+	return ctx.Err()
+}
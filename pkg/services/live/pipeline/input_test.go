@@ -0,0 +1,226 @@
+package pipeline
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/grafana/grafana/pkg/services/secrets/fakes"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testMQTTInputConfig() InputConfig {
+	return InputConfig{
+		OrgId: 1,
+		UID:   "devices",
+		Settings: InputSettings{
+			Type: InputTypeMQTT,
+			MQTT: &MQTTInputSettings{
+				Broker: "tcp://localhost:1883",
+				User:   "grafana",
+			},
+			Topics: []InputTopic{
+				{Topic: "devices/+/temperature", Channel: "stream/devices/temperature"},
+				{Topic: "devices/+/humidity", Channel: "stream/devices/humidity"},
+			},
+		},
+	}
+}
+
+func TestInputConfig_Valid(t *testing.T) {
+	ok, reason := testMQTTInputConfig().Valid()
+	require.True(t, ok, reason)
+
+	tests := []struct {
+		name   string
+		modify func(c *InputConfig)
+		reason string
+	}{
+		{
+			name:   "missing uid",
+			modify: func(c *InputConfig) { c.UID = "" },
+			reason: "uid required",
+		},
+		{
+			name:   "unknown type",
+			modify: func(c *InputConfig) { c.Settings.Type = "amqp" },
+			reason: "unknown input type: amqp",
+		},
+		{
+			name:   "missing kafka brokers",
+			modify: func(c *InputConfig) { c.Settings.Type = InputTypeKafka },
+			reason: "kafka brokers required",
+		},
+		{
+			name:   "invalid qos",
+			modify: func(c *InputConfig) { c.Settings.MQTT.QoS = 3 },
+			reason: "invalid mqtt qos: 3",
+		},
+		{
+			name:   "missing topics",
+			modify: func(c *InputConfig) { c.Settings.Topics = nil },
+			reason: "topics required",
+		},
+		{
+			name: "duplicate topic",
+			modify: func(c *InputConfig) {
+				c.Settings.Topics = append(c.Settings.Topics, InputTopic{Topic: "devices/+/humidity", Channel: "stream/devices/other"})
+			},
+			reason: "duplicate topic: devices/+/humidity",
+		},
+		{
+			name:   "invalid channel",
+			modify: func(c *InputConfig) { c.Settings.Topics[0].Channel = "devices" },
+			reason: "invalid channel for topic devices/+/temperature: invalid channel ID",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testMQTTInputConfig()
+			tt.modify(&c)
+			ok, reason := c.Valid()
+			require.False(t, ok)
+			require.Equal(t, tt.reason, reason)
+		})
+	}
+}
+
+func TestFileStorage_ListInputConfigs(t *testing.T) {
+	dataPath := t.TempDir()
+	storage := &FileStorage{DataPath: dataPath}
+
+	configs, err := storage.ListInputConfigs(context.Background(), 1)
+	require.NoError(t, err)
+	require.Empty(t, configs)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dataPath, "pipeline"), 0750))
+	err = os.WriteFile(filepath.Join(dataPath, "pipeline", "input-configs.json"), []byte(`{
+		"inputConfigs": [{
+			"uid": "devices",
+			"settings": {
+				"type": "mqtt",
+				"mqtt": {"broker": "tcp://localhost:1883"},
+				"topics": [{"topic": "devices/+/temperature", "channel": "stream/devices/temperature"}]
+			}
+		}]
+	}`), 0600)
+	require.NoError(t, err)
+
+	configs, err = storage.ListInputConfigs(context.Background(), 1)
+	require.NoError(t, err)
+	require.Len(t, configs, 1)
+	require.Equal(t, int64(1), configs[0].OrgId)
+	require.Equal(t, "tcp://localhost:1883", configs[0].Settings.MQTT.Broker)
+
+	configs, err = storage.ListInputConfigs(context.Background(), 2)
+	require.NoError(t, err)
+	require.Empty(t, configs)
+}
+
+func TestInputBuilder_BuildInput(t *testing.T) {
+	builder := &InputBuilder{
+		Processor:      &testInputProcessor{},
+		SecretsService: fakes.NewFakeSecretsService(),
+	}
+
+	t.Run("mqtt", func(t *testing.T) {
+		c := testMQTTInputConfig()
+		c.SecureSettings = map[string][]byte{"password": []byte("secret")}
+		input, err := builder.BuildInput(context.Background(), c)
+		require.NoError(t, err)
+		require.Equal(t, InputTypeMQTT, input.Type())
+		require.Equal(t, "secret", input.(*MQTTInput).password)
+	})
+
+	t.Run("kafka", func(t *testing.T) {
+		c := testMQTTInputConfig()
+		c.Settings.Type = InputTypeKafka
+		c.Settings.Kafka = &KafkaInputSettings{Brokers: []string{"localhost:9092"}, User: "grafana", Password: "plain"}
+		input, err := builder.BuildInput(context.Background(), c)
+		require.NoError(t, err)
+		require.Equal(t, InputTypeKafka, input.Type())
+
+		readerConfig := input.(*KafkaInput).readerConfig()
+		require.Equal(t, defaultKafkaGroupID, readerConfig.GroupID)
+		require.ElementsMatch(t, []string{"devices/+/temperature", "devices/+/humidity"}, readerConfig.GroupTopics)
+		require.NotNil(t, readerConfig.Dialer.SASLMechanism)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		c := testMQTTInputConfig()
+		c.Settings.MQTT = nil
+		_, err := builder.BuildInput(context.Background(), c)
+		require.EqualError(t, err, "invalid input config devices: mqtt broker required")
+	})
+}
+
+func TestInputConfigToDto(t *testing.T) {
+	t.Run("kafka", func(t *testing.T) {
+		c := testMQTTInputConfig()
+		c.Settings.Type = InputTypeKafka
+		c.Settings.MQTT = nil
+		c.Settings.Kafka = &KafkaInputSettings{Brokers: []string{"localhost:9092"}, User: "grafana", Password: "plain"}
+		dto := InputConfigToDto(c)
+		require.Equal(t, "", dto.Settings.Kafka.Password)
+		require.Equal(t, "grafana", dto.Settings.Kafka.User)
+		require.True(t, dto.SecureFields["password"])
+		require.Equal(t, "plain", c.Settings.Kafka.Password)
+	})
+
+	t.Run("mqtt", func(t *testing.T) {
+		c := testMQTTInputConfig()
+		c.Settings.MQTT.Password = "plain"
+		dto := InputConfigToDto(c)
+		require.Equal(t, "", dto.Settings.MQTT.Password)
+		require.True(t, dto.SecureFields["password"])
+		require.Equal(t, "plain", c.Settings.MQTT.Password)
+	})
+
+	t.Run("without password", func(t *testing.T) {
+		dto := InputConfigToDto(testMQTTInputConfig())
+		require.Empty(t, dto.SecureFields)
+	})
+}
+
+type testInputProcessor struct {
+	orgID    int64
+	channels []string
+}
+
+func (p *testInputProcessor) ProcessInput(_ context.Context, orgID int64, channelID string, _ []byte) (bool, error) {
+	p.orgID = orgID
+	p.channels = append(p.channels, channelID)
+	return true, nil
+}
+
+func TestTopicChannels_ProcessMessage(t *testing.T) {
+	processor := &testInputProcessor{}
+	channels := newTopicChannels(processor, testMQTTInputConfig())
+
+	channels.processMessage(context.Background(), "devices/+/humidity", []byte(`{"value": 1}`))
+	channels.processMessage(context.Background(), "unknown", []byte(`{"value": 1}`))
+	require.Equal(t, int64(1), processor.orgID)
+	require.Equal(t, []string{"stream/devices/humidity"}, processor.channels)
+}
+
+func TestTopicChannels_ProcessMessagePipeline(t *testing.T) {
+	outputter := &testOutputter{}
+	p, err := New(&testRuleGetter{
+		rules: map[string]*LiveChannelRule{
+			"stream/devices/temperature": {
+				Converter:       NewAutoJsonConverter(AutoJsonConverterConfig{}),
+				FrameOutputters: []FrameOutputter{outputter},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	channels := newTopicChannels(p, testMQTTInputConfig())
+	channels.processMessage(context.Background(), "devices/+/temperature", []byte(`{"value": 21.5}`))
+	require.NotNil(t, outputter.frame)
+	field, _ := outputter.frame.FieldByName("value")
+	require.NotNil(t, field)
+	require.Equal(t, 21.5, *(field.At(0).(*float64)))
+}
@@ -3,6 +3,8 @@ package pipeline
 import (
 	"fmt"
 
+	"github.com/grafana/grafana-plugin-sdk-go/live"
+
 	"github.com/grafana/grafana/pkg/services/live/pipeline/pattern"
 	"github.com/grafana/grafana/pkg/services/live/pipeline/tree"
 )
@@ -121,6 +123,137 @@ type WriteSettings struct {
 	BasicAuth *BasicAuth `json:"basicAuth,omitempty"`
 }
 
+// InputConfigToDto converts an input config to its representation in the API. Plain text passwords
+// are not returned, they are reported as a secure field instead.
+func InputConfigToDto(c InputConfig) InputConfigDto {
+	secureFields := make(map[string]bool, len(c.SecureSettings))
+	for k := range c.SecureSettings {
+		secureFields[k] = true
+	}
+	settings := c.Settings
+	if settings.Kafka != nil && settings.Kafka.Password != "" {
+		kafka := *settings.Kafka
+		kafka.Password = ""
+		settings.Kafka = &kafka
+		secureFields["password"] = true
+	}
+	if settings.MQTT != nil && settings.MQTT.Password != "" {
+		mqtt := *settings.MQTT
+		mqtt.Password = ""
+		settings.MQTT = &mqtt
+		secureFields["password"] = true
+	}
+	return InputConfigDto{
+		UID:          c.UID,
+		Settings:     settings,
+		SecureFields: secureFields,
+	}
+}
+
+type InputConfigDto struct {
+	UID          string          `json:"uid"`
+	Settings     InputSettings   `json:"settings"`
+	SecureFields map[string]bool `json:"secureFields"`
+}
+
+type InputConfig struct {
+	OrgId          int64             `json:"-"`
+	UID            string            `json:"uid"`
+	Settings       InputSettings     `json:"settings"`
+	SecureSettings map[string][]byte `json:"secureSettings,omitempty"`
+}
+
+func (c InputConfig) Valid() (bool, string) {
+	if c.UID == "" {
+		return false, "uid required"
+	}
+	if !typeRegistered(c.Settings.Type, InputsRegistry) {
+		return false, fmt.Sprintf("unknown input type: %s", c.Settings.Type)
+	}
+	switch c.Settings.Type {
+	case InputTypeKafka:
+		if c.Settings.Kafka == nil || len(c.Settings.Kafka.Brokers) == 0 {
+			return false, "kafka brokers required"
+		}
+	case InputTypeMQTT:
+		if c.Settings.MQTT == nil || c.Settings.MQTT.Broker == "" {
+			return false, "mqtt broker required"
+		}
+		if c.Settings.MQTT.QoS > 2 {
+			return false, fmt.Sprintf("invalid mqtt qos: %d", c.Settings.MQTT.QoS)
+		}
+	}
+	if len(c.Settings.Topics) == 0 {
+		return false, "topics required"
+	}
+	topics := make(map[string]struct{}, len(c.Settings.Topics))
+	for _, t := range c.Settings.Topics {
+		if t.Topic == "" {
+			return false, "topic required"
+		}
+		if _, ok := topics[t.Topic]; ok {
+			return false, fmt.Sprintf("duplicate topic: %s", t.Topic)
+		}
+		topics[t.Topic] = struct{}{}
+		if _, err := live.ParseChannel(t.Channel); err != nil {
+			return false, fmt.Sprintf("invalid channel for topic %s: %v", t.Topic, err)
+		}
+	}
+	return true, ""
+}
+
+type InputSettings struct {
+	// Type of the input, kafka or mqtt.
+	Type string `json:"type"`
+	// Kafka settings, required for kafka type.
+	Kafka *KafkaInputSettings `json:"kafka,omitempty"`
+	// MQTT settings, required for mqtt type.
+	MQTT *MQTTInputSettings `json:"mqtt,omitempty"`
+	// Topics to receive messages from, and the channels to process them in.
+	Topics []InputTopic `json:"topics"`
+}
+
+// InputTopic maps a topic of a message broker to a channel. Each message
+// received on the topic is processed by the channel rule matching the channel.
+type InputTopic struct {
+	Topic   string `json:"topic"`
+	Channel string `json:"channel"`
+}
+
+type KafkaInputSettings struct {
+	// Brokers to connect to, host:port.
+	Brokers []string `json:"brokers"`
+	// GroupID is a consumer group ID. Grafana instances with the same group
+	// share the messages of the topics. Defaults to grafana-live.
+	GroupID string `json:"groupId,omitempty"`
+	// TLS enables TLS connections to brokers.
+	TLS bool `json:"tls,omitempty"`
+	// User for SASL/PLAIN authentication, no authentication if empty.
+	User string `json:"user,omitempty"`
+	// Password is a plain text non-encrypted password.
+	// TODO: remove after integrating with the database.
+	Password string `json:"password,omitempty"`
+}
+
+type MQTTInputSettings struct {
+	// Broker to connect to, for example tcp://localhost:1883 or ssl://localhost:8883.
+	Broker string `json:"broker"`
+	// ClientID of the connection. Generated if empty, brokers disconnect
+	// clients with duplicate IDs.
+	ClientID string `json:"clientId,omitempty"`
+	// QoS of the subscriptions, 0, 1 or 2.
+	QoS byte `json:"qos,omitempty"`
+	// User for authentication, no authentication if empty.
+	User string `json:"user,omitempty"`
+	// Password is a plain text non-encrypted password.
+	// TODO: remove after integrating with the database.
+	Password string `json:"password,omitempty"`
+}
+
+type InputConfigs struct {
+	Configs []InputConfig `json:"inputConfigs"`
+}
+
 type WriteConfigs struct {
 	Configs []WriteConfig `json:"writeConfigs"`
 }
@@ -110,6 +110,19 @@ type Subscriber interface {
 	Subscribe(ctx context.Context, vars Vars, data []byte) (models.SubscribeReply, backend.SubscribeStreamStatus, error)
 }
 
+// Input receives data from an external source, such as a message broker, and
+// passes it to a channel of Pipeline.
+type Input interface {
+	Type() string
+	// Run receives data until ctx is done.
+	Run(ctx context.Context) error
+}
+
+// InputProcessor processes data received by an Input. Implemented by Pipeline.
+type InputProcessor interface {
+	ProcessInput(ctx context.Context, orgID int64, channelID string, body []byte) (bool, error)
+}
+
 // PublishAuthChecker checks whether current user can publish to a channel.
 type PublishAuthChecker interface {
 	CanPublish(ctx context.Context, u *models.SignedInUser) (bool, error)
@@ -99,3 +99,20 @@ var DataOutputsRegistry = []EntityInfo{
 		Description: "output data to Loki as logs",
 	},
 }
+
+var InputsRegistry = []EntityInfo{
+	{
+		Type:        InputTypeKafka,
+		Description: "consume messages from Kafka topics",
+		Example: KafkaInputSettings{
+			Brokers: []string{"localhost:9092"},
+		},
+	},
+	{
+		Type:        InputTypeMQTT,
+		Description: "subscribe to MQTT broker topics",
+		Example: MQTTInputSettings{
+			Broker: "tcp://localhost:1883",
+		},
+	},
+}
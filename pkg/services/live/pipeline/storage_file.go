@@ -338,3 +338,43 @@ func (f *FileStorage) saveWriteConfigs(_ int64, writeConfigs WriteConfigs) error
 	}
 	return nil
 }
+
+func (f *FileStorage) ListInputConfigs(_ context.Context, orgID int64) ([]InputConfig, error) {
+	inputConfigs, err := f.readInputConfigs()
+	if err != nil {
+		return nil, fmt.Errorf("can't read input configs: %w", err)
+	}
+	var orgConfigs []InputConfig
+	for _, c := range inputConfigs.Configs {
+		if c.OrgId == orgID || (orgID == 1 && c.OrgId == 0) {
+			c.OrgId = orgID
+			orgConfigs = append(orgConfigs, c)
+		}
+	}
+	return orgConfigs, nil
+}
+
+func (f *FileStorage) inputConfigsFilePath() string {
+	return filepath.Join(f.DataPath, "pipeline", "input-configs.json")
+}
+
+// readInputConfigs reads input configs, inputs are optional so a missing
+// file means there are none.
+func (f *FileStorage) readInputConfigs() (InputConfigs, error) {
+	filePath := f.inputConfigsFilePath()
+	// Safe to ignore gosec warning G304.
+	// nolint:gosec
+	bytes, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return InputConfigs{}, nil
+		}
+		return InputConfigs{}, fmt.Errorf("can't read %s file: %w", filePath, err)
+	}
+	var inputConfigs InputConfigs
+	err = json.Unmarshal(bytes, &inputConfigs)
+	if err != nil {
+		return InputConfigs{}, fmt.Errorf("can't unmarshal %s data: %w", filePath, err)
+	}
+	return inputConfigs, nil
+}
@@ -35,7 +35,7 @@ type Scheduler interface {
 
 type Alertmanager interface {
 	// Configuration
-	SaveAndApplyConfig(ctx context.Context, config *apimodels.PostableUserConfig) error
+	SaveAndApplyConfig(ctx context.Context, config *apimodels.PostableUserConfig, createdBy string) error
 	SaveAndApplyDefaultConfig(ctx context.Context) error
 	GetStatus() apimodels.GettableStatus
 
@@ -44,6 +44,9 @@ type Alertmanager interface {
 	DeleteSilence(silenceID string) error
 	GetSilence(silenceID string) (apimodels.GettableSilence, error)
 	ListSilences(filter []string) (apimodels.GettableSilences, error)
+	CreateSilences(pss []*apimodels.PostableSilence) ([]string, error)
+	ExpireSilences(filter []string) ([]string, error)
+	PreviewSilence(ps *apimodels.PostableSilence) (apimodels.GettableAlerts, error)
 
 	// Alerts
 	GetAlerts(active, silenced, inhibited bool, filter []string, receiver string) (apimodels.GettableAlerts, error)
@@ -69,6 +72,8 @@ type API struct {
 	ProvenanceStore      provisioning.ProvisioningStore
 	RuleStore            store.RuleStore
 	InstanceStore        store.InstanceStore
+	StateHistoryStore    store.StateHistoryStore
+	DeliveryStore        store.NotificationDeliveryStore
 	AlertingStore        AlertingStore
 	AdminConfigStore     store.AdminConfigurationStore
 	DataProxy            *datasourceproxy.DataSourceProxyService
@@ -81,6 +86,10 @@ type API struct {
 	Templates            *provisioning.TemplateService
 	MuteTimings          *provisioning.MuteTimingService
 	AlertRules           *provisioning.AlertRuleService
+	ProvisioningDryRun   *provisioning.DryRunService
+	ProvisioningExport   *provisioning.ExportService
+	RuleTemplates        *provisioning.RuleTemplateService
+	SilenceSchedules     SilenceScheduleService
 }
 
 // RegisterAPIEndpoints registers API handlers
@@ -94,7 +103,7 @@ func (api *API) RegisterAPIEndpoints(m *metrics.API) {
 	api.RegisterAlertmanagerApiEndpoints(NewForkedAM(
 		api.DatasourceCache,
 		NewLotexAM(proxy, logger),
-		&AlertmanagerSrv{crypto: api.MultiOrgAlertmanager.Crypto, log: logger, ac: api.AccessControl, mam: api.MultiOrgAlertmanager},
+		&AlertmanagerSrv{crypto: api.MultiOrgAlertmanager.Crypto, log: logger, ac: api.AccessControl, mam: api.MultiOrgAlertmanager, silenceSchedules: api.SilenceSchedules},
 	), m)
 	// Register endpoints for proxying to Prometheus-compatible backends.
 	api.RegisterPrometheusApiEndpoints(NewForkedProm(
@@ -142,5 +151,21 @@ func (api *API) RegisterAPIEndpoints(m *metrics.API) {
 		templates:           api.Templates,
 		muteTimings:         api.MuteTimings,
 		alertRules:          api.AlertRules,
+		dryRun:              api.ProvisioningDryRun,
+		export:              api.ProvisioningExport,
+		ruleTemplates:       api.RuleTemplates,
+	}), m)
+
+	api.RegisterHistoryApiEndpoints(NewForkedHistoryApi(&HistorySrv{
+		log:     logger,
+		store:   api.RuleStore,
+		history: api.StateHistoryStore,
+		ac:      api.AccessControl,
+	}), m)
+
+	api.RegisterDeliveriesApiEndpoints(NewForkedDeliveriesApi(&DeliveriesSrv{
+		log:   logger,
+		store: api.DeliveryStore,
+		mam:   api.MultiOrgAlertmanager,
 	}), m)
 }
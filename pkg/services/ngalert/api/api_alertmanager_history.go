@@ -0,0 +1,53 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/models"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/store"
+)
+
+func (srv AlertmanagerSrv) RouteGetAlertingConfigHistory(c *models.ReqContext) response.Response {
+	versions, err := srv.mam.GetAlertmanagerConfigurationHistory(c.Req.Context(), c.OrgId, c.QueryInt("limit"))
+	if err != nil {
+		return ErrResp(http.StatusInternalServerError, err, "failed to get the history of the Alertmanager configuration")
+	}
+	return response.JSON(http.StatusOK, versions)
+}
+
+func (srv AlertmanagerSrv) RouteGetAlertingConfigDiff(c *models.ReqContext) response.Response {
+	from, to := c.QueryInt64("from"), c.QueryInt64("to")
+	if from <= 0 || to <= 0 {
+		return ErrResp(http.StatusBadRequest, errors.New("the query parameters 'from' and 'to' must be IDs of versions"), "")
+	}
+	changes, err := srv.mam.DiffAlertmanagerConfigurationVersions(c.Req.Context(), c.OrgId, from, to)
+	if err != nil {
+		if errors.Is(err, store.ErrAlertmanagerConfigurationVersionNotFound) {
+			return ErrResp(http.StatusNotFound, err, "")
+		}
+		return ErrResp(http.StatusInternalServerError, err, "failed to compare the versions of the Alertmanager configuration")
+	}
+	return response.JSON(http.StatusOK, apimodels.AlertmanagerConfigDiff{From: from, To: to, Changes: changes})
+}
+
+// RoutePostAlertingConfigRollback saves a previous version of the configuration as the latest version. It goes through
+// the same validation as any other configuration that is posted.
+func (srv AlertmanagerSrv) RoutePostAlertingConfigRollback(c *models.ReqContext) response.Response {
+	id, err := strconv.ParseInt(pathParam(c, ":ID"), 10, 64)
+	if err != nil {
+		return ErrResp(http.StatusBadRequest, fmt.Errorf("invalid version ID: %w", err), "")
+	}
+	cfg, err := srv.mam.GetAlertmanagerConfigurationForRollback(c.Req.Context(), c.OrgId, id)
+	if err != nil {
+		if errors.Is(err, store.ErrAlertmanagerConfigurationVersionNotFound) {
+			return ErrResp(http.StatusNotFound, err, "")
+		}
+		return ErrResp(http.StatusInternalServerError, err, "failed to get the version of the Alertmanager configuration")
+	}
+	return srv.RoutePostAlertingConfig(c, cfg)
+}
@@ -0,0 +1,216 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/models"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/notifier"
+)
+
+// SilenceScheduleService manages the silence schedules of the organizations.
+type SilenceScheduleService interface {
+	List(ctx context.Context, orgID int64) ([]*ngmodels.SilenceSchedule, error)
+	Get(ctx context.Context, orgID int64, uid string) (*ngmodels.SilenceSchedule, error)
+	Create(ctx context.Context, schedule *ngmodels.SilenceSchedule) (*ngmodels.SilenceSchedule, error)
+	Update(ctx context.Context, schedule *ngmodels.SilenceSchedule) (*ngmodels.SilenceSchedule, error)
+	Delete(ctx context.Context, orgID int64, uid string) error
+}
+
+func (srv AlertmanagerSrv) RoutePostSilencesBulk(c *models.ReqContext, body apimodels.PostableSilences) response.Response {
+	if len(body) == 0 {
+		return ErrResp(http.StatusBadRequest, errors.New("at least one silence is required"), "")
+	}
+	for i, ps := range body {
+		if ps == nil {
+			return ErrResp(http.StatusBadRequest, fmt.Errorf("silence %d is empty", i), "")
+		}
+		if err := ps.Validate(strfmt.Default); err != nil {
+			return ErrResp(http.StatusBadRequest, fmt.Errorf("silence %d: %w", i, err), "silence failed validation")
+		}
+	}
+
+	am, errResp := srv.AlertmanagerFor(c.OrgId)
+	if errResp != nil {
+		return errResp
+	}
+
+	ids, err := am.CreateSilences(body)
+	if err != nil {
+		if errors.Is(err, notifier.ErrCreateSilenceBadPayload) {
+			return ErrResp(http.StatusBadRequest, err, "")
+		}
+		return ErrResp(http.StatusInternalServerError, err, "failed to create silences")
+	}
+	return response.JSON(http.StatusAccepted, apimodels.SilencesResult{SilenceIDs: ids})
+}
+
+func (srv AlertmanagerSrv) RoutePostSilencesExpire(c *models.ReqContext, body apimodels.SilencesExpireRequest) response.Response {
+	am, errResp := srv.AlertmanagerFor(c.OrgId)
+	if errResp != nil {
+		return errResp
+	}
+
+	ids, err := am.ExpireSilences(body.Filter)
+	if err != nil {
+		if errors.Is(err, notifier.ErrExpireSilencesBadPayload) {
+			return ErrResp(http.StatusBadRequest, err, "")
+		}
+		return ErrResp(http.StatusInternalServerError, err, "failed to expire silences")
+	}
+	return response.JSON(http.StatusOK, apimodels.SilencesResult{SilenceIDs: ids})
+}
+
+func (srv AlertmanagerSrv) RoutePostSilencePreview(c *models.ReqContext, body apimodels.PostableSilence) response.Response {
+	if err := body.Validate(strfmt.Default); err != nil {
+		return ErrResp(http.StatusBadRequest, err, "silence failed validation")
+	}
+
+	am, errResp := srv.AlertmanagerFor(c.OrgId)
+	if errResp != nil {
+		return errResp
+	}
+
+	alerts, err := am.PreviewSilence(&body)
+	if err != nil {
+		if errors.Is(err, notifier.ErrCreateSilenceBadPayload) {
+			return ErrResp(http.StatusBadRequest, err, "")
+		}
+		if errors.Is(err, notifier.ErrGetAlertsUnavailable) {
+			return ErrResp(http.StatusServiceUnavailable, err, "")
+		}
+		return ErrResp(http.StatusInternalServerError, err, "failed to preview silence")
+	}
+	return response.JSON(http.StatusOK, alerts)
+}
+
+func (srv AlertmanagerSrv) RouteGetSilenceSchedules(c *models.ReqContext) response.Response {
+	schedules, err := srv.silenceSchedules.List(c.Req.Context(), c.OrgId)
+	if err != nil {
+		return ErrResp(http.StatusInternalServerError, err, "failed to get silence schedules")
+	}
+	result := make(apimodels.SilenceSchedules, 0, len(schedules))
+	for _, s := range schedules {
+		result = append(result, silenceScheduleToAPI(s))
+	}
+	return response.JSON(http.StatusOK, result)
+}
+
+func (srv AlertmanagerSrv) RouteGetSilenceSchedule(c *models.ReqContext) response.Response {
+	schedule, err := srv.silenceSchedules.Get(c.Req.Context(), c.OrgId, pathParam(c, ":UID"))
+	if err != nil {
+		return silenceScheduleErrResp(err, "failed to get silence schedule")
+	}
+	return response.JSON(http.StatusOK, silenceScheduleToAPI(schedule))
+}
+
+func (srv AlertmanagerSrv) RoutePostSilenceSchedule(c *models.ReqContext, body apimodels.SilenceSchedule) response.Response {
+	schedule, err := silenceScheduleFromAPI(c, body)
+	if err != nil {
+		return ErrResp(http.StatusBadRequest, err, "")
+	}
+	created, err := srv.silenceSchedules.Create(c.Req.Context(), schedule)
+	if err != nil {
+		return silenceScheduleErrResp(err, "failed to create silence schedule")
+	}
+	return response.JSON(http.StatusCreated, silenceScheduleToAPI(created))
+}
+
+func (srv AlertmanagerSrv) RoutePutSilenceSchedule(c *models.ReqContext, body apimodels.SilenceSchedule) response.Response {
+	body.UID = pathParam(c, ":UID")
+	schedule, err := silenceScheduleFromAPI(c, body)
+	if err != nil {
+		return ErrResp(http.StatusBadRequest, err, "")
+	}
+	updated, err := srv.silenceSchedules.Update(c.Req.Context(), schedule)
+	if err != nil {
+		return silenceScheduleErrResp(err, "failed to update silence schedule")
+	}
+	return response.JSON(http.StatusOK, silenceScheduleToAPI(updated))
+}
+
+func (srv AlertmanagerSrv) RouteDeleteSilenceSchedule(c *models.ReqContext) response.Response {
+	if err := srv.silenceSchedules.Delete(c.Req.Context(), c.OrgId, pathParam(c, ":UID")); err != nil {
+		return silenceScheduleErrResp(err, "failed to delete silence schedule")
+	}
+	return response.JSON(http.StatusNoContent, nil)
+}
+
+func silenceScheduleErrResp(err error, message string) response.Response {
+	if errors.Is(err, ngmodels.ErrSilenceScheduleNotFound) {
+		return ErrResp(http.StatusNotFound, err, "")
+	}
+	if errors.Is(err, notifier.ErrSilenceScheduleInvalid) {
+		return ErrResp(http.StatusBadRequest, err, "")
+	}
+	return ErrResp(http.StatusInternalServerError, err, message)
+}
+
+// silenceScheduleFromAPI converts the silence schedule of a request. The creator defaults to the signed in user.
+func silenceScheduleFromAPI(c *models.ReqContext, s apimodels.SilenceSchedule) (*ngmodels.SilenceSchedule, error) {
+	if err := s.Matchers.Validate(strfmt.Default); err != nil {
+		return nil, err
+	}
+	matchers := make([]ngmodels.SilenceMatcher, 0, len(s.Matchers))
+	for _, m := range s.Matchers {
+		isEqual := true
+		if m.IsEqual != nil {
+			isEqual = *m.IsEqual
+		}
+		matchers = append(matchers, ngmodels.SilenceMatcher{
+			Name:    *m.Name,
+			Value:   *m.Value,
+			IsRegex: *m.IsRegex,
+			IsEqual: isEqual,
+		})
+	}
+	createdBy := s.CreatedBy
+	if createdBy == "" {
+		createdBy = c.Login
+	}
+	return &ngmodels.SilenceSchedule{
+		OrgID:         c.OrgId,
+		UID:           s.UID,
+		Comment:       s.Comment,
+		CreatedBy:     createdBy,
+		Matchers:      matchers,
+		Cron:          s.Cron,
+		Duration:      time.Duration(s.Duration),
+		TimeIntervals: s.TimeIntervals,
+		Timezone:      s.Timezone,
+	}, nil
+}
+
+func silenceScheduleToAPI(s *ngmodels.SilenceSchedule) apimodels.SilenceSchedule {
+	result := apimodels.SilenceSchedule{
+		UID:           s.UID,
+		Comment:       s.Comment,
+		CreatedBy:     s.CreatedBy,
+		Cron:          s.Cron,
+		Duration:      model.Duration(s.Duration),
+		TimeIntervals: s.TimeIntervals,
+		Timezone:      s.Timezone,
+		SilenceID:     s.SilenceID,
+		Updated:       s.Updated,
+	}
+	for _, m := range s.Matchers {
+		m := m
+		result.Matchers = append(result.Matchers, &amv2.Matcher{
+			Name:    &m.Name,
+			Value:   &m.Value,
+			IsRegex: &m.IsRegex,
+			IsEqual: &m.IsEqual,
+		})
+	}
+	return result
+}
@@ -0,0 +1,203 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/notifier"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+func TestRoutePostSilencesBulk(t *testing.T) {
+	srv := createSut(t, nil)
+
+	t.Run("creates the silences", func(t *testing.T) {
+		s1, s2 := silenceGen(withEmptyID)(), silenceGen(withEmptyID)()
+		resp := srv.RoutePostSilencesBulk(createRequestCtxInOrg(1), apimodels.PostableSilences{&s1, &s2})
+		require.Equal(t, http.StatusAccepted, resp.Status())
+
+		var result apimodels.SilencesResult
+		require.NoError(t, json.Unmarshal(resp.Body(), &result))
+		require.Len(t, result.SilenceIDs, 2)
+	})
+
+	t.Run("rejects an empty request", func(t *testing.T) {
+		resp := srv.RoutePostSilencesBulk(createRequestCtxInOrg(1), apimodels.PostableSilences{})
+		require.Equal(t, http.StatusBadRequest, resp.Status())
+	})
+
+	t.Run("rejects invalid silences", func(t *testing.T) {
+		s1, s2 := silenceGen(withEmptyID)(), silenceGen(withEmptyID)()
+		s2.Comment = nil
+		resp := srv.RoutePostSilencesBulk(createRequestCtxInOrg(1), apimodels.PostableSilences{&s1, &s2})
+		require.Equal(t, http.StatusBadRequest, resp.Status())
+	})
+
+	t.Run("rejects updates of silences", func(t *testing.T) {
+		s := silenceGen()()
+		resp := srv.RoutePostSilencesBulk(createRequestCtxInOrg(1), apimodels.PostableSilences{&s})
+		require.Equal(t, http.StatusBadRequest, resp.Status())
+	})
+}
+
+func TestRoutePostSilencesExpire(t *testing.T) {
+	srv := createSut(t, nil)
+
+	resp := srv.RoutePostSilencesExpire(createRequestCtxInOrg(1), apimodels.SilencesExpireRequest{})
+	require.Equal(t, http.StatusBadRequest, resp.Status())
+
+	name, value, isEqual, isRegex := "host", "a", true, false
+	s := silenceGen(withEmptyID)()
+	s.Matchers = amv2.Matchers{{Name: &name, Value: &value, IsEqual: &isEqual, IsRegex: &isRegex}}
+	resp = srv.RoutePostSilencesBulk(createRequestCtxInOrg(1), apimodels.PostableSilences{&s})
+	require.Equal(t, http.StatusAccepted, resp.Status())
+
+	resp = srv.RoutePostSilencesExpire(createRequestCtxInOrg(1), apimodels.SilencesExpireRequest{Filter: []string{`host="a"`}})
+	require.Equal(t, http.StatusOK, resp.Status())
+	var result apimodels.SilencesResult
+	require.NoError(t, json.Unmarshal(resp.Body(), &result))
+	require.Len(t, result.SilenceIDs, 1)
+}
+
+func TestRoutePostSilencePreview(t *testing.T) {
+	srv := createSut(t, nil)
+
+	resp := srv.RoutePostSilencePreview(createRequestCtxInOrg(1), silenceGen(withEmptyID)())
+	require.Equal(t, http.StatusOK, resp.Status())
+	require.JSONEq(t, `[]`, string(resp.Body()))
+
+	s := silenceGen(withEmptyID)()
+	s.Matchers = nil
+	resp = srv.RoutePostSilencePreview(createRequestCtxInOrg(1), s)
+	require.Equal(t, http.StatusBadRequest, resp.Status())
+}
+
+func TestRouteSilenceSchedules(t *testing.T) {
+	schedules := &fakeSilenceScheduleService{schedules: map[string]*ngmodels.SilenceSchedule{}}
+	srv := AlertmanagerSrv{silenceSchedules: schedules}
+	name, value, isRegex := "env", "prod", false
+	body := apimodels.SilenceSchedule{
+		UID:      "maintenance",
+		Matchers: amv2.Matchers{{Name: &name, Value: &value, IsRegex: &isRegex}},
+		Cron:     "0 22 * * 5",
+		Duration: model.Duration(2 * time.Hour),
+	}
+
+	t.Run("create defaults the creator to the signed in user", func(t *testing.T) {
+		rc := createRequestCtxInOrg(1)
+		rc.SignedInUser.Login = "admin"
+		resp := srv.RoutePostSilenceSchedule(rc, body)
+		require.Equal(t, http.StatusCreated, resp.Status())
+
+		created := schedules.schedules["maintenance"]
+		require.Equal(t, "admin", created.CreatedBy)
+		require.Equal(t, int64(1), created.OrgID)
+		require.Equal(t, 2*time.Hour, created.Duration)
+		require.Equal(t, []ngmodels.SilenceMatcher{{Name: "env", Value: "prod", IsEqual: true}}, created.Matchers)
+	})
+
+	t.Run("create rejects invalid silence schedules", func(t *testing.T) {
+		invalid := body
+		invalid.Cron = ""
+		resp := srv.RoutePostSilenceSchedule(createRequestCtxInOrg(1), invalid)
+		require.Equal(t, http.StatusBadRequest, resp.Status())
+
+		invalid = body
+		invalid.Matchers = amv2.Matchers{{Name: &name}}
+		resp = srv.RoutePostSilenceSchedule(createRequestCtxInOrg(1), invalid)
+		require.Equal(t, http.StatusBadRequest, resp.Status())
+	})
+
+	t.Run("get returns the silence schedules", func(t *testing.T) {
+		rc := createRequestCtxInOrg(1)
+		rc.Req = web.SetURLParams(rc.Req, map[string]string{":UID": "maintenance"})
+		resp := srv.RouteGetSilenceSchedule(rc)
+		require.Equal(t, http.StatusOK, resp.Status())
+		var schedule apimodels.SilenceSchedule
+		require.NoError(t, json.Unmarshal(resp.Body(), &schedule))
+		require.Equal(t, "0 22 * * 5", schedule.Cron)
+		require.Equal(t, model.Duration(2*time.Hour), schedule.Duration)
+
+		resp = srv.RouteGetSilenceSchedules(createRequestCtxInOrg(1))
+		require.Equal(t, http.StatusOK, resp.Status())
+		var all apimodels.SilenceSchedules
+		require.NoError(t, json.Unmarshal(resp.Body(), &all))
+		require.Len(t, all, 1)
+	})
+
+	t.Run("put replaces the silence schedule of the path", func(t *testing.T) {
+		rc := createRequestCtxInOrg(1)
+		rc.Req = web.SetURLParams(rc.Req, map[string]string{":UID": "maintenance"})
+		updated := body
+		updated.UID = "ignored"
+		updated.Comment = "weekly maintenance"
+		resp := srv.RoutePutSilenceSchedule(rc, updated)
+		require.Equal(t, http.StatusOK, resp.Status())
+		require.Equal(t, "weekly maintenance", schedules.schedules["maintenance"].Comment)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		rc := createRequestCtxInOrg(1)
+		rc.Req = web.SetURLParams(rc.Req, map[string]string{":UID": "maintenance"})
+		resp := srv.RouteDeleteSilenceSchedule(rc)
+		require.Equal(t, http.StatusNoContent, resp.Status())
+
+		resp = srv.RouteDeleteSilenceSchedule(rc)
+		require.Equal(t, http.StatusNotFound, resp.Status())
+		resp = srv.RouteGetSilenceSchedule(rc)
+		require.Equal(t, http.StatusNotFound, resp.Status())
+	})
+}
+
+type fakeSilenceScheduleService struct {
+	schedules map[string]*ngmodels.SilenceSchedule
+}
+
+func (f *fakeSilenceScheduleService) List(_ context.Context, _ int64) ([]*ngmodels.SilenceSchedule, error) {
+	result := make([]*ngmodels.SilenceSchedule, 0, len(f.schedules))
+	for _, s := range f.schedules {
+		result = append(result, s)
+	}
+	return result, nil
+}
+
+func (f *fakeSilenceScheduleService) Get(_ context.Context, _ int64, uid string) (*ngmodels.SilenceSchedule, error) {
+	s, ok := f.schedules[uid]
+	if !ok {
+		return nil, ngmodels.ErrSilenceScheduleNotFound
+	}
+	return s, nil
+}
+
+func (f *fakeSilenceScheduleService) Create(_ context.Context, schedule *ngmodels.SilenceSchedule) (*ngmodels.SilenceSchedule, error) {
+	if err := notifier.ValidateSilenceSchedule(schedule); err != nil {
+		return nil, err
+	}
+	f.schedules[schedule.UID] = schedule
+	return schedule, nil
+}
+
+func (f *fakeSilenceScheduleService) Update(_ context.Context, schedule *ngmodels.SilenceSchedule) (*ngmodels.SilenceSchedule, error) {
+	if _, ok := f.schedules[schedule.UID]; !ok {
+		return nil, ngmodels.ErrSilenceScheduleNotFound
+	}
+	f.schedules[schedule.UID] = schedule
+	return schedule, nil
+}
+
+func (f *fakeSilenceScheduleService) Delete(_ context.Context, _ int64, uid string) error {
+	if _, ok := f.schedules[uid]; !ok {
+		return ngmodels.ErrSilenceScheduleNotFound
+	}
+	delete(f.schedules, uid)
+	return nil
+}
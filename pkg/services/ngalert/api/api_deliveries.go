@@ -0,0 +1,152 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/models"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/notifier"
+	"github.com/grafana/grafana/pkg/services/ngalert/store"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+const (
+	defaultNotificationDeliveriesLimit = 100
+	maxNotificationDeliveriesLimit     = 1000
+)
+
+type DeliveriesSrv struct {
+	log   log.Logger
+	store store.NotificationDeliveryStore
+	mam   *notifier.MultiOrgAlertmanager
+}
+
+func (srv DeliveriesSrv) RouteGetNotificationDeliveries(c *models.ReqContext) response.Response {
+	if srv.store == nil {
+		return ErrResp(http.StatusNotFound, notifier.ErrDeliveryLogDisabled, "")
+	}
+
+	query, err := parseNotificationDeliveriesQuery(c)
+	if err != nil {
+		return ErrResp(http.StatusBadRequest, err, "")
+	}
+	if err := srv.store.ListNotificationDeliveries(c.Req.Context(), query); err != nil {
+		return ErrResp(http.StatusInternalServerError, err, "failed to get notification deliveries")
+	}
+
+	result := apimodels.NotificationDeliveries{
+		Page:       query.Page,
+		Limit:      query.Limit,
+		Deliveries: make([]apimodels.NotificationDelivery, 0, len(query.Result)),
+	}
+	for _, d := range query.Result {
+		result.Deliveries = append(result.Deliveries, toNotificationDelivery(d))
+	}
+	return response.JSON(http.StatusOK, result)
+}
+
+func (srv DeliveriesSrv) RouteReplayNotificationDelivery(c *models.ReqContext) response.Response {
+	if srv.store == nil {
+		return ErrResp(http.StatusNotFound, notifier.ErrDeliveryLogDisabled, "")
+	}
+
+	id, err := strconv.ParseInt(web.Params(c.Req)[":DeliveryID"], 10, 64)
+	if err != nil {
+		return ErrResp(http.StatusBadRequest, errors.New("delivery ID must be an integer"), "")
+	}
+
+	am, err := srv.mam.AlertmanagerFor(c.OrgId)
+	if err != nil {
+		if errors.Is(err, notifier.ErrNoAlertmanagerForOrg) {
+			return ErrResp(http.StatusNotFound, err, "")
+		}
+		if errors.Is(err, notifier.ErrAlertmanagerNotReady) {
+			return ErrResp(http.StatusConflict, err, "")
+		}
+		return ErrResp(http.StatusInternalServerError, err, "unable to obtain org's Alertmanager")
+	}
+
+	d, err := am.ReplayNotificationDelivery(c.Req.Context(), id)
+	if err != nil {
+		if errors.Is(err, ngmodels.ErrNotificationDeliveryNotFound) || errors.Is(err, notifier.ErrDeliveryLogDisabled) {
+			return ErrResp(http.StatusNotFound, err, "")
+		}
+		if errors.Is(err, notifier.ErrDeliveryIntegrationNotFound) {
+			return ErrResp(http.StatusConflict, err, "")
+		}
+		return ErrResp(http.StatusInternalServerError, err, "failed to replay notification delivery")
+	}
+	return response.JSON(http.StatusOK, toNotificationDelivery(d))
+}
+
+func toNotificationDelivery(d *ngmodels.NotificationDelivery) apimodels.NotificationDelivery {
+	result := apimodels.NotificationDelivery{
+		ID:                d.ID,
+		Receiver:          d.Receiver,
+		IntegrationUID:    d.IntegrationUID,
+		IntegrationType:   d.IntegrationType,
+		GroupKey:          d.GroupKey,
+		GroupLabels:       d.GroupLabels,
+		AlertFingerprints: d.AlertFingerprints,
+		Status:            string(d.Status),
+		Attempts:          d.Attempts,
+		Error:             d.Error,
+		ResponseCode:      d.ResponseCode,
+		ResponseBody:      d.ResponseBody,
+		CreatedAt:         time.UnixMilli(d.CreatedAt).UTC(),
+		LastAttemptAt:     time.UnixMilli(d.LastAttemptAt).UTC(),
+		ReplayOf:          d.ReplayOf,
+	}
+	if d.NextAttemptAt != 0 {
+		next := time.UnixMilli(d.NextAttemptAt).UTC()
+		result.NextAttemptAt = &next
+	}
+	return result
+}
+
+func parseNotificationDeliveriesQuery(c *models.ReqContext) (*ngmodels.ListNotificationDeliveriesQuery, error) {
+	query := &ngmodels.ListNotificationDeliveriesQuery{
+		OrgID:          c.SignedInUser.OrgId,
+		Receiver:       c.Query("receiver"),
+		IntegrationUID: c.Query("integrationUID"),
+		Page:           1,
+		Limit:          defaultNotificationDeliveriesLimit,
+	}
+
+	switch status := ngmodels.NotificationDeliveryStatus(c.Query("status")); status {
+	case "", ngmodels.NotificationDeliverySuccess, ngmodels.NotificationDeliveryFailed, ngmodels.NotificationDeliveryRetrying:
+		query.Status = status
+	default:
+		return nil, fmt.Errorf("invalid status %q", status)
+	}
+
+	var err error
+	if query.From, err = parseEpochMillis(c.Query("from")); err != nil {
+		return nil, fmt.Errorf("invalid from: %w", err)
+	}
+	if query.To, err = parseEpochMillis(c.Query("to")); err != nil {
+		return nil, fmt.Errorf("invalid to: %w", err)
+	}
+	if !query.From.IsZero() && !query.To.IsZero() && query.To.Before(query.From) {
+		return nil, errors.New("to must not be before from")
+	}
+
+	if s := c.Query("page"); s != "" {
+		if query.Page, err = strconv.Atoi(s); err != nil || query.Page < 1 {
+			return nil, errors.New("page must be a positive integer")
+		}
+	}
+	if s := c.Query("limit"); s != "" {
+		if query.Limit, err = strconv.Atoi(s); err != nil || query.Limit < 1 || query.Limit > maxNotificationDeliveriesLimit {
+			return nil, fmt.Errorf("limit must be an integer between 1 and %d", maxNotificationDeliveriesLimit)
+		}
+	}
+	return query, nil
+}
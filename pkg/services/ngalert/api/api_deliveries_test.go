@@ -0,0 +1,128 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/models"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/store"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+func TestRouteGetNotificationDeliveries(t *testing.T) {
+	orgID := int64(1)
+	start := time.Unix(1000, 0).UTC()
+
+	setup := func(t *testing.T) DeliveriesSrv {
+		deliveryStore := &store.FakeNotificationDeliveryStore{}
+		for i, status := range []ngmodels.NotificationDeliveryStatus{ngmodels.NotificationDeliverySuccess, ngmodels.NotificationDeliveryFailed, ngmodels.NotificationDeliveryRetrying} {
+			require.NoError(t, deliveryStore.SaveNotificationDelivery(context.Background(), &ngmodels.NotificationDelivery{
+				OrgID:             orgID,
+				Receiver:          "team-a",
+				IntegrationUID:    "uid-1",
+				IntegrationType:   "webhook",
+				AlertFingerprints: []string{"abc"},
+				Status:            status,
+				Attempts:          1,
+				CreatedAt:         start.Add(time.Duration(i) * time.Minute).UnixMilli(),
+				LastAttemptAt:     start.Add(time.Duration(i) * time.Minute).UnixMilli(),
+			}))
+		}
+		require.NoError(t, deliveryStore.SaveNotificationDelivery(context.Background(), &ngmodels.NotificationDelivery{
+			OrgID:     2,
+			Receiver:  "team-a",
+			Status:    ngmodels.NotificationDeliverySuccess,
+			CreatedAt: start.UnixMilli(),
+		}))
+		return DeliveriesSrv{
+			log:   log.NewNopLogger(),
+			store: deliveryStore,
+		}
+	}
+
+	request := func(t *testing.T, url string) *models.ReqContext {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		require.NoError(t, err)
+		return &models.ReqContext{Context: &web.Context{Req: req}, SignedInUser: &models.SignedInUser{OrgId: orgID, OrgRole: models.ROLE_EDITOR}}
+	}
+
+	t.Run("should return the deliveries of the org, most recent first", func(t *testing.T) {
+		srv := setup(t)
+		r := srv.RouteGetNotificationDeliveries(request(t, "/api/v1/notifications/deliveries"))
+		require.Equal(t, http.StatusOK, r.Status())
+
+		result := apimodels.NotificationDeliveries{}
+		require.NoError(t, json.Unmarshal(r.Body(), &result))
+		require.Equal(t, 1, result.Page)
+		require.Equal(t, defaultNotificationDeliveriesLimit, result.Limit)
+		require.Len(t, result.Deliveries, 3)
+		require.Equal(t, "retrying", result.Deliveries[0].Status)
+		require.Equal(t, start.Add(2*time.Minute), result.Deliveries[0].CreatedAt)
+		require.Equal(t, []string{"abc"}, result.Deliveries[0].AlertFingerprints)
+		require.Nil(t, result.Deliveries[0].NextAttemptAt)
+	})
+
+	t.Run("should filter by status", func(t *testing.T) {
+		srv := setup(t)
+		r := srv.RouteGetNotificationDeliveries(request(t, "/api/v1/notifications/deliveries?status=failed"))
+		require.Equal(t, http.StatusOK, r.Status())
+
+		result := apimodels.NotificationDeliveries{}
+		require.NoError(t, json.Unmarshal(r.Body(), &result))
+		require.Len(t, result.Deliveries, 1)
+		require.Equal(t, "failed", result.Deliveries[0].Status)
+	})
+
+	t.Run("should return 400 on invalid parameters", func(t *testing.T) {
+		srv := setup(t)
+		for _, q := range []string{"status=unknown", "from=abc", "from=2&to=1", "page=0", "limit=1001"} {
+			r := srv.RouteGetNotificationDeliveries(request(t, "/api/v1/notifications/deliveries?"+q))
+			require.Equalf(t, http.StatusBadRequest, r.Status(), "query %s", q)
+		}
+	})
+
+	t.Run("should return 404 if the delivery log is disabled", func(t *testing.T) {
+		srv := setup(t)
+		srv.store = nil
+		r := srv.RouteGetNotificationDeliveries(request(t, "/api/v1/notifications/deliveries"))
+		require.Equal(t, http.StatusNotFound, r.Status())
+	})
+}
+
+func TestRouteReplayNotificationDelivery(t *testing.T) {
+	srv := DeliveriesSrv{
+		log:   log.NewNopLogger(),
+		store: &store.FakeNotificationDeliveryStore{},
+		mam:   createMultiOrgAlertmanager(t),
+	}
+
+	request := func(t *testing.T, orgID int64, id string) *models.ReqContext {
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/notifications/deliveries/"+id+"/replay", nil)
+		require.NoError(t, err)
+		req = web.SetURLParams(req, map[string]string{":DeliveryID": id})
+		return &models.ReqContext{Context: &web.Context{Req: req}, SignedInUser: &models.SignedInUser{OrgId: orgID, OrgRole: models.ROLE_EDITOR}}
+	}
+
+	t.Run("should return 400 if the ID is not a number", func(t *testing.T) {
+		r := srv.RouteReplayNotificationDelivery(request(t, 1, "abc"))
+		require.Equal(t, http.StatusBadRequest, r.Status())
+	})
+
+	t.Run("should return 404 if the org has no Alertmanager", func(t *testing.T) {
+		r := srv.RouteReplayNotificationDelivery(request(t, 5, "1"))
+		require.Equal(t, http.StatusNotFound, r.Status())
+	})
+
+	t.Run("should return 404 if the Alertmanager does not log deliveries", func(t *testing.T) {
+		r := srv.RouteReplayNotificationDelivery(request(t, 1, "1"))
+		require.Equal(t, http.StatusNotFound, r.Status())
+	})
+}
@@ -0,0 +1,187 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/alertmanager/pkg/labels"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/store"
+)
+
+const (
+	defaultStateHistoryLimit = 100
+	maxStateHistoryLimit     = 1000
+)
+
+type HistorySrv struct {
+	log     log.Logger
+	store   store.RuleStore
+	history store.StateHistoryStore
+	ac      accesscontrol.AccessControl
+}
+
+func (srv HistorySrv) RouteGetStateHistory(c *models.ReqContext) response.Response {
+	if srv.history == nil {
+		return ErrResp(http.StatusNotFound, errors.New("state history is disabled"), "")
+	}
+
+	query, err := parseStateHistoryQuery(c)
+	if err != nil {
+		return ErrResp(http.StatusBadRequest, err, "")
+	}
+
+	result := apimodels.StateHistory{
+		Page:        query.Page,
+		Limit:       query.Limit,
+		Transitions: []apimodels.StateTransition{},
+	}
+
+	if ruleUID := c.Query("ruleUID"); ruleUID != "" {
+		query.RuleUIDs = []string{ruleUID}
+	}
+	visible, err := srv.authorizeStateHistoryQuery(c, query)
+	if err != nil {
+		return ErrResp(http.StatusInternalServerError, err, "failed to get rules visible to the user")
+	}
+	if !visible {
+		return response.JSON(http.StatusOK, result)
+	}
+
+	if err := srv.history.ListAlertStateHistory(c.Req.Context(), query); err != nil {
+		return ErrResp(http.StatusInternalServerError, err, "failed to get state history")
+	}
+	for _, h := range query.Result {
+		result.Transitions = append(result.Transitions, apimodels.StateTransition{
+			RuleUID:        h.RuleUID,
+			RuleVersion:    h.RuleVersion,
+			Labels:         h.Labels,
+			PreviousState:  string(h.PreviousState),
+			PreviousReason: h.PreviousReason,
+			State:          string(h.State),
+			Reason:         h.Reason,
+			Values:         h.Values,
+			Time:           time.UnixMilli(h.EvaluatedAt).UTC(),
+		})
+	}
+	return response.JSON(http.StatusOK, result)
+}
+
+// authorizeStateHistoryQuery restricts the query to the transitions the user can read. Transitions are
+// authorized by the folder stored with them, and rules that query data sources the user cannot access are
+// excluded. The data sources of deleted rules are unknown, so only admins can read their history.
+// It returns false if the user cannot read any transition.
+func (srv HistorySrv) authorizeStateHistoryQuery(c *models.ReqContext, query *ngmodels.ListAlertStateHistoryQuery) (bool, error) {
+	namespaceMap, err := srv.store.GetUserVisibleNamespaces(c.Req.Context(), c.OrgId, c.SignedInUser)
+	if err != nil {
+		return false, err
+	}
+	if len(namespaceMap) == 0 {
+		srv.log.Debug("user does not have access to any namespaces")
+		return false, nil
+	}
+
+	namespaceUIDs := make([]string, 0, len(namespaceMap))
+	for k := range namespaceMap {
+		namespaceUIDs = append(namespaceUIDs, k)
+	}
+
+	q := ngmodels.ListAlertRulesQuery{
+		OrgID:         c.SignedInUser.OrgId,
+		NamespaceUIDs: namespaceUIDs,
+	}
+	if err := srv.store.ListAlertRules(c.Req.Context(), &q); err != nil {
+		return false, err
+	}
+
+	hasAccess := func(evaluator accesscontrol.Evaluator) bool {
+		return accesscontrol.HasAccess(srv.ac, c)(accesscontrol.ReqViewer, evaluator)
+	}
+	query.NamespaceUIDs = namespaceUIDs
+	if c.SignedInUser.HasRole(models.ROLE_ADMIN) {
+		for _, rule := range q.Result {
+			if !authorizeDatasourceAccessForRule(rule, hasAccess) {
+				query.ExcludeRuleUIDs = append(query.ExcludeRuleUIDs, rule.UID)
+			}
+		}
+		return true, nil
+	}
+
+	requested := make(map[string]struct{}, len(query.RuleUIDs))
+	for _, uid := range query.RuleUIDs {
+		requested[uid] = struct{}{}
+	}
+	ruleUIDs := make([]string, 0, len(q.Result))
+	for _, rule := range q.Result {
+		if _, ok := requested[rule.UID]; len(requested) > 0 && !ok {
+			continue
+		}
+		if authorizeDatasourceAccessForRule(rule, hasAccess) {
+			ruleUIDs = append(ruleUIDs, rule.UID)
+		}
+	}
+	if len(ruleUIDs) == 0 {
+		return false, nil
+	}
+	query.RuleUIDs = ruleUIDs
+	return true, nil
+}
+
+func parseStateHistoryQuery(c *models.ReqContext) (*ngmodels.ListAlertStateHistoryQuery, error) {
+	query := &ngmodels.ListAlertStateHistoryQuery{
+		OrgID: c.SignedInUser.OrgId,
+		Page:  1,
+		Limit: defaultStateHistoryLimit,
+	}
+
+	for _, s := range c.QueryStrings("matcher") {
+		matchers, err := labels.ParseMatchers(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid matcher %q: %w", s, err)
+		}
+		query.Matchers = append(query.Matchers, matchers...)
+	}
+
+	var err error
+	if query.From, err = parseEpochMillis(c.Query("from")); err != nil {
+		return nil, fmt.Errorf("invalid from: %w", err)
+	}
+	if query.To, err = parseEpochMillis(c.Query("to")); err != nil {
+		return nil, fmt.Errorf("invalid to: %w", err)
+	}
+	if !query.From.IsZero() && !query.To.IsZero() && query.To.Before(query.From) {
+		return nil, errors.New("to must not be before from")
+	}
+
+	if s := c.Query("page"); s != "" {
+		if query.Page, err = strconv.Atoi(s); err != nil || query.Page < 1 {
+			return nil, errors.New("page must be a positive integer")
+		}
+	}
+	if s := c.Query("limit"); s != "" {
+		if query.Limit, err = strconv.Atoi(s); err != nil || query.Limit < 1 || query.Limit > maxStateHistoryLimit {
+			return nil, fmt.Errorf("limit must be an integer between 1 and %d", maxStateHistoryLimit)
+		}
+	}
+	return query, nil
+}
+
+func parseEpochMillis(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	ms, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.UnixMilli(ms), nil
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/models"
+	acmock "github.com/grafana/grafana/pkg/services/accesscontrol/mock"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/store"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+func TestRouteGetStateHistory(t *testing.T) {
+	orgID := int64(1)
+	start := time.Unix(1000, 0).UTC()
+
+	setup := func(t *testing.T) (HistorySrv, []*ngmodels.AlertRule) {
+		ruleStore := store.NewFakeRuleStore(t)
+		rules := ngmodels.GenerateAlertRules(2, ngmodels.AlertRuleGen(withOrgID(orgID)))
+		ruleStore.PutRule(context.Background(), rules...)
+
+		historyStore := &store.FakeStateHistoryStore{}
+		var entries []*ngmodels.AlertStateHistory
+		// The third rule was deleted but its folder is still visible, the fourth one is in a folder the user cannot see.
+		deleted := &ngmodels.AlertRule{OrgID: orgID, UID: "deleted", NamespaceUID: rules[0].NamespaceUID}
+		hidden := &ngmodels.AlertRule{OrgID: orgID, UID: "hidden", NamespaceUID: "hidden-folder"}
+		for i, rule := range append(rules, deleted, hidden) {
+			entries = append(entries, &ngmodels.AlertStateHistory{
+				OrgID:         orgID,
+				RuleUID:       rule.UID,
+				NamespaceUID:  rule.NamespaceUID,
+				Labels:        map[string]string{"host": "a"},
+				PreviousState: ngmodels.InstanceStateNormal,
+				State:         ngmodels.InstanceStateFiring,
+				EvaluatedAt:   start.Add(time.Duration(i) * time.Minute).UnixMilli(),
+			})
+		}
+		require.NoError(t, historyStore.SaveAlertStateHistory(context.Background(), entries))
+
+		return HistorySrv{
+			log:     log.NewNopLogger(),
+			store:   ruleStore,
+			history: historyStore,
+			ac:      acmock.New().WithDisabled(),
+		}, rules
+	}
+
+	requestAs := func(t *testing.T, url string, role models.RoleType) *models.ReqContext {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		require.NoError(t, err)
+		return &models.ReqContext{Context: &web.Context{Req: req}, SignedInUser: &models.SignedInUser{OrgId: orgID, OrgRole: role}}
+	}
+	request := func(t *testing.T, url string) *models.ReqContext {
+		return requestAs(t, url, models.ROLE_VIEWER)
+	}
+
+	t.Run("should return transitions of visible rules only", func(t *testing.T) {
+		srv, rules := setup(t)
+		r := srv.RouteGetStateHistory(request(t, "/api/v1/rules/history"))
+		require.Equal(t, http.StatusOK, r.Status())
+
+		result := apimodels.StateHistory{}
+		require.NoError(t, json.Unmarshal(r.Body(), &result))
+		require.Equal(t, 1, result.Page)
+		require.Equal(t, defaultStateHistoryLimit, result.Limit)
+		require.Len(t, result.Transitions, 2)
+		require.Equal(t, rules[1].UID, result.Transitions[0].RuleUID)
+		require.Equal(t, "Normal", result.Transitions[0].PreviousState)
+		require.Equal(t, "Alerting", result.Transitions[0].State)
+		require.Equal(t, start.Add(time.Minute), result.Transitions[0].Time)
+		require.Equal(t, rules[0].UID, result.Transitions[1].RuleUID)
+	})
+
+	t.Run("should return transitions of deleted rules to admins only", func(t *testing.T) {
+		srv, _ := setup(t)
+		r := srv.RouteGetStateHistory(requestAs(t, "/api/v1/rules/history?ruleUID=deleted", models.ROLE_ADMIN))
+		require.Equal(t, http.StatusOK, r.Status())
+		result := apimodels.StateHistory{}
+		require.NoError(t, json.Unmarshal(r.Body(), &result))
+		require.Len(t, result.Transitions, 1)
+		require.Equal(t, "deleted", result.Transitions[0].RuleUID)
+
+		result = apimodels.StateHistory{}
+		r = srv.RouteGetStateHistory(requestAs(t, "/api/v1/rules/history?ruleUID=deleted", models.ROLE_EDITOR))
+		require.Equal(t, http.StatusOK, r.Status())
+		require.NoError(t, json.Unmarshal(r.Body(), &result))
+		require.Empty(t, result.Transitions)
+	})
+
+	t.Run("should filter by rule UID", func(t *testing.T) {
+		srv, rules := setup(t)
+		r := srv.RouteGetStateHistory(request(t, "/api/v1/rules/history?ruleUID="+rules[0].UID))
+		require.Equal(t, http.StatusOK, r.Status())
+
+		result := apimodels.StateHistory{}
+		require.NoError(t, json.Unmarshal(r.Body(), &result))
+		require.Len(t, result.Transitions, 1)
+		require.Equal(t, rules[0].UID, result.Transitions[0].RuleUID)
+
+		result = apimodels.StateHistory{}
+		r = srv.RouteGetStateHistory(request(t, "/api/v1/rules/history?ruleUID=hidden"))
+		require.Equal(t, http.StatusOK, r.Status())
+		require.NoError(t, json.Unmarshal(r.Body(), &result))
+		require.Empty(t, result.Transitions)
+	})
+
+	t.Run("should return 400 on invalid parameters", func(t *testing.T) {
+		srv, _ := setup(t)
+		for _, q := range []string{"matcher=host%3D~%22(%22", "from=abc", "from=2&to=1", "page=0", "limit=1001"} {
+			r := srv.RouteGetStateHistory(request(t, "/api/v1/rules/history?"+q))
+			require.Equalf(t, http.StatusBadRequest, r.Status(), "query %s", q)
+		}
+	})
+
+	t.Run("should return 404 if the state history is disabled", func(t *testing.T) {
+		srv, _ := setup(t)
+		srv.history = nil
+		r := srv.RouteGetStateHistory(request(t, "/api/v1/rules/history"))
+		require.Equal(t, http.StatusNotFound, r.Status())
+	})
+}
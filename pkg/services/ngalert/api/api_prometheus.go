@@ -208,6 +208,11 @@ func (srv PrometheusSrv) toRuleGroup(groupName string, folder *models.Folder, ru
 			Type:           apiv1.RuleTypeAlerting,
 			LastEvaluation: time.Time{},
 		}
+		if rule.IsRecordingRule() {
+			// recording rules have no alert instances and therefore no state
+			alertingRule.State = ""
+			newRule.Type = apiv1.RuleTypeRecording
+		}
 
 		for _, alertState := range srv.manager.GetStatesForRuleUID(rule.OrgID, rule.UID) {
 			activeAt := alertState.StartsAt
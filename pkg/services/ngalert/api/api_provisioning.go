@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/grafana/grafana/pkg/api/response"
@@ -19,6 +20,7 @@ import (
 const (
 	namePathParam      = ":name"
 	uidPathParam       = ":UID"
+	ruleUIDPathParam   = ":RuleUID"
 	groupPathParam     = ":Group"
 	folderUIDPathParam = ":FolderUID"
 )
@@ -30,6 +32,9 @@ type ProvisioningSrv struct {
 	templates           TemplateService
 	muteTimings         MuteTimingService
 	alertRules          AlertRuleService
+	dryRun              DryRunService
+	export              ExportService
+	ruleTemplates       RuleTemplateService
 }
 
 type ContactPointService interface {
@@ -43,6 +48,7 @@ type TemplateService interface {
 	GetTemplates(ctx context.Context, orgID int64) (map[string]string, error)
 	SetTemplate(ctx context.Context, orgID int64, tmpl apimodels.MessageTemplate) (apimodels.MessageTemplate, error)
 	DeleteTemplate(ctx context.Context, orgID int64, name string) error
+	PreviewTemplate(ctx context.Context, orgID int64, name string, req apimodels.TemplatePreviewRequest) (apimodels.TemplatePreviews, error)
 }
 
 type NotificationPolicyService interface {
@@ -65,6 +71,26 @@ type AlertRuleService interface {
 	UpdateAlertGroup(ctx context.Context, orgID int64, folderUID, rulegroup string, interval int64) error
 }
 
+type RuleTemplateService interface {
+	GetRuleTemplates(ctx context.Context, orgID int64) ([]alerting_models.AlertRuleTemplate, error)
+	GetRuleTemplate(ctx context.Context, orgID int64, uid string) (alerting_models.AlertRuleTemplate, error)
+	CreateRuleTemplate(ctx context.Context, orgID int64, t alerting_models.AlertRuleTemplate) (alerting_models.AlertRuleTemplate, error)
+	UpdateRuleTemplate(ctx context.Context, orgID int64, t alerting_models.AlertRuleTemplate) (alerting_models.AlertRuleTemplate, error)
+	DeleteRuleTemplate(ctx context.Context, orgID int64, uid string) error
+	GetRuleTemplateInstances(ctx context.Context, orgID int64, uid string) ([]alerting_models.AlertRuleTemplateInstance, error)
+	CreateRuleFromTemplate(ctx context.Context, instance alerting_models.AlertRuleTemplateInstance, folderUID, ruleGroup string) (alerting_models.AlertRule, error)
+	UpdateRuleTemplateInstance(ctx context.Context, instance alerting_models.AlertRuleTemplateInstance) (alerting_models.AlertRule, error)
+}
+
+type DryRunService interface {
+	Diff(ctx context.Context, file apimodels.ProvisioningFile) (apimodels.ProvisioningDiff, error)
+}
+
+type ExportService interface {
+	Export(ctx context.Context, orgID int64, secrets provisioning.SecretsExport) (apimodels.ProvisioningFile, error)
+	ExportRules(ctx context.Context, orgID int64, folderUIDs []string, group string) (apimodels.ProvisioningFile, error)
+}
+
 func (srv *ProvisioningSrv) RouteGetPolicyTree(c *models.ReqContext) response.Response {
 	policies, err := srv.policies.GetPolicyTree(c.Req.Context(), c.OrgId)
 	if errors.Is(err, store.ErrNoAlertmanagerConfiguration) {
@@ -177,6 +203,21 @@ func (srv *ProvisioningSrv) RouteDeleteTemplate(c *models.ReqContext) response.R
 	return response.JSON(http.StatusNoContent, nil)
 }
 
+func (srv *ProvisioningSrv) RoutePostTemplatePreview(c *models.ReqContext, body apimodels.TemplatePreviewRequest) response.Response {
+	name := pathParam(c, namePathParam)
+	previews, err := srv.templates.PreviewTemplate(c.Req.Context(), c.OrgId, name, body)
+	if errors.Is(err, provisioning.ErrTemplateNotFound) || errors.Is(err, store.ErrNoAlertmanagerConfiguration) {
+		return ErrResp(http.StatusNotFound, err, "")
+	}
+	if errors.Is(err, provisioning.ErrValidation) {
+		return ErrResp(http.StatusBadRequest, err, "")
+	}
+	if err != nil {
+		return ErrResp(http.StatusInternalServerError, err, "")
+	}
+	return response.JSON(http.StatusOK, previews)
+}
+
 func (srv *ProvisioningSrv) RouteGetMuteTiming(c *models.ReqContext) response.Response {
 	name := pathParam(c, namePathParam)
 	timings, err := srv.muteTimings.GetMuteTimings(c.Req.Context(), c.OrgId)
@@ -283,6 +324,219 @@ func (srv *ProvisioningSrv) RoutePutAlertRuleGroup(c *models.ReqContext, ag apim
 	return response.JSON(http.StatusOK, ag)
 }
 
+func (srv *ProvisioningSrv) RoutePostProvisioningDryRun(c *models.ReqContext, file apimodels.ProvisioningFile) response.Response {
+	if err := setProvisioningFileOrg(&file, c.OrgId); err != nil {
+		return ErrResp(http.StatusBadRequest, err, "")
+	}
+	diff, err := srv.dryRun.Diff(c.Req.Context(), file)
+	if err != nil {
+		if errors.Is(err, provisioning.ErrValidation) {
+			return ErrResp(http.StatusBadRequest, err, "")
+		}
+		return ErrResp(http.StatusInternalServerError, err, "")
+	}
+	return response.JSON(http.StatusOK, diff)
+}
+
+func (srv *ProvisioningSrv) RouteGetProvisioningExport(c *models.ReqContext) response.Response {
+	format := c.Query("format")
+	if !validExportFormat(format) {
+		return ErrResp(http.StatusBadRequest, fmt.Errorf("unsupported format %s", format), "")
+	}
+	secrets := provisioning.SecretsExport(c.Query("secrets"))
+	if secrets == "" {
+		secrets = provisioning.SecretsRedact
+	}
+	file, err := srv.export.Export(c.Req.Context(), c.OrgId, secrets)
+	if errors.Is(err, provisioning.ErrValidation) {
+		return ErrResp(http.StatusBadRequest, err, "")
+	}
+	if errors.Is(err, store.ErrNoAlertmanagerConfiguration) {
+		return ErrResp(http.StatusNotFound, err, "")
+	}
+	if err != nil {
+		return ErrResp(http.StatusInternalServerError, err, "")
+	}
+	return exportResponse(file, format)
+}
+
+func (srv *ProvisioningSrv) RouteGetAlertRulesExport(c *models.ReqContext) response.Response {
+	format := c.Query("format")
+	if !validExportFormat(format) {
+		return ErrResp(http.StatusBadRequest, fmt.Errorf("unsupported format %s", format), "")
+	}
+	file, err := srv.export.ExportRules(c.Req.Context(), c.OrgId, c.QueryStrings("folderUid"), c.Query("group"))
+	if err != nil {
+		return ErrResp(http.StatusInternalServerError, err, "")
+	}
+	return exportResponse(file, format)
+}
+
+func (srv *ProvisioningSrv) RouteGetRuleTemplates(c *models.ReqContext) response.Response {
+	templates, err := srv.ruleTemplates.GetRuleTemplates(c.Req.Context(), c.OrgId)
+	if err != nil {
+		return ErrResp(http.StatusInternalServerError, err, "")
+	}
+	result := make(apimodels.RuleTemplates, 0, len(templates))
+	for _, t := range templates {
+		result = append(result, apimodels.NewRuleTemplate(t))
+	}
+	return response.JSON(http.StatusOK, result)
+}
+
+func (srv *ProvisioningSrv) RouteGetRuleTemplate(c *models.ReqContext) response.Response {
+	t, err := srv.ruleTemplates.GetRuleTemplate(c.Req.Context(), c.OrgId, pathParam(c, uidPathParam))
+	if err != nil {
+		return ruleTemplateErrResp(err)
+	}
+	return response.JSON(http.StatusOK, apimodels.NewRuleTemplate(t))
+}
+
+func (srv *ProvisioningSrv) RoutePostRuleTemplate(c *models.ReqContext, body apimodels.RuleTemplate) response.Response {
+	t, err := srv.ruleTemplates.CreateRuleTemplate(c.Req.Context(), c.OrgId, body.UpstreamModel())
+	if err != nil {
+		return ruleTemplateErrResp(err)
+	}
+	return response.JSON(http.StatusCreated, apimodels.NewRuleTemplate(t))
+}
+
+func (srv *ProvisioningSrv) RoutePutRuleTemplate(c *models.ReqContext, body apimodels.RuleTemplate) response.Response {
+	body.UID = pathParam(c, uidPathParam)
+	t, err := srv.ruleTemplates.UpdateRuleTemplate(c.Req.Context(), c.OrgId, body.UpstreamModel())
+	if err != nil {
+		return ruleTemplateErrResp(err)
+	}
+	return response.JSON(http.StatusOK, apimodels.NewRuleTemplate(t))
+}
+
+func (srv *ProvisioningSrv) RouteDeleteRuleTemplate(c *models.ReqContext) response.Response {
+	if err := srv.ruleTemplates.DeleteRuleTemplate(c.Req.Context(), c.OrgId, pathParam(c, uidPathParam)); err != nil {
+		return ruleTemplateErrResp(err)
+	}
+	return response.JSON(http.StatusNoContent, nil)
+}
+
+func (srv *ProvisioningSrv) RouteGetRuleTemplateInstances(c *models.ReqContext) response.Response {
+	instances, err := srv.ruleTemplates.GetRuleTemplateInstances(c.Req.Context(), c.OrgId, pathParam(c, uidPathParam))
+	if err != nil {
+		return ruleTemplateErrResp(err)
+	}
+	result := make(apimodels.RuleTemplateInstances, 0, len(instances))
+	for _, i := range instances {
+		result = append(result, apimodels.RuleTemplateInstance{
+			RuleUID:         i.RuleUID,
+			Parameters:      i.Parameters,
+			TemplateVersion: i.TemplateVersion,
+		})
+	}
+	return response.JSON(http.StatusOK, result)
+}
+
+func (srv *ProvisioningSrv) RoutePostRuleTemplateInstance(c *models.ReqContext, body apimodels.RuleTemplateInstance) response.Response {
+	rule, err := srv.ruleTemplates.CreateRuleFromTemplate(c.Req.Context(), alerting_models.AlertRuleTemplateInstance{
+		OrgID:       c.OrgId,
+		TemplateUID: pathParam(c, uidPathParam),
+		RuleUID:     body.RuleUID,
+		Parameters:  body.Parameters,
+	}, body.FolderUID, body.RuleGroup)
+	if err != nil {
+		return ruleTemplateErrResp(err)
+	}
+	return response.JSON(http.StatusCreated, apimodels.NewAlertRule(rule, alerting_models.ProvenanceAPI))
+}
+
+func (srv *ProvisioningSrv) RoutePutRuleTemplateInstance(c *models.ReqContext, body apimodels.RuleTemplateInstance) response.Response {
+	rule, err := srv.ruleTemplates.UpdateRuleTemplateInstance(c.Req.Context(), alerting_models.AlertRuleTemplateInstance{
+		OrgID:       c.OrgId,
+		TemplateUID: pathParam(c, uidPathParam),
+		RuleUID:     pathParam(c, ruleUIDPathParam),
+		Parameters:  body.Parameters,
+	})
+	if err != nil {
+		return ruleTemplateErrResp(err)
+	}
+	return response.JSON(http.StatusOK, apimodels.NewAlertRule(rule, alerting_models.ProvenanceAPI))
+}
+
+func ruleTemplateErrResp(err error) response.Response {
+	switch {
+	case errors.Is(err, provisioning.ErrValidation):
+		return ErrResp(http.StatusBadRequest, err, "")
+	case errors.Is(err, alerting_models.ErrRuleTemplateNotFound),
+		errors.Is(err, alerting_models.ErrRuleTemplateInstanceNotFound),
+		errors.Is(err, alerting_models.ErrAlertRuleNotFound):
+		return ErrResp(http.StatusNotFound, err, "")
+	case errors.Is(err, alerting_models.ErrRuleTemplateVersionConflict):
+		return ErrResp(http.StatusConflict, err, "")
+	}
+	return ErrResp(http.StatusInternalServerError, err, "")
+}
+
+func validExportFormat(format string) bool {
+	return format == "" || format == "json" || format == "yaml"
+}
+
+func exportResponse(file apimodels.ProvisioningFile, format string) response.Response {
+	if format != "yaml" {
+		return response.JSON(http.StatusOK, file)
+	}
+	b, err := provisioning.MarshalProvisioningFileYAML(file)
+	if err != nil {
+		return ErrResp(http.StatusInternalServerError, err, "failed to marshal the export")
+	}
+	return response.Respond(http.StatusOK, b).SetHeader("Content-Type", "application/yaml")
+}
+
+// setProvisioningFileOrg assigns the resources of the file without an organization to the given one.
+// It fails if a resource belongs to another organization.
+func setProvisioningFileOrg(file *apimodels.ProvisioningFile, orgID int64) error {
+	setOrg := func(resourceOrgID *int64) error {
+		if *resourceOrgID == 0 {
+			*resourceOrgID = orgID
+		}
+		if *resourceOrgID != orgID {
+			return fmt.Errorf("resources of organization %d cannot be provisioned in organization %d", *resourceOrgID, orgID)
+		}
+		return nil
+	}
+	for i := range file.Groups {
+		if err := setOrg(&file.Groups[i].OrgID); err != nil {
+			return err
+		}
+	}
+	for i := range file.DeleteRules {
+		if err := setOrg(&file.DeleteRules[i].OrgID); err != nil {
+			return err
+		}
+	}
+	for i := range file.ContactPoints {
+		if err := setOrg(&file.ContactPoints[i].OrgID); err != nil {
+			return err
+		}
+	}
+	for i := range file.DeleteContactPoints {
+		if err := setOrg(&file.DeleteContactPoints[i].OrgID); err != nil {
+			return err
+		}
+	}
+	for i := range file.Policies {
+		if err := setOrg(&file.Policies[i].OrgID); err != nil {
+			return err
+		}
+	}
+	for i := range file.MuteTimes {
+		if err := setOrg(&file.MuteTimes[i].OrgID); err != nil {
+			return err
+		}
+	}
+	for i := range file.Templates {
+		if err := setOrg(&file.Templates[i].OrgID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func pathParam(c *models.ReqContext, param string) string {
 	return web.Params(c.Req)[param]
 }
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"testing"
 
+	responseimpl "github.com/grafana/grafana/pkg/api/response"
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/models"
 	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
@@ -99,6 +101,186 @@ func TestProvisioningApi(t *testing.T) {
 			require.Contains(t, string(response.Body()), "something went wrong")
 		})
 	})
+
+	t.Run("dry run", func(t *testing.T) {
+		t.Run("assigns resources without organization to the current one", func(t *testing.T) {
+			sut := createProvisioningSrvSut()
+			dryRun := &fakeDryRunService{}
+			sut.dryRun = dryRun
+			rc := createTestRequestCtx()
+			file := apimodels.ProvisioningFile{
+				APIVersion:  1,
+				DeleteRules: []apimodels.ProvisionedReference{{UID: "a"}, {OrgID: 1, UID: "b"}},
+			}
+
+			response := sut.RoutePostProvisioningDryRun(&rc, file)
+
+			require.Equal(t, 200, response.Status())
+			require.Equal(t, int64(1), dryRun.file.DeleteRules[0].OrgID)
+			require.Equal(t, int64(1), dryRun.file.DeleteRules[1].OrgID)
+		})
+
+		t.Run("returns 400 if a resource belongs to another organization", func(t *testing.T) {
+			sut := createProvisioningSrvSut()
+			sut.dryRun = &fakeDryRunService{}
+			rc := createTestRequestCtx()
+			file := apimodels.ProvisioningFile{
+				APIVersion:    1,
+				ContactPoints: []apimodels.ProvisionedContactPoint{{OrgID: 2, Name: "cp", Type: "email"}},
+			}
+
+			response := sut.RoutePostProvisioningDryRun(&rc, file)
+
+			require.Equal(t, 400, response.Status())
+		})
+
+		t.Run("returns 400 if the file is invalid", func(t *testing.T) {
+			sut := createProvisioningSrvSut()
+			sut.dryRun = &fakeDryRunService{err: fmt.Errorf("%w: invalid file", provisioning.ErrValidation)}
+			rc := createTestRequestCtx()
+
+			response := sut.RoutePostProvisioningDryRun(&rc, apimodels.ProvisioningFile{})
+
+			require.Equal(t, 400, response.Status())
+		})
+	})
+
+	t.Run("export", func(t *testing.T) {
+		t.Run("redacts secrets by default", func(t *testing.T) {
+			sut := createProvisioningSrvSut()
+			export := &fakeExportService{}
+			sut.export = export
+			rc := createTestRequestCtx()
+			rc.Req.Form = url.Values{}
+
+			response := sut.RouteGetProvisioningExport(&rc)
+
+			require.Equal(t, 200, response.Status())
+			require.Equal(t, provisioning.SecretsRedact, export.secrets)
+		})
+
+		t.Run("renders YAML", func(t *testing.T) {
+			sut := createProvisioningSrvSut()
+			sut.export = &fakeExportService{}
+			rc := createTestRequestCtx()
+			rc.Req.Form = url.Values{"format": {"yaml"}, "folderUid": {"a", "b"}}
+
+			response := sut.RouteGetAlertRulesExport(&rc)
+
+			require.Equal(t, 200, response.Status())
+			require.Equal(t, "application/yaml", response.(*responseimpl.NormalResponse).Header().Get("Content-Type"))
+			require.Equal(t, "apiVersion: 1\n", string(response.Body()))
+			require.Equal(t, []string{"a", "b"}, sut.export.(*fakeExportService).folderUIDs)
+		})
+
+		t.Run("returns 400 on unknown format", func(t *testing.T) {
+			sut := createProvisioningSrvSut()
+			sut.export = &fakeExportService{}
+			rc := createTestRequestCtx()
+			rc.Req.Form = url.Values{"format": {"xml"}}
+
+			require.Equal(t, 400, sut.RouteGetProvisioningExport(&rc).Status())
+			require.Equal(t, 400, sut.RouteGetAlertRulesExport(&rc).Status())
+		})
+
+		t.Run("returns 400 on unknown secrets option", func(t *testing.T) {
+			sut := createProvisioningSrvSut()
+			sut.export = &fakeExportService{err: fmt.Errorf("%w: unknown secrets export option", provisioning.ErrValidation)}
+			rc := createTestRequestCtx()
+			rc.Req.Form = url.Values{"secrets": {"plain"}}
+
+			response := sut.RouteGetProvisioningExport(&rc)
+
+			require.Equal(t, 400, response.Status())
+		})
+	})
+
+	t.Run("template preview", func(t *testing.T) {
+		t.Run("POST preview renders the template of the path", func(t *testing.T) {
+			sut := createProvisioningSrvSut()
+			templates := &fakeTemplateService{}
+			sut.templates = templates
+			rc := createTestRequestCtx()
+			rc.Req = web.SetURLParams(rc.Req, map[string]string{namePathParam: "custom"})
+
+			response := sut.RoutePostTemplatePreview(&rc, apimodels.TemplatePreviewRequest{Integration: "slack"})
+
+			require.Equal(t, 200, response.Status())
+			require.Equal(t, "custom", templates.name)
+			require.Equal(t, "slack", templates.preview.Integration)
+		})
+
+		t.Run("maps errors to status codes", func(t *testing.T) {
+			tests := map[error]int{
+				fmt.Errorf("%w: invalid template", provisioning.ErrValidation): 400,
+				provisioning.ErrTemplateNotFound:                               404,
+				store.ErrNoAlertmanagerConfiguration:                           404,
+			}
+			for err, status := range tests {
+				sut := createProvisioningSrvSut()
+				sut.templates = &fakeTemplateService{err: err}
+				rc := createTestRequestCtx()
+
+				require.Equal(t, status, sut.RoutePostTemplatePreview(&rc, apimodels.TemplatePreviewRequest{}).Status())
+			}
+		})
+	})
+
+	t.Run("rule templates", func(t *testing.T) {
+		t.Run("PUT template uses the UID of the path", func(t *testing.T) {
+			sut := createProvisioningSrvSut()
+			templates := &fakeRuleTemplateService{}
+			sut.ruleTemplates = templates
+			rc := createTestRequestCtx()
+			rc.Req = web.SetURLParams(rc.Req, map[string]string{uidPathParam: "cpu"})
+
+			response := sut.RoutePutRuleTemplate(&rc, apimodels.RuleTemplate{UID: "other", Title: "High CPU", Rule: []byte("{}")})
+
+			require.Equal(t, 200, response.Status())
+			require.Equal(t, "cpu", templates.template.UID)
+			require.Equal(t, "{}", templates.template.Rule)
+		})
+
+		t.Run("POST rule creates a rule from the template of the path", func(t *testing.T) {
+			sut := createProvisioningSrvSut()
+			templates := &fakeRuleTemplateService{}
+			sut.ruleTemplates = templates
+			rc := createTestRequestCtx()
+			rc.Req = web.SetURLParams(rc.Req, map[string]string{uidPathParam: "cpu"})
+
+			response := sut.RoutePostRuleTemplateInstance(&rc, apimodels.RuleTemplateInstance{
+				FolderUID:  "folder",
+				RuleGroup:  "group",
+				Parameters: map[string]string{"service": "checkout"},
+			})
+
+			require.Equal(t, 201, response.Status())
+			require.Equal(t, domain.AlertRuleTemplateInstance{
+				OrgID:       1,
+				TemplateUID: "cpu",
+				Parameters:  map[string]string{"service": "checkout"},
+			}, templates.instance)
+			require.Equal(t, "folder", templates.folderUID)
+		})
+
+		t.Run("maps errors to status codes", func(t *testing.T) {
+			tests := map[error]int{
+				fmt.Errorf("%w: missing value of parameter service", provisioning.ErrValidation): 400,
+				domain.ErrRuleTemplateNotFound:         404,
+				domain.ErrRuleTemplateInstanceNotFound: 404,
+				domain.ErrRuleTemplateVersionConflict:  409,
+			}
+			for err, status := range tests {
+				sut := createProvisioningSrvSut()
+				sut.ruleTemplates = &fakeRuleTemplateService{err: err}
+				rc := createTestRequestCtx()
+				rc.Req = web.SetURLParams(rc.Req, map[string]string{uidPathParam: "cpu", ruleUIDPathParam: "rule"})
+
+				require.Equal(t, status, sut.RoutePutRuleTemplate(&rc, apimodels.RuleTemplate{}).Status())
+				require.Equal(t, status, sut.RoutePutRuleTemplateInstance(&rc, apimodels.RuleTemplateInstance{}).Status())
+			}
+		})
+	})
 }
 
 func createProvisioningSrvSut() ProvisioningSrv {
@@ -170,3 +352,96 @@ func (f *fakeRejectingNotificationPolicyService) GetPolicyTree(ctx context.Conte
 func (f *fakeRejectingNotificationPolicyService) UpdatePolicyTree(ctx context.Context, orgID int64, tree apimodels.Route, p domain.Provenance) error {
 	return fmt.Errorf("%w: invalid policy tree", provisioning.ErrValidation)
 }
+
+type fakeDryRunService struct {
+	file apimodels.ProvisioningFile
+	err  error
+}
+
+func (f *fakeDryRunService) Diff(ctx context.Context, file apimodels.ProvisioningFile) (apimodels.ProvisioningDiff, error) {
+	f.file = file
+	return apimodels.ProvisioningDiff{}, f.err
+}
+
+type fakeExportService struct {
+	secrets    provisioning.SecretsExport
+	folderUIDs []string
+	err        error
+}
+
+func (f *fakeExportService) Export(ctx context.Context, orgID int64, secrets provisioning.SecretsExport) (apimodels.ProvisioningFile, error) {
+	f.secrets = secrets
+	return apimodels.ProvisioningFile{APIVersion: 1}, f.err
+}
+
+func (f *fakeExportService) ExportRules(ctx context.Context, orgID int64, folderUIDs []string, group string) (apimodels.ProvisioningFile, error) {
+	f.folderUIDs = folderUIDs
+	return apimodels.ProvisioningFile{APIVersion: 1}, f.err
+}
+
+type fakeTemplateService struct {
+	name    string
+	preview apimodels.TemplatePreviewRequest
+	err     error
+}
+
+func (f *fakeTemplateService) GetTemplates(ctx context.Context, orgID int64) (map[string]string, error) {
+	return map[string]string{}, f.err
+}
+
+func (f *fakeTemplateService) SetTemplate(ctx context.Context, orgID int64, tmpl apimodels.MessageTemplate) (apimodels.MessageTemplate, error) {
+	return tmpl, f.err
+}
+
+func (f *fakeTemplateService) DeleteTemplate(ctx context.Context, orgID int64, name string) error {
+	return f.err
+}
+
+func (f *fakeTemplateService) PreviewTemplate(ctx context.Context, orgID int64, name string, req apimodels.TemplatePreviewRequest) (apimodels.TemplatePreviews, error) {
+	f.name, f.preview = name, req
+	return apimodels.TemplatePreviews{}, f.err
+}
+
+type fakeRuleTemplateService struct {
+	template  domain.AlertRuleTemplate
+	instance  domain.AlertRuleTemplateInstance
+	folderUID string
+	err       error
+}
+
+func (f *fakeRuleTemplateService) GetRuleTemplates(ctx context.Context, orgID int64) ([]domain.AlertRuleTemplate, error) {
+	return []domain.AlertRuleTemplate{f.template}, f.err
+}
+
+func (f *fakeRuleTemplateService) GetRuleTemplate(ctx context.Context, orgID int64, uid string) (domain.AlertRuleTemplate, error) {
+	return f.template, f.err
+}
+
+func (f *fakeRuleTemplateService) CreateRuleTemplate(ctx context.Context, orgID int64, t domain.AlertRuleTemplate) (domain.AlertRuleTemplate, error) {
+	f.template = t
+	return t, f.err
+}
+
+func (f *fakeRuleTemplateService) UpdateRuleTemplate(ctx context.Context, orgID int64, t domain.AlertRuleTemplate) (domain.AlertRuleTemplate, error) {
+	f.template = t
+	return t, f.err
+}
+
+func (f *fakeRuleTemplateService) DeleteRuleTemplate(ctx context.Context, orgID int64, uid string) error {
+	return f.err
+}
+
+func (f *fakeRuleTemplateService) GetRuleTemplateInstances(ctx context.Context, orgID int64, uid string) ([]domain.AlertRuleTemplateInstance, error) {
+	return []domain.AlertRuleTemplateInstance{f.instance}, f.err
+}
+
+func (f *fakeRuleTemplateService) CreateRuleFromTemplate(ctx context.Context, instance domain.AlertRuleTemplateInstance, folderUID, ruleGroup string) (domain.AlertRule, error) {
+	f.instance = instance
+	f.folderUID = folderUID
+	return domain.AlertRule{UID: "rule", NamespaceUID: folderUID, RuleGroup: ruleGroup}, f.err
+}
+
+func (f *fakeRuleTemplateService) UpdateRuleTemplateInstance(ctx context.Context, instance domain.AlertRuleTemplateInstance) (domain.AlertRule, error) {
+	f.instance = instance
+	return domain.AlertRule{UID: instance.RuleUID}, f.err
+}
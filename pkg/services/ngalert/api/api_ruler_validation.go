@@ -86,9 +86,25 @@ func validateRuleNode(
 		}
 	}
 
+	// the condition of a recording rule is not evaluated, the recorded query is validated instead
+	validatedCondition := ruleNode.GrafanaManagedAlert.Condition
+	record := ruleNode.GrafanaManagedAlert.Record
+	if record != nil {
+		if !cfg.RecordingRules.Enabled {
+			return nil, errors.New("recording rules are disabled")
+		}
+		if err := record.Validate(ruleNode.GrafanaManagedAlert.Data); err != nil {
+			return nil, err
+		}
+		if ruleNode.ApiRuleNode != nil && ruleNode.ApiRuleNode.For != 0 {
+			return nil, errors.New("recording rules cannot have a pending period")
+		}
+		validatedCondition = record.From
+	}
+
 	if len(ruleNode.GrafanaManagedAlert.Data) != 0 {
 		cond := ngmodels.Condition{
-			Condition: ruleNode.GrafanaManagedAlert.Condition,
+			Condition: validatedCondition,
 			OrgID:     orgId,
 			Data:      ruleNode.GrafanaManagedAlert.Data,
 		}
@@ -108,6 +124,7 @@ func validateRuleNode(
 		RuleGroup:       groupName,
 		NoDataState:     noDataState,
 		ExecErrState:    errorState,
+		Record:          record,
 	}
 
 	if ruleNode.ApiRuleNode != nil {
@@ -177,6 +194,7 @@ func validateRuleGroup(
 			}
 			uids[rule.UID] = idx
 		}
+		rule.RuleGroupIndex = idx + 1
 		result = append(result, rule)
 	}
 	return result, nil
@@ -117,6 +117,9 @@ func TestValidateRuleGroup(t *testing.T) {
 		require.NoError(t, err)
 		require.Len(t, alerts, len(rules))
 		require.Equal(t, len(rules), conditionValidations)
+		for idx, alert := range alerts {
+			require.Equal(t, idx+1, alert.RuleGroupIndex)
+		}
 	})
 	t.Run("should default to default interval from config if group interval is 0", func(t *testing.T) {
 		g := validGroup(cfg, rules...)
@@ -714,3 +717,79 @@ func TestValidateRuleNodeIntervalFailures(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateRuleNode_Record(t *testing.T) {
+	cfg := config(t)
+	cfg.RecordingRules.Enabled = true
+
+	recordingRule := func() apimodels.PostableExtendedRuleNode {
+		r := validRule()
+		r.ApiRuleNode.For = 0
+		r.GrafanaManagedAlert.Condition = ""
+		r.GrafanaManagedAlert.Record = &models.Record{Metric: "test_metric", From: "A"}
+		return r
+	}
+
+	t.Run("converts record to AlertRule", func(t *testing.T) {
+		r := recordingRule()
+		var validated models.Condition
+		alert, err := validateRuleNode(&r, "", cfg.BaseInterval, rand.Int63(), randFolder(), func(condition models.Condition) error {
+			validated = condition
+			return nil
+		}, cfg)
+		require.NoError(t, err)
+		require.Equal(t, r.GrafanaManagedAlert.Record, alert.Record)
+		require.Equal(t, "", alert.Condition)
+		require.Equal(t, "A", validated.Condition)
+		require.True(t, alert.IsRecordingRule())
+	})
+
+	testCases := []struct {
+		name   string
+		cfg    func(cfg setting.UnifiedAlertingSettings) *setting.UnifiedAlertingSettings
+		record func(r *apimodels.PostableExtendedRuleNode)
+	}{
+		{
+			name: "fail if recording rules are disabled",
+			cfg: func(cfg setting.UnifiedAlertingSettings) *setting.UnifiedAlertingSettings {
+				cfg.RecordingRules.Enabled = false
+				return &cfg
+			},
+		},
+		{
+			name: "fail if metric name is invalid",
+			record: func(r *apimodels.PostableExtendedRuleNode) {
+				r.GrafanaManagedAlert.Record.Metric = "invalid-metric"
+			},
+		},
+		{
+			name: "fail if recorded query does not exist",
+			record: func(r *apimodels.PostableExtendedRuleNode) {
+				r.GrafanaManagedAlert.Record.From = "B"
+			},
+		},
+		{
+			name: "fail if pending period is set",
+			record: func(r *apimodels.PostableExtendedRuleNode) {
+				r.ApiRuleNode.For = model.Duration(time.Minute)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			r := recordingRule()
+			if testCase.record != nil {
+				testCase.record(&r)
+			}
+			c := cfg
+			if testCase.cfg != nil {
+				c = testCase.cfg(*cfg)
+			}
+			_, err := validateRuleNode(&r, "", cfg.BaseInterval, rand.Int63(), randFolder(), func(condition models.Condition) error {
+				return nil
+			}, c)
+			require.Error(t, err)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 
@@ -19,6 +20,8 @@ import (
 	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
 	"github.com/grafana/grafana/pkg/services/ngalert/eval"
 	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/state"
+	"github.com/grafana/grafana/pkg/setting"
 	"github.com/grafana/grafana/pkg/util"
 	"github.com/grafana/grafana/pkg/web"
 )
@@ -132,3 +135,114 @@ func (srv TestingApiSrv) RouteEvalQueries(c *models.ReqContext, cmd apimodels.Ev
 
 	return response.JSONStreaming(http.StatusOK, evalResults)
 }
+
+// maxBacktestEvaluations limits how many times a rule can be evaluated by a single backtesting request.
+const maxBacktestEvaluations = 1000
+
+func (srv TestingApiSrv) RouteBacktestConfig(c *models.ReqContext, cmd apimodels.BacktestConfig) response.Response {
+	if !authorizeDatasourceAccessForRule(&ngmodels.AlertRule{Data: cmd.Data}, func(evaluator accesscontrol.Evaluator) bool {
+		return accesscontrol.HasAccess(srv.accessControl, c)(accesscontrol.ReqSignedIn, evaluator)
+	}) {
+		return ErrResp(http.StatusUnauthorized, fmt.Errorf("%w to query one or many data sources used by the rule", ErrAuthorization), "")
+	}
+
+	rule, err := validateBacktestConfig(cmd, c.SignedInUser.OrgId)
+	if err != nil {
+		return ErrResp(http.StatusBadRequest, err, "invalid backtesting configuration")
+	}
+
+	evalCond := ngmodels.Condition{
+		Condition: cmd.Condition,
+		OrgID:     c.SignedInUser.OrgId,
+		Data:      cmd.Data,
+	}
+	if err := validateCondition(c.Req.Context(), evalCond, c.SignedInUser, c.SkipCache, srv.DatasourceCache); err != nil {
+		return ErrResp(http.StatusBadRequest, err, "invalid condition")
+	}
+
+	timelines, err := state.Backtest(c.Req.Context(), srv.log, rule, cmd.From, cmd.To, func(now time.Time) (eval.Results, error) {
+		return srv.evaluator.ConditionEval(&evalCond, now, srv.ExpressionService)
+	})
+	if err != nil {
+		return ErrResp(http.StatusBadRequest, err, "Failed to backtest the rule")
+	}
+
+	result := apimodels.BacktestResult{Instances: make([]apimodels.BacktestInstance, 0, len(timelines))}
+	for _, tl := range timelines {
+		labels := tl.Labels.Copy()
+		ngmodels.WithoutInternalLabels()(labels)
+		instance := apimodels.BacktestInstance{
+			Labels:      labels,
+			Transitions: make([]apimodels.BacktestTransition, 0, len(tl.Transitions)),
+		}
+		for _, t := range tl.Transitions {
+			instance.Transitions = append(instance.Transitions, apimodels.BacktestTransition{
+				Time:     t.Time,
+				State:    t.State.String(),
+				Reason:   t.Reason,
+				Resolved: t.Resolved,
+			})
+		}
+		result.Instances = append(result.Instances, instance)
+	}
+	return response.JSON(http.StatusOK, result)
+}
+
+// validateBacktestConfig checks the time range of the configuration and converts it to the rule that is backtested.
+func validateBacktestConfig(cmd apimodels.BacktestConfig, orgID int64) (*ngmodels.AlertRule, error) {
+	if cmd.From.IsZero() || cmd.To.IsZero() {
+		return nil, errors.New("both from and to must be specified")
+	}
+	if !cmd.From.Before(cmd.To) {
+		return nil, errors.New("from must be before to")
+	}
+
+	interval := time.Duration(cmd.Interval)
+	if interval == 0 {
+		interval = setting.DefaultRuleEvaluationInterval
+	}
+	if interval < time.Second || interval%time.Second != 0 {
+		return nil, fmt.Errorf("interval must be a positive multiple of one second, got %s", interval)
+	}
+	if evaluations := cmd.To.Sub(cmd.From)/interval + 1; evaluations > maxBacktestEvaluations {
+		return nil, fmt.Errorf("the range requires %d evaluations but at most %d are allowed, use a shorter range or a longer interval", evaluations, maxBacktestEvaluations)
+	}
+	if cmd.For < 0 {
+		return nil, errors.New("for must not be negative")
+	}
+
+	noDataState := ngmodels.NoData
+	if cmd.NoDataState != "" {
+		var err error
+		noDataState, err = ngmodels.NoDataStateFromString(string(cmd.NoDataState))
+		if err != nil {
+			return nil, err
+		}
+	}
+	errorState := ngmodels.AlertingErrState
+	if cmd.ExecErrState != "" {
+		var err error
+		errorState, err = ngmodels.ErrStateFromString(string(cmd.ExecErrState))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	title := cmd.Title
+	if title == "" {
+		title = "Backtest"
+	}
+
+	return &ngmodels.AlertRule{
+		OrgID:           orgID,
+		Title:           title,
+		Condition:       cmd.Condition,
+		Data:            cmd.Data,
+		IntervalSeconds: int64(interval.Seconds()),
+		For:             time.Duration(cmd.For),
+		NoDataState:     noDataState,
+		ExecErrState:    errorState,
+		Labels:          cmd.Labels,
+		Annotations:     cmd.Annotations,
+	}, nil
+}
@@ -1,11 +1,13 @@
 package api
 
 import (
+	"encoding/json"
 	"net/http"
 	"testing"
 	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
@@ -266,6 +268,100 @@ func TestRouteEvalQueries(t *testing.T) {
 	})
 }
 
+func TestRouteBacktestConfig(t *testing.T) {
+	rc := &models2.ReqContext{
+		Context: &web.Context{
+			Req: &http.Request{},
+		},
+		SignedInUser: &models2.SignedInUser{
+			OrgId: 1,
+		},
+	}
+	from := time.Unix(0, 0)
+
+	t.Run("should return 401 if user cannot query a data source", func(t *testing.T) {
+		data1 := models.GenerateAlertQuery()
+		data2 := models.GenerateAlertQuery()
+
+		ac := acMock.New().WithPermissions([]*accesscontrol.Permission{
+			{Action: datasources.ActionQuery, Scope: datasources.ScopeProvider.GetResourceScopeUID(data1.DatasourceUID)},
+		})
+
+		srv := createTestingApiSrv(nil, ac, nil)
+
+		response := srv.RouteBacktestConfig(rc, definitions.BacktestConfig{
+			From:      from,
+			To:        from.Add(time.Hour),
+			Condition: data1.RefID,
+			Data:      []models.AlertQuery{data1, data2},
+		})
+
+		require.Equal(t, http.StatusUnauthorized, response.Status())
+	})
+
+	t.Run("should return 400 if the range is invalid", func(t *testing.T) {
+		data1 := models.GenerateAlertQuery()
+
+		ac := acMock.New().WithPermissions([]*accesscontrol.Permission{
+			{Action: datasources.ActionQuery, Scope: datasources.ScopeProvider.GetResourceScopeUID(data1.DatasourceUID)},
+		})
+		evaluator := &eval.FakeEvaluator{}
+		srv := createTestingApiSrv(nil, ac, evaluator)
+
+		for _, cfg := range []definitions.BacktestConfig{
+			{To: from.Add(time.Hour)},
+			{From: from.Add(time.Hour), To: from},
+			{From: from, To: from.Add(time.Hour), Interval: model.Duration(time.Millisecond)},
+			{From: from, To: from.Add(24 * time.Hour), Interval: model.Duration(time.Second)},
+			{From: from, To: from.Add(time.Hour), NoDataState: "Unknown"},
+		} {
+			cfg.Condition = data1.RefID
+			cfg.Data = []models.AlertQuery{data1}
+			response := srv.RouteBacktestConfig(rc, cfg)
+			require.Equal(t, http.StatusBadRequest, response.Status())
+		}
+		evaluator.AssertNotCalled(t, "ConditionEval", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return 200 and evaluate the rule at every interval", func(t *testing.T) {
+		data1 := models.GenerateAlertQuery()
+
+		ac := acMock.New().WithPermissions([]*accesscontrol.Permission{
+			{Action: datasources.ActionQuery, Scope: datasources.ScopeProvider.GetResourceScopeUID(data1.DatasourceUID)},
+		})
+		ds := &fakes.FakeCacheService{DataSources: []*models2.DataSource{
+			{Uid: data1.DatasourceUID},
+		}}
+
+		evaluator := &eval.FakeEvaluator{}
+		result := eval.Results{{State: eval.Alerting}}
+		evaluator.EXPECT().ConditionEval(mock.Anything, mock.Anything, mock.Anything).Return(result, nil)
+
+		srv := createTestingApiSrv(ds, ac, evaluator)
+
+		response := srv.RouteBacktestConfig(rc, definitions.BacktestConfig{
+			From:      from,
+			To:        from.Add(10 * time.Minute),
+			Interval:  model.Duration(time.Minute),
+			Condition: data1.RefID,
+			Data:      []models.AlertQuery{data1},
+			For:       model.Duration(5 * time.Minute),
+		})
+
+		require.Equal(t, http.StatusOK, response.Status())
+		evaluator.AssertNumberOfCalls(t, "ConditionEval", 11)
+
+		var body definitions.BacktestResult
+		require.NoError(t, json.Unmarshal(response.Body(), &body))
+		require.Len(t, body.Instances, 1)
+		require.Equal(t, map[string]string{"alertname": "Backtest"}, body.Instances[0].Labels)
+		require.Len(t, body.Instances[0].Transitions, 2)
+		require.Equal(t, "Pending", body.Instances[0].Transitions[0].State)
+		require.Equal(t, "Alerting", body.Instances[0].Transitions[1].State)
+		require.True(t, from.Add(5*time.Minute).Equal(body.Instances[0].Transitions[1].Time))
+	})
+}
+
 func createTestingApiSrv(ds *fakes.FakeCacheService, ac *acMock.Mock, evaluator *eval.FakeEvaluator) *TestingApiSrv {
 	if ac == nil {
 		ac = acMock.New().WithDisabled()
@@ -62,11 +62,21 @@ func (api *API) authorize(method, path string) web.Handler {
 	case http.MethodGet + "/api/prometheus/grafana/api/v1/rules":
 		eval = ac.EvalPermission(ac.ActionAlertingRuleRead)
 
+	// Grafana State History Paths
+	case http.MethodGet + "/api/v1/rules/history":
+		fallback = middleware.ReqSignedIn
+		// the history is restricted to the rules the user can read in the request handler
+		eval = ac.EvalPermission(ac.ActionAlertingRuleRead)
+
 	// Grafana Rules Testing Paths
 	case http.MethodPost + "/api/v1/rule/test/grafana":
 		fallback = middleware.ReqSignedIn
 		// additional authorization is done in the request handler
 		eval = ac.EvalPermission(ac.ActionAlertingRuleRead)
+	case http.MethodPost + "/api/v1/rule/backtest":
+		fallback = middleware.ReqSignedIn
+		// additional authorization is done in the request handler
+		eval = ac.EvalPermission(ac.ActionAlertingRuleRead)
 	case http.MethodPost + "/api/v1/eval":
 		fallback = middleware.ReqSignedIn
 		// additional authorization is done in the request handler
@@ -107,6 +117,20 @@ func (api *API) authorize(method, path string) web.Handler {
 	case http.MethodPost + "/api/alertmanager/grafana/api/v2/silences":
 		// additional authorization is done in the request handler
 		eval = ac.EvalAny(ac.EvalPermission(ac.ActionAlertingInstanceCreate), ac.EvalPermission(ac.ActionAlertingInstanceUpdate))
+	case http.MethodPost + "/api/alertmanager/grafana/api/v2/silences/bulk":
+		eval = ac.EvalPermission(ac.ActionAlertingInstanceCreate)
+	case http.MethodPost + "/api/alertmanager/grafana/api/v2/silences/expire":
+		eval = ac.EvalPermission(ac.ActionAlertingInstanceUpdate)
+	case http.MethodPost + "/api/alertmanager/grafana/api/v2/silences/preview":
+		eval = ac.EvalPermission(ac.ActionAlertingInstanceRead)
+	case http.MethodGet + "/api/alertmanager/grafana/api/v2/silence-schedules",
+		http.MethodGet + "/api/alertmanager/grafana/api/v2/silence-schedules/{UID}":
+		eval = ac.EvalPermission(ac.ActionAlertingInstanceRead)
+	case http.MethodPost + "/api/alertmanager/grafana/api/v2/silence-schedules":
+		eval = ac.EvalPermission(ac.ActionAlertingInstanceCreate)
+	case http.MethodPut + "/api/alertmanager/grafana/api/v2/silence-schedules/{UID}",
+		http.MethodDelete + "/api/alertmanager/grafana/api/v2/silence-schedules/{UID}":
+		eval = ac.EvalPermission(ac.ActionAlertingInstanceUpdate)
 
 	// Alert Instances. Grafana Paths
 	case http.MethodGet + "/api/alertmanager/grafana/api/v2/alerts/groups":
@@ -147,17 +171,25 @@ func (api *API) authorize(method, path string) web.Handler {
 	// Grafana Paths
 	case http.MethodDelete + "/api/alertmanager/grafana/config/api/v1/alerts": // reset alertmanager config to the default
 		eval = ac.EvalPermission(ac.ActionAlertingNotificationsWrite)
-	case http.MethodGet + "/api/alertmanager/grafana/config/api/v1/alerts":
+	case http.MethodGet + "/api/alertmanager/grafana/config/api/v1/alerts",
+		http.MethodGet + "/api/alertmanager/grafana/config/history",
+		http.MethodGet + "/api/alertmanager/grafana/config/history/diff":
 		fallback = middleware.ReqEditorRole
 		eval = ac.EvalPermission(ac.ActionAlertingNotificationsRead)
 	case http.MethodGet + "/api/alertmanager/grafana/api/v2/status":
 		eval = ac.EvalPermission(ac.ActionAlertingNotificationsRead)
-	case http.MethodPost + "/api/alertmanager/grafana/config/api/v1/alerts":
+	case http.MethodPost + "/api/alertmanager/grafana/config/api/v1/alerts",
+		http.MethodPost + "/api/alertmanager/grafana/config/history/{ID}/rollback":
 		// additional authorization is done in the request handler
 		eval = ac.EvalAny(ac.EvalPermission(ac.ActionAlertingNotificationsWrite))
 	case http.MethodPost + "/api/alertmanager/grafana/config/api/v1/receivers/test":
 		fallback = middleware.ReqEditorRole
 		eval = ac.EvalPermission(ac.ActionAlertingNotificationsRead)
+	case http.MethodGet + "/api/v1/notifications/deliveries":
+		fallback = middleware.ReqEditorRole
+		eval = ac.EvalPermission(ac.ActionAlertingNotificationsRead)
+	case http.MethodPost + "/api/v1/notifications/deliveries/{DeliveryID}/replay":
+		eval = ac.EvalPermission(ac.ActionAlertingNotificationsWrite)
 
 	// External Alertmanager Paths
 	case http.MethodDelete + "/api/alertmanager/{DatasourceUID}/config/api/v1/alerts":
@@ -185,7 +217,14 @@ func (api *API) authorize(method, path string) web.Handler {
 		http.MethodGet + "/api/v1/provisioning/templates/{name}",
 		http.MethodGet + "/api/v1/provisioning/mute-timings",
 		http.MethodGet + "/api/v1/provisioning/mute-timings/{name}",
-		http.MethodGet + "/api/v1/provisioning/alert-rules/{UID}":
+		http.MethodGet + "/api/v1/provisioning/alert-rules/{UID}",
+		http.MethodPost + "/api/v1/provisioning/dry-run",
+		http.MethodPost + "/api/v1/provisioning/templates/{name}/preview",
+		http.MethodGet + "/api/v1/provisioning/export",
+		http.MethodGet + "/api/v1/provisioning/export/alert-rules",
+		http.MethodGet + "/api/v1/provisioning/rule-templates",
+		http.MethodGet + "/api/v1/provisioning/rule-templates/{UID}",
+		http.MethodGet + "/api/v1/provisioning/rule-templates/{UID}/rules":
 		return middleware.ReqOrgAdmin
 
 	case http.MethodPut + "/api/v1/provisioning/policies",
@@ -200,7 +239,12 @@ func (api *API) authorize(method, path string) web.Handler {
 		http.MethodPost + "/api/v1/provisioning/alert-rules",
 		http.MethodPut + "/api/v1/provisioning/alert-rules/{UID}",
 		http.MethodDelete + "/api/v1/provisioning/alert-rules/{UID}",
-		http.MethodPut + "/api/v1/provisioning/folder/{FolderUID}/rule-groups/{Group}":
+		http.MethodPut + "/api/v1/provisioning/folder/{FolderUID}/rule-groups/{Group}",
+		http.MethodPost + "/api/v1/provisioning/rule-templates",
+		http.MethodPut + "/api/v1/provisioning/rule-templates/{UID}",
+		http.MethodDelete + "/api/v1/provisioning/rule-templates/{UID}",
+		http.MethodPost + "/api/v1/provisioning/rule-templates/{UID}/rules",
+		http.MethodPut + "/api/v1/provisioning/rule-templates/{UID}/rules/{RuleUID}":
 		return middleware.ReqOrgAdmin
 	}
 
@@ -202,3 +202,47 @@ func (f *ForkedAlertmanagerApi) forkRoutePostGrafanaAlertingConfig(ctx *models.R
 func (f *ForkedAlertmanagerApi) forkRoutePostTestGrafanaReceivers(ctx *models.ReqContext, conf apimodels.TestReceiversConfigBodyParams) response.Response {
 	return f.GrafanaSvc.RoutePostTestReceivers(ctx, conf)
 }
+
+func (f *ForkedAlertmanagerApi) forkRoutePostGrafanaSilencesBulk(ctx *models.ReqContext, body apimodels.PostableSilences) response.Response {
+	return f.GrafanaSvc.RoutePostSilencesBulk(ctx, body)
+}
+
+func (f *ForkedAlertmanagerApi) forkRoutePostGrafanaSilencesExpire(ctx *models.ReqContext, body apimodels.SilencesExpireRequest) response.Response {
+	return f.GrafanaSvc.RoutePostSilencesExpire(ctx, body)
+}
+
+func (f *ForkedAlertmanagerApi) forkRoutePostGrafanaSilencePreview(ctx *models.ReqContext, body apimodels.PostableSilence) response.Response {
+	return f.GrafanaSvc.RoutePostSilencePreview(ctx, body)
+}
+
+func (f *ForkedAlertmanagerApi) forkRouteGetGrafanaSilenceSchedules(ctx *models.ReqContext) response.Response {
+	return f.GrafanaSvc.RouteGetSilenceSchedules(ctx)
+}
+
+func (f *ForkedAlertmanagerApi) forkRouteGetGrafanaSilenceSchedule(ctx *models.ReqContext) response.Response {
+	return f.GrafanaSvc.RouteGetSilenceSchedule(ctx)
+}
+
+func (f *ForkedAlertmanagerApi) forkRoutePostGrafanaSilenceSchedule(ctx *models.ReqContext, body apimodels.SilenceSchedule) response.Response {
+	return f.GrafanaSvc.RoutePostSilenceSchedule(ctx, body)
+}
+
+func (f *ForkedAlertmanagerApi) forkRoutePutGrafanaSilenceSchedule(ctx *models.ReqContext, body apimodels.SilenceSchedule) response.Response {
+	return f.GrafanaSvc.RoutePutSilenceSchedule(ctx, body)
+}
+
+func (f *ForkedAlertmanagerApi) forkRouteDeleteGrafanaSilenceSchedule(ctx *models.ReqContext) response.Response {
+	return f.GrafanaSvc.RouteDeleteSilenceSchedule(ctx)
+}
+
+func (f *ForkedAlertmanagerApi) forkRouteGetGrafanaAlertingConfigHistory(ctx *models.ReqContext) response.Response {
+	return f.GrafanaSvc.RouteGetAlertingConfigHistory(ctx)
+}
+
+func (f *ForkedAlertmanagerApi) forkRouteGetGrafanaAlertingConfigDiff(ctx *models.ReqContext) response.Response {
+	return f.GrafanaSvc.RouteGetAlertingConfigDiff(ctx)
+}
+
+func (f *ForkedAlertmanagerApi) forkRoutePostGrafanaAlertingConfigRollback(ctx *models.ReqContext) response.Response {
+	return f.GrafanaSvc.RoutePostAlertingConfigRollback(ctx)
+}
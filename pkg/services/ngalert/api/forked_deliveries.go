@@ -0,0 +1,26 @@
+package api
+
+import (
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/models"
+)
+
+// ForkedDeliveriesApi always forwards requests to grafana backend
+type ForkedDeliveriesApi struct {
+	svc *DeliveriesSrv
+}
+
+// NewForkedDeliveriesApi creates a new ForkedDeliveriesApi instance
+func NewForkedDeliveriesApi(svc *DeliveriesSrv) *ForkedDeliveriesApi {
+	return &ForkedDeliveriesApi{
+		svc: svc,
+	}
+}
+
+func (f *ForkedDeliveriesApi) forkRouteGetNotificationDeliveries(c *models.ReqContext) response.Response {
+	return f.svc.RouteGetNotificationDeliveries(c)
+}
+
+func (f *ForkedDeliveriesApi) forkRouteReplayNotificationDelivery(c *models.ReqContext) response.Response {
+	return f.svc.RouteReplayNotificationDelivery(c)
+}
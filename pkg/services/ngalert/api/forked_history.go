@@ -0,0 +1,22 @@
+package api
+
+import (
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/models"
+)
+
+// ForkedHistoryApi always forwards requests to grafana backend
+type ForkedHistoryApi struct {
+	svc *HistorySrv
+}
+
+// NewForkedHistoryApi creates a new ForkedHistoryApi instance
+func NewForkedHistoryApi(svc *HistorySrv) *ForkedHistoryApi {
+	return &ForkedHistoryApi{
+		svc: svc,
+	}
+}
+
+func (f *ForkedHistoryApi) forkRouteGetStateHistory(c *models.ReqContext) response.Response {
+	return f.svc.RouteGetStateHistory(c)
+}
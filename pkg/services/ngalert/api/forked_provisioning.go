@@ -59,6 +59,10 @@ func (f *ForkedProvisioningApi) forkRouteDeleteTemplate(ctx *models.ReqContext)
 	return f.svc.RouteDeleteTemplate(ctx)
 }
 
+func (f *ForkedProvisioningApi) forkRoutePostTemplatePreview(ctx *models.ReqContext, body apimodels.TemplatePreviewRequest) response.Response {
+	return f.svc.RoutePostTemplatePreview(ctx, body)
+}
+
 func (f *ForkedProvisioningApi) forkRouteGetMuteTiming(ctx *models.ReqContext) response.Response {
 	return f.svc.RouteGetMuteTiming(ctx)
 }
@@ -98,3 +102,47 @@ func (f *ForkedProvisioningApi) forkRouteDeleteAlertRule(ctx *models.ReqContext)
 func (f *ForkedProvisioningApi) forkRoutePutAlertRuleGroup(ctx *models.ReqContext, ag apimodels.AlertRuleGroup) response.Response {
 	return f.svc.RoutePutAlertRuleGroup(ctx, ag)
 }
+
+func (f *ForkedProvisioningApi) forkRoutePostProvisioningDryRun(ctx *models.ReqContext, file apimodels.ProvisioningFile) response.Response {
+	return f.svc.RoutePostProvisioningDryRun(ctx, file)
+}
+
+func (f *ForkedProvisioningApi) forkRouteGetProvisioningExport(ctx *models.ReqContext) response.Response {
+	return f.svc.RouteGetProvisioningExport(ctx)
+}
+
+func (f *ForkedProvisioningApi) forkRouteGetAlertRulesExport(ctx *models.ReqContext) response.Response {
+	return f.svc.RouteGetAlertRulesExport(ctx)
+}
+
+func (f *ForkedProvisioningApi) forkRouteGetRuleTemplates(ctx *models.ReqContext) response.Response {
+	return f.svc.RouteGetRuleTemplates(ctx)
+}
+
+func (f *ForkedProvisioningApi) forkRouteGetRuleTemplate(ctx *models.ReqContext) response.Response {
+	return f.svc.RouteGetRuleTemplate(ctx)
+}
+
+func (f *ForkedProvisioningApi) forkRoutePostRuleTemplate(ctx *models.ReqContext, t apimodels.RuleTemplate) response.Response {
+	return f.svc.RoutePostRuleTemplate(ctx, t)
+}
+
+func (f *ForkedProvisioningApi) forkRoutePutRuleTemplate(ctx *models.ReqContext, t apimodels.RuleTemplate) response.Response {
+	return f.svc.RoutePutRuleTemplate(ctx, t)
+}
+
+func (f *ForkedProvisioningApi) forkRouteDeleteRuleTemplate(ctx *models.ReqContext) response.Response {
+	return f.svc.RouteDeleteRuleTemplate(ctx)
+}
+
+func (f *ForkedProvisioningApi) forkRouteGetRuleTemplateInstances(ctx *models.ReqContext) response.Response {
+	return f.svc.RouteGetRuleTemplateInstances(ctx)
+}
+
+func (f *ForkedProvisioningApi) forkRoutePostRuleTemplateInstance(ctx *models.ReqContext, i apimodels.RuleTemplateInstance) response.Response {
+	return f.svc.RoutePostRuleTemplateInstance(ctx, i)
+}
+
+func (f *ForkedProvisioningApi) forkRoutePutRuleTemplateInstance(ctx *models.ReqContext, i apimodels.RuleTemplateInstance) response.Response {
+	return f.svc.RoutePutRuleTemplateInstance(ctx, i)
+}
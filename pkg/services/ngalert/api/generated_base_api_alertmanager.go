@@ -24,6 +24,7 @@ type AlertmanagerApiForkingService interface {
 	RouteDeleteAlertingConfig(*models.ReqContext) response.Response
 	RouteDeleteGrafanaAlertingConfig(*models.ReqContext) response.Response
 	RouteDeleteGrafanaSilence(*models.ReqContext) response.Response
+	RouteDeleteGrafanaSilenceSchedule(*models.ReqContext) response.Response
 	RouteDeleteSilence(*models.ReqContext) response.Response
 	RouteGetAMAlertGroups(*models.ReqContext) response.Response
 	RouteGetAMAlerts(*models.ReqContext) response.Response
@@ -33,7 +34,11 @@ type AlertmanagerApiForkingService interface {
 	RouteGetGrafanaAMAlerts(*models.ReqContext) response.Response
 	RouteGetGrafanaAMStatus(*models.ReqContext) response.Response
 	RouteGetGrafanaAlertingConfig(*models.ReqContext) response.Response
+	RouteGetGrafanaAlertingConfigDiff(*models.ReqContext) response.Response
+	RouteGetGrafanaAlertingConfigHistory(*models.ReqContext) response.Response
 	RouteGetGrafanaSilence(*models.ReqContext) response.Response
+	RouteGetGrafanaSilenceSchedule(*models.ReqContext) response.Response
+	RouteGetGrafanaSilenceSchedules(*models.ReqContext) response.Response
 	RouteGetGrafanaSilences(*models.ReqContext) response.Response
 	RouteGetSilence(*models.ReqContext) response.Response
 	RouteGetSilences(*models.ReqContext) response.Response
@@ -41,8 +46,14 @@ type AlertmanagerApiForkingService interface {
 	RoutePostAlertingConfig(*models.ReqContext) response.Response
 	RoutePostGrafanaAMAlerts(*models.ReqContext) response.Response
 	RoutePostGrafanaAlertingConfig(*models.ReqContext) response.Response
+	RoutePostGrafanaAlertingConfigRollback(*models.ReqContext) response.Response
+	RoutePostGrafanaSilencePreview(*models.ReqContext) response.Response
+	RoutePostGrafanaSilenceSchedule(*models.ReqContext) response.Response
+	RoutePostGrafanaSilencesBulk(*models.ReqContext) response.Response
+	RoutePostGrafanaSilencesExpire(*models.ReqContext) response.Response
 	RoutePostTestGrafanaReceivers(*models.ReqContext) response.Response
 	RoutePostTestReceivers(*models.ReqContext) response.Response
+	RoutePutGrafanaSilenceSchedule(*models.ReqContext) response.Response
 }
 
 func (f *ForkedAlertmanagerApi) RouteCreateGrafanaSilence(ctx *models.ReqContext) response.Response {
@@ -68,6 +79,9 @@ func (f *ForkedAlertmanagerApi) RouteDeleteGrafanaAlertingConfig(ctx *models.Req
 func (f *ForkedAlertmanagerApi) RouteDeleteGrafanaSilence(ctx *models.ReqContext) response.Response {
 	return f.forkRouteDeleteGrafanaSilence(ctx)
 }
+func (f *ForkedAlertmanagerApi) RouteDeleteGrafanaSilenceSchedule(ctx *models.ReqContext) response.Response {
+	return f.forkRouteDeleteGrafanaSilenceSchedule(ctx)
+}
 func (f *ForkedAlertmanagerApi) RouteDeleteSilence(ctx *models.ReqContext) response.Response {
 	return f.forkRouteDeleteSilence(ctx)
 }
@@ -95,9 +109,21 @@ func (f *ForkedAlertmanagerApi) RouteGetGrafanaAMStatus(ctx *models.ReqContext)
 func (f *ForkedAlertmanagerApi) RouteGetGrafanaAlertingConfig(ctx *models.ReqContext) response.Response {
 	return f.forkRouteGetGrafanaAlertingConfig(ctx)
 }
+func (f *ForkedAlertmanagerApi) RouteGetGrafanaAlertingConfigDiff(ctx *models.ReqContext) response.Response {
+	return f.forkRouteGetGrafanaAlertingConfigDiff(ctx)
+}
+func (f *ForkedAlertmanagerApi) RouteGetGrafanaAlertingConfigHistory(ctx *models.ReqContext) response.Response {
+	return f.forkRouteGetGrafanaAlertingConfigHistory(ctx)
+}
 func (f *ForkedAlertmanagerApi) RouteGetGrafanaSilence(ctx *models.ReqContext) response.Response {
 	return f.forkRouteGetGrafanaSilence(ctx)
 }
+func (f *ForkedAlertmanagerApi) RouteGetGrafanaSilenceSchedule(ctx *models.ReqContext) response.Response {
+	return f.forkRouteGetGrafanaSilenceSchedule(ctx)
+}
+func (f *ForkedAlertmanagerApi) RouteGetGrafanaSilenceSchedules(ctx *models.ReqContext) response.Response {
+	return f.forkRouteGetGrafanaSilenceSchedules(ctx)
+}
 func (f *ForkedAlertmanagerApi) RouteGetGrafanaSilences(ctx *models.ReqContext) response.Response {
 	return f.forkRouteGetGrafanaSilences(ctx)
 }
@@ -135,6 +161,37 @@ func (f *ForkedAlertmanagerApi) RoutePostGrafanaAlertingConfig(ctx *models.ReqCo
 	}
 	return f.forkRoutePostGrafanaAlertingConfig(ctx, conf)
 }
+func (f *ForkedAlertmanagerApi) RoutePostGrafanaAlertingConfigRollback(ctx *models.ReqContext) response.Response {
+	return f.forkRoutePostGrafanaAlertingConfigRollback(ctx)
+}
+func (f *ForkedAlertmanagerApi) RoutePostGrafanaSilencePreview(ctx *models.ReqContext) response.Response {
+	conf := apimodels.PostableSilence{}
+	if err := web.Bind(ctx.Req, &conf); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	return f.forkRoutePostGrafanaSilencePreview(ctx, conf)
+}
+func (f *ForkedAlertmanagerApi) RoutePostGrafanaSilenceSchedule(ctx *models.ReqContext) response.Response {
+	conf := apimodels.SilenceSchedule{}
+	if err := web.Bind(ctx.Req, &conf); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	return f.forkRoutePostGrafanaSilenceSchedule(ctx, conf)
+}
+func (f *ForkedAlertmanagerApi) RoutePostGrafanaSilencesBulk(ctx *models.ReqContext) response.Response {
+	conf := apimodels.PostableSilences{}
+	if err := web.Bind(ctx.Req, &conf); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	return f.forkRoutePostGrafanaSilencesBulk(ctx, conf)
+}
+func (f *ForkedAlertmanagerApi) RoutePostGrafanaSilencesExpire(ctx *models.ReqContext) response.Response {
+	conf := apimodels.SilencesExpireRequest{}
+	if err := web.Bind(ctx.Req, &conf); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	return f.forkRoutePostGrafanaSilencesExpire(ctx, conf)
+}
 func (f *ForkedAlertmanagerApi) RoutePostTestGrafanaReceivers(ctx *models.ReqContext) response.Response {
 	conf := apimodels.TestReceiversConfigBodyParams{}
 	if err := web.Bind(ctx.Req, &conf); err != nil {
@@ -150,6 +207,14 @@ func (f *ForkedAlertmanagerApi) RoutePostTestReceivers(ctx *models.ReqContext) r
 	return f.forkRoutePostTestReceivers(ctx, conf)
 }
 
+func (f *ForkedAlertmanagerApi) RoutePutGrafanaSilenceSchedule(ctx *models.ReqContext) response.Response {
+	conf := apimodels.SilenceSchedule{}
+	if err := web.Bind(ctx.Req, &conf); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	return f.forkRoutePutGrafanaSilenceSchedule(ctx, conf)
+}
+
 func (api *API) RegisterAlertmanagerApiEndpoints(srv AlertmanagerApiForkingService, m *metrics.API) {
 	api.RouteRegister.Group("", func(group routing.RouteRegister) {
 		group.Post(
@@ -202,6 +267,16 @@ func (api *API) RegisterAlertmanagerApiEndpoints(srv AlertmanagerApiForkingServi
 				m,
 			),
 		)
+		group.Delete(
+			toMacaronPath("/api/alertmanager/grafana/api/v2/silence-schedules/{UID}"),
+			api.authorize(http.MethodDelete, "/api/alertmanager/grafana/api/v2/silence-schedules/{UID}"),
+			metrics.Instrument(
+				http.MethodDelete,
+				"/api/alertmanager/grafana/api/v2/silence-schedules/{UID}",
+				srv.RouteDeleteGrafanaSilenceSchedule,
+				m,
+			),
+		)
 		group.Delete(
 			toMacaronPath("/api/alertmanager/{DatasourceUID}/api/v2/silence/{SilenceId}"),
 			api.authorize(http.MethodDelete, "/api/alertmanager/{DatasourceUID}/api/v2/silence/{SilenceId}"),
@@ -292,6 +367,26 @@ func (api *API) RegisterAlertmanagerApiEndpoints(srv AlertmanagerApiForkingServi
 				m,
 			),
 		)
+		group.Get(
+			toMacaronPath("/api/alertmanager/grafana/config/history/diff"),
+			api.authorize(http.MethodGet, "/api/alertmanager/grafana/config/history/diff"),
+			metrics.Instrument(
+				http.MethodGet,
+				"/api/alertmanager/grafana/config/history/diff",
+				srv.RouteGetGrafanaAlertingConfigDiff,
+				m,
+			),
+		)
+		group.Get(
+			toMacaronPath("/api/alertmanager/grafana/config/history"),
+			api.authorize(http.MethodGet, "/api/alertmanager/grafana/config/history"),
+			metrics.Instrument(
+				http.MethodGet,
+				"/api/alertmanager/grafana/config/history",
+				srv.RouteGetGrafanaAlertingConfigHistory,
+				m,
+			),
+		)
 		group.Get(
 			toMacaronPath("/api/alertmanager/grafana/api/v2/silence/{SilenceId}"),
 			api.authorize(http.MethodGet, "/api/alertmanager/grafana/api/v2/silence/{SilenceId}"),
@@ -302,6 +397,26 @@ func (api *API) RegisterAlertmanagerApiEndpoints(srv AlertmanagerApiForkingServi
 				m,
 			),
 		)
+		group.Get(
+			toMacaronPath("/api/alertmanager/grafana/api/v2/silence-schedules/{UID}"),
+			api.authorize(http.MethodGet, "/api/alertmanager/grafana/api/v2/silence-schedules/{UID}"),
+			metrics.Instrument(
+				http.MethodGet,
+				"/api/alertmanager/grafana/api/v2/silence-schedules/{UID}",
+				srv.RouteGetGrafanaSilenceSchedule,
+				m,
+			),
+		)
+		group.Get(
+			toMacaronPath("/api/alertmanager/grafana/api/v2/silence-schedules"),
+			api.authorize(http.MethodGet, "/api/alertmanager/grafana/api/v2/silence-schedules"),
+			metrics.Instrument(
+				http.MethodGet,
+				"/api/alertmanager/grafana/api/v2/silence-schedules",
+				srv.RouteGetGrafanaSilenceSchedules,
+				m,
+			),
+		)
 		group.Get(
 			toMacaronPath("/api/alertmanager/grafana/api/v2/silences"),
 			api.authorize(http.MethodGet, "/api/alertmanager/grafana/api/v2/silences"),
@@ -372,6 +487,56 @@ func (api *API) RegisterAlertmanagerApiEndpoints(srv AlertmanagerApiForkingServi
 				m,
 			),
 		)
+		group.Post(
+			toMacaronPath("/api/alertmanager/grafana/config/history/{ID}/rollback"),
+			api.authorize(http.MethodPost, "/api/alertmanager/grafana/config/history/{ID}/rollback"),
+			metrics.Instrument(
+				http.MethodPost,
+				"/api/alertmanager/grafana/config/history/{ID}/rollback",
+				srv.RoutePostGrafanaAlertingConfigRollback,
+				m,
+			),
+		)
+		group.Post(
+			toMacaronPath("/api/alertmanager/grafana/api/v2/silences/preview"),
+			api.authorize(http.MethodPost, "/api/alertmanager/grafana/api/v2/silences/preview"),
+			metrics.Instrument(
+				http.MethodPost,
+				"/api/alertmanager/grafana/api/v2/silences/preview",
+				srv.RoutePostGrafanaSilencePreview,
+				m,
+			),
+		)
+		group.Post(
+			toMacaronPath("/api/alertmanager/grafana/api/v2/silence-schedules"),
+			api.authorize(http.MethodPost, "/api/alertmanager/grafana/api/v2/silence-schedules"),
+			metrics.Instrument(
+				http.MethodPost,
+				"/api/alertmanager/grafana/api/v2/silence-schedules",
+				srv.RoutePostGrafanaSilenceSchedule,
+				m,
+			),
+		)
+		group.Post(
+			toMacaronPath("/api/alertmanager/grafana/api/v2/silences/bulk"),
+			api.authorize(http.MethodPost, "/api/alertmanager/grafana/api/v2/silences/bulk"),
+			metrics.Instrument(
+				http.MethodPost,
+				"/api/alertmanager/grafana/api/v2/silences/bulk",
+				srv.RoutePostGrafanaSilencesBulk,
+				m,
+			),
+		)
+		group.Post(
+			toMacaronPath("/api/alertmanager/grafana/api/v2/silences/expire"),
+			api.authorize(http.MethodPost, "/api/alertmanager/grafana/api/v2/silences/expire"),
+			metrics.Instrument(
+				http.MethodPost,
+				"/api/alertmanager/grafana/api/v2/silences/expire",
+				srv.RoutePostGrafanaSilencesExpire,
+				m,
+			),
+		)
 		group.Post(
 			toMacaronPath("/api/alertmanager/grafana/config/api/v1/receivers/test"),
 			api.authorize(http.MethodPost, "/api/alertmanager/grafana/config/api/v1/receivers/test"),
@@ -392,5 +557,15 @@ func (api *API) RegisterAlertmanagerApiEndpoints(srv AlertmanagerApiForkingServi
 				m,
 			),
 		)
+		group.Put(
+			toMacaronPath("/api/alertmanager/grafana/api/v2/silence-schedules/{UID}"),
+			api.authorize(http.MethodPut, "/api/alertmanager/grafana/api/v2/silence-schedules/{UID}"),
+			metrics.Instrument(
+				http.MethodPut,
+				"/api/alertmanager/grafana/api/v2/silence-schedules/{UID}",
+				srv.RoutePutGrafanaSilenceSchedule,
+				m,
+			),
+		)
 	}, middleware.ReqSignedIn)
 }
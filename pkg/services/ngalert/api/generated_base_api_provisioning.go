@@ -22,22 +22,34 @@ type ProvisioningApiForkingService interface {
 	RouteDeleteAlertRule(*models.ReqContext) response.Response
 	RouteDeleteContactpoints(*models.ReqContext) response.Response
 	RouteDeleteMuteTiming(*models.ReqContext) response.Response
+	RouteDeleteRuleTemplate(*models.ReqContext) response.Response
 	RouteDeleteTemplate(*models.ReqContext) response.Response
 	RouteGetAlertRule(*models.ReqContext) response.Response
+	RouteGetAlertRulesExport(*models.ReqContext) response.Response
 	RouteGetContactpoints(*models.ReqContext) response.Response
 	RouteGetMuteTiming(*models.ReqContext) response.Response
 	RouteGetMuteTimings(*models.ReqContext) response.Response
 	RouteGetPolicyTree(*models.ReqContext) response.Response
+	RouteGetProvisioningExport(*models.ReqContext) response.Response
+	RouteGetRuleTemplate(*models.ReqContext) response.Response
+	RouteGetRuleTemplateInstances(*models.ReqContext) response.Response
+	RouteGetRuleTemplates(*models.ReqContext) response.Response
 	RouteGetTemplate(*models.ReqContext) response.Response
 	RouteGetTemplates(*models.ReqContext) response.Response
 	RoutePostAlertRule(*models.ReqContext) response.Response
 	RoutePostContactpoints(*models.ReqContext) response.Response
 	RoutePostMuteTiming(*models.ReqContext) response.Response
+	RoutePostProvisioningDryRun(*models.ReqContext) response.Response
+	RoutePostRuleTemplate(*models.ReqContext) response.Response
+	RoutePostRuleTemplateInstance(*models.ReqContext) response.Response
+	RoutePostTemplatePreview(*models.ReqContext) response.Response
 	RoutePutAlertRule(*models.ReqContext) response.Response
 	RoutePutAlertRuleGroup(*models.ReqContext) response.Response
 	RoutePutContactpoint(*models.ReqContext) response.Response
 	RoutePutMuteTiming(*models.ReqContext) response.Response
 	RoutePutPolicyTree(*models.ReqContext) response.Response
+	RoutePutRuleTemplate(*models.ReqContext) response.Response
+	RoutePutRuleTemplateInstance(*models.ReqContext) response.Response
 	RoutePutTemplate(*models.ReqContext) response.Response
 }
 
@@ -50,12 +62,18 @@ func (f *ForkedProvisioningApi) RouteDeleteContactpoints(ctx *models.ReqContext)
 func (f *ForkedProvisioningApi) RouteDeleteMuteTiming(ctx *models.ReqContext) response.Response {
 	return f.forkRouteDeleteMuteTiming(ctx)
 }
+func (f *ForkedProvisioningApi) RouteDeleteRuleTemplate(ctx *models.ReqContext) response.Response {
+	return f.forkRouteDeleteRuleTemplate(ctx)
+}
 func (f *ForkedProvisioningApi) RouteDeleteTemplate(ctx *models.ReqContext) response.Response {
 	return f.forkRouteDeleteTemplate(ctx)
 }
 func (f *ForkedProvisioningApi) RouteGetAlertRule(ctx *models.ReqContext) response.Response {
 	return f.forkRouteGetAlertRule(ctx)
 }
+func (f *ForkedProvisioningApi) RouteGetAlertRulesExport(ctx *models.ReqContext) response.Response {
+	return f.forkRouteGetAlertRulesExport(ctx)
+}
 func (f *ForkedProvisioningApi) RouteGetContactpoints(ctx *models.ReqContext) response.Response {
 	return f.forkRouteGetContactpoints(ctx)
 }
@@ -68,6 +86,18 @@ func (f *ForkedProvisioningApi) RouteGetMuteTimings(ctx *models.ReqContext) resp
 func (f *ForkedProvisioningApi) RouteGetPolicyTree(ctx *models.ReqContext) response.Response {
 	return f.forkRouteGetPolicyTree(ctx)
 }
+func (f *ForkedProvisioningApi) RouteGetProvisioningExport(ctx *models.ReqContext) response.Response {
+	return f.forkRouteGetProvisioningExport(ctx)
+}
+func (f *ForkedProvisioningApi) RouteGetRuleTemplate(ctx *models.ReqContext) response.Response {
+	return f.forkRouteGetRuleTemplate(ctx)
+}
+func (f *ForkedProvisioningApi) RouteGetRuleTemplateInstances(ctx *models.ReqContext) response.Response {
+	return f.forkRouteGetRuleTemplateInstances(ctx)
+}
+func (f *ForkedProvisioningApi) RouteGetRuleTemplates(ctx *models.ReqContext) response.Response {
+	return f.forkRouteGetRuleTemplates(ctx)
+}
 func (f *ForkedProvisioningApi) RouteGetTemplate(ctx *models.ReqContext) response.Response {
 	return f.forkRouteGetTemplate(ctx)
 }
@@ -95,6 +125,34 @@ func (f *ForkedProvisioningApi) RoutePostMuteTiming(ctx *models.ReqContext) resp
 	}
 	return f.forkRoutePostMuteTiming(ctx, conf)
 }
+func (f *ForkedProvisioningApi) RoutePostProvisioningDryRun(ctx *models.ReqContext) response.Response {
+	conf := apimodels.ProvisioningFile{}
+	if err := web.Bind(ctx.Req, &conf); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	return f.forkRoutePostProvisioningDryRun(ctx, conf)
+}
+func (f *ForkedProvisioningApi) RoutePostRuleTemplate(ctx *models.ReqContext) response.Response {
+	conf := apimodels.RuleTemplate{}
+	if err := web.Bind(ctx.Req, &conf); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	return f.forkRoutePostRuleTemplate(ctx, conf)
+}
+func (f *ForkedProvisioningApi) RoutePostRuleTemplateInstance(ctx *models.ReqContext) response.Response {
+	conf := apimodels.RuleTemplateInstance{}
+	if err := web.Bind(ctx.Req, &conf); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	return f.forkRoutePostRuleTemplateInstance(ctx, conf)
+}
+func (f *ForkedProvisioningApi) RoutePostTemplatePreview(ctx *models.ReqContext) response.Response {
+	conf := apimodels.TemplatePreviewRequest{}
+	if err := web.Bind(ctx.Req, &conf); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	return f.forkRoutePostTemplatePreview(ctx, conf)
+}
 func (f *ForkedProvisioningApi) RoutePutAlertRule(ctx *models.ReqContext) response.Response {
 	conf := apimodels.AlertRule{}
 	if err := web.Bind(ctx.Req, &conf); err != nil {
@@ -130,6 +188,20 @@ func (f *ForkedProvisioningApi) RoutePutPolicyTree(ctx *models.ReqContext) respo
 	}
 	return f.forkRoutePutPolicyTree(ctx, conf)
 }
+func (f *ForkedProvisioningApi) RoutePutRuleTemplate(ctx *models.ReqContext) response.Response {
+	conf := apimodels.RuleTemplate{}
+	if err := web.Bind(ctx.Req, &conf); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	return f.forkRoutePutRuleTemplate(ctx, conf)
+}
+func (f *ForkedProvisioningApi) RoutePutRuleTemplateInstance(ctx *models.ReqContext) response.Response {
+	conf := apimodels.RuleTemplateInstance{}
+	if err := web.Bind(ctx.Req, &conf); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	return f.forkRoutePutRuleTemplateInstance(ctx, conf)
+}
 func (f *ForkedProvisioningApi) RoutePutTemplate(ctx *models.ReqContext) response.Response {
 	conf := apimodels.MessageTemplateContent{}
 	if err := web.Bind(ctx.Req, &conf); err != nil {
@@ -180,6 +252,16 @@ func (api *API) RegisterProvisioningApiEndpoints(srv ProvisioningApiForkingServi
 				m,
 			),
 		)
+		group.Delete(
+			toMacaronPath("/api/v1/provisioning/rule-templates/{UID}"),
+			api.authorize(http.MethodDelete, "/api/v1/provisioning/rule-templates/{UID}"),
+			metrics.Instrument(
+				http.MethodDelete,
+				"/api/v1/provisioning/rule-templates/{UID}",
+				srv.RouteDeleteRuleTemplate,
+				m,
+			),
+		)
 		group.Get(
 			toMacaronPath("/api/v1/provisioning/alert-rules/{UID}"),
 			api.authorize(http.MethodGet, "/api/v1/provisioning/alert-rules/{UID}"),
@@ -210,6 +292,26 @@ func (api *API) RegisterProvisioningApiEndpoints(srv ProvisioningApiForkingServi
 				m,
 			),
 		)
+		group.Get(
+			toMacaronPath("/api/v1/provisioning/export"),
+			api.authorize(http.MethodGet, "/api/v1/provisioning/export"),
+			metrics.Instrument(
+				http.MethodGet,
+				"/api/v1/provisioning/export",
+				srv.RouteGetProvisioningExport,
+				m,
+			),
+		)
+		group.Get(
+			toMacaronPath("/api/v1/provisioning/export/alert-rules"),
+			api.authorize(http.MethodGet, "/api/v1/provisioning/export/alert-rules"),
+			metrics.Instrument(
+				http.MethodGet,
+				"/api/v1/provisioning/export/alert-rules",
+				srv.RouteGetAlertRulesExport,
+				m,
+			),
+		)
 		group.Get(
 			toMacaronPath("/api/v1/provisioning/mute-timings"),
 			api.authorize(http.MethodGet, "/api/v1/provisioning/mute-timings"),
@@ -250,6 +352,36 @@ func (api *API) RegisterProvisioningApiEndpoints(srv ProvisioningApiForkingServi
 				m,
 			),
 		)
+		group.Get(
+			toMacaronPath("/api/v1/provisioning/rule-templates/{UID}"),
+			api.authorize(http.MethodGet, "/api/v1/provisioning/rule-templates/{UID}"),
+			metrics.Instrument(
+				http.MethodGet,
+				"/api/v1/provisioning/rule-templates/{UID}",
+				srv.RouteGetRuleTemplate,
+				m,
+			),
+		)
+		group.Get(
+			toMacaronPath("/api/v1/provisioning/rule-templates/{UID}/rules"),
+			api.authorize(http.MethodGet, "/api/v1/provisioning/rule-templates/{UID}/rules"),
+			metrics.Instrument(
+				http.MethodGet,
+				"/api/v1/provisioning/rule-templates/{UID}/rules",
+				srv.RouteGetRuleTemplateInstances,
+				m,
+			),
+		)
+		group.Get(
+			toMacaronPath("/api/v1/provisioning/rule-templates"),
+			api.authorize(http.MethodGet, "/api/v1/provisioning/rule-templates"),
+			metrics.Instrument(
+				http.MethodGet,
+				"/api/v1/provisioning/rule-templates",
+				srv.RouteGetRuleTemplates,
+				m,
+			),
+		)
 		group.Post(
 			toMacaronPath("/api/v1/provisioning/alert-rules"),
 			api.authorize(http.MethodPost, "/api/v1/provisioning/alert-rules"),
@@ -280,6 +412,46 @@ func (api *API) RegisterProvisioningApiEndpoints(srv ProvisioningApiForkingServi
 				m,
 			),
 		)
+		group.Post(
+			toMacaronPath("/api/v1/provisioning/dry-run"),
+			api.authorize(http.MethodPost, "/api/v1/provisioning/dry-run"),
+			metrics.Instrument(
+				http.MethodPost,
+				"/api/v1/provisioning/dry-run",
+				srv.RoutePostProvisioningDryRun,
+				m,
+			),
+		)
+		group.Post(
+			toMacaronPath("/api/v1/provisioning/rule-templates"),
+			api.authorize(http.MethodPost, "/api/v1/provisioning/rule-templates"),
+			metrics.Instrument(
+				http.MethodPost,
+				"/api/v1/provisioning/rule-templates",
+				srv.RoutePostRuleTemplate,
+				m,
+			),
+		)
+		group.Post(
+			toMacaronPath("/api/v1/provisioning/rule-templates/{UID}/rules"),
+			api.authorize(http.MethodPost, "/api/v1/provisioning/rule-templates/{UID}/rules"),
+			metrics.Instrument(
+				http.MethodPost,
+				"/api/v1/provisioning/rule-templates/{UID}/rules",
+				srv.RoutePostRuleTemplateInstance,
+				m,
+			),
+		)
+		group.Post(
+			toMacaronPath("/api/v1/provisioning/templates/{name}/preview"),
+			api.authorize(http.MethodPost, "/api/v1/provisioning/templates/{name}/preview"),
+			metrics.Instrument(
+				http.MethodPost,
+				"/api/v1/provisioning/templates/{name}/preview",
+				srv.RoutePostTemplatePreview,
+				m,
+			),
+		)
 		group.Put(
 			toMacaronPath("/api/v1/provisioning/alert-rules/{UID}"),
 			api.authorize(http.MethodPut, "/api/v1/provisioning/alert-rules/{UID}"),
@@ -340,5 +512,25 @@ func (api *API) RegisterProvisioningApiEndpoints(srv ProvisioningApiForkingServi
 				m,
 			),
 		)
+		group.Put(
+			toMacaronPath("/api/v1/provisioning/rule-templates/{UID}"),
+			api.authorize(http.MethodPut, "/api/v1/provisioning/rule-templates/{UID}"),
+			metrics.Instrument(
+				http.MethodPut,
+				"/api/v1/provisioning/rule-templates/{UID}",
+				srv.RoutePutRuleTemplate,
+				m,
+			),
+		)
+		group.Put(
+			toMacaronPath("/api/v1/provisioning/rule-templates/{UID}/rules/{RuleUID}"),
+			api.authorize(http.MethodPut, "/api/v1/provisioning/rule-templates/{UID}/rules/{RuleUID}"),
+			metrics.Instrument(
+				http.MethodPut,
+				"/api/v1/provisioning/rule-templates/{UID}/rules/{RuleUID}",
+				srv.RoutePutRuleTemplateInstance,
+				m,
+			),
+		)
 	}, middleware.ReqSignedIn)
 }
@@ -0,0 +1,92 @@
+package definitions
+
+import (
+	"time"
+)
+
+// swagger:route GET /api/alertmanager/grafana/config/history alertmanager RouteGetGrafanaAlertingConfigHistory
+//
+// Get the versions of the Alertmanager configuration, the latest first.
+//
+//     Responses:
+//       200: GettableAlertmanagerConfigVersions
+
+// swagger:route GET /api/alertmanager/grafana/config/history/diff alertmanager RouteGetGrafanaAlertingConfigDiff
+//
+// Get the differences between two versions of the Alertmanager configuration. The values of secure settings are redacted.
+//
+//     Responses:
+//       200: AlertmanagerConfigDiff
+//       400: ValidationError
+//       404: NotFound
+
+// swagger:route POST /api/alertmanager/grafana/config/history/{ID}/rollback alertmanager RoutePostGrafanaAlertingConfigRollback
+//
+// Roll back the Alertmanager configuration to a previous version. The version is validated and saved as a new version.
+//
+//     Responses:
+//       202: Ack
+//       400: ValidationError
+//       404: NotFound
+
+// swagger:parameters RouteGetGrafanaAlertingConfigHistory
+type AlertingConfigHistoryParams struct {
+	// Limit is the maximum number of versions to return. All versions are returned if it is not positive.
+	// in:query
+	// required:false
+	Limit int `json:"limit"`
+}
+
+// swagger:parameters RouteGetGrafanaAlertingConfigDiff
+type AlertingConfigDiffParams struct {
+	// in:query
+	// required:true
+	From int64 `json:"from"`
+	// in:query
+	// required:true
+	To int64 `json:"to"`
+}
+
+// swagger:parameters RoutePostGrafanaAlertingConfigRollback
+type AlertingConfigVersionIDParam struct {
+	// in:path
+	ID int64
+}
+
+// swagger:model
+type GettableAlertmanagerConfigVersions []GettableAlertmanagerConfigVersion
+
+// swagger:model
+type GettableAlertmanagerConfigVersion struct {
+	ID int64 `json:"id"`
+	// CreatedBy is the login of the user that saved the version. It is empty if Grafana saved it, such as the default configuration or a change made by provisioning.
+	CreatedBy         string    `json:"createdBy"`
+	CreatedAt         time.Time `json:"createdAt"`
+	Default           bool      `json:"default"`
+	ConfigurationHash string    `json:"configurationHash"`
+}
+
+// swagger:model
+type AlertmanagerConfigDiff struct {
+	From    int64                      `json:"from"`
+	To      int64                      `json:"to"`
+	Changes []AlertmanagerConfigChange `json:"changes"`
+}
+
+// AlertmanagerConfigChange is a difference between two versions of the Alertmanager configuration.
+// swagger:model
+type AlertmanagerConfigChange struct {
+	// Path is the JSON pointer of the value that differs. Receivers, mute time intervals and contact points
+	// are identified by their names or UIDs rather than their indices.
+	Path string `json:"path"`
+	// Kind is either added, removed or changed.
+	Kind string      `json:"kind"`
+	From interface{} `json:"from,omitempty"`
+	To   interface{} `json:"to,omitempty"`
+}
+
+const (
+	AlertmanagerConfigChangeAdded   = "added"
+	AlertmanagerConfigChangeRemoved = "removed"
+	AlertmanagerConfigChangeChanged = "changed"
+)
@@ -0,0 +1,148 @@
+package definitions
+
+import (
+	"time"
+
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/timeinterval"
+	"github.com/prometheus/common/model"
+)
+
+// swagger:route POST /api/alertmanager/grafana/api/v2/silences/bulk alertmanager RoutePostGrafanaSilencesBulk
+//
+// Create several silences at once. Either all silences are created or none.
+//
+//     Responses:
+//       202: SilencesResult
+//       400: ValidationError
+
+// swagger:route POST /api/alertmanager/grafana/api/v2/silences/expire alertmanager RoutePostGrafanaSilencesExpire
+//
+// Expire the active and pending silences that match a set of labels.
+//
+//     Responses:
+//       200: SilencesResult
+//       400: ValidationError
+
+// swagger:route POST /api/alertmanager/grafana/api/v2/silences/preview alertmanager RoutePostGrafanaSilencePreview
+//
+// List the firing alerts that a silence would mute, without creating it.
+//
+//     Responses:
+//       200: gettableAlerts
+//       400: ValidationError
+
+// swagger:route GET /api/alertmanager/grafana/api/v2/silence-schedules alertmanager RouteGetGrafanaSilenceSchedules
+//
+// Get all the silence schedules.
+//
+//     Responses:
+//       200: SilenceSchedules
+
+// swagger:route GET /api/alertmanager/grafana/api/v2/silence-schedules/{UID} alertmanager RouteGetGrafanaSilenceSchedule
+//
+// Get a silence schedule.
+//
+//     Responses:
+//       200: SilenceSchedule
+//       404: NotFound
+
+// swagger:route POST /api/alertmanager/grafana/api/v2/silence-schedules alertmanager RoutePostGrafanaSilenceSchedule
+//
+// Create a silence schedule.
+//
+//     Consumes:
+//     - application/json
+//
+//     Responses:
+//       201: SilenceSchedule
+//       400: ValidationError
+
+// swagger:route PUT /api/alertmanager/grafana/api/v2/silence-schedules/{UID} alertmanager RoutePutGrafanaSilenceSchedule
+//
+// Replace a silence schedule.
+//
+//     Consumes:
+//     - application/json
+//
+//     Responses:
+//       200: SilenceSchedule
+//       400: ValidationError
+//       404: NotFound
+
+// swagger:route DELETE /api/alertmanager/grafana/api/v2/silence-schedules/{UID} alertmanager RouteDeleteGrafanaSilenceSchedule
+//
+// Delete a silence schedule and expire its silence.
+//
+//     Responses:
+//       204: Ack
+//       404: NotFound
+
+// swagger:parameters RoutePostGrafanaSilencesBulk
+type BulkSilencesParams struct {
+	// in:body
+	Body PostableSilences
+}
+
+// swagger:model
+type PostableSilences []*PostableSilence
+
+// swagger:parameters RoutePostGrafanaSilencesExpire
+type ExpireSilencesParams struct {
+	// in:body
+	Body SilencesExpireRequest
+}
+
+// swagger:model
+type SilencesExpireRequest struct {
+	// Filter is the set of labels that the matchers of the silences must match, such as env="prod".
+	Filter []string `json:"filter"`
+}
+
+// swagger:model
+type SilencesResult struct {
+	SilenceIDs []string `json:"silenceIds"`
+}
+
+// swagger:parameters RoutePostGrafanaSilencePreview
+type SilencePreviewParams struct {
+	// in:body
+	Silence PostableSilence
+}
+
+// swagger:parameters RouteGetGrafanaSilenceSchedule RoutePutGrafanaSilenceSchedule RouteDeleteGrafanaSilenceSchedule
+type SilenceScheduleUIDParam struct {
+	// in:path
+	UID string
+}
+
+// swagger:parameters RoutePostGrafanaSilenceSchedule RoutePutGrafanaSilenceSchedule
+type SilenceSchedulePayload struct {
+	// in:body
+	Body SilenceSchedule
+}
+
+// swagger:model
+type SilenceSchedules []SilenceSchedule
+
+// SilenceSchedule silences alerts during recurring windows, such as maintenance windows.
+// The windows are defined either by a cron expression and a duration, or by time intervals.
+// swagger:model
+type SilenceSchedule struct {
+	UID       string        `json:"uid"`
+	Comment   string        `json:"comment,omitempty"`
+	CreatedBy string        `json:"createdBy"`
+	Matchers  amv2.Matchers `json:"matchers"`
+	// Cron is a cron expression of the beginnings of the windows, in the time zone of Timezone.
+	Cron string `json:"cron,omitempty"`
+	// Duration is the duration of the windows that begin at the times of Cron.
+	Duration model.Duration `json:"duration,omitempty"`
+	// TimeIntervals are the windows, in the same format as the time intervals of mute timings.
+	TimeIntervals []timeinterval.TimeInterval `json:"time_intervals,omitempty"`
+	// Timezone is the name of the IANA time zone in which Cron and TimeIntervals are evaluated, such as
+	// Europe/Berlin. The windows are in UTC if it is empty.
+	Timezone string `json:"timezone,omitempty"`
+	// SilenceID is the ID of the silence of the current or the last window.
+	SilenceID string    `json:"silenceId,omitempty"`
+	Updated   time.Time `json:"updated"`
+}
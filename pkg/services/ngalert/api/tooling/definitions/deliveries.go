@@ -0,0 +1,102 @@
+package definitions
+
+import (
+	"time"
+)
+
+// swagger:route GET /api/v1/notifications/deliveries deliveries RouteGetNotificationDeliveries
+//
+// Get the notifications sent by the contact points of the Grafana Alertmanager, most recent first.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       200: NotificationDeliveries
+//       400: ValidationError
+//       404: NotFound
+
+// swagger:route POST /api/v1/notifications/deliveries/{DeliveryID}/replay deliveries RouteReplayNotificationDelivery
+//
+// Send the alerts of a notification again with the same integration of the contact point.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       200: NotificationDelivery
+//       404: NotFound
+//       409: Failure
+
+// swagger:parameters RouteGetNotificationDeliveries
+type NotificationDeliveriesParams struct {
+	// Only return deliveries of the contact point with this name.
+	// in:query
+	// required:false
+	Receiver string `json:"receiver"`
+	// Only return deliveries of the integration with this UID.
+	// in:query
+	// required:false
+	IntegrationUID string `json:"integrationUID"`
+	// Only return deliveries with this status.
+	// in:query
+	// required:false
+	// enum: success,failed,retrying
+	Status string `json:"status"`
+	// Start of the time range in milliseconds since the epoch.
+	// in:query
+	// required:false
+	From int64 `json:"from"`
+	// End of the time range in milliseconds since the epoch.
+	// in:query
+	// required:false
+	To int64 `json:"to"`
+	// in:query
+	// required:false
+	// default:1
+	Page int `json:"page"`
+	// in:query
+	// required:false
+	// default:100
+	Limit int `json:"limit"`
+}
+
+// swagger:parameters RouteReplayNotificationDelivery
+type NotificationDeliveryParams struct {
+	// in:path
+	// required:true
+	DeliveryID int64
+}
+
+// swagger:model
+type NotificationDeliveries struct {
+	Page       int                    `json:"page"`
+	Limit      int                    `json:"limit"`
+	Deliveries []NotificationDelivery `json:"deliveries"`
+}
+
+// swagger:model
+type NotificationDelivery struct {
+	ID              int64  `json:"id"`
+	Receiver        string `json:"receiver"`
+	IntegrationUID  string `json:"integrationUID"`
+	IntegrationType string `json:"integrationType"`
+	GroupKey        string `json:"groupKey"`
+	// Example: {"alertname": "HighCPU"}
+	GroupLabels map[string]string `json:"groupLabels"`
+	// Fingerprints of the alerts that were sent.
+	AlertFingerprints []string `json:"alertFingerprints"`
+	// Example: failed
+	Status   string `json:"status"`
+	Attempts int    `json:"attempts"`
+	Error    string `json:"error,omitempty"`
+	// Status code of the last response received from the integration, if any.
+	ResponseCode int `json:"responseCode,omitempty"`
+	// Body of the last response received from the integration, truncated to 1KiB.
+	ResponseBody  string     `json:"responseBody,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	LastAttemptAt time.Time  `json:"lastAttemptAt"`
+	NextAttemptAt *time.Time `json:"nextAttemptAt,omitempty"`
+	// ID of the delivery this delivery is a replay of.
+	ReplayOf int64 `json:"replayOf,omitempty"`
+}
@@ -0,0 +1,67 @@
+package definitions
+
+import (
+	"time"
+)
+
+// swagger:route GET /api/v1/rules/history history RouteGetStateHistory
+//
+// Get the state transitions of alert instances, most recent first.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       200: StateHistory
+//       400: ValidationError
+
+// swagger:parameters RouteGetStateHistory
+type StateHistoryParams struct {
+	// Only return transitions of the rule with this UID.
+	// in:query
+	// required:false
+	RuleUID string `json:"ruleUID"`
+	// Label matchers, e.g. severity="critical", that must all match the labels of an instance.
+	// in:query
+	// required:false
+	Matcher []string `json:"matcher"`
+	// Start of the time range in milliseconds since the epoch.
+	// in:query
+	// required:false
+	From int64 `json:"from"`
+	// End of the time range in milliseconds since the epoch.
+	// in:query
+	// required:false
+	To int64 `json:"to"`
+	// in:query
+	// required:false
+	// default:1
+	Page int `json:"page"`
+	// in:query
+	// required:false
+	// default:100
+	Limit int `json:"limit"`
+}
+
+// swagger:model
+type StateHistory struct {
+	Page        int               `json:"page"`
+	Limit       int               `json:"limit"`
+	Transitions []StateTransition `json:"transitions"`
+}
+
+// StateTransition is a change of state of an alert instance.
+type StateTransition struct {
+	RuleUID     string            `json:"ruleUID"`
+	RuleVersion int64             `json:"ruleVersion"`
+	Labels      map[string]string `json:"labels"`
+	// Example: Normal
+	PreviousState  string `json:"previousState"`
+	PreviousReason string `json:"previousReason,omitempty"`
+	// Example: Alerting
+	State  string `json:"state"`
+	Reason string `json:"reason,omitempty"`
+	// Values of the reduce and math expressions of the evaluation that caused the transition.
+	Values map[string]*float64 `json:"values,omitempty"`
+	Time   time.Time           `json:"time"`
+}
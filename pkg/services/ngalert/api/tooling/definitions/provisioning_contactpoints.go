@@ -111,10 +111,14 @@ func (e *EmbeddedContactPoint) SecretKeys() ([]string, error) {
 		return []string{}, nil
 	case "googlechat":
 		return []string{}, nil
+	case "jira":
+		return []string{"password"}, nil
 	case "kafka":
 		return []string{}, nil
 	case "line":
 		return []string{"token"}, nil
+	case "mattermost":
+		return []string{"url"}, nil
 	case "opsgenie":
 		return []string{"apiKey"}, nil
 	case "pagerduty":
@@ -123,6 +127,8 @@ func (e *EmbeddedContactPoint) SecretKeys() ([]string, error) {
 		return []string{"userKey", "apiToken"}, nil
 	case "sensugo":
 		return []string{"apiKey"}, nil
+	case "servicenow":
+		return []string{"password"}, nil
 	case "slack":
 		return []string{"url", "token"}, nil
 	case "teams":
@@ -134,9 +140,14 @@ func (e *EmbeddedContactPoint) SecretKeys() ([]string, error) {
 	case "victorops":
 		return []string{}, nil
 	case "webhook":
-		return []string{}, nil
+		return []string{"hmacSecret", "tlsClientKey"}, nil
 	case "wecom":
 		return []string{"url"}, nil
+	case "zulip":
+		return []string{"apiKey"}, nil
+	}
+	if secretKeys, ok := channels.PluginSecretKeys(e.Type); ok {
+		return secretKeys, nil
 	}
 	return nil, fmt.Errorf("no secrets configured for type '%s'", e.Type)
 }
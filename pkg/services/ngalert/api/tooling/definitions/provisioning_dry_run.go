@@ -0,0 +1,87 @@
+package definitions
+
+// swagger:route POST /api/v1/provisioning/dry-run provisioning stable RoutePostProvisioningDryRun
+//
+// Report the changes that a provisioning file would make, without persisting anything.
+// Grafana does not apply alerting provisioning files, so this only previews them.
+//
+//     Consumes:
+//     - application/json
+//
+//     Responses:
+//       200: ProvisioningDiff
+//       400: ValidationError
+
+// swagger:parameters RoutePostProvisioningDryRun
+type ProvisioningFilePayload struct {
+	// in:body
+	Body ProvisioningFile
+}
+
+// swagger:model
+type ProvisioningDiff struct {
+	RuleGroups    []RuleGroupDiff    `json:"ruleGroups"`
+	ContactPoints []ContactPointDiff `json:"contactPoints"`
+	Policies      []PolicyTreeDiff   `json:"policies"`
+	MuteTimings   []MuteTimingDiff   `json:"muteTimings"`
+	Templates     []TemplateDiff     `json:"templates"`
+}
+
+// HasChanges returns true if applying the provisioning files would change anything.
+func (d ProvisioningDiff) HasChanges() bool {
+	return len(d.RuleGroups) > 0 || len(d.ContactPoints) > 0 || len(d.Policies) > 0 || len(d.MuteTimings) > 0 || len(d.Templates) > 0
+}
+
+// RuleGroupDiff lists the changes to the rules of a rule group.
+type RuleGroupDiff struct {
+	OrgID     int64  `json:"orgId"`
+	FolderUID string `json:"folderUid"`
+	RuleGroup string `json:"ruleGroup"`
+	// IntervalChange is set if the evaluation interval of an existing group changes.
+	IntervalChange *IntervalChange `json:"intervalChange,omitempty"`
+	Created        []ResourceDiff  `json:"created,omitempty"`
+	Updated        []ResourceDiff  `json:"updated,omitempty"`
+	Deleted        []ResourceDiff  `json:"deleted,omitempty"`
+}
+
+type IntervalChange struct {
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+}
+
+// ContactPointDiff lists the changes to the contact points of an organization.
+type ContactPointDiff struct {
+	OrgID   int64          `json:"orgId"`
+	Created []ResourceDiff `json:"created,omitempty"`
+	Updated []ResourceDiff `json:"updated,omitempty"`
+	Deleted []ResourceDiff `json:"deleted,omitempty"`
+}
+
+// PolicyTreeDiff lists the changes to the notification policy tree of an organization.
+type PolicyTreeDiff struct {
+	OrgID   int64    `json:"orgId"`
+	Changes []string `json:"changes"`
+}
+
+// MuteTimingDiff lists the mute timings of an organization that are created or updated.
+type MuteTimingDiff struct {
+	OrgID   int64          `json:"orgId"`
+	Created []ResourceDiff `json:"created,omitempty"`
+	Updated []ResourceDiff `json:"updated,omitempty"`
+}
+
+// TemplateDiff lists the notification templates of an organization that are created or updated.
+type TemplateDiff struct {
+	OrgID   int64          `json:"orgId"`
+	Created []ResourceDiff `json:"created,omitempty"`
+	Updated []ResourceDiff `json:"updated,omitempty"`
+}
+
+// ResourceDiff describes a created, updated or deleted resource.
+type ResourceDiff struct {
+	UID  string `json:"uid,omitempty"`
+	Name string `json:"name"`
+	// Changes are the paths of the changed fields of an updated resource. The values are omitted
+	// because they can contain secrets.
+	Changes []string `json:"changes,omitempty"`
+}
@@ -0,0 +1,59 @@
+package definitions
+
+// swagger:route GET /api/v1/provisioning/export provisioning stable RouteGetProvisioningExport
+//
+// Export the alert rules, contact points, notification policies, mute timings and templates of the organization
+// in the format of provisioning files.
+//
+//     Produces:
+//     - application/json
+//     - application/yaml
+//
+//     Responses:
+//       200: ProvisioningFile
+//       400: ValidationError
+//       404: NotFound
+
+// swagger:route GET /api/v1/provisioning/export/alert-rules provisioning stable RouteGetAlertRulesExport
+//
+// Export the alert rules of the organization in the format of provisioning files.
+//
+//     Produces:
+//     - application/json
+//     - application/yaml
+//
+//     Responses:
+//       200: ProvisioningFile
+//       400: ValidationError
+
+// swagger:parameters RouteGetProvisioningExport RouteGetAlertRulesExport
+type ExportFormatParam struct {
+	// Format of the exported file, json or yaml.
+	// in:query
+	// required:false
+	// default:json
+	Format string `json:"format"`
+}
+
+// swagger:parameters RouteGetProvisioningExport
+type ExportSecretsParam struct {
+	// How the secure settings of contact points are exported: redact replaces them with a placeholder,
+	// encrypt exports them encrypted with the secret key of the Grafana instance. Either way, the exported
+	// contact points can only be compared with the stored ones by the dry run of the same instance.
+	// in:query
+	// required:false
+	// default:redact
+	Secrets string `json:"secrets"`
+}
+
+// swagger:parameters RouteGetAlertRulesExport
+type ExportRulesParams struct {
+	// Only export the rule groups in these folders.
+	// in:query
+	// required:false
+	FolderUID []string `json:"folderUid"`
+	// Only export the rule groups with this name.
+	// in:query
+	// required:false
+	Group string `json:"group"`
+}
@@ -0,0 +1,101 @@
+package definitions
+
+import (
+	"github.com/prometheus/alertmanager/timeinterval"
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// ProvisioningFile is the content of an alerting provisioning file. Grafana does not apply these files:
+// they are only compared with the stored resources by the dry run and written by the export.
+type ProvisioningFile struct {
+	APIVersion int64 `json:"apiVersion"`
+	// Groups replace the rules of the rule groups with the same name in the same folder.
+	Groups              []ProvisionedRuleGroup          `json:"groups,omitempty"`
+	DeleteRules         []ProvisionedReference          `json:"deleteRules,omitempty"`
+	ContactPoints       []ProvisionedContactPoint       `json:"contactPoints,omitempty"`
+	DeleteContactPoints []ProvisionedReference          `json:"deleteContactPoints,omitempty"`
+	Policies            []ProvisionedNotificationPolicy `json:"policies,omitempty"`
+	MuteTimes           []ProvisionedMuteTiming         `json:"muteTimes,omitempty"`
+	Templates           []ProvisionedTemplate           `json:"templates,omitempty"`
+}
+
+// Merge appends the resources of other to the file.
+func (f *ProvisioningFile) Merge(other ProvisioningFile) {
+	f.Groups = append(f.Groups, other.Groups...)
+	f.DeleteRules = append(f.DeleteRules, other.DeleteRules...)
+	f.ContactPoints = append(f.ContactPoints, other.ContactPoints...)
+	f.DeleteContactPoints = append(f.DeleteContactPoints, other.DeleteContactPoints...)
+	f.Policies = append(f.Policies, other.Policies...)
+	f.MuteTimes = append(f.MuteTimes, other.MuteTimes...)
+	f.Templates = append(f.Templates, other.Templates...)
+}
+
+type ProvisionedRuleGroup struct {
+	OrgID     int64  `json:"orgId"`
+	Name      string `json:"name"`
+	FolderUID string `json:"folderUid"`
+	// Interval is the evaluation interval of the group in seconds.
+	Interval int64                  `json:"interval"`
+	Rules    []ProvisionedAlertRule `json:"rules"`
+}
+
+type ProvisionedAlertRule struct {
+	UID          string                     `json:"uid"`
+	Title        string                     `json:"title"`
+	Condition    string                     `json:"condition"`
+	Data         []models.AlertQuery        `json:"data"`
+	NoDataState  models.NoDataState         `json:"noDataState"`
+	ExecErrState models.ExecutionErrorState `json:"execErrState"`
+	For          model.Duration             `json:"for"`
+	Annotations  map[string]string          `json:"annotations,omitempty"`
+	Labels       map[string]string          `json:"labels,omitempty"`
+	Record       *models.Record             `json:"record,omitempty"`
+}
+
+// ProvisionedReference identifies a provisioned resource by its UID.
+type ProvisionedReference struct {
+	OrgID int64  `json:"orgId"`
+	UID   string `json:"uid"`
+}
+
+type ProvisionedContactPoint struct {
+	OrgID    int64            `json:"orgId"`
+	UID      string           `json:"uid"`
+	Name     string           `json:"name"`
+	Type     string           `json:"type"`
+	Settings *simplejson.Json `json:"settings"`
+	// SecureSettings are secure settings encrypted with the secret key of the Grafana instance that exported them.
+	SecureSettings        map[string]string `json:"secureSettings,omitempty"`
+	DisableResolveMessage bool              `json:"disableResolveMessage"`
+}
+
+func (cp ProvisionedContactPoint) EmbeddedContactPoint() EmbeddedContactPoint {
+	return EmbeddedContactPoint{
+		UID:                   cp.UID,
+		Name:                  cp.Name,
+		Type:                  cp.Type,
+		Settings:              cp.Settings,
+		DisableResolveMessage: cp.DisableResolveMessage,
+	}
+}
+
+// ProvisionedNotificationPolicy replaces the notification policy tree of an organization.
+type ProvisionedNotificationPolicy struct {
+	OrgID  int64 `json:"orgId"`
+	Policy Route `json:"policy"`
+}
+
+type ProvisionedMuteTiming struct {
+	OrgID         int64                       `json:"orgId"`
+	Name          string                      `json:"name"`
+	TimeIntervals []timeinterval.TimeInterval `json:"time_intervals"`
+}
+
+type ProvisionedTemplate struct {
+	OrgID    int64  `json:"orgId"`
+	Name     string `json:"name"`
+	Template string `json:"template"`
+}
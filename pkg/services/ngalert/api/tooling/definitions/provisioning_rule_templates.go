@@ -0,0 +1,171 @@
+package definitions
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// swagger:route GET /api/v1/provisioning/rule-templates provisioning stable RouteGetRuleTemplates
+//
+// Get all the alert rule templates.
+//
+//     Responses:
+//       200: RuleTemplates
+
+// swagger:route GET /api/v1/provisioning/rule-templates/{UID} provisioning stable RouteGetRuleTemplate
+//
+// Get an alert rule template.
+//
+//     Responses:
+//       200: RuleTemplate
+//       404: NotFound
+
+// swagger:route POST /api/v1/provisioning/rule-templates provisioning stable RoutePostRuleTemplate
+//
+// Create a new alert rule template.
+//
+//     Consumes:
+//     - application/json
+//
+//     Responses:
+//       201: RuleTemplate
+//       400: ValidationError
+
+// swagger:route PUT /api/v1/provisioning/rule-templates/{UID} provisioning stable RoutePutRuleTemplate
+//
+// Update an alert rule template and render the alert rules created from it again.
+//
+//     Consumes:
+//     - application/json
+//
+//     Responses:
+//       200: RuleTemplate
+//       400: ValidationError
+//       404: NotFound
+//       409: Failure
+
+// swagger:route DELETE /api/v1/provisioning/rule-templates/{UID} provisioning stable RouteDeleteRuleTemplate
+//
+// Delete an alert rule template. The alert rules created from it are kept, but are not linked to it anymore.
+//
+//     Responses:
+//       204: description: The alert rule template was deleted successfully.
+//       404: NotFound
+
+// swagger:route GET /api/v1/provisioning/rule-templates/{UID}/rules provisioning stable RouteGetRuleTemplateInstances
+//
+// Get the alert rules created from an alert rule template and the values of their parameters.
+//
+//     Responses:
+//       200: RuleTemplateInstances
+//       404: NotFound
+
+// swagger:route POST /api/v1/provisioning/rule-templates/{UID}/rules provisioning stable RoutePostRuleTemplateInstance
+//
+// Create an alert rule from an alert rule template.
+//
+//     Consumes:
+//     - application/json
+//
+//     Responses:
+//       201: AlertRule
+//       400: ValidationError
+//       404: NotFound
+
+// swagger:route PUT /api/v1/provisioning/rule-templates/{UID}/rules/{RuleUID} provisioning stable RoutePutRuleTemplateInstance
+//
+// Change the values of the parameters of an alert rule created from an alert rule template and render it again.
+//
+//     Consumes:
+//     - application/json
+//
+//     Responses:
+//       200: AlertRule
+//       400: ValidationError
+//       404: NotFound
+
+// swagger:parameters RouteGetRuleTemplate RoutePutRuleTemplate RouteDeleteRuleTemplate RouteGetRuleTemplateInstances RoutePostRuleTemplateInstance RoutePutRuleTemplateInstance
+type RuleTemplateUIDReference struct {
+	// in:path
+	UID string
+}
+
+// swagger:parameters RoutePutRuleTemplateInstance
+type RuleTemplateInstanceRuleUIDReference struct {
+	// in:path
+	RuleUID string
+}
+
+// swagger:parameters RoutePostRuleTemplate RoutePutRuleTemplate
+type RuleTemplatePayload struct {
+	// in:body
+	Body RuleTemplate
+}
+
+// swagger:parameters RoutePostRuleTemplateInstance RoutePutRuleTemplateInstance
+type RuleTemplateInstancePayload struct {
+	// in:body
+	Body RuleTemplateInstance
+}
+
+// swagger:model
+type RuleTemplates []RuleTemplate
+
+// swagger:model
+type RuleTemplate struct {
+	UID         string                         `json:"uid"`
+	Title       string                         `json:"title"`
+	Description string                         `json:"description,omitempty"`
+	Parameters  []models.RuleTemplateParameter `json:"parameters"`
+	// Rule is a provisioned alert rule. A ${name} placeholder in one of its strings is replaced by the value
+	// of the parameter with this name. A string that consists of a single placeholder is replaced by a value
+	// of the type of the parameter, so that "${threshold}" becomes a number if threshold is a number parameter.
+	// Example: {"title": "High CPU of ${service}", "condition": "B", "for": "${for}", "data": []}
+	Rule json.RawMessage `json:"rule"`
+	// Version is incremented on every update. An update with a version fails if the template was changed since.
+	Version int64     `json:"version,omitempty"`
+	Updated time.Time `json:"updated,omitempty"`
+}
+
+func (t *RuleTemplate) UpstreamModel() models.AlertRuleTemplate {
+	return models.AlertRuleTemplate{
+		UID:         t.UID,
+		Title:       t.Title,
+		Description: t.Description,
+		Parameters:  t.Parameters,
+		Rule:        string(t.Rule),
+		Version:     t.Version,
+		Updated:     t.Updated,
+	}
+}
+
+func NewRuleTemplate(t models.AlertRuleTemplate) RuleTemplate {
+	return RuleTemplate{
+		UID:         t.UID,
+		Title:       t.Title,
+		Description: t.Description,
+		Parameters:  t.Parameters,
+		Rule:        json.RawMessage(t.Rule),
+		Version:     t.Version,
+		Updated:     t.Updated,
+	}
+}
+
+// swagger:model
+type RuleTemplateInstances []RuleTemplateInstance
+
+// swagger:model
+type RuleTemplateInstance struct {
+	// UID of the alert rule. It is generated if it is empty when the alert rule is created.
+	RuleUID string `json:"ruleUid"`
+	// The folder and the rule group of the alert rule. They are only used when the alert rule is created.
+	FolderUID string `json:"folderUID,omitempty"`
+	RuleGroup string `json:"ruleGroup,omitempty"`
+	// Parameters are the values of the parameters of the template. Numbers and durations are given as strings.
+	// Example: {"service": "checkout", "threshold": "80", "for": "5m"}
+	Parameters map[string]string `json:"parameters"`
+	// TemplateVersion is the version of the template the alert rule was last rendered from.
+	TemplateVersion int64 `json:"templateVersion,omitempty"`
+}
@@ -1,6 +1,11 @@
 package definitions
 
 import (
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
 )
 
@@ -38,7 +43,19 @@ import (
 //     Responses:
 //       204: Ack
 
-// swagger:parameters RouteGetTemplate RoutePutTemplate RouteDeleteTemplate
+// swagger:route POST /api/v1/provisioning/templates/{name}/preview provisioning stable RoutePostTemplatePreview
+//
+// Render the notifications of integrations with a template, without sending them. The template does not have to be saved.
+//
+//     Consumes:
+//     - application/json
+//
+//     Responses:
+//       200: TemplatePreviews
+//       400: ValidationError
+//       404: NotFound
+
+// swagger:parameters RouteGetTemplate RoutePutTemplate RouteDeleteTemplate RoutePostTemplatePreview
 type RouteGetTemplateParam struct {
 	// Template Name
 	// in:path
@@ -72,3 +89,46 @@ func (t *MessageTemplate) ResourceType() string {
 func (t *MessageTemplate) ResourceID() string {
 	return t.Name
 }
+
+// swagger:parameters RoutePostTemplatePreview
+type TemplatePreviewPayload struct {
+	// in:body
+	Body TemplatePreviewRequest
+}
+
+// swagger:model
+type TemplatePreviewRequest struct {
+	// Template is the content of the template to render the notifications with. The saved template is used if it is empty.
+	Template string `json:"template,omitempty"`
+	// Integration is the type of the integration to render the notification of, such as slack or email.
+	// The notifications of all integrations are rendered if it is empty.
+	Integration string `json:"integration,omitempty"`
+	// Settings are the settings of a contact point of the integration. The templates in them, such as title or message,
+	// override the default templates of the integration.
+	// Example: {"title": "{{ template \"mytemplate.title\" . }}"}
+	Settings *simplejson.Json `json:"settings,omitempty"`
+	// Alerts are the alerts of the notification. A sample firing alert is used if there are none.
+	Alerts []TemplatePreviewAlert `json:"alerts,omitempty"`
+}
+
+type TemplatePreviewAlert struct {
+	Labels      model.LabelSet `json:"labels,omitempty"`
+	Annotations model.LabelSet `json:"annotations,omitempty"`
+	// StartsAt defaults to the current time.
+	StartsAt time.Time `json:"startsAt,omitempty"`
+	// EndsAt is in the past if the alert is resolved.
+	EndsAt       time.Time `json:"endsAt,omitempty"`
+	GeneratorURL string    `json:"generatorURL,omitempty"`
+}
+
+// swagger:model
+type TemplatePreviews []TemplatePreview
+
+type TemplatePreview struct {
+	Integration string `json:"integration"`
+	// Title is empty if the notifications of the integration have none.
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	// Errors are the errors of the templates that failed to execute. Failed templates are rendered as empty strings.
+	Errors []string `json:"errors,omitempty"`
+}
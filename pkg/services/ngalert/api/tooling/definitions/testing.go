@@ -8,6 +8,7 @@ import (
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
 
 	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/promql"
 
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
@@ -52,6 +53,20 @@ import (
 //     Responses:
 //       200: EvalQueriesResponse
 
+// swagger:route Post /api/v1/rule/backtest testing RouteBacktestConfig
+//
+// Replay the evaluation of a rule over a historical time range
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       200: BacktestResult
+//       400: ValidationError
+
 // swagger:parameters RouteTestReceiverConfig
 type TestReceiverRequest struct {
 	// in:body
@@ -84,6 +99,53 @@ type EvalQueriesPayload struct {
 	Now  time.Time           `json:"now"`
 }
 
+// swagger:parameters RouteBacktestConfig
+type BacktestConfigRequest struct {
+	// in:body
+	Body BacktestConfig
+}
+
+// swagger:model
+type BacktestConfig struct {
+	// From is the time of the first evaluation.
+	From time.Time `json:"from"`
+	// To is the time of the last evaluation.
+	To time.Time `json:"to"`
+	// Interval is the time between evaluations. Defaults to the default evaluation interval of rules.
+	Interval model.Duration `json:"interval,omitempty"`
+
+	Condition string              `json:"condition"`
+	Data      []models.AlertQuery `json:"data"`
+
+	Title        string              `json:"title,omitempty"`
+	Labels       map[string]string   `json:"labels,omitempty"`
+	Annotations  map[string]string   `json:"annotations,omitempty"`
+	For          model.Duration      `json:"for,omitempty"`
+	NoDataState  NoDataState         `json:"no_data_state,omitempty"`
+	ExecErrState ExecutionErrorState `json:"exec_err_state,omitempty"`
+}
+
+// swagger:model
+type BacktestResult struct {
+	Instances []BacktestInstance `json:"instances"`
+}
+
+// BacktestInstance is the timeline of a single alert instance.
+type BacktestInstance struct {
+	Labels      map[string]string    `json:"labels"`
+	Transitions []BacktestTransition `json:"transitions"`
+}
+
+// BacktestTransition is a change of state of an alert instance.
+type BacktestTransition struct {
+	Time time.Time `json:"time"`
+	// Example: Alerting
+	State  string `json:"state"`
+	Reason string `json:"reason,omitempty"`
+	// Resolved is true if the instance stopped firing.
+	Resolved bool `json:"resolved,omitempty"`
+}
+
 func (p *TestRulePayload) UnmarshalJSON(b []byte) error {
 	type plain TestRulePayload
 	if err := json.Unmarshal(b, (*plain)(p)); err != nil {
@@ -0,0 +1,239 @@
+package enrichment
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/grafana/pkg/expr"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/secrets"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+// Enricher adds annotations and labels, such as the owner or the runbook, to alerts before they
+// are sent to the Alertmanagers.
+type Enricher interface {
+	// Run keeps the lookup table of the enricher up to date until ctx is done.
+	Run(ctx context.Context) error
+	// Enrich adds the annotations and labels of the rows of the lookup table that match the alerts
+	// of the organization.
+	Enrich(orgID int64, alerts *definitions.PostableAlerts)
+}
+
+// New returns an enricher for the enrichment settings. If enrichment is disabled, the returned
+// enricher leaves the alerts as they are.
+func New(cfg setting.UnifiedAlertingEnrichmentSettings, files FileReader, exprService *expr.Service, dsCache datasources.CacheService,
+	secretsService secrets.Service, logger log.Logger) (Enricher, error) {
+	if !cfg.Enabled {
+		return NoopEnricher{}, nil
+	}
+
+	var source Source
+	switch cfg.Source {
+	case "static":
+		s, err := NewStaticSource(cfg.Static)
+		if err != nil {
+			return nil, err
+		}
+		source = s
+	case "file":
+		if files == nil {
+			return nil, fmt.Errorf("the file source of enrichment requires the storage service")
+		}
+		source = NewFileSource(files, cfg.OrgID, cfg.FilePath)
+	case "datasource":
+		source = NewDatasourceSource(cfg.OrgID, cfg.DatasourceUID, cfg.Query, exprService, dsCache, secretsService, logger)
+	default:
+		return nil, fmt.Errorf("unknown enrichment source %q", cfg.Source)
+	}
+	return NewLookupEnricher(cfg, source, logger), nil
+}
+
+// NoopEnricher leaves the alerts as they are.
+type NoopEnricher struct{}
+
+func (NoopEnricher) Run(context.Context) error { return nil }
+
+func (NoopEnricher) Enrich(int64, *definitions.PostableAlerts) {}
+
+// LookupEnricher enriches the alerts of an organization with the rows of a lookup table that it reads
+// periodically from a source. A row matches an alert if its values of the match labels equal the values
+// of the labels of the alert. The columns are added as annotations, except for the label columns.
+// Labels are part of the identity of alerts, so a change of their values in the table starts new alerts.
+// Until the table has been read for the first time, alerts are left as they are.
+type LookupEnricher struct {
+	orgID        int64
+	source       Source
+	matchLabels  []string
+	labelColumns map[string]struct{}
+	overwrite    bool
+	interval     time.Duration
+	logger       log.Logger
+
+	mtx   sync.RWMutex
+	table *lookupTable
+}
+
+func NewLookupEnricher(cfg setting.UnifiedAlertingEnrichmentSettings, source Source, logger log.Logger) *LookupEnricher {
+	labelColumns := make(map[string]struct{}, len(cfg.LabelColumns))
+	for _, c := range cfg.LabelColumns {
+		labelColumns[c] = struct{}{}
+	}
+	return &LookupEnricher{
+		orgID:        cfg.OrgID,
+		source:       source,
+		matchLabels:  cfg.MatchLabels,
+		labelColumns: labelColumns,
+		overwrite:    cfg.Overwrite,
+		interval:     cfg.RefreshInterval,
+		logger:       logger,
+	}
+}
+
+func (e *LookupEnricher) Run(ctx context.Context) error {
+	e.refresh(ctx)
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			e.refresh(ctx)
+		}
+	}
+}
+
+// refresh reads the lookup table again. If that fails, the previous table is kept.
+func (e *LookupEnricher) refresh(ctx context.Context) {
+	if err := e.Load(ctx); err != nil {
+		e.logger.Error("failed to read the lookup table of enrichment, keeping the previous one", "err", err)
+	}
+}
+
+// Load reads the lookup table from the source.
+func (e *LookupEnricher) Load(ctx context.Context) error {
+	rows, err := e.source.Rows(ctx)
+	if err != nil {
+		return err
+	}
+	table, err := newLookupTable(e.matchLabels, rows)
+	if err != nil {
+		return err
+	}
+	e.mtx.Lock()
+	e.table = table
+	e.mtx.Unlock()
+	e.logger.Debug("lookup table of enrichment loaded", "rows", len(table.rows))
+	return nil
+}
+
+func (e *LookupEnricher) Enrich(orgID int64, alerts *definitions.PostableAlerts) {
+	if orgID != e.orgID {
+		return
+	}
+	e.mtx.RLock()
+	table := e.table
+	e.mtx.RUnlock()
+	if table == nil {
+		return
+	}
+
+	for i := range alerts.PostableAlerts {
+		alert := &alerts.PostableAlerts[i]
+		row, ok := table.lookup(alert.Labels)
+		if !ok {
+			continue
+		}
+		for column, value := range row {
+			if value == "" {
+				continue
+			}
+			if _, ok := e.labelColumns[column]; ok {
+				if alert.Labels == nil {
+					alert.Labels = make(map[string]string)
+				}
+				setValue(alert.Labels, column, value, e.overwrite)
+				continue
+			}
+			if alert.Annotations == nil {
+				alert.Annotations = make(map[string]string)
+			}
+			setValue(alert.Annotations, column, value, e.overwrite)
+		}
+	}
+}
+
+func setValue(m map[string]string, name, value string, overwrite bool) {
+	if _, ok := m[name]; ok && !overwrite {
+		return
+	}
+	m[name] = value
+}
+
+// lookupTable indexes the rows of a lookup table by their values of the match labels.
+type lookupTable struct {
+	matchLabels []string
+	rows        map[string]map[string]string
+}
+
+// newLookupTable indexes rows by their values of matchLabels. The columns of the match labels are not
+// part of the indexed rows. If several rows have the same values of the match labels, the last one wins.
+func newLookupTable(matchLabels []string, rows []map[string]string) (*lookupTable, error) {
+	t := &lookupTable{
+		matchLabels: matchLabels,
+		rows:        make(map[string]map[string]string, len(rows)),
+	}
+	match := make(map[string]struct{}, len(matchLabels))
+	for _, l := range matchLabels {
+		match[l] = struct{}{}
+	}
+
+	values := make([]string, len(matchLabels))
+	for i, row := range rows {
+		for j, l := range matchLabels {
+			v, ok := row[l]
+			if !ok {
+				return nil, fmt.Errorf("row %d does not have the column of the match label %q", i, l)
+			}
+			values[j] = v
+		}
+		columns := make(map[string]string, len(row))
+		for column, value := range row {
+			if _, ok := match[column]; ok {
+				continue
+			}
+			// Internal labels such as __alert_rule_uid__ must not be changed by the lookup table.
+			if !model.LabelName(column).IsValid() || strings.HasPrefix(column, "__") {
+				return nil, fmt.Errorf("row %d has column %q, which is not a valid label name", i, column)
+			}
+			columns[column] = value
+		}
+		t.rows[lookupKey(values)] = columns
+	}
+	return t, nil
+}
+
+func (t *lookupTable) lookup(labels map[string]string) (map[string]string, bool) {
+	values := make([]string, len(t.matchLabels))
+	for i, l := range t.matchLabels {
+		v, ok := labels[l]
+		if !ok {
+			return nil, false
+		}
+		values[i] = v
+	}
+	row, ok := t.rows[lookupKey(values)]
+	return row, ok
+}
+
+func lookupKey(values []string) string {
+	return strings.Join(values, "\xff")
+}
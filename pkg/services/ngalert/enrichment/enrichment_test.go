@@ -0,0 +1,196 @@
+package enrichment
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/filestorage"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+func alertsForTests(labels ...amv2.LabelSet) *definitions.PostableAlerts {
+	alerts := &definitions.PostableAlerts{}
+	for _, l := range labels {
+		alerts.PostableAlerts = append(alerts.PostableAlerts, amv2.PostableAlert{
+			Alert:       amv2.Alert{Labels: l},
+			Annotations: amv2.LabelSet{"summary": "high latency"},
+		})
+	}
+	return alerts
+}
+
+func TestLookupEnricher(t *testing.T) {
+	source, err := NewStaticSource(`[
+		{"service": "api", "env": "prod", "team": "backend", "runbook_url": "https://runbooks/api"},
+		{"service": "web", "env": "prod", "team": "frontend", "summary": "web is slow"},
+		{"service": "db", "env": "prod", "team": ""}
+	]`)
+	require.NoError(t, err)
+	cfg := setting.UnifiedAlertingEnrichmentSettings{
+		OrgID:           1,
+		MatchLabels:     []string{"service", "env"},
+		LabelColumns:    []string{"team"},
+		RefreshInterval: time.Minute,
+	}
+
+	t.Run("leaves alerts as they are until the table is loaded", func(t *testing.T) {
+		e := NewLookupEnricher(cfg, source, log.NewNopLogger())
+		alerts := alertsForTests(amv2.LabelSet{"service": "api", "env": "prod"})
+		e.Enrich(1, alerts)
+		require.Equal(t, amv2.LabelSet{"service": "api", "env": "prod"}, alerts.PostableAlerts[0].Labels)
+	})
+
+	t.Run("adds labels and annotations of matching rows", func(t *testing.T) {
+		e := NewLookupEnricher(cfg, source, log.NewNopLogger())
+		require.NoError(t, e.Load(context.Background()))
+		alerts := alertsForTests(
+			amv2.LabelSet{"alertname": "Latency", "service": "api", "env": "prod"},
+			amv2.LabelSet{"alertname": "Latency", "service": "api", "env": "dev"},
+			amv2.LabelSet{"alertname": "Latency", "service": "db", "env": "prod"},
+			amv2.LabelSet{"alertname": "Latency", "env": "prod"},
+		)
+		e.Enrich(1, alerts)
+
+		require.Equal(t, amv2.LabelSet{"alertname": "Latency", "service": "api", "env": "prod", "team": "backend"}, alerts.PostableAlerts[0].Labels)
+		require.Equal(t, amv2.LabelSet{"summary": "high latency", "runbook_url": "https://runbooks/api"}, alerts.PostableAlerts[0].Annotations)
+		require.Equal(t, amv2.LabelSet{"alertname": "Latency", "service": "api", "env": "dev"}, alerts.PostableAlerts[1].Labels)
+		// Empty values are not added.
+		require.Equal(t, amv2.LabelSet{"alertname": "Latency", "service": "db", "env": "prod"}, alerts.PostableAlerts[2].Labels)
+		require.Equal(t, amv2.LabelSet{"alertname": "Latency", "env": "prod"}, alerts.PostableAlerts[3].Labels)
+	})
+
+	t.Run("adds the values of the label columns as labels only", func(t *testing.T) {
+		e := NewLookupEnricher(cfg, source, log.NewNopLogger())
+		require.NoError(t, e.Load(context.Background()))
+		alerts := alertsForTests(amv2.LabelSet{"service": "api", "env": "prod"})
+		e.Enrich(1, alerts)
+		require.Equal(t, amv2.LabelSet{"service": "api", "env": "prod", "team": "backend"}, alerts.PostableAlerts[0].Labels)
+		require.NotContains(t, alerts.PostableAlerts[0].Annotations, "team")
+	})
+
+	t.Run("leaves the alerts of other organizations as they are", func(t *testing.T) {
+		e := NewLookupEnricher(cfg, source, log.NewNopLogger())
+		require.NoError(t, e.Load(context.Background()))
+		alerts := alertsForTests(amv2.LabelSet{"service": "api", "env": "prod"})
+		e.Enrich(2, alerts)
+		require.Equal(t, amv2.LabelSet{"service": "api", "env": "prod"}, alerts.PostableAlerts[0].Labels)
+		require.Equal(t, amv2.LabelSet{"summary": "high latency"}, alerts.PostableAlerts[0].Annotations)
+	})
+
+	t.Run("keeps existing values unless overwrite is enabled", func(t *testing.T) {
+		e := NewLookupEnricher(cfg, source, log.NewNopLogger())
+		require.NoError(t, e.Load(context.Background()))
+		alerts := alertsForTests(amv2.LabelSet{"service": "web", "env": "prod", "team": "sre"})
+		e.Enrich(1, alerts)
+		require.Equal(t, "sre", alerts.PostableAlerts[0].Labels["team"])
+		require.Equal(t, "high latency", alerts.PostableAlerts[0].Annotations["summary"])
+
+		overwrite := cfg
+		overwrite.Overwrite = true
+		e = NewLookupEnricher(overwrite, source, log.NewNopLogger())
+		require.NoError(t, e.Load(context.Background()))
+		alerts = alertsForTests(amv2.LabelSet{"service": "web", "env": "prod", "team": "sre"})
+		e.Enrich(1, alerts)
+		require.Equal(t, "frontend", alerts.PostableAlerts[0].Labels["team"])
+		require.Equal(t, "web is slow", alerts.PostableAlerts[0].Annotations["summary"])
+	})
+
+	t.Run("keeps the previous table if the source fails", func(t *testing.T) {
+		failing := &fakeSource{rows: []map[string]string{{"service": "api", "env": "prod", "team": "backend"}}}
+		e := NewLookupEnricher(cfg, failing, log.NewNopLogger())
+		e.refresh(context.Background())
+		failing.err = errors.New("unavailable")
+		e.refresh(context.Background())
+
+		alerts := alertsForTests(amv2.LabelSet{"service": "api", "env": "prod"})
+		e.Enrich(1, alerts)
+		require.Equal(t, "backend", alerts.PostableAlerts[0].Labels["team"])
+	})
+}
+
+func TestNewLookupTable(t *testing.T) {
+	_, err := newLookupTable([]string{"service"}, []map[string]string{{"team": "backend"}})
+	require.Error(t, err)
+
+	_, err = newLookupTable([]string{"service"}, []map[string]string{{"service": "api", "__alert_rule_uid__": "abc"}})
+	require.Error(t, err)
+
+	_, err = newLookupTable([]string{"service"}, []map[string]string{{"service": "api", "runbook url": "abc"}})
+	require.Error(t, err)
+}
+
+func TestFileSource(t *testing.T) {
+	files := &fakeFileReader{files: map[string][]byte{
+		"resources/owners.csv":  []byte("service, team\napi, backend\nweb,frontend\n"),
+		"resources/owners.json": []byte(`[{"service": "api", "team": "backend", "tier": 1}]`),
+		"resources/owners.txt":  []byte("api backend"),
+	}}
+
+	rows, err := NewFileSource(files, 1, "resources/owners.csv").Rows(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []map[string]string{{"service": "api", "team": "backend"}, {"service": "web", "team": "frontend"}}, rows)
+
+	rows, err = NewFileSource(files, 1, "resources/owners.json").Rows(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []map[string]string{{"service": "api", "team": "backend", "tier": "1"}}, rows)
+
+	_, err = NewFileSource(files, 1, "resources/owners.txt").Rows(context.Background())
+	require.Error(t, err)
+	_, err = NewFileSource(files, 1, "resources/missing.csv").Rows(context.Background())
+	require.Error(t, err)
+}
+
+func TestRowsFromFrames(t *testing.T) {
+	team := "backend"
+	frame := data.NewFrame("",
+		data.NewField("service", nil, []string{"api", "web"}),
+		data.NewField("team", nil, []*string{&team, nil}),
+		data.NewField("tier", nil, []int64{1, 2}),
+	)
+	require.Equal(t, []map[string]string{
+		{"service": "api", "team": "backend", "tier": "1"},
+		{"service": "web", "team": "", "tier": "2"},
+	}, rowsFromFrames(data.Frames{frame}))
+}
+
+func TestNew(t *testing.T) {
+	e, err := New(setting.UnifiedAlertingEnrichmentSettings{}, nil, nil, nil, nil, log.NewNopLogger())
+	require.NoError(t, err)
+	require.IsType(t, NoopEnricher{}, e)
+
+	_, err = New(setting.UnifiedAlertingEnrichmentSettings{Enabled: true, Source: "static", Static: "{"}, nil, nil, nil, nil, log.NewNopLogger())
+	require.Error(t, err)
+
+	_, err = New(setting.UnifiedAlertingEnrichmentSettings{Enabled: true, Source: "file", FilePath: "owners.csv"}, nil, nil, nil, nil, log.NewNopLogger())
+	require.Error(t, err)
+}
+
+type fakeSource struct {
+	rows []map[string]string
+	err  error
+}
+
+func (f *fakeSource) Rows(context.Context) ([]map[string]string, error) {
+	return f.rows, f.err
+}
+
+type fakeFileReader struct {
+	files map[string][]byte
+}
+
+func (f *fakeFileReader) Read(_ context.Context, _ *models.SignedInUser, path string) (*filestorage.File, error) {
+	contents, ok := f.files[path]
+	if !ok {
+		return nil, nil
+	}
+	return &filestorage.File{Contents: contents}, nil
+}
@@ -0,0 +1,204 @@
+package enrichment
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/expr"
+	"github.com/grafana/grafana/pkg/infra/filestorage"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/secrets"
+)
+
+// Source reads the rows of a lookup table. A row maps the names of the columns to their values.
+type Source interface {
+	Rows(ctx context.Context) ([]map[string]string, error)
+}
+
+// StaticSource is a lookup table that is part of the configuration.
+type StaticSource struct {
+	rows []map[string]string
+}
+
+// NewStaticSource returns the lookup table of a JSON array of objects.
+func NewStaticSource(table string) (*StaticSource, error) {
+	rows, err := rowsFromJSON([]byte(table))
+	if err != nil {
+		return nil, fmt.Errorf("invalid static lookup table: %w", err)
+	}
+	return &StaticSource{rows: rows}, nil
+}
+
+func (s *StaticSource) Rows(context.Context) ([]map[string]string, error) {
+	return s.rows, nil
+}
+
+// FileReader reads files of the Grafana storage. It is implemented by store.StorageService.
+type FileReader interface {
+	Read(ctx context.Context, user *models.SignedInUser, path string) (*filestorage.File, error)
+}
+
+// FileSource reads the lookup table from a CSV or JSON file of the Grafana storage. The first line
+// of a CSV file has the names of the columns. A JSON file has an array of objects.
+type FileSource struct {
+	files FileReader
+	orgID int64
+	path  string
+}
+
+func NewFileSource(files FileReader, orgID int64, path string) *FileSource {
+	return &FileSource{files: files, orgID: orgID, path: path}
+}
+
+func (s *FileSource) Rows(ctx context.Context) ([]map[string]string, error) {
+	file, err := s.files.Read(ctx, &models.SignedInUser{OrgId: s.orgID, OrgRole: models.ROLE_ADMIN}, s.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", s.path, err)
+	}
+	if file == nil {
+		return nil, fmt.Errorf("file %s not found", s.path)
+	}
+
+	switch strings.ToLower(path.Ext(s.path)) {
+	case ".csv":
+		return rowsFromCSV(file.Contents)
+	case ".json":
+		return rowsFromJSON(file.Contents)
+	default:
+		return nil, fmt.Errorf("file %s is neither a CSV nor a JSON file", s.path)
+	}
+}
+
+// datasourceRefID is the ref ID of the query of DatasourceSource.
+const datasourceRefID = "A"
+
+// DatasourceSource reads the lookup table from the result of a data source query, such as an SQL query in table format.
+// Every row of every frame of the result is a row of the lookup table.
+type DatasourceSource struct {
+	orgID          int64
+	datasourceUID  string
+	query          string
+	exprService    *expr.Service
+	dsCache        datasources.CacheService
+	secretsService secrets.Service
+	logger         log.Logger
+}
+
+func NewDatasourceSource(orgID int64, datasourceUID, query string, exprService *expr.Service, dsCache datasources.CacheService,
+	secretsService secrets.Service, logger log.Logger) *DatasourceSource {
+	return &DatasourceSource{
+		orgID:          orgID,
+		datasourceUID:  datasourceUID,
+		query:          query,
+		exprService:    exprService,
+		dsCache:        dsCache,
+		secretsService: secretsService,
+		logger:         logger,
+	}
+}
+
+func (s *DatasourceSource) Rows(ctx context.Context) ([]map[string]string, error) {
+	queries := []ngmodels.AlertQuery{{
+		RefID:         datasourceRefID,
+		DatasourceUID: s.datasourceUID,
+		Model:         json.RawMessage(s.query),
+		// The time range only matters to queries that use it, such as time series queries.
+		RelativeTimeRange: ngmodels.RelativeTimeRange{From: ngmodels.Duration(10 * time.Minute)},
+	}}
+	execCtx := eval.AlertExecCtx{OrgID: s.orgID, ExpressionsEnabled: true, Log: s.logger, Ctx: ctx}
+	req, err := eval.GetExprRequest(execCtx, queries, time.Now(), s.dsCache, s.secretsService)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build the query of the lookup table: %w", err)
+	}
+	resp, err := s.exprService.TransformData(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the lookup table: %w", err)
+	}
+	result, ok := resp.Responses[datasourceRefID]
+	if !ok {
+		return nil, errors.New("the query of the lookup table returned no result")
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to query the lookup table: %w", result.Error)
+	}
+	return rowsFromFrames(result.Frames), nil
+}
+
+func rowsFromJSON(b []byte) ([]map[string]string, error) {
+	var objects []map[string]interface{}
+	if err := json.Unmarshal(b, &objects); err != nil {
+		return nil, err
+	}
+	rows := make([]map[string]string, 0, len(objects))
+	for _, o := range objects {
+		row := make(map[string]string, len(o))
+		for k, v := range o {
+			if v == nil {
+				row[k] = ""
+				continue
+			}
+			row[k] = fmt.Sprint(v)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func rowsFromCSV(b []byte) ([]map[string]string, error) {
+	records, err := csv.NewReader(bytes.NewReader(b)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	header := records[0]
+	for i := range header {
+		header[i] = strings.TrimSpace(header[i])
+	}
+	rows := make([]map[string]string, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(map[string]string, len(header))
+		for i, column := range header {
+			row[column] = strings.TrimSpace(record[i])
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func rowsFromFrames(frames data.Frames) []map[string]string {
+	var rows []map[string]string
+	for _, frame := range frames {
+		length, err := frame.RowLen()
+		if err != nil {
+			continue
+		}
+		for i := 0; i < length; i++ {
+			row := make(map[string]string, len(frame.Fields))
+			for _, field := range frame.Fields {
+				v, ok := field.ConcreteAt(i)
+				if !ok {
+					row[field.Name] = ""
+					continue
+				}
+				row[field.Name] = fmt.Sprint(v)
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/prometheus/common/model"
 
 	"github.com/grafana/grafana/pkg/util/cmputil"
 )
@@ -122,14 +123,44 @@ type AlertRule struct {
 	For         time.Duration
 	Annotations map[string]string
 	Labels      map[string]string
+	// RuleGroupIndex is the position of the rule in its group, starting at 1.
+	// Rules of a group are evaluated in this order.
+	RuleGroupIndex int `xorm:"rule_group_idx"`
+	// Record is set if the rule is a recording rule.
+	Record *Record `xorm:"record"`
+}
+
+// Record defines how the result of a recording rule is written.
+type Record struct {
+	// Metric is the name of the metric the result is written to.
+	Metric string `json:"metric" yaml:"metric"`
+	// From is the RefID of the query or expression whose result is written.
+	From string `json:"from" yaml:"from"`
+}
+
+// Validate checks that the metric name is valid and that the result is taken from one of the queries or expressions.
+func (r *Record) Validate(data []AlertQuery) error {
+	if !model.IsValidMetricName(model.LabelValue(r.Metric)) {
+		return fmt.Errorf("%w: invalid metric name %q", ErrAlertRuleFailedValidation, r.Metric)
+	}
+	for _, q := range data {
+		if q.RefID == r.From {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: recorded query or expression %q does not exist", ErrAlertRuleFailedValidation, r.From)
 }
 
 type SchedulableAlertRule struct {
 	Title           string
 	UID             string `xorm:"uid"`
 	OrgID           int64  `xorm:"org_id"`
+	NamespaceUID    string `xorm:"namespace_uid"`
+	RuleGroup       string
+	RuleGroupIndex  int `xorm:"rule_group_idx"`
 	IntervalSeconds int64
 	Version         int64
+	Record          *Record `xorm:"record"`
 }
 
 type LabelOption func(map[string]string)
@@ -209,6 +240,16 @@ func (alertRule *SchedulableAlertRule) GetKey() AlertRuleKey {
 	return AlertRuleKey{OrgID: alertRule.OrgID, UID: alertRule.UID}
 }
 
+// GetGroupKey returns the identifier of a group the rule belongs to
+func (alertRule *SchedulableAlertRule) GetGroupKey() AlertRuleGroupKey {
+	return AlertRuleGroupKey{OrgID: alertRule.OrgID, NamespaceUID: alertRule.NamespaceUID, RuleGroup: alertRule.RuleGroup}
+}
+
+// IsRecordingRule returns true if the rule records the result of a query instead of alerting on it.
+func (alertRule *AlertRule) IsRecordingRule() bool {
+	return alertRule.Record != nil
+}
+
 // PreSave sets default values and loads the updated model for each alert query.
 func (alertRule *AlertRule) PreSave(timeNow func() time.Time) error {
 	for i, q := range alertRule.Data {
@@ -254,9 +295,11 @@ type AlertRuleVersion struct {
 	ExecErrState    ExecutionErrorState
 	// ideally this field should have been apimodels.ApiDuration
 	// but this is currently not possible because of circular dependencies
-	For         time.Duration
-	Annotations map[string]string
-	Labels      map[string]string
+	For            time.Duration
+	Annotations    map[string]string
+	Labels         map[string]string
+	RuleGroupIndex int     `xorm:"rule_group_idx"`
+	Record         *Record `xorm:"record"`
 }
 
 // GetAlertRuleByUIDQuery is the query for retrieving/deleting an alert rule by UID and organisation ID.
@@ -281,6 +324,8 @@ type ListAlertRulesQuery struct {
 	NamespaceUIDs []string
 	ExcludeOrgs   []int64
 	RuleGroup     string
+	// RuleUIDs restricts the result to these rules if it is not empty.
+	RuleUIDs []string
 
 	// DashboardUID and PanelID are optional and allow filtering rules
 	// to return just those for a dashboard and panel.
@@ -347,15 +392,16 @@ func (c Condition) IsValid() bool {
 // There are several exceptions:
 // 1. Following fields are not patched and therefore will be ignored: AlertRule.ID, AlertRule.OrgID, AlertRule.Updated, AlertRule.Version, AlertRule.UID, AlertRule.DashboardUID, AlertRule.PanelID, AlertRule.Annotations and AlertRule.Labels
 // 2. There are fields that are patched together:
-//    - AlertRule.Condition and AlertRule.Data
-// If either of the pair is specified, neither is patched.
+//    - AlertRule.Condition, AlertRule.Data and AlertRule.Record
+// If Data and either Condition or Record are specified, none of them is patched.
 func PatchPartialAlertRule(existingRule *AlertRule, ruleToPatch *AlertRule) {
 	if ruleToPatch.Title == "" {
 		ruleToPatch.Title = existingRule.Title
 	}
-	if ruleToPatch.Condition == "" || len(ruleToPatch.Data) == 0 {
+	if (ruleToPatch.Condition == "" && ruleToPatch.Record == nil) || len(ruleToPatch.Data) == 0 {
 		ruleToPatch.Condition = existingRule.Condition
 		ruleToPatch.Data = existingRule.Data
+		ruleToPatch.Record = existingRule.Record
 	}
 	if ruleToPatch.IntervalSeconds == 0 {
 		ruleToPatch.IntervalSeconds = existingRule.IntervalSeconds
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrRuleTemplateNotFound is returned when the alert rule template does not exist.
+	ErrRuleTemplateNotFound = errors.New("alert rule template not found")
+	// ErrRuleTemplateInstanceNotFound is returned when the alert rule is not created from the template.
+	ErrRuleTemplateInstanceNotFound = errors.New("alert rule is not created from the alert rule template")
+	// ErrRuleTemplateVersionConflict is returned when the alert rule template was changed concurrently.
+	ErrRuleTemplateVersionConflict = errors.New("alert rule template was changed by someone else")
+)
+
+type RuleTemplateParameterType string
+
+const (
+	RuleTemplateParameterString RuleTemplateParameterType = "string"
+	// RuleTemplateParameterNumber is a parameter whose value is rendered as a JSON number, such as a threshold.
+	RuleTemplateParameterNumber RuleTemplateParameterType = "number"
+	// RuleTemplateParameterDuration is a parameter whose value is a Prometheus duration, such as 5m.
+	RuleTemplateParameterDuration RuleTemplateParameterType = "duration"
+	// RuleTemplateParameterDatasource is a parameter whose value is the UID of a data source.
+	RuleTemplateParameterDatasource RuleTemplateParameterType = "datasource"
+)
+
+// RuleTemplateParameter is a typed parameter of an alert rule template.
+type RuleTemplateParameter struct {
+	Name        string                    `json:"name"`
+	Type        RuleTemplateParameterType `json:"type"`
+	Description string                    `json:"description,omitempty"`
+	// Default is the value of the parameter if a rule does not set it. Parameters without a default are required.
+	Default *string `json:"default,omitempty"`
+}
+
+// AlertRuleTemplate is the body of an alert rule with placeholders for the values of its parameters.
+// Alert rules created from the template are rendered again whenever the template changes.
+type AlertRuleTemplate struct {
+	ID          int64                   `xorm:"pk autoincr 'id'"`
+	OrgID       int64                   `xorm:"org_id"`
+	UID         string                  `xorm:"uid"`
+	Title       string                  `xorm:"title"`
+	Description string                  `xorm:"description"`
+	Parameters  []RuleTemplateParameter `xorm:"parameters"`
+	// Rule is the JSON of a provisioned alert rule whose string values may contain ${parameter} placeholders.
+	Rule    string `xorm:"rule"`
+	Version int64
+	Updated time.Time
+}
+
+func (t *AlertRuleTemplate) TableName() string {
+	return "alert_rule_template"
+}
+
+// AlertRuleTemplateInstance links an alert rule to the template it is created from.
+type AlertRuleTemplateInstance struct {
+	ID          int64  `xorm:"pk autoincr 'id'"`
+	OrgID       int64  `xorm:"org_id"`
+	TemplateUID string `xorm:"template_uid"`
+	RuleUID     string `xorm:"rule_uid"`
+	// Parameters are the values of the parameters of the template the rule is rendered with.
+	Parameters map[string]string `xorm:"parameters"`
+	// TemplateVersion is the version of the template the rule was last rendered from.
+	TemplateVersion int64 `xorm:"template_version"`
+}
+
+func (i *AlertRuleTemplateInstance) TableName() string {
+	return "alert_rule_template_instance"
+}
@@ -203,6 +203,13 @@ func TestPatchPartialAlertRule(t *testing.T) {
 					r.Labels = nil
 				},
 			},
+			{
+				name: "Data of a recording rule without condition",
+				mutator: func(r *AlertRule) {
+					r.Condition = ""
+					r.Record = &Record{Metric: "metric", From: r.Data[0].RefID}
+				},
+			},
 		}
 
 		for _, testCase := range testCases {
@@ -354,6 +361,13 @@ func TestDiff(t *testing.T) {
 			assert.Equal(t, rule2.For, diff[0].Right.Interface())
 			difCnt++
 		}
+		if rule1.RuleGroupIndex != rule2.RuleGroupIndex {
+			diff := diffs.GetDiffsForField("RuleGroupIndex")
+			assert.Len(t, diff, 1)
+			assert.Equal(t, rule1.RuleGroupIndex, diff[0].Left.Interface())
+			assert.Equal(t, rule2.RuleGroupIndex, diff[0].Right.Interface())
+			difCnt++
+		}
 
 		require.Lenf(t, diffs, difCnt, "Got some unexpected diffs. Either add to ignore or add assert to it")
 
@@ -362,6 +376,18 @@ func TestDiff(t *testing.T) {
 		}
 	})
 
+	t.Run("should detect changes in Record", func(t *testing.T) {
+		rule1 := AlertRuleGen()()
+		rule1.Record = &Record{Metric: "metric", From: "A"}
+		rule2 := CopyRule(rule1)
+		require.Empty(t, rule1.Diff(rule2))
+
+		rule2.Record.Metric = "other"
+		diff := rule1.Diff(rule2)
+		require.Len(t, diff, 1)
+		require.Equal(t, "Record.Metric", diff[0].Path)
+	})
+
 	t.Run("should not see difference between nil and empty Annotations", func(t *testing.T) {
 		rule1 := AlertRuleGen()()
 		rule1.Annotations = make(map[string]string)
@@ -12,6 +12,9 @@ type AlertConfiguration struct {
 	CreatedAt                 int64 `xorm:"created"`
 	Default                   bool
 	OrgID                     int64 `xorm:"org_id"`
+	// CreatedBy is the login of the user that saved the configuration. It is empty if Grafana saved it, such as the default
+	// configuration or a change made by provisioning.
+	CreatedBy string
 }
 
 // GetLatestAlertmanagerConfigurationQuery is the query to get the latest alertmanager configuration.
@@ -27,4 +30,5 @@ type SaveAlertmanagerConfigurationCmd struct {
 	ConfigurationVersion      string
 	Default                   bool
 	OrgID                     int64
+	CreatedBy                 string
 }
@@ -72,6 +72,8 @@ type ListAlertInstancesQuery struct {
 	RuleUID     string
 	State       InstanceStateType
 	StateReason string
+	// RuleUIDs restricts the result to the instances of these rules if it is not empty.
+	RuleUIDs []string
 
 	Result []*AlertInstance
 }
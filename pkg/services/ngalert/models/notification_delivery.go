@@ -0,0 +1,67 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrNotificationDeliveryNotFound is returned when the notification delivery does not exist.
+var ErrNotificationDeliveryNotFound = errors.New("notification delivery not found")
+
+type NotificationDeliveryStatus string
+
+const (
+	NotificationDeliverySuccess NotificationDeliveryStatus = "success"
+	NotificationDeliveryFailed  NotificationDeliveryStatus = "failed"
+	// NotificationDeliveryRetrying is the status of a delivery that failed with a transient error and waits in the retry queue.
+	NotificationDeliveryRetrying NotificationDeliveryStatus = "retrying"
+)
+
+// NotificationDelivery is a notification sent, or attempted to be sent, by an integration of a receiver.
+type NotificationDelivery struct {
+	ID               int64             `xorm:"pk autoincr 'id'"`
+	OrgID            int64             `xorm:"org_id"`
+	Receiver         string            `xorm:"receiver"`
+	IntegrationUID   string            `xorm:"integration_uid"`
+	IntegrationType  string            `xorm:"integration_type"`
+	IntegrationIndex int               `xorm:"integration_index"`
+	GroupKey         string            `xorm:"group_key"`
+	GroupLabels      map[string]string `xorm:"group_labels"`
+	// AlertFingerprints are the fingerprints of the alerts that were sent.
+	AlertFingerprints []string `xorm:"alert_fingerprints"`
+	// Alerts is the JSON encoded list of alerts that were sent, so that the delivery can be retried and replayed.
+	Alerts       string                     `xorm:"alerts"`
+	Status       NotificationDeliveryStatus `xorm:"status"`
+	Attempts     int                        `xorm:"attempts"`
+	Error        string                     `xorm:"error"`
+	ResponseCode int                        `xorm:"response_code"`
+	// ResponseBody is the truncated body of the last response received from the integration.
+	ResponseBody string `xorm:"response_body"`
+	// CreatedAt, LastAttemptAt and NextAttemptAt are in milliseconds since the epoch.
+	CreatedAt     int64 `xorm:"created_at"`
+	LastAttemptAt int64 `xorm:"last_attempt_at"`
+	// NextAttemptAt is only set while the delivery is in the retry queue.
+	NextAttemptAt int64 `xorm:"next_attempt_at"`
+	// ReplayOf is the ID of the delivery this delivery is a replay of, or 0 if it is not a replay.
+	ReplayOf int64 `xorm:"replay_of"`
+}
+
+// ListNotificationDeliveriesQuery is the query for listing the notification deliveries of an organization, most recent first.
+type ListNotificationDeliveriesQuery struct {
+	OrgID          int64
+	Receiver       string
+	IntegrationUID string
+	Status         NotificationDeliveryStatus
+	From           time.Time
+	To             time.Time
+	// Page starts at 1.
+	Page  int
+	Limit int
+
+	Result []*NotificationDelivery
+}
+
+// A XORM interface that defines the used table for this struct.
+func (d *NotificationDelivery) TableName() string {
+	return "alert_notification_delivery"
+}
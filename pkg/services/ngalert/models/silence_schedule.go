@@ -0,0 +1,44 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/alertmanager/timeinterval"
+)
+
+// ErrSilenceScheduleNotFound is returned when the silence schedule does not exist.
+var ErrSilenceScheduleNotFound = errors.New("silence schedule not found")
+
+// SilenceMatcher is a matcher of the silences of a silence schedule, as in the Alertmanager API.
+type SilenceMatcher struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	IsRegex bool   `json:"isRegex"`
+	IsEqual bool   `json:"isEqual"`
+}
+
+// SilenceSchedule silences alerts during recurring windows, such as maintenance windows. A silence is created
+// in the Alertmanager of the organization when a window begins, and it expires when the window ends.
+type SilenceSchedule struct {
+	ID        int64            `xorm:"pk autoincr 'id'"`
+	OrgID     int64            `xorm:"org_id"`
+	UID       string           `xorm:"uid"`
+	Comment   string           `xorm:"comment"`
+	CreatedBy string           `xorm:"created_by"`
+	Matchers  []SilenceMatcher `xorm:"matchers"`
+	// Cron is a cron expression of the beginnings of the windows, each of which lasts Duration.
+	Cron     string        `xorm:"cron"`
+	Duration time.Duration `xorm:"duration"`
+	// TimeIntervals are the windows in the format of mute timings. They are used if Cron is empty.
+	TimeIntervals []timeinterval.TimeInterval `xorm:"time_intervals"`
+	// Timezone is the name of the IANA time zone in which Cron and TimeIntervals are evaluated. It is UTC if empty.
+	Timezone string `xorm:"timezone"`
+	// SilenceID is the ID of the silence of the current or the last window.
+	SilenceID string `xorm:"silence_id"`
+	Updated   time.Time
+}
+
+func (s *SilenceSchedule) TableName() string {
+	return "silence_schedule"
+}
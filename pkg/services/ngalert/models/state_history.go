@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"github.com/prometheus/alertmanager/pkg/labels"
+)
+
+// AlertStateHistory is a single transition of an alert instance from one state to another.
+type AlertStateHistory struct {
+	ID      int64  `xorm:"pk autoincr 'id'"`
+	OrgID   int64  `xorm:"org_id"`
+	RuleUID string `xorm:"rule_uid"`
+	// NamespaceUID is the folder of the rule at the time of the transition. It authorizes access to the
+	// history of rules that have since been deleted.
+	NamespaceUID   string            `xorm:"namespace_uid"`
+	RuleVersion    int64             `xorm:"rule_version"`
+	Labels         map[string]string `xorm:"labels"`
+	LabelsHash     string            `xorm:"labels_hash"`
+	PreviousState  InstanceStateType `xorm:"previous_state"`
+	PreviousReason string            `xorm:"previous_reason"`
+	State          InstanceStateType `xorm:"state"`
+	Reason         string            `xorm:"reason"`
+	// Values are the values of the reduce and math expressions of the evaluation that caused the transition.
+	Values map[string]*float64 `xorm:"eval_values"`
+	// EvaluatedAt is the time of the evaluation in milliseconds since the epoch.
+	EvaluatedAt int64 `xorm:"evaluated_at"`
+}
+
+// ListAlertStateHistoryQuery is the query for listing the state history of alert instances, most recent first.
+type ListAlertStateHistoryQuery struct {
+	OrgID int64
+	// RuleUIDs restricts the history to these rules. It is not restricted if empty.
+	RuleUIDs []string
+	// NamespaceUIDs restricts the history to rules in these folders. It is not restricted if empty.
+	NamespaceUIDs []string
+	// ExcludeRuleUIDs excludes the history of these rules.
+	ExcludeRuleUIDs []string
+	// Matchers must all match the labels of an instance for its transitions to be returned.
+	Matchers labels.Matchers
+	From     time.Time
+	To       time.Time
+	// Page starts at 1.
+	Page  int
+	Limit int
+
+	Result []*AlertStateHistory
+}
+
+// A XORM interface that defines the used table for this struct.
+func (h *AlertStateHistory) TableName() string {
+	return "alert_state_history"
+}
@@ -79,6 +79,7 @@ func AlertRuleGen(mutators ...func(*AlertRule)) func() *AlertRule {
 			For:             forInterval,
 			Annotations:     annotations,
 			Labels:          labels,
+			RuleGroupIndex:  rand.Intn(1500) + 1,
 		}
 
 		for _, mutator := range mutators {
@@ -158,6 +159,7 @@ func CopyRule(r *AlertRule) *AlertRule {
 		NoDataState:     r.NoDataState,
 		ExecErrState:    r.ExecErrState,
 		For:             r.For,
+		RuleGroupIndex:  r.RuleGroupIndex,
 	}
 
 	if r.DashboardUID != nil {
@@ -168,6 +170,10 @@ func CopyRule(r *AlertRule) *AlertRule {
 		p := *r.PanelID
 		result.PanelID = &p
 	}
+	if r.Record != nil {
+		rec := *r.Record
+		result.Record = &rec
+	}
 
 	for _, d := range r.Data {
 		q := AlertQuery{
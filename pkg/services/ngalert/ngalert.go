@@ -11,11 +11,13 @@ import (
 	"github.com/grafana/grafana/pkg/expr"
 	"github.com/grafana/grafana/pkg/infra/kvstore"
 	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/plugins"
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/dashboards"
 	"github.com/grafana/grafana/pkg/services/datasourceproxy"
 	"github.com/grafana/grafana/pkg/services/datasources"
 	"github.com/grafana/grafana/pkg/services/ngalert/api"
+	"github.com/grafana/grafana/pkg/services/ngalert/enrichment"
 	"github.com/grafana/grafana/pkg/services/ngalert/eval"
 	"github.com/grafana/grafana/pkg/services/ngalert/image"
 	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
@@ -24,18 +26,22 @@ import (
 	"github.com/grafana/grafana/pkg/services/ngalert/schedule"
 	"github.com/grafana/grafana/pkg/services/ngalert/state"
 	"github.com/grafana/grafana/pkg/services/ngalert/store"
+	"github.com/grafana/grafana/pkg/services/ngalert/writer"
 	"github.com/grafana/grafana/pkg/services/notifications"
 	"github.com/grafana/grafana/pkg/services/quota"
 	"github.com/grafana/grafana/pkg/services/rendering"
 	"github.com/grafana/grafana/pkg/services/secrets"
 	"github.com/grafana/grafana/pkg/services/sqlstore"
+	storage "github.com/grafana/grafana/pkg/services/store"
 	"github.com/grafana/grafana/pkg/setting"
 )
 
 func ProvideService(cfg *setting.Cfg, dataSourceCache datasources.CacheService, routeRegister routing.RouteRegister,
 	sqlStore *sqlstore.SQLStore, kvStore kvstore.KVStore, expressionService *expr.Service, dataProxy *datasourceproxy.DataSourceProxyService,
 	quotaService *quota.QuotaService, secretsService secrets.Service, notificationService notifications.Service, m *metrics.NGAlert,
-	folderService dashboards.FolderService, ac accesscontrol.AccessControl, dashboardService dashboards.DashboardService, renderService rendering.Service) (*AlertNG, error) {
+	folderService dashboards.FolderService, ac accesscontrol.AccessControl, dashboardService dashboards.DashboardService, renderService rendering.Service,
+	pluginStore plugins.Store, pluginClient plugins.Client, storageService storage.StorageService,
+	entityEventsService storage.EntityEventsService) (*AlertNG, error) {
 	ng := &AlertNG{
 		Cfg:                 cfg,
 		DataSourceCache:     dataSourceCache,
@@ -53,6 +59,10 @@ func ProvideService(cfg *setting.Cfg, dataSourceCache datasources.CacheService,
 		accesscontrol:       ac,
 		dashboardService:    dashboardService,
 		renderService:       renderService,
+		pluginStore:         pluginStore,
+		pluginClient:        pluginClient,
+		storageService:      storageService,
+		entityEventsService: entityEventsService,
 	}
 
 	if ng.IsDisabled() {
@@ -84,8 +94,14 @@ type AlertNG struct {
 	imageService        image.ImageService
 	schedule            schedule.ScheduleService
 	stateManager        *state.Manager
+	silenceScheduler    *notifier.SilenceScheduler
 	folderService       dashboards.FolderService
 	dashboardService    dashboards.DashboardService
+	pluginStore         plugins.Store
+	pluginClient        plugins.Client
+	storageService      storage.StorageService
+	entityEventsService storage.EntityEventsService
+	enricher            enrichment.Enricher
 
 	// Alerting notification services
 	MultiOrgAlertmanager *notifier.MultiOrgAlertmanager
@@ -103,11 +119,18 @@ func (ng *AlertNG) init() error {
 		FolderService:    ng.folderService,
 		AccessControl:    ng.accesscontrol,
 		DashboardService: ng.dashboardService,
+		EntityEvents:     ng.entityEventsService,
+	}
+
+	// Contact point types of plugins must be registered before the Alertmanagers load their configurations.
+	if ng.pluginStore != nil && ng.pluginClient != nil {
+		notifier.RegisterPluginNotifiers(context.Background(), ng.pluginStore, ng.pluginClient, ng.Log)
 	}
 
 	decryptFn := ng.SecretsService.GetDecryptedValue
 	multiOrgMetrics := ng.Metrics.GetMultiOrgAlertmanagerMetrics()
-	ng.MultiOrgAlertmanager, err = notifier.NewMultiOrgAlertmanager(ng.Cfg, store, store, ng.KVStore, store, decryptFn, multiOrgMetrics, ng.NotificationService, log.New("ngalert.multiorg.alertmanager"), ng.SecretsService)
+	deliveryStore := ng.notificationDeliveryStore(store)
+	ng.MultiOrgAlertmanager, err = notifier.NewMultiOrgAlertmanager(ng.Cfg, store, store, ng.KVStore, store, deliveryStore, decryptFn, multiOrgMetrics, ng.NotificationService, log.New("ngalert.multiorg.alertmanager"), ng.SecretsService)
 	if err != nil {
 		return err
 	}
@@ -138,26 +161,41 @@ func (ng *AlertNG) init() error {
 		AdminConfigPollInterval: ng.Cfg.UnifiedAlerting.AdminConfigPollInterval,
 		DisabledOrgs:            ng.Cfg.UnifiedAlerting.DisabledOrgs,
 		MinRuleInterval:         ng.Cfg.UnifiedAlerting.MinInterval,
+		RecordingWriter:         writer.New(ng.Cfg.UnifiedAlerting.RecordingRules, log.New("ngalert.writer")),
+	}
+	if ng.Cfg.UnifiedAlerting.HAShardRuleEvaluation {
+		schedCfg.ClusterMembership = ng.MultiOrgAlertmanager
 	}
 
+	ng.enricher, err = enrichment.New(ng.Cfg.UnifiedAlerting.Enrichment, ng.storageService, ng.ExpressionService, ng.DataSourceCache, ng.SecretsService, log.New("ngalert.enrichment"))
+	if err != nil {
+		return err
+	}
+	schedCfg.Enricher = ng.enricher
+
 	appUrl, err := url.Parse(ng.Cfg.AppURL)
 	if err != nil {
 		ng.Log.Error("Failed to parse application URL. Continue without it.", "err", err)
 		appUrl = nil
 	}
 
-	stateManager := state.NewManager(ng.Log, ng.Metrics.GetStateMetrics(), appUrl, store, store, ng.SQLStore, ng.dashboardService, ng.imageService)
+	historyStore := ng.stateHistoryStore(store)
+	stateManager := state.NewManager(ng.Log, ng.Metrics.GetStateMetrics(), appUrl, store, store, ng.SQLStore, ng.dashboardService, ng.imageService, historyStore)
 	scheduler := schedule.NewScheduler(schedCfg, ng.ExpressionService, appUrl, stateManager)
 
 	ng.stateManager = stateManager
 	ng.schedule = scheduler
+	ng.silenceScheduler = notifier.NewSilenceScheduler(ng.MultiOrgAlertmanager, store, log.New("ngalert.silence-scheduler"))
 
 	// Provisioning
 	policyService := provisioning.NewNotificationPolicyService(store, store, store, ng.Log)
 	contactPointService := provisioning.NewContactPointService(store, ng.SecretsService, store, store, ng.Log)
-	templateService := provisioning.NewTemplateService(store, store, store, ng.Log)
+	templateService := provisioning.NewTemplateService(store, store, store, appUrl, ng.Log)
 	muteTimingService := provisioning.NewMuteTimingService(store, store, store, ng.Log)
 	alertRuleService := provisioning.NewAlertRuleService(store, store, store, int64(ng.Cfg.UnifiedAlerting.DefaultRuleEvaluationInterval.Seconds()), ng.Log)
+	dryRunService := provisioning.NewDryRunService(store, contactPointService, int64(ng.Cfg.UnifiedAlerting.DefaultRuleEvaluationInterval.Seconds()), ng.Log)
+	exportService := provisioning.NewExportService(store, store, ng.Log)
+	ruleTemplateService := provisioning.NewRuleTemplateService(store, store, alertRuleService, store, ng.Log)
 
 	api := api.API{
 		Cfg:                  ng.Cfg,
@@ -170,6 +208,8 @@ func (ng *AlertNG) init() error {
 		SecretsService:       ng.SecretsService,
 		TransactionManager:   store,
 		InstanceStore:        store,
+		StateHistoryStore:    historyStore,
+		DeliveryStore:        deliveryStore,
 		RuleStore:            store,
 		AlertingStore:        store,
 		AdminConfigStore:     store,
@@ -182,6 +222,10 @@ func (ng *AlertNG) init() error {
 		Templates:            templateService,
 		MuteTimings:          muteTimingService,
 		AlertRules:           alertRuleService,
+		ProvisioningDryRun:   dryRunService,
+		ProvisioningExport:   exportService,
+		RuleTemplates:        ruleTemplateService,
+		SilenceSchedules:     ng.silenceScheduler,
 	}
 	api.RegisterAPIEndpoints(ng.Metrics.GetAPIMetrics())
 
@@ -203,9 +247,31 @@ func (ng *AlertNG) Run(ctx context.Context) error {
 	children.Go(func() error {
 		return ng.MultiOrgAlertmanager.Run(subCtx)
 	})
+	children.Go(func() error {
+		return ng.silenceScheduler.Run(subCtx)
+	})
+	children.Go(func() error {
+		return ng.enricher.Run(subCtx)
+	})
 	return children.Wait()
 }
 
+// stateHistoryStore returns the store for the state history of alert instances, or nil if it is disabled.
+func (ng *AlertNG) stateHistoryStore(s *store.DBstore) store.StateHistoryStore {
+	if !ng.Cfg.UnifiedAlerting.StateHistory.Enabled {
+		return nil
+	}
+	return s
+}
+
+// notificationDeliveryStore returns the store for the delivery log of notifications, or nil if it is disabled.
+func (ng *AlertNG) notificationDeliveryStore(s *store.DBstore) store.NotificationDeliveryStore {
+	if !ng.Cfg.UnifiedAlerting.DeliveryLog.Enabled {
+		return nil
+	}
+	return s
+}
+
 // IsDisabled returns true if the alerting service is disable for this instance.
 func (ng *AlertNG) IsDisabled() bool {
 	if ng.Cfg == nil {
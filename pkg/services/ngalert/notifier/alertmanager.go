@@ -125,6 +125,11 @@ type Alertmanager struct {
 	stageMetrics      *notify.Metrics
 	dispatcherMetrics *dispatch.DispatcherMetrics
 
+	// deliveryLog records the notifications sent by the integrations. It is nil if the delivery log is disabled.
+	deliveryLog *deliveryLog
+	// integrations are the integrations of the applied configuration by receiver name.
+	integrations map[string][]notify.Integration
+
 	reloadConfigMtx sync.RWMutex
 	config          *apimodels.PostableUserConfig
 	configHash      [16]byte
@@ -134,7 +139,8 @@ type Alertmanager struct {
 }
 
 func newAlertmanager(ctx context.Context, orgID int64, cfg *setting.Cfg, store AlertingStore, kvStore kvstore.KVStore,
-	peer ClusterPeer, decryptFn channels.GetDecryptedValueFn, ns notifications.Service, m *metrics.Alertmanager) (*Alertmanager, error) {
+	deliveryStore store.NotificationDeliveryStore, peer ClusterPeer, decryptFn channels.GetDecryptedValueFn, ns notifications.Service,
+	m *metrics.Alertmanager) (*Alertmanager, error) {
 	am := &Alertmanager{
 		Settings:            cfg,
 		stopc:               make(chan struct{}),
@@ -211,6 +217,18 @@ func newAlertmanager(ctx context.Context, orgID int64, cfg *setting.Cfg, store A
 		return nil, fmt.Errorf("unable to initialize the alert provider component of alerting: %w", err)
 	}
 
+	// Initialize the delivery log and its retry queue
+	if deliveryStore != nil {
+		am.deliveryLog = newDeliveryLog(am.orgID, deliveryStore, cfg.UnifiedAlerting.DeliveryLog, am.logger.New("component", "deliveries"))
+		if am.deliveryLog.retryEnabled() {
+			am.wg.Add(1)
+			go func() {
+				defer am.wg.Done()
+				am.deliveryLog.run(am.stopc, am.integrationFor)
+			}()
+		}
+	}
+
 	return am, nil
 }
 
@@ -276,8 +294,8 @@ func (am *Alertmanager) SaveAndApplyDefaultConfig(ctx context.Context) error {
 }
 
 // SaveAndApplyConfig saves the configuration the database and applies the configuration to the Alertmanager.
-// It rollbacks the save if we fail to apply the configuration.
-func (am *Alertmanager) SaveAndApplyConfig(ctx context.Context, cfg *apimodels.PostableUserConfig) error {
+// It rollbacks the save if we fail to apply the configuration. createdBy is the login of the user that saved it.
+func (am *Alertmanager) SaveAndApplyConfig(ctx context.Context, cfg *apimodels.PostableUserConfig, createdBy string) error {
 	rawConfig, err := json.Marshal(&cfg)
 	if err != nil {
 		return fmt.Errorf("failed to serialize to the Alertmanager configuration: %w", err)
@@ -290,6 +308,7 @@ func (am *Alertmanager) SaveAndApplyConfig(ctx context.Context, cfg *apimodels.P
 		AlertmanagerConfiguration: string(rawConfig),
 		ConfigurationVersion:      fmt.Sprintf("v%d", ngmodels.AlertConfigurationVersion),
 		OrgID:                     am.orgID,
+		CreatedBy:                 createdBy,
 	}
 
 	err = am.Store.SaveAlertmanagerConfigurationWithCallback(ctx, cmd, func() error {
@@ -441,6 +460,7 @@ func (am *Alertmanager) applyConfig(cfg *apimodels.PostableUserConfig, rawConfig
 		am.inhibitor.Run()
 	}()
 
+	am.integrations = integrationsMap
 	am.config = cfg
 	am.configHash = md5.Sum(rawConfig)
 
@@ -473,11 +493,37 @@ func (am *Alertmanager) buildReceiverIntegrations(receiver *apimodels.PostableAp
 		if err != nil {
 			return nil, err
 		}
+		if am.deliveryLog != nil {
+			dn := am.deliveryLog.wrap(receiver.Name, i, r.UID, r.Type, n)
+			integrations = append(integrations, notify.NewIntegration(dn, dn, r.Type, i))
+			continue
+		}
 		integrations = append(integrations, notify.NewIntegration(n, n, r.Type, i))
 	}
 	return integrations, nil
 }
 
+// integrationFor returns the integration of the applied configuration with the given receiver name and index.
+func (am *Alertmanager) integrationFor(receiver string, idx int) (notify.Integration, bool) {
+	am.reloadConfigMtx.RLock()
+	defer am.reloadConfigMtx.RUnlock()
+	for _, i := range am.integrations[receiver] {
+		if i.Index() == idx {
+			return i, true
+		}
+	}
+	return notify.Integration{}, false
+}
+
+// ReplayNotificationDelivery sends the alerts of a notification delivery again with the same integration
+// and returns the new delivery.
+func (am *Alertmanager) ReplayNotificationDelivery(ctx context.Context, id int64) (*ngmodels.NotificationDelivery, error) {
+	if am.deliveryLog == nil {
+		return nil, ErrDeliveryLogDisabled
+	}
+	return am.deliveryLog.replay(ctx, id, am.integrationFor)
+}
+
 func (am *Alertmanager) buildReceiverIntegration(r *apimodels.PostableGrafanaReceiver, tmpl *template.Template) (channels.NotificationChannel, error) {
 	// secure settings are already encrypted at this point
 	secureSettings := make(map[string][]byte, len(r.SecureSettings))
@@ -89,7 +89,9 @@ func (moa *MultiOrgAlertmanager) GetAlertmanagerConfiguration(ctx context.Contex
 	return result, nil
 }
 
-func (moa *MultiOrgAlertmanager) ApplyAlertmanagerConfiguration(ctx context.Context, org int64, config definitions.PostableUserConfig) error {
+// ApplyAlertmanagerConfiguration validates, saves and applies the configuration of an organization. createdBy is the
+// login of the user that saved it.
+func (moa *MultiOrgAlertmanager) ApplyAlertmanagerConfiguration(ctx context.Context, org int64, config definitions.PostableUserConfig, createdBy string) error {
 	// Get the last known working configuration
 	query := models.GetLatestAlertmanagerConfigurationQuery{OrgID: org}
 	if err := moa.configStore.GetLatestAlertmanagerConfiguration(ctx, &query); err != nil {
@@ -115,7 +117,7 @@ func (moa *MultiOrgAlertmanager) ApplyAlertmanagerConfiguration(ctx context.Cont
 		}
 	}
 
-	if err := am.SaveAndApplyConfig(ctx, &config); err != nil {
+	if err := am.SaveAndApplyConfig(ctx, &config, createdBy); err != nil {
 		moa.logger.Error("unable to save and apply alertmanager configuration", "err", err)
 		return AlertmanagerConfigRejectedError{err}
 	}
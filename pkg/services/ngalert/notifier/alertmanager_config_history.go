@@ -0,0 +1,251 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// redactedSecureSetting replaces the values of secure settings in diffs. redactedChangedSecureSetting replaces
+// the new value of a secure setting that changed between the versions.
+const (
+	redactedSecureSetting        = "[REDACTED]"
+	redactedChangedSecureSetting = "[REDACTED, CHANGED]"
+)
+
+// GetAlertmanagerConfigurationHistory returns the versions of the configuration of an organization, the latest first.
+func (moa *MultiOrgAlertmanager) GetAlertmanagerConfigurationHistory(ctx context.Context, org int64, limit int) (definitions.GettableAlertmanagerConfigVersions, error) {
+	configs, err := moa.configStore.GetAlertmanagerConfigurationHistory(ctx, org, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the history of the configuration: %w", err)
+	}
+	result := make(definitions.GettableAlertmanagerConfigVersions, 0, len(configs))
+	for _, c := range configs {
+		result = append(result, definitions.GettableAlertmanagerConfigVersion{
+			ID:                c.ID,
+			CreatedBy:         c.CreatedBy,
+			CreatedAt:         time.Unix(c.CreatedAt, 0).UTC(),
+			Default:           c.Default,
+			ConfigurationHash: c.ConfigurationHash,
+		})
+	}
+	return result, nil
+}
+
+// GetAlertmanagerConfigurationForRollback returns a version of the configuration of an organization in the form
+// that ApplyAlertmanagerConfiguration accepts, with decrypted secure settings. Contact points that are not part of
+// the latest configuration anymore lose their UIDs, so that they are created again.
+func (moa *MultiOrgAlertmanager) GetAlertmanagerConfigurationForRollback(ctx context.Context, org int64, id int64) (definitions.PostableUserConfig, error) {
+	cfg, err := moa.decryptedConfigurationVersion(ctx, org, id)
+	if err != nil {
+		return definitions.PostableUserConfig{}, err
+	}
+
+	current := map[string]*definitions.PostableGrafanaReceiver{}
+	query := models.GetLatestAlertmanagerConfigurationQuery{OrgID: org}
+	if err := moa.configStore.GetLatestAlertmanagerConfiguration(ctx, &query); err == nil {
+		if latest, err := Load([]byte(query.Result.AlertmanagerConfiguration)); err == nil {
+			current = latest.GetGrafanaReceiverMap()
+		}
+	}
+	for _, r := range cfg.AlertmanagerConfig.Receivers {
+		for _, gr := range r.PostableGrafanaReceivers.GrafanaManagedReceivers {
+			if _, ok := current[gr.UID]; !ok {
+				gr.UID = ""
+			}
+		}
+	}
+	return *cfg, nil
+}
+
+// DiffAlertmanagerConfigurationVersions returns the differences between two versions of the configuration of an organization.
+// The values of secure settings are redacted, but changes of them are reported.
+func (moa *MultiOrgAlertmanager) DiffAlertmanagerConfigurationVersions(ctx context.Context, org int64, from, to int64) ([]definitions.AlertmanagerConfigChange, error) {
+	fromCfg, err := moa.decryptedConfigurationVersion(ctx, org, from)
+	if err != nil {
+		return nil, err
+	}
+	toCfg, err := moa.decryptedConfigurationVersion(ctx, org, to)
+	if err != nil {
+		return nil, err
+	}
+	redactSecureSettings(fromCfg, toCfg)
+
+	fromTree, err := configurationTree(fromCfg)
+	if err != nil {
+		return nil, err
+	}
+	toTree, err := configurationTree(toCfg)
+	if err != nil {
+		return nil, err
+	}
+	changes := []definitions.AlertmanagerConfigChange{}
+	diffConfigurationTrees("", fromTree, toTree, &changes)
+	return changes, nil
+}
+
+// decryptedConfigurationVersion returns a version of the configuration of an organization with decrypted secure settings.
+func (moa *MultiOrgAlertmanager) decryptedConfigurationVersion(ctx context.Context, org int64, id int64) (*definitions.PostableUserConfig, error) {
+	version, err := moa.configStore.GetAlertmanagerConfigurationVersion(ctx, org, id)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := Load([]byte(version.AlertmanagerConfiguration))
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal version %d of the Alertmanager configuration: %w", id, err)
+	}
+	for _, r := range cfg.AlertmanagerConfig.Receivers {
+		for _, gr := range r.PostableGrafanaReceivers.GrafanaManagedReceivers {
+			for k := range gr.SecureSettings {
+				v, err := moa.Crypto.getDecryptedSecret(gr, k)
+				if err != nil {
+					return nil, fmt.Errorf("failed to decrypt stored secure setting: %s: %w", k, err)
+				}
+				gr.SecureSettings[k] = v
+			}
+		}
+	}
+	return cfg, nil
+}
+
+// redactSecureSettings replaces the decrypted secure settings of two versions of a configuration, so that the
+// values are hidden but settings whose values differ between the versions still differ.
+func redactSecureSettings(from, to *definitions.PostableUserConfig) {
+	fromReceivers := from.GetGrafanaReceiverMap()
+	for _, gr := range to.GetGrafanaReceiverMap() {
+		previous, ok := fromReceivers[gr.UID]
+		for k, v := range gr.SecureSettings {
+			if ok && previous.SecureSettings[k] != v {
+				if _, existed := previous.SecureSettings[k]; existed {
+					gr.SecureSettings[k] = redactedChangedSecureSetting
+					continue
+				}
+			}
+			gr.SecureSettings[k] = redactedSecureSetting
+		}
+	}
+	for _, gr := range fromReceivers {
+		for k := range gr.SecureSettings {
+			gr.SecureSettings[k] = redactedSecureSetting
+		}
+	}
+}
+
+// configurationTree returns the JSON representation of a configuration as maps, slices and values.
+func configurationTree(cfg *definitions.PostableUserConfig) (interface{}, error) {
+	b, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var tree interface{}
+	if err := json.Unmarshal(b, &tree); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+// diffConfigurationTrees appends the differences between two JSON trees to changes. Objects of arrays are matched by
+// their name or UID if they all have one, and otherwise by their index.
+func diffConfigurationTrees(path string, from, to interface{}, changes *[]definitions.AlertmanagerConfigChange) {
+	switch f := from.(type) {
+	case map[string]interface{}:
+		if t, ok := to.(map[string]interface{}); ok {
+			diffConfigurationObjects(path, f, t, changes)
+			return
+		}
+	case []interface{}:
+		if t, ok := to.([]interface{}); ok {
+			fromKeys, toKeys := arrayElementKeys(f), arrayElementKeys(t)
+			if fromKeys == nil || toKeys == nil {
+				fromKeys, toKeys = arrayIndexKeys(f), arrayIndexKeys(t)
+			}
+			diffConfigurationObjects(path, keyedElements(fromKeys, f), keyedElements(toKeys, t), changes)
+			return
+		}
+	}
+	if !reflect.DeepEqual(from, to) {
+		*changes = append(*changes, definitions.AlertmanagerConfigChange{Path: path, Kind: definitions.AlertmanagerConfigChangeChanged, From: from, To: to})
+	}
+}
+
+func diffConfigurationObjects(path string, from, to map[string]interface{}, changes *[]definitions.AlertmanagerConfigChange) {
+	keys := make([]string, 0, len(from)+len(to))
+	for k := range from {
+		keys = append(keys, k)
+	}
+	for k := range to {
+		if _, ok := from[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		p := path + "/" + escapeJSONPointer(k)
+		f, inFrom := from[k]
+		t, inTo := to[k]
+		switch {
+		case !inTo:
+			*changes = append(*changes, definitions.AlertmanagerConfigChange{Path: p, Kind: definitions.AlertmanagerConfigChangeRemoved, From: f})
+		case !inFrom:
+			*changes = append(*changes, definitions.AlertmanagerConfigChange{Path: p, Kind: definitions.AlertmanagerConfigChangeAdded, To: t})
+		default:
+			diffConfigurationTrees(p, f, t, changes)
+		}
+	}
+}
+
+// arrayElementKeys returns the names or the UIDs of the elements of an array, or nil if not all elements have a unique one.
+func arrayElementKeys(elements []interface{}) []string {
+	for _, field := range []string{"name", "uid"} {
+		keys := make([]string, 0, len(elements))
+		seen := make(map[string]struct{}, len(elements))
+		for _, e := range elements {
+			o, ok := e.(map[string]interface{})
+			if !ok {
+				break
+			}
+			k, ok := o[field].(string)
+			if !ok || k == "" {
+				break
+			}
+			if _, ok := seen[k]; ok {
+				break
+			}
+			seen[k] = struct{}{}
+			keys = append(keys, k)
+		}
+		if len(keys) == len(elements) {
+			return keys
+		}
+	}
+	return nil
+}
+
+func arrayIndexKeys(elements []interface{}) []string {
+	keys := make([]string, 0, len(elements))
+	for i := range elements {
+		keys = append(keys, strconv.Itoa(i))
+	}
+	return keys
+}
+
+func keyedElements(keys []string, elements []interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(elements))
+	for i, e := range elements {
+		result[keys[i]] = e
+	}
+	return result
+}
+
+func escapeJSONPointer(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
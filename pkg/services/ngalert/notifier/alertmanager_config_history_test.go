@@ -0,0 +1,67 @@
+package notifier
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+)
+
+func TestDiffConfigurationTrees(t *testing.T) {
+	tree := func(s string) interface{} {
+		var v interface{}
+		require.NoError(t, json.Unmarshal([]byte(s), &v))
+		return v
+	}
+
+	testCases := []struct {
+		name     string
+		from, to string
+		expected []definitions.AlertmanagerConfigChange
+	}{
+		{
+			name:     "equal trees have no changes",
+			from:     `{"route": {"receiver": "a"}}`,
+			to:       `{"route": {"receiver": "a"}}`,
+			expected: []definitions.AlertmanagerConfigChange{},
+		},
+		{
+			name: "changed, added and removed values",
+			from: `{"route": {"receiver": "a", "group_wait": "30s"}}`,
+			to:   `{"route": {"receiver": "b", "repeat_interval": "4h"}}`,
+			expected: []definitions.AlertmanagerConfigChange{
+				{Path: "/route/group_wait", Kind: definitions.AlertmanagerConfigChangeRemoved, From: "30s"},
+				{Path: "/route/receiver", Kind: definitions.AlertmanagerConfigChangeChanged, From: "a", To: "b"},
+				{Path: "/route/repeat_interval", Kind: definitions.AlertmanagerConfigChangeAdded, To: "4h"},
+			},
+		},
+		{
+			name: "named elements are matched by name",
+			from: `{"receivers": [{"name": "a"}, {"name": "b", "x": 1}]}`,
+			to:   `{"receivers": [{"name": "b", "x": 2}, {"name": "c/d"}]}`,
+			expected: []definitions.AlertmanagerConfigChange{
+				{Path: "/receivers/a", Kind: definitions.AlertmanagerConfigChangeRemoved, From: map[string]interface{}{"name": "a"}},
+				{Path: "/receivers/b/x", Kind: definitions.AlertmanagerConfigChangeChanged, From: 1.0, To: 2.0},
+				{Path: "/receivers/c~1d", Kind: definitions.AlertmanagerConfigChangeAdded, To: map[string]interface{}{"name": "c/d"}},
+			},
+		},
+		{
+			name: "unnamed elements are matched by index",
+			from: `{"matchers": ["a=1", "b=2"]}`,
+			to:   `{"matchers": ["a=1"]}`,
+			expected: []definitions.AlertmanagerConfigChange{
+				{Path: "/matchers/1", Kind: definitions.AlertmanagerConfigChangeRemoved, From: "b=2"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			changes := []definitions.AlertmanagerConfigChange{}
+			diffConfigurationTrees("", tree(tc.from), tree(tc.to), &changes)
+			require.Equal(t, tc.expected, changes)
+		})
+	}
+}
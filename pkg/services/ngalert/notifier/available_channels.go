@@ -104,7 +104,7 @@ func GetAvailableNotifiers() []*alerting.NotifierPlugin {
 		},
 	}
 
-	return []*alerting.NotifierPlugin{
+	notifiers := []*alerting.NotifierPlugin{
 		{
 			Type:        "dingding",
 			Name:        "DingDing",
@@ -672,6 +672,67 @@ func GetAvailableNotifiers() []*alerting.NotifierPlugin {
 					InputType:    alerting.InputTypeText,
 					PropertyName: "maxAlerts",
 				},
+				{
+					Label:        "Body",
+					Description:  "Template of the request body. The Grafana webhook JSON is sent if it is empty.",
+					Element:      alerting.ElementTypeTextArea,
+					PropertyName: "body",
+				},
+				{
+					Label:        "Content Type",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypeText,
+					Placeholder:  "application/json",
+					PropertyName: "contentType",
+				},
+				{
+					Label:        "HTTP Headers",
+					Description:  "Custom headers of the request as a JSON object.",
+					Element:      alerting.ElementTypeTextArea,
+					Placeholder:  `{"X-Custom-Header": "value"}`,
+					PropertyName: "httpHeaders",
+				},
+				{
+					Label:        "HMAC Secret",
+					Description:  "Signs the request with HMAC-SHA256 of the timestamp header, a dot and the body.",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypePassword,
+					PropertyName: "hmacSecret",
+					Secure:       true,
+				},
+				{
+					Label:        "Signature Header",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypeText,
+					Placeholder:  "X-Grafana-Alerting-Signature",
+					PropertyName: "signatureHeader",
+				},
+				{
+					Label:        "Timestamp Header",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypeText,
+					Placeholder:  "X-Grafana-Alerting-Timestamp",
+					PropertyName: "timestampHeader",
+				},
+				{
+					Label:        "TLS Client Certificate",
+					Description:  "PEM encoded client certificate for mTLS.",
+					Element:      alerting.ElementTypeTextArea,
+					PropertyName: "tlsClientCert",
+				},
+				{
+					Label:        "TLS Client Key",
+					Description:  "PEM encoded key of the client certificate.",
+					Element:      alerting.ElementTypeTextArea,
+					PropertyName: "tlsClientKey",
+					Secure:       true,
+				},
+				{
+					Label:        "TLS CA Certificate",
+					Description:  "PEM encoded CA certificate that the certificate of the server is verified against.",
+					Element:      alerting.ElementTypeTextArea,
+					PropertyName: "tlsCACert",
+				},
 			},
 		},
 		{
@@ -910,5 +971,257 @@ func GetAvailableNotifiers() []*alerting.NotifierPlugin {
 				},
 			},
 		},
+		{
+			Type:        "jira",
+			Name:        "Jira",
+			Description: "Creates an issue in Jira for every alert group and updates it until the alert group resolves",
+			Heading:     "Jira settings",
+			Options: []alerting.NotifierOption{
+				{
+					Label:        "URL",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypeText,
+					Placeholder:  "https://example.atlassian.net",
+					PropertyName: "url",
+					Required:     true,
+				},
+				{
+					Label:        "User",
+					Description:  "The email address of the user for Jira Cloud, or the username for Jira Server.",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypeText,
+					PropertyName: "user",
+				},
+				{
+					Label:        "API token or password",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypePassword,
+					PropertyName: "password",
+					Secure:       true,
+				},
+				{
+					Label:        "Project key",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypeText,
+					Placeholder:  "OPS",
+					PropertyName: "project",
+					Required:     true,
+				},
+				{
+					Label:        "Issue type",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypeText,
+					Placeholder:  "Bug",
+					PropertyName: "issueType",
+				},
+				{
+					Label:        "Summary",
+					Description:  "Summary of the issue, limited to 255 characters. You can use template variables.",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypeText,
+					Placeholder:  `{{ template "default.title" . }}`,
+					PropertyName: "summary",
+				},
+				{
+					Label:        "Description",
+					Description:  "Description of the issue. You can use template variables.",
+					Element:      alerting.ElementTypeTextArea,
+					Placeholder:  `{{ template "default.message" . }}`,
+					PropertyName: "description",
+				},
+				{
+					Label:        "Labels",
+					Description:  "Comma separated labels that are added to new issues.",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypeText,
+					PropertyName: "labels",
+				},
+				{
+					Label:        "Resolve transition ID",
+					Description:  "ID of the workflow transition that resolves the issue when the alert group resolves. Without it, the issue is only updated.",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypeText,
+					PropertyName: "resolveTransition",
+				},
+			},
+		},
+		{
+			Type:        "servicenow",
+			Name:        "ServiceNow",
+			Description: "Opens an incident in ServiceNow for every alert group and resolves it when the alert group resolves",
+			Heading:     "ServiceNow settings",
+			Options: []alerting.NotifierOption{
+				{
+					Label:        "Instance URL",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypeText,
+					Placeholder:  "https://example.service-now.com",
+					PropertyName: "url",
+					Required:     true,
+				},
+				{
+					Label:        "User",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypeText,
+					PropertyName: "user",
+					Required:     true,
+				},
+				{
+					Label:        "Password",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypePassword,
+					PropertyName: "password",
+					Required:     true,
+					Secure:       true,
+				},
+				{
+					Label:        "Table",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypeText,
+					Placeholder:  "incident",
+					PropertyName: "table",
+				},
+				{
+					Label:        "Short description",
+					Description:  "Short description of the incident, limited to 160 characters. You can use template variables.",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypeText,
+					Placeholder:  `{{ template "default.title" . }}`,
+					PropertyName: "shortDescription",
+				},
+				{
+					Label:        "Description",
+					Description:  "Description of the incident. You can use template variables.",
+					Element:      alerting.ElementTypeTextArea,
+					Placeholder:  `{{ template "default.message" . }}`,
+					PropertyName: "description",
+				},
+				{
+					Label:        "Assignment group",
+					Description:  "The sys_id or name of the group the incident is assigned to.",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypeText,
+					PropertyName: "assignmentGroup",
+				},
+				{
+					Label:        "Close code",
+					Description:  "Resolution code of the incident when the alert group resolves.",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypeText,
+					Placeholder:  "Solved (Permanently)",
+					PropertyName: "closeCode",
+				},
+			},
+		},
+		{
+			Type:        "mattermost",
+			Name:        "Mattermost",
+			Description: "Sends notifications to a Mattermost incoming webhook",
+			Heading:     "Mattermost settings",
+			Options: []alerting.NotifierOption{
+				{
+					Label:        "Webhook URL",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypeText,
+					Placeholder:  "https://mattermost.example.com/hooks/xxxxxxxx",
+					PropertyName: "url",
+					Required:     true,
+					Secure:       true,
+				},
+				{
+					Label:        "Channel",
+					Description:  "Overrides the channel of the webhook, if the webhook allows it.",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypeText,
+					Placeholder:  "town-square",
+					PropertyName: "channel",
+				},
+				{
+					Label:        "Username",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypeText,
+					Placeholder:  "Grafana",
+					PropertyName: "username",
+				},
+				{
+					Label:        "Icon URL",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypeText,
+					PropertyName: "icon_url",
+				},
+				{
+					Label:        "Title",
+					Description:  "Templated title of the message",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypeText,
+					Placeholder:  `{{ template "default.title" . }}`,
+					PropertyName: "title",
+				},
+				{
+					Label:        "Message",
+					Description:  "Markdown message. You can use template variables.",
+					Element:      alerting.ElementTypeTextArea,
+					Placeholder:  `{{ template "default.message" . }}`,
+					PropertyName: "message",
+				},
+			},
+		},
+		{
+			Type:        "zulip",
+			Name:        "Zulip",
+			Description: "Sends notifications to a topic of a Zulip stream",
+			Heading:     "Zulip settings",
+			Options: []alerting.NotifierOption{
+				{
+					Label:        "Server URL",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypeText,
+					Placeholder:  "https://example.zulipchat.com",
+					PropertyName: "url",
+					Required:     true,
+				},
+				{
+					Label:        "Bot email",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypeText,
+					Placeholder:  "grafana-bot@example.zulipchat.com",
+					PropertyName: "botEmail",
+					Required:     true,
+				},
+				{
+					Label:        "API key",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypePassword,
+					PropertyName: "apiKey",
+					Required:     true,
+					Secure:       true,
+				},
+				{
+					Label:        "Stream",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypeText,
+					Placeholder:  "alerts",
+					PropertyName: "stream",
+					Required:     true,
+				},
+				{
+					Label:        "Topic",
+					Description:  "Topic of the message, limited to 60 characters. By default, the notifications of an alert group are sent to the same topic.",
+					Element:      alerting.ElementTypeInput,
+					InputType:    alerting.InputTypeText,
+					Placeholder:  `{{ .GroupLabels.SortedPairs.Values | join " " }}`,
+					PropertyName: "topic",
+				},
+				{
+					Label:        "Message",
+					Description:  "Markdown message. You can use template variables.",
+					Element:      alerting.ElementTypeTextArea,
+					Placeholder:  `{{ template "default.message" . }}`,
+					PropertyName: "message",
+				},
+			},
+		},
 	}
+
+	return append(notifiers, pluginNotifiers()...)
 }
@@ -11,6 +11,9 @@ import (
 
 const DefaultMessageTitleEmbed = `{{ template "default.title" . }}`
 
+// DefaultMessageEmbed is the default template of the message of a notification.
+const DefaultMessageEmbed = `{{ template "default.message" . }}`
+
 var DefaultTemplateString = `
 {{ define "__subject" }}[{{ .Status | toUpper }}{{ if eq .Status "firing" }}:{{ .Alerts.Firing | len }}{{ if gt (.Alerts.Resolved | len) 0 }}, RESOLVED:{{ .Alerts.Resolved | len }}{{ end }}{{ end }}] {{ .GroupLabels.SortedPairs.Values | join " " }} {{ if gt (len .CommonLabels) (len .GroupLabels) }}({{ with .CommonLabels.Remove .GroupLabels.Names }}{{ .Values | join " " }}{{ end }}){{ end }}{{ end }}
 
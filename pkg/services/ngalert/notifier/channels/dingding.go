@@ -32,7 +32,7 @@ func NewDingDingConfig(config *NotificationChannelConfig) (*DingDingConfig, erro
 	return &DingDingConfig{
 		NotificationChannelConfig: config,
 		MsgType:                   config.Settings.Get("msgType").MustString(defaultDingdingMsgType),
-		Message:                   config.Settings.Get("message").MustString(`{{ template "default.message" .}}`),
+		Message:                   config.Settings.Get("message").MustString(DefaultMessageEmbed),
 		URL:                       config.Settings.Get("url").MustString(),
 	}, nil
 }
@@ -95,8 +95,7 @@ func (dd *DingDingNotifier) Notify(ctx context.Context, as ...*types.Alert) (boo
 	var tmplErr error
 	tmpl, _ := TmplText(ctx, dd.tmpl, as, dd.log, &tmplErr)
 
-	message := tmpl(dd.Message)
-	title := tmpl(DefaultMessageTitleEmbed)
+	title, message := dingDingNotification(tmpl, dd.Message)
 
 	var bodyMsg map[string]interface{}
 	if tmpl(dd.MsgType) == "actionCard" {
@@ -153,3 +152,9 @@ func (dd *DingDingNotifier) Notify(ctx context.Context, as ...*types.Alert) (boo
 func (dd *DingDingNotifier) SendResolved() bool {
 	return !dd.GetDisableResolveMessage()
 }
+
+// dingDingNotification renders the title and the text of a message.
+func dingDingNotification(tmpl func(string) string, message string) (string, string) {
+	text := tmpl(message)
+	return tmpl(DefaultMessageTitleEmbed), text
+}
@@ -61,7 +61,7 @@ func NewDiscordConfig(config *NotificationChannelConfig) (*DiscordConfig, error)
 	}
 	return &DiscordConfig{
 		NotificationChannelConfig: config,
-		Content:                   config.Settings.Get("message").MustString(`{{ template "default.message" . }}`),
+		Content:                   config.Settings.Get("message").MustString(DefaultMessageEmbed),
 		AvatarURL:                 config.Settings.Get("avatar_url").MustString(),
 		WebhookURL:                discordURL,
 		UseDiscordUsername:        config.Settings.Get("use_discord_username").MustBool(false),
@@ -112,8 +112,9 @@ func (d DiscordNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool,
 	var tmplErr error
 	tmpl, _ := TmplText(ctx, d.tmpl, as, d.log, &tmplErr)
 
+	title, content := discordNotification(tmpl, d.Content)
 	if d.Content != "" {
-		bodyJSON.Set("content", tmpl(d.Content))
+		bodyJSON.Set("content", content)
 		if tmplErr != nil {
 			d.log.Warn("failed to template Discord notification content", "err", tmplErr.Error())
 			// Reset tmplErr for templating other fields.
@@ -136,7 +137,7 @@ func (d DiscordNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool,
 	}
 
 	linkEmbed := simplejson.New()
-	linkEmbed.Set("title", tmpl(DefaultMessageTitleEmbed))
+	linkEmbed.Set("title", title)
 	linkEmbed.Set("footer", footer)
 	linkEmbed.Set("type", "rich")
 
@@ -300,3 +301,10 @@ func (d DiscordNotifier) buildRequest(ctx context.Context, url string, body []by
 	cmd.Body = b.String()
 	return cmd, nil
 }
+
+// discordNotification renders the title of the embed and the content of a message. The title is rendered first, so
+// that an invalid content does not prevent it from being rendered.
+func discordNotification(tmpl func(string) string, content string) (string, string) {
+	t := tmpl(DefaultMessageTitleEmbed)
+	return t, tmpl(content)
+}
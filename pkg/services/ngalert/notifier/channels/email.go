@@ -93,7 +93,7 @@ func (en *EmailNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool,
 	var tmplErr error
 	tmpl, data := TmplText(ctx, en.tmpl, as, en.log, &tmplErr)
 
-	subject := tmpl(en.Subject)
+	subject, message := emailNotification(tmpl, en.Subject, en.Message)
 
 	alertPageURL := en.tmpl.ExternalURL.String()
 	ruleURL := en.tmpl.ExternalURL.String()
@@ -113,7 +113,7 @@ func (en *EmailNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool,
 			Subject: subject,
 			Data: map[string]interface{}{
 				"Title":             subject,
-				"Message":           tmpl(en.Message),
+				"Message":           message,
 				"Status":            data.Status,
 				"Alerts":            data.Alerts,
 				"GroupLabels":       data.GroupLabels,
@@ -165,3 +165,9 @@ func (en *EmailNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool,
 func (en *EmailNotifier) SendResolved() bool {
 	return !en.GetDisableResolveMessage()
 }
+
+// emailNotification renders the subject and the message of an email.
+func emailNotification(tmpl func(string) string, subject, message string) (string, string) {
+	s := tmpl(subject)
+	return s, tmpl(message)
+}
@@ -52,12 +52,15 @@ var receiverFactories = map[string]func(FactoryConfig) (NotificationChannel, err
 	"discord":                 DiscordFactory,
 	"email":                   EmailFactory,
 	"googlechat":              GoogleChatFactory,
+	"jira":                    JiraFactory,
 	"kafka":                   KafkaFactory,
 	"line":                    LineFactory,
+	"mattermost":              MattermostFactory,
 	"opsgenie":                OpsgenieFactory,
 	"pagerduty":               PagerdutyFactory,
 	"pushover":                PushoverFactory,
 	"sensugo":                 SensuGoFactory,
+	"servicenow":              ServiceNowFactory,
 	"slack":                   SlackFactory,
 	"teams":                   TeamsFactory,
 	"telegram":                TelegramFactory,
@@ -65,10 +68,14 @@ var receiverFactories = map[string]func(FactoryConfig) (NotificationChannel, err
 	"victorops":               VictorOpsFactory,
 	"webhook":                 WebHookFactory,
 	"wecom":                   WeComFactory,
+	"zulip":                   ZulipFactory,
 }
 
 func Factory(receiverType string) (func(FactoryConfig) (NotificationChannel, error), bool) {
 	receiverType = strings.ToLower(receiverType)
 	factory, exists := receiverFactories[receiverType]
+	if !exists {
+		return pluginFactory(receiverType)
+	}
 	return factory, exists
 }
@@ -55,7 +55,7 @@ func NewGoogleChatConfig(config *NotificationChannelConfig) (*GoogleChatConfig,
 	return &GoogleChatConfig{
 		NotificationChannelConfig: config,
 		URL:                       url,
-		Content:                   config.Settings.Get("message").MustString(`{{ template "default.message" . }}`),
+		Content:                   config.Settings.Get("message").MustString(DefaultMessageEmbed),
 	}, nil
 }
 
@@ -86,7 +86,8 @@ func (gcn *GoogleChatNotifier) Notify(ctx context.Context, as ...*types.Alert) (
 
 	widgets := []widget{}
 
-	if msg := tmpl(gcn.content); msg != "" {
+	title, msg := googleChatNotification(tmpl, gcn.content)
+	if msg != "" {
 		// Add a text paragraph widget for the message if there is a message.
 		// Google Chat API doesn't accept an empty text property.
 		widgets = append(widgets, textParagraphWidget{
@@ -131,12 +132,12 @@ func (gcn *GoogleChatNotifier) Notify(ctx context.Context, as ...*types.Alert) (
 
 	// Nest the required structs.
 	res := &outerStruct{
-		PreviewText:  tmpl(DefaultMessageTitleEmbed),
-		FallbackText: tmpl(DefaultMessageTitleEmbed),
+		PreviewText:  title,
+		FallbackText: title,
 		Cards: []card{
 			{
 				Header: header{
-					Title: tmpl(DefaultMessageTitleEmbed),
+					Title: title,
 				},
 				Sections: []section{
 					{
@@ -296,3 +297,10 @@ type onClick struct {
 type openLink struct {
 	URL string `json:"url"`
 }
+
+// googleChatNotification renders the title of the card and its message. The title is rendered first, so that an
+// invalid message does not prevent it from being rendered.
+func googleChatNotification(tmpl func(string) string, message string) (string, string) {
+	t := tmpl(DefaultMessageTitleEmbed)
+	return t, tmpl(message)
+}
@@ -0,0 +1,244 @@
+package channels
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/models"
+)
+
+const jiraMaxSummaryLen = 255
+
+// JiraNotifier is responsible for creating an issue in Jira when an alert group starts firing,
+// and for updating the same issue until the alert group resolves.
+type JiraNotifier struct {
+	*Base
+	URL               string
+	User              string
+	Password          string
+	Project           string
+	IssueType         string
+	Summary           string
+	Description       string
+	Labels            []string
+	ResolveTransition string
+	tmpl              *template.Template
+	log               log.Logger
+}
+
+type JiraConfig struct {
+	*NotificationChannelConfig
+	URL               string
+	User              string
+	Password          string
+	Project           string
+	IssueType         string
+	Summary           string
+	Description       string
+	Labels            []string
+	ResolveTransition string
+}
+
+func JiraFactory(fc FactoryConfig) (NotificationChannel, error) {
+	cfg, err := NewJiraConfig(fc.Config, fc.DecryptFunc)
+	if err != nil {
+		return nil, receiverInitError{
+			Reason: err.Error(),
+			Cfg:    *fc.Config,
+		}
+	}
+	return NewJiraNotifier(cfg, fc.Template), nil
+}
+
+func NewJiraConfig(config *NotificationChannelConfig, decryptFunc GetDecryptedValueFn) (*JiraConfig, error) {
+	jiraURL := config.Settings.Get("url").MustString()
+	if jiraURL == "" {
+		return nil, errors.New("could not find Jira URL in settings")
+	}
+	project := config.Settings.Get("project").MustString()
+	if project == "" {
+		return nil, errors.New("could not find Jira project key in settings")
+	}
+	var labels []string
+	for _, l := range strings.Split(config.Settings.Get("labels").MustString(), ",") {
+		if l = strings.TrimSpace(l); l != "" {
+			labels = append(labels, l)
+		}
+	}
+	return &JiraConfig{
+		NotificationChannelConfig: config,
+		URL:                       jiraURL,
+		User:                      config.Settings.Get("user").MustString(),
+		Password:                  decryptFunc(context.Background(), config.SecureSettings, "password", config.Settings.Get("password").MustString()),
+		Project:                   project,
+		IssueType:                 config.Settings.Get("issueType").MustString("Bug"),
+		Summary:                   config.Settings.Get("summary").MustString(DefaultMessageTitleEmbed),
+		Description:               config.Settings.Get("description").MustString(DefaultMessageEmbed),
+		Labels:                    labels,
+		ResolveTransition:         config.Settings.Get("resolveTransition").MustString(),
+	}, nil
+}
+
+// NewJiraNotifier is the constructor for the Jira notifier
+func NewJiraNotifier(config *JiraConfig, t *template.Template) *JiraNotifier {
+	return &JiraNotifier{
+		Base: NewBase(&models.AlertNotification{
+			Uid:                   config.UID,
+			Name:                  config.Name,
+			Type:                  config.Type,
+			DisableResolveMessage: config.DisableResolveMessage,
+			Settings:              config.Settings,
+		}),
+		URL:               config.URL,
+		User:              config.User,
+		Password:          config.Password,
+		Project:           config.Project,
+		IssueType:         config.IssueType,
+		Summary:           config.Summary,
+		Description:       config.Description,
+		Labels:            config.Labels,
+		ResolveTransition: config.ResolveTransition,
+		tmpl:              t,
+		log:               log.New("alerting.notifier.jira"),
+	}
+}
+
+// Notify creates or updates the Jira issue of the alert group. The issue is found by a label that is derived
+// from the group key, so repeated notifications of the same alert group update the same issue.
+func (jn *JiraNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	key, err := notify.ExtractGroupKey(ctx)
+	if err != nil {
+		return false, err
+	}
+	groupLabel := fmt.Sprintf("ALERT{%s}", key.Hash())
+	jn.log.Debug("executing Jira notification", "notification", jn.Name, "label", groupLabel)
+
+	var tmplErr error
+	tmpl, _ := TmplText(ctx, jn.tmpl, as, jn.log, &tmplErr)
+	summary, description := jiraNotification(tmpl, jn.Summary, jn.Description)
+	fields := map[string]interface{}{
+		"summary":     summary,
+		"description": description,
+	}
+	if tmplErr != nil {
+		jn.log.Warn("failed to template Jira message", "err", tmplErr.Error())
+	}
+
+	issueKey, err := jn.findOpenIssue(ctx, groupLabel)
+	if err != nil {
+		return false, fmt.Errorf("search Jira issue: %w", err)
+	}
+
+	if types.Alerts(as...).Status() == model.AlertResolved {
+		if issueKey == "" {
+			jn.log.Debug("no open Jira issue to resolve", "label", groupLabel)
+			return true, nil
+		}
+		if err := jn.updateIssue(ctx, issueKey, fields); err != nil {
+			return false, err
+		}
+		if jn.ResolveTransition == "" {
+			return true, nil
+		}
+		body := map[string]interface{}{"transition": map[string]string{"id": jn.ResolveTransition}}
+		if _, err := jn.send(ctx, http.MethodPost, "/rest/api/2/issue/"+issueKey+"/transitions", body); err != nil {
+			return false, fmt.Errorf("resolve Jira issue %s: %w", issueKey, err)
+		}
+		return true, nil
+	}
+
+	if issueKey != "" {
+		if err := jn.updateIssue(ctx, issueKey, fields); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	fields["project"] = map[string]string{"key": jn.Project}
+	fields["issuetype"] = map[string]string{"name": jn.IssueType}
+	fields["labels"] = append([]string{groupLabel}, jn.Labels...)
+	resp, err := jn.send(ctx, http.MethodPost, "/rest/api/2/issue", map[string]interface{}{"fields": fields})
+	if err != nil {
+		return false, fmt.Errorf("create Jira issue: %w", err)
+	}
+	var created struct {
+		Key string `json:"key"`
+	}
+	if err := json.Unmarshal(resp, &created); err != nil {
+		jn.log.Warn("failed to parse the response of Jira", "err", err)
+	}
+	jn.log.Debug("created Jira issue", "issue", created.Key, "label", groupLabel)
+	return true, nil
+}
+
+// findOpenIssue returns the key of the most recent issue of the alert group that is not done,
+// or an empty string if there is none.
+func (jn *JiraNotifier) findOpenIssue(ctx context.Context, groupLabel string) (string, error) {
+	body := map[string]interface{}{
+		"jql":        fmt.Sprintf(`project = %q AND labels = %q AND statusCategory != Done ORDER BY created DESC`, jn.Project, groupLabel),
+		"fields":     []string{"status"},
+		"maxResults": 1,
+	}
+	resp, err := jn.send(ctx, http.MethodPost, "/rest/api/2/search", body)
+	if err != nil {
+		return "", err
+	}
+	var result struct {
+		Issues []struct {
+			Key string `json:"key"`
+		} `json:"issues"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return "", fmt.Errorf("failed to parse the response of Jira: %w", err)
+	}
+	if len(result.Issues) == 0 {
+		return "", nil
+	}
+	return result.Issues[0].Key, nil
+}
+
+func (jn *JiraNotifier) updateIssue(ctx context.Context, issueKey string, fields map[string]interface{}) error {
+	if _, err := jn.send(ctx, http.MethodPut, "/rest/api/2/issue/"+issueKey, map[string]interface{}{"fields": fields}); err != nil {
+		return fmt.Errorf("update Jira issue %s: %w", issueKey, err)
+	}
+	return nil
+}
+
+func (jn *JiraNotifier) send(ctx context.Context, method, path string, body interface{}) ([]byte, error) {
+	u, err := url.Parse(joinUrlPath(jn.URL, path, jn.log))
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	return sendHTTPRequest(ctx, u, httpCfg{
+		method:   method,
+		body:     b,
+		user:     jn.User,
+		password: jn.Password,
+	}, jn.log)
+}
+
+func (jn *JiraNotifier) SendResolved() bool {
+	return !jn.GetDisableResolveMessage()
+}
+
+// jiraNotification renders the summary and the description of an issue. The summary is shortened to the maximum
+// length that Jira accepts.
+func jiraNotification(tmpl func(string) string, summary, description string) (string, string) {
+	s := truncateRunes(tmpl(summary), jiraMaxSummaryLen)
+	return s, tmpl(description)
+}
@@ -0,0 +1,200 @@
+package channels
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/secrets/fakes"
+	secretsManager "github.com/grafana/grafana/pkg/services/secrets/manager"
+)
+
+func TestJiraNotifier(t *testing.T) {
+	tmpl := templateForTests(t)
+
+	externalURL, err := url.Parse("http://localhost")
+	require.NoError(t, err)
+	tmpl.ExternalURL = externalURL
+
+	firing := []*types.Alert{
+		{
+			Alert: model.Alert{
+				Labels:      model.LabelSet{"alertname": "alert1", "lbl1": "val1"},
+				Annotations: model.LabelSet{"ann1": "annv1"},
+			},
+		},
+	}
+	resolved := []*types.Alert{
+		{
+			Alert: model.Alert{
+				Labels:      model.LabelSet{"alertname": "alert1", "lbl1": "val1"},
+				Annotations: model.LabelSet{"ann1": "annv1"},
+				StartsAt:    time.Now().Add(-time.Hour),
+				EndsAt:      time.Now().Add(-time.Minute),
+			},
+		},
+	}
+	groupLabel := fmt.Sprintf("ALERT{%s}", notify.Key("alertname").Hash())
+
+	cases := []struct {
+		name         string
+		settings     string
+		alerts       []*types.Alert
+		openIssue    string
+		expRequests  []string
+		expLastBody  string
+		expInitError string
+	}{
+		{
+			name: "Creates an issue when the alert group starts firing",
+			settings: `{
+				"user": "user@example.com",
+				"password": "token",
+				"project": "OPS",
+				"labels": "grafana, production",
+				"description": "{{ len .Alerts.Firing }} firing"
+			}`,
+			alerts:      firing,
+			expRequests: []string{"POST /rest/api/2/search", "POST /rest/api/2/issue"},
+			expLastBody: `{"fields": {
+				"project": {"key": "OPS"},
+				"issuetype": {"name": "Bug"},
+				"summary": "[FIRING:1]  (val1)",
+				"description": "1 firing",
+				"labels": ["` + groupLabel + `", "grafana", "production"]
+			}}`,
+		}, {
+			name: "Updates the open issue of the alert group",
+			settings: `{
+				"user": "user@example.com",
+				"password": "token",
+				"project": "OPS",
+				"issueType": "Incident",
+				"description": "{{ len .Alerts.Firing }} firing"
+			}`,
+			alerts:      firing,
+			openIssue:   "OPS-1",
+			expRequests: []string{"POST /rest/api/2/search", "PUT /rest/api/2/issue/OPS-1"},
+			expLastBody: `{"fields": {"summary": "[FIRING:1]  (val1)", "description": "1 firing"}}`,
+		}, {
+			name: "Updates and transitions the open issue when the alert group resolves",
+			settings: `{
+				"user": "user@example.com",
+				"password": "token",
+				"project": "OPS",
+				"resolveTransition": "31"
+			}`,
+			alerts:      resolved,
+			openIssue:   "OPS-1",
+			expRequests: []string{"POST /rest/api/2/search", "PUT /rest/api/2/issue/OPS-1", "POST /rest/api/2/issue/OPS-1/transitions"},
+			expLastBody: `{"transition": {"id": "31"}}`,
+		}, {
+			name: "Does nothing when a resolved alert group has no open issue",
+			settings: `{
+				"user": "user@example.com",
+				"password": "token",
+				"project": "OPS",
+				"resolveTransition": "31"
+			}`,
+			alerts:      resolved,
+			expRequests: []string{"POST /rest/api/2/search"},
+		}, {
+			name: "Truncates a long summary by characters",
+			settings: `{
+				"user": "user@example.com",
+				"password": "token",
+				"project": "OPS",
+				"summary": "` + strings.Repeat("ä", 300) + `",
+				"description": "{{ len .Alerts.Firing }} firing"
+			}`,
+			alerts:      firing,
+			openIssue:   "OPS-1",
+			expRequests: []string{"POST /rest/api/2/search", "PUT /rest/api/2/issue/OPS-1"},
+			expLastBody: `{"fields": {"summary": "` + strings.Repeat("ä", 252) + `...", "description": "1 firing"}}`,
+		}, {
+			name:         "Error in initing: missing project",
+			settings:     `{"url": "https://example.atlassian.net"}`,
+			expInitError: `could not find Jira project key in settings`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var requests, bodies []string
+			var user, password string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				requests = append(requests, r.Method+" "+r.URL.Path)
+				bodies = append(bodies, string(b))
+				user, password, _ = r.BasicAuth()
+				switch {
+				case r.URL.Path == "/rest/api/2/search" && c.openIssue != "":
+					_, _ = fmt.Fprintf(w, `{"issues": [{"key": %q}]}`, c.openIssue)
+				case r.URL.Path == "/rest/api/2/search":
+					_, _ = w.Write([]byte(`{"issues": []}`))
+				case r.Method == http.MethodPost && r.URL.Path == "/rest/api/2/issue":
+					w.WriteHeader(http.StatusCreated)
+					_, _ = w.Write([]byte(`{"id": "10001", "key": "OPS-2"}`))
+				default:
+					w.WriteHeader(http.StatusNoContent)
+				}
+			}))
+			defer server.Close()
+
+			settingsJSON, err := simplejson.NewJson([]byte(c.settings))
+			require.NoError(t, err)
+			if _, ok := settingsJSON.CheckGet("url"); !ok {
+				settingsJSON.Set("url", server.URL)
+			}
+			secureSettings := make(map[string][]byte)
+
+			m := &NotificationChannelConfig{
+				Name:           "jira_testing",
+				Type:           "jira",
+				Settings:       settingsJSON,
+				SecureSettings: secureSettings,
+			}
+
+			secretsService := secretsManager.SetupTestService(t, fakes.NewFakeSecretsStore())
+			decryptFn := secretsService.GetDecryptedValue
+			cfg, err := NewJiraConfig(m, decryptFn)
+			if c.expInitError != "" {
+				require.Error(t, err)
+				require.Equal(t, c.expInitError, err.Error())
+				return
+			}
+			require.NoError(t, err)
+
+			ctx := notify.WithGroupKey(context.Background(), "alertname")
+			ctx = notify.WithGroupLabels(ctx, model.LabelSet{"alertname": ""})
+			pn := NewJiraNotifier(cfg, tmpl)
+			ok, err := pn.Notify(ctx, c.alerts...)
+			require.NoError(t, err)
+			require.True(t, ok)
+
+			require.Equal(t, c.expRequests, requests)
+			require.JSONEq(t, fmt.Sprintf(`{
+				"jql": "project = \"OPS\" AND labels = \"%s\" AND statusCategory != Done ORDER BY created DESC",
+				"fields": ["status"],
+				"maxResults": 1
+			}`, groupLabel), bodies[0])
+			if c.expLastBody != "" {
+				require.JSONEq(t, c.expLastBody, bodies[len(bodies)-1])
+			}
+			require.Equal(t, "user@example.com", user)
+			require.Equal(t, "token", password)
+		})
+	}
+}
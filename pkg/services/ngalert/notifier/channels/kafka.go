@@ -93,11 +93,12 @@ func (kn *KafkaNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool,
 	var tmplErr error
 	tmpl, _ := TmplText(ctx, kn.tmpl, as, kn.log, &tmplErr)
 
+	description, details := kafkaNotification(tmpl)
 	bodyJSON := simplejson.New()
 	bodyJSON.Set("alert_state", state)
-	bodyJSON.Set("description", tmpl(DefaultMessageTitleEmbed))
+	bodyJSON.Set("description", description)
 	bodyJSON.Set("client", "Grafana")
-	bodyJSON.Set("details", tmpl(`{{ template "default.message" . }}`))
+	bodyJSON.Set("details", details)
 
 	ruleURL := joinUrlPath(kn.tmpl.ExternalURL.String(), "/alerting/list", kn.log)
 	bodyJSON.Set("client_url", ruleURL)
@@ -146,3 +147,9 @@ func (kn *KafkaNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool,
 func (kn *KafkaNotifier) SendResolved() bool {
 	return !kn.GetDisableResolveMessage()
 }
+
+// kafkaNotification renders the description and the details of a record.
+func kafkaNotification(tmpl func(string) string) (string, string) {
+	d := tmpl(DefaultMessageTitleEmbed)
+	return d, tmpl(DefaultMessageEmbed)
+}
@@ -82,12 +82,7 @@ func (ln *LineNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool, e
 	var tmplErr error
 	tmpl, _ := TmplText(ctx, ln.tmpl, as, ln.log, &tmplErr)
 
-	body := fmt.Sprintf(
-		"%s\n%s\n\n%s",
-		tmpl(DefaultMessageTitleEmbed),
-		ruleURL,
-		tmpl(`{{ template "default.message" . }}`),
-	)
+	body := lineMessage(tmpl, ruleURL)
 	if tmplErr != nil {
 		ln.log.Warn("failed to template Line message", "err", tmplErr.Error())
 	}
@@ -116,3 +111,13 @@ func (ln *LineNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool, e
 func (ln *LineNotifier) SendResolved() bool {
 	return !ln.GetDisableResolveMessage()
 }
+
+// lineMessage renders the message of a notification.
+func lineMessage(tmpl func(string) string, ruleURL string) string {
+	return fmt.Sprintf(
+		"%s\n%s\n\n%s",
+		tmpl(DefaultMessageTitleEmbed),
+		ruleURL,
+		tmpl(DefaultMessageEmbed),
+	)
+}
@@ -0,0 +1,168 @@
+package channels
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/notifications"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+// mattermostMaxTextLen is the maximum length of the text of a Mattermost post.
+const mattermostMaxTextLen = 16383
+
+// MattermostNotifier is responsible for sending alert notifications to a Mattermost incoming webhook.
+type MattermostNotifier struct {
+	*Base
+	URL      string
+	Channel  string
+	Username string
+	IconURL  string
+	Title    string
+	Message  string
+	tmpl     *template.Template
+	log      log.Logger
+	ns       notifications.WebhookSender
+}
+
+type MattermostConfig struct {
+	*NotificationChannelConfig
+	URL      string
+	Channel  string
+	Username string
+	IconURL  string
+	Title    string
+	Message  string
+}
+
+// mattermostMessage is the payload of a Mattermost incoming webhook.
+type mattermostMessage struct {
+	Channel     string                 `json:"channel,omitempty"`
+	Username    string                 `json:"username,omitempty"`
+	IconURL     string                 `json:"icon_url,omitempty"`
+	Attachments []mattermostAttachment `json:"attachments"`
+}
+
+// mattermostAttachment is a message attachment of Mattermost. The text supports Markdown.
+type mattermostAttachment struct {
+	Fallback   string `json:"fallback"`
+	Color      string `json:"color"`
+	Title      string `json:"title"`
+	TitleLink  string `json:"title_link"`
+	Text       string `json:"text"`
+	Footer     string `json:"footer"`
+	FooterIcon string `json:"footer_icon"`
+}
+
+func MattermostFactory(fc FactoryConfig) (NotificationChannel, error) {
+	cfg, err := NewMattermostConfig(fc.Config, fc.DecryptFunc)
+	if err != nil {
+		return nil, receiverInitError{
+			Reason: err.Error(),
+			Cfg:    *fc.Config,
+		}
+	}
+	return NewMattermostNotifier(cfg, fc.NotificationService, fc.Template), nil
+}
+
+func NewMattermostConfig(config *NotificationChannelConfig, decryptFunc GetDecryptedValueFn) (*MattermostConfig, error) {
+	url := decryptFunc(context.Background(), config.SecureSettings, "url", config.Settings.Get("url").MustString())
+	if url == "" {
+		return nil, errors.New("could not find webhook URL in settings")
+	}
+	return &MattermostConfig{
+		NotificationChannelConfig: config,
+		URL:                       url,
+		Channel:                   config.Settings.Get("channel").MustString(),
+		Username:                  config.Settings.Get("username").MustString("Grafana"),
+		IconURL:                   config.Settings.Get("icon_url").MustString(),
+		Title:                     config.Settings.Get("title").MustString(DefaultMessageTitleEmbed),
+		Message:                   config.Settings.Get("message").MustString(DefaultMessageEmbed),
+	}, nil
+}
+
+// NewMattermostNotifier is the constructor for the Mattermost notifier
+func NewMattermostNotifier(config *MattermostConfig, ns notifications.WebhookSender, t *template.Template) *MattermostNotifier {
+	return &MattermostNotifier{
+		Base: NewBase(&models.AlertNotification{
+			Uid:                   config.UID,
+			Name:                  config.Name,
+			Type:                  config.Type,
+			DisableResolveMessage: config.DisableResolveMessage,
+			Settings:              config.Settings,
+		}),
+		URL:      config.URL,
+		Channel:  config.Channel,
+		Username: config.Username,
+		IconURL:  config.IconURL,
+		Title:    config.Title,
+		Message:  config.Message,
+		tmpl:     t,
+		log:      log.New("alerting.notifier.mattermost"),
+		ns:       ns,
+	}
+}
+
+// Notify sends an alert notification to Mattermost.
+func (mn *MattermostNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	mn.log.Debug("executing Mattermost notification", "notification", mn.Name)
+
+	var tmplErr error
+	tmpl, _ := TmplText(ctx, mn.tmpl, as, mn.log, &tmplErr)
+
+	title, text := mattermostNotification(tmpl, mn.Title, mn.Message)
+	msg := mattermostMessage{
+		Channel:  tmpl(mn.Channel),
+		Username: tmpl(mn.Username),
+		IconURL:  tmpl(mn.IconURL),
+		Attachments: []mattermostAttachment{
+			{
+				Fallback:   title,
+				Color:      getAlertStatusColor(types.Alerts(as...).Status()),
+				Title:      title,
+				TitleLink:  joinUrlPath(mn.tmpl.ExternalURL.String(), "/alerting/list", mn.log),
+				Text:       text,
+				Footer:     "Grafana v" + setting.BuildVersion,
+				FooterIcon: FooterIconURL,
+			},
+		},
+	}
+	if tmplErr != nil {
+		mn.log.Warn("failed to template Mattermost message", "err", tmplErr.Error())
+	}
+
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return false, err
+	}
+
+	cmd := &models.SendWebhookSync{
+		Url:         mn.URL,
+		Body:        string(body),
+		HttpMethod:  "POST",
+		ContentType: "application/json",
+	}
+	if err := mn.ns.SendWebhookSync(ctx, cmd); err != nil {
+		mn.log.Error("failed to send Mattermost notification", "err", err, "notification", mn.Name)
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (mn *MattermostNotifier) SendResolved() bool {
+	return !mn.GetDisableResolveMessage()
+}
+
+// mattermostNotification renders the title and the text of an attachment. The text is shortened to the maximum size
+// of a post.
+func mattermostNotification(tmpl func(string) string, title, message string) (string, string) {
+	t := tmpl(title)
+	return t, truncateBytes(tmpl(message), mattermostMaxTextLen)
+}
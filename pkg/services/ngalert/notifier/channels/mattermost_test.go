@@ -0,0 +1,138 @@
+package channels
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/secrets/fakes"
+	secretsManager "github.com/grafana/grafana/pkg/services/secrets/manager"
+)
+
+func TestMattermostNotifier(t *testing.T) {
+	tmpl := templateForTests(t)
+
+	externalURL, err := url.Parse("http://localhost")
+	require.NoError(t, err)
+	tmpl.ExternalURL = externalURL
+
+	cases := []struct {
+		name         string
+		settings     string
+		alerts       []*types.Alert
+		expMsg       string
+		expInitError string
+	}{
+		{
+			name:     "One alert with the default settings",
+			settings: `{"url": "http://mattermost.localhost/hooks/abc"}`,
+			alerts: []*types.Alert{
+				{
+					Alert: model.Alert{
+						Labels:      model.LabelSet{"alertname": "alert1", "lbl1": "val1"},
+						Annotations: model.LabelSet{"ann1": "annv1", "__dashboardUid__": "abcd", "__panelId__": "efgh"},
+					},
+				},
+			},
+			expMsg: `{
+				"username": "Grafana",
+				"attachments": [
+					{
+						"fallback": "[FIRING:1]  (val1)",
+						"color": "#D63232",
+						"title": "[FIRING:1]  (val1)",
+						"title_link": "http://localhost/alerting/list",
+						"text": "**Firing**\n\nValue: [no value]\nLabels:\n - alertname = alert1\n - lbl1 = val1\nAnnotations:\n - ann1 = annv1\nSilence: http://localhost/alerting/silence/new?alertmanager=grafana&matcher=alertname%3Dalert1&matcher=lbl1%3Dval1\nDashboard: http://localhost/d/abcd\nPanel: http://localhost/d/abcd?viewPanel=efgh\n",
+						"footer": "Grafana v",
+						"footer_icon": "https://grafana.com/assets/img/fav32.png"
+					}
+				]
+			}`,
+		}, {
+			name: "Multiple alerts with custom settings",
+			settings: `{
+				"url": "http://mattermost.localhost/hooks/abc",
+				"channel": "alerts",
+				"username": "Alerting",
+				"icon_url": "https://example.com/icon.png",
+				"title": "{{ len .Alerts.Firing }} alerts are firing",
+				"message": "{{ range .Alerts }}- {{ .Labels.alertname }}\n{{ end }}"
+			}`,
+			alerts: []*types.Alert{
+				{
+					Alert: model.Alert{
+						Labels:      model.LabelSet{"alertname": "alert1", "lbl1": "val1"},
+						Annotations: model.LabelSet{"ann1": "annv1"},
+					},
+				}, {
+					Alert: model.Alert{
+						Labels:      model.LabelSet{"alertname": "alert2", "lbl1": "val2"},
+						Annotations: model.LabelSet{"ann1": "annv2"},
+					},
+				},
+			},
+			expMsg: `{
+				"channel": "alerts",
+				"username": "Alerting",
+				"icon_url": "https://example.com/icon.png",
+				"attachments": [
+					{
+						"fallback": "2 alerts are firing",
+						"color": "#D63232",
+						"title": "2 alerts are firing",
+						"title_link": "http://localhost/alerting/list",
+						"text": "- alert1\n- alert2\n",
+						"footer": "Grafana v",
+						"footer_icon": "https://grafana.com/assets/img/fav32.png"
+					}
+				]
+			}`,
+		}, {
+			name:         "Error in initing",
+			settings:     `{}`,
+			expInitError: `could not find webhook URL in settings`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			settingsJSON, err := simplejson.NewJson([]byte(c.settings))
+			require.NoError(t, err)
+			secureSettings := make(map[string][]byte)
+
+			m := &NotificationChannelConfig{
+				Name:           "mattermost_testing",
+				Type:           "mattermost",
+				Settings:       settingsJSON,
+				SecureSettings: secureSettings,
+			}
+
+			webhookSender := mockNotificationService()
+			secretsService := secretsManager.SetupTestService(t, fakes.NewFakeSecretsStore())
+			decryptFn := secretsService.GetDecryptedValue
+			cfg, err := NewMattermostConfig(m, decryptFn)
+			if c.expInitError != "" {
+				require.Error(t, err)
+				require.Equal(t, c.expInitError, err.Error())
+				return
+			}
+			require.NoError(t, err)
+
+			ctx := notify.WithGroupKey(context.Background(), "alertname")
+			ctx = notify.WithGroupLabels(ctx, model.LabelSet{"alertname": ""})
+			pn := NewMattermostNotifier(cfg, webhookSender, tmpl)
+			ok, err := pn.Notify(ctx, c.alerts...)
+			require.NoError(t, err)
+			require.True(t, ok)
+
+			require.Equal(t, "http://mattermost.localhost/hooks/abc", webhookSender.Webhook.Url)
+			require.JSONEq(t, c.expMsg, webhookSender.Webhook.Body)
+		})
+	}
+}
@@ -87,7 +87,7 @@ func NewOpsgenieConfig(config *NotificationChannelConfig, decryptFunc GetDecrypt
 		APIUrl:                    config.Settings.Get("apiUrl").MustString(OpsgenieAlertURL),
 		AutoClose:                 config.Settings.Get("autoClose").MustBool(true),
 		OverridePriority:          config.Settings.Get("overridePriority").MustBool(true),
-		Message:                   config.Settings.Get("message").MustString(`{{ template "default.title" . }}`),
+		Message:                   config.Settings.Get("message").MustString(DefaultMessageTitleEmbed),
 		Description:               config.Settings.Get("description").MustString(""),
 		SendTagsAs:                sendTagsAs,
 	}, nil
@@ -189,25 +189,7 @@ func (on *OpsgenieNotifier) buildOpsgenieMessage(ctx context.Context, alerts mod
 	var tmplErr error
 	tmpl, data := TmplText(ctx, on.tmpl, as, on.log, &tmplErr)
 
-	titleTmpl := on.Message
-	if strings.TrimSpace(titleTmpl) == "" {
-		titleTmpl = `{{ template "default.title" . }}`
-	}
-
-	title := tmpl(titleTmpl)
-	if len(title) > 130 {
-		title = title[:127] + "..."
-	}
-
-	description := tmpl(on.Description)
-	if strings.TrimSpace(description) == "" {
-		description = fmt.Sprintf(
-			"%s\n%s\n\n%s",
-			tmpl(DefaultMessageTitleEmbed),
-			ruleURL,
-			tmpl(`{{ template "default.message" . }}`),
-		)
-	}
+	title, description := opsgenieNotification(tmpl, on.Message, on.Description, ruleURL)
 
 	var priority string
 
@@ -290,3 +272,27 @@ func (on *OpsgenieNotifier) sendDetails() bool {
 func (on *OpsgenieNotifier) sendTags() bool {
 	return on.SendTagsAs == OpsgenieSendTags || on.SendTagsAs == OpsgenieSendBoth
 }
+
+// opsgenieNotification renders the message and the description of an alert. The message is shortened to the
+// maximum length that Opsgenie accepts. The default title, the rule URL and the default message are used if the
+// description is empty.
+func opsgenieNotification(tmpl func(string) string, message, description, ruleURL string) (string, string) {
+	if strings.TrimSpace(message) == "" {
+		message = DefaultMessageTitleEmbed
+	}
+	title := tmpl(message)
+	if len(title) > 130 {
+		title = title[:127] + "..."
+	}
+
+	d := tmpl(description)
+	if strings.TrimSpace(d) == "" {
+		d = fmt.Sprintf(
+			"%s\n%s\n\n%s",
+			tmpl(DefaultMessageTitleEmbed),
+			ruleURL,
+			tmpl(DefaultMessageEmbed),
+		)
+	}
+	return title, d
+}
@@ -21,6 +21,9 @@ import (
 const (
 	pagerDutyEventTrigger = "trigger"
 	pagerDutyEventResolve = "resolve"
+
+	// pagerdutyFiringDetails is the template of the details of the firing alerts of an event.
+	pagerdutyFiringDetails = `{{ template "__text_alert_list" .Alerts.Firing }}`
 )
 
 var (
@@ -93,7 +96,7 @@ func NewPagerdutyNotifier(config *PagerdutyConfig, ns notifications.WebhookSende
 		}),
 		Key: config.Key,
 		CustomDetails: map[string]string{
-			"firing":       `{{ template "__text_alert_list" .Alerts.Firing }}`,
+			"firing":       pagerdutyFiringDetails,
 			"resolved":     `{{ template "__text_alert_list" .Alerts.Resolved }}`,
 			"num_firing":   `{{ .Alerts.Firing | len }}`,
 			"num_resolved": `{{ .Alerts.Resolved | len }}`,
@@ -167,6 +170,7 @@ func (pn *PagerdutyNotifier) buildPagerdutyMessage(ctx context.Context, alerts m
 		details[k] = detail
 	}
 
+	description, summary := pagerdutyNotification(tmpl, pn.Summary)
 	msg := &pagerDutyMessage{
 		Client:      "Grafana",
 		ClientURL:   pn.tmpl.ExternalURL.String(),
@@ -177,10 +181,10 @@ func (pn *PagerdutyNotifier) buildPagerdutyMessage(ctx context.Context, alerts m
 			HRef: pn.tmpl.ExternalURL.String(),
 			Text: "External URL",
 		}},
-		Description: tmpl(DefaultMessageTitleEmbed), // TODO: this can be configurable template.
+		Description: description, // TODO: this can be configurable template.
 		Payload: pagerDutyPayload{
 			Component:     tmpl(pn.Component),
-			Summary:       tmpl(pn.Summary),
+			Summary:       summary,
 			Severity:      tmpl(pn.Severity),
 			CustomDetails: details,
 			Class:         tmpl(pn.Class),
@@ -250,3 +254,9 @@ type pagerDutyPayload struct {
 	Group         string            `json:"group,omitempty"`
 	CustomDetails map[string]string `json:"custom_details,omitempty"`
 }
+
+// pagerdutyNotification renders the description and the summary of an event.
+func pagerdutyNotification(tmpl func(string) string, summary string) (string, string) {
+	d := tmpl(DefaultMessageTitleEmbed)
+	return d, tmpl(summary)
+}
@@ -0,0 +1,207 @@
+package channels
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/models"
+)
+
+const (
+	// PluginNotifierSchemaPath is the resource path of a notifier plugin that returns the settings schema
+	// of its contact point type.
+	PluginNotifierSchemaPath = "notifier/schema"
+	// PluginNotifierNotifyPath is the resource path of a notifier plugin that sends a notification.
+	PluginNotifierNotifyPath = "notifier/notify"
+)
+
+var (
+	pluginFactoriesMtx sync.RWMutex
+	pluginFactories    = map[string]func(FactoryConfig) (NotificationChannel, error){}
+	pluginSecretKeys   = map[string][]string{}
+)
+
+// RegisterPluginNotifier registers a contact point type that is provided by a backend plugin.
+// The secret keys are the settings that are stored encrypted. Built-in types cannot be replaced.
+func RegisterPluginNotifier(receiverType string, client backend.CallResourceHandler, secretKeys []string) error {
+	if _, exists := receiverFactories[receiverType]; exists {
+		return fmt.Errorf("contact point type %s is built in", receiverType)
+	}
+	pluginFactoriesMtx.Lock()
+	defer pluginFactoriesMtx.Unlock()
+	pluginFactories[receiverType] = PluginFactory(receiverType, client)
+	pluginSecretKeys[receiverType] = secretKeys
+	return nil
+}
+
+// PluginSecretKeys returns the secret keys of a contact point type provided by a plugin.
+func PluginSecretKeys(receiverType string) ([]string, bool) {
+	pluginFactoriesMtx.RLock()
+	defer pluginFactoriesMtx.RUnlock()
+	keys, ok := pluginSecretKeys[receiverType]
+	return keys, ok
+}
+
+func pluginFactory(receiverType string) (func(FactoryConfig) (NotificationChannel, error), bool) {
+	pluginFactoriesMtx.RLock()
+	defer pluginFactoriesMtx.RUnlock()
+	factory, ok := pluginFactories[receiverType]
+	return factory, ok
+}
+
+// PluginNotifier sends notifications through a backend plugin that provides a contact point type.
+type PluginNotifier struct {
+	*Base
+	PluginID       string
+	OrgID          int64
+	Settings       json.RawMessage
+	SecureSettings map[string]string
+	client         backend.CallResourceHandler
+	tmpl           *template.Template
+	log            log.Logger
+}
+
+// PluginNotifyRequest is the body of the request that a notifier plugin receives for every notification.
+type PluginNotifyRequest struct {
+	OrgID    int64  `json:"orgId"`
+	UID      string `json:"uid"`
+	Name     string `json:"name"`
+	GroupKey string `json:"groupKey"`
+	// Settings are the settings of the contact point, SecureSettings are the decrypted secure settings.
+	Settings       json.RawMessage   `json:"settings"`
+	SecureSettings map[string]string `json:"secureSettings"`
+	// Title and Message are rendered with the default templates.
+	Title   string        `json:"title"`
+	Message string        `json:"message"`
+	Data    *ExtendedData `json:"data"`
+}
+
+// PluginFactory returns the factory of a contact point type that is provided by the plugin with the given ID.
+func PluginFactory(pluginID string, client backend.CallResourceHandler) func(FactoryConfig) (NotificationChannel, error) {
+	return func(fc FactoryConfig) (NotificationChannel, error) {
+		settings, err := fc.Config.Settings.MarshalJSON()
+		if err != nil {
+			return nil, receiverInitError{
+				Reason: "invalid settings",
+				Err:    err,
+				Cfg:    *fc.Config,
+			}
+		}
+		secureSettings := make(map[string]string, len(fc.Config.SecureSettings))
+		for key := range fc.Config.SecureSettings {
+			secureSettings[key] = fc.DecryptFunc(context.Background(), fc.Config.SecureSettings, key, "")
+		}
+		return &PluginNotifier{
+			Base: NewBase(&models.AlertNotification{
+				Uid:                   fc.Config.UID,
+				Name:                  fc.Config.Name,
+				Type:                  fc.Config.Type,
+				DisableResolveMessage: fc.Config.DisableResolveMessage,
+				Settings:              fc.Config.Settings,
+			}),
+			PluginID:       pluginID,
+			OrgID:          fc.Config.OrgID,
+			Settings:       settings,
+			SecureSettings: secureSettings,
+			client:         client,
+			tmpl:           fc.Template,
+			log:            log.New("alerting.notifier.plugin", "plugin", pluginID),
+		}, nil
+	}
+}
+
+// Notify sends the rendered template data of the alerts to the plugin.
+func (pn *PluginNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	groupKey, err := notify.ExtractGroupKey(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var tmplErr error
+	tmpl, data := TmplText(ctx, pn.tmpl, as, pn.log, &tmplErr)
+	title, message := pluginNotification(tmpl)
+	req := PluginNotifyRequest{
+		OrgID:          pn.OrgID,
+		UID:            pn.UID,
+		Name:           pn.Name,
+		GroupKey:       groupKey.String(),
+		Settings:       pn.Settings,
+		SecureSettings: pn.SecureSettings,
+		Title:          title,
+		Message:        message,
+		Data:           data,
+	}
+	if tmplErr != nil {
+		pn.log.Warn("failed to template plugin notification", "err", tmplErr.Error())
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return false, err
+	}
+	resp, err := CallPluginResource(ctx, pn.client, pn.PluginID, pn.OrgID, http.MethodPost, PluginNotifierNotifyPath, body)
+	if err != nil {
+		return true, err
+	}
+	if resp.Status/100 != 2 {
+		// Like the other notifiers, retry on server errors and rate limiting.
+		retry := resp.Status/100 == 5 || resp.Status == http.StatusTooManyRequests
+		return retry, fmt.Errorf("plugin %s returned status %d: %s", pn.PluginID, resp.Status, string(resp.Body))
+	}
+	return true, nil
+}
+
+func (pn *PluginNotifier) SendResolved() bool {
+	return !pn.GetDisableResolveMessage()
+}
+
+// CallPluginResource calls a resource of a backend plugin and returns the response.
+func CallPluginResource(ctx context.Context, client backend.CallResourceHandler, pluginID string, orgID int64, method, path string, body []byte) (*backend.CallResourceResponse, error) {
+	req := &backend.CallResourceRequest{
+		PluginContext: backend.PluginContext{OrgID: orgID, PluginID: pluginID},
+		Path:          path,
+		Method:        method,
+		URL:           path,
+		Headers:       map[string][]string{"Content-Type": {"application/json"}},
+		Body:          body,
+	}
+	sender := &pluginResponseSender{}
+	err := client.CallResource(ctx, req, sender)
+	if err != nil {
+		return nil, err
+	}
+	if sender.resp == nil {
+		return nil, fmt.Errorf("plugin %s did not respond", pluginID)
+	}
+	return sender.resp, nil
+}
+
+// pluginResponseSender collects the response of a resource call. Streamed responses are sent in chunks,
+// whose bodies are joined.
+type pluginResponseSender struct {
+	resp *backend.CallResourceResponse
+}
+
+func (s *pluginResponseSender) Send(r *backend.CallResourceResponse) error {
+	if s.resp == nil {
+		s.resp = r
+		return nil
+	}
+	s.resp.Body = append(s.resp.Body, r.Body...)
+	return nil
+}
+
+// pluginNotification renders the title and the message that are sent to a plugin.
+func pluginNotification(tmpl func(string) string) (string, string) {
+	t := tmpl(DefaultMessageTitleEmbed)
+	return t, tmpl(DefaultMessageEmbed)
+}
@@ -0,0 +1,150 @@
+package channels
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+)
+
+type fakePluginClient struct {
+	status int
+	err    error
+	reqs   []*backend.CallResourceRequest
+}
+
+func (f *fakePluginClient) CallResource(_ context.Context, req *backend.CallResourceRequest, sender backend.CallResourceResponseSender) error {
+	f.reqs = append(f.reqs, req)
+	if f.err != nil {
+		return f.err
+	}
+	return sender.Send(&backend.CallResourceResponse{Status: f.status, Body: []byte("body")})
+}
+
+func TestPluginNotifier(t *testing.T) {
+	tmpl := templateForTests(t)
+
+	externalURL, err := url.Parse("http://localhost")
+	require.NoError(t, err)
+	tmpl.ExternalURL = externalURL
+
+	alerts := []*types.Alert{
+		{
+			Alert: model.Alert{
+				Labels:      model.LabelSet{"alertname": "alert1", "lbl1": "val1"},
+				Annotations: model.LabelSet{"ann1": "annv1"},
+			},
+		},
+	}
+
+	cases := []struct {
+		name        string
+		status      int
+		err         error
+		expRetry    bool
+		expMsgError string
+	}{
+		{
+			name:     "Plugin accepts the notification",
+			status:   http.StatusOK,
+			expRetry: true,
+		}, {
+			name:        "Plugin fails with a server error",
+			status:      http.StatusInternalServerError,
+			expRetry:    true,
+			expMsgError: "plugin test-notifier returned status 500: body",
+		}, {
+			name:        "Plugin rejects the notification",
+			status:      http.StatusBadRequest,
+			expRetry:    false,
+			expMsgError: "plugin test-notifier returned status 400: body",
+		}, {
+			name:        "Plugin is unavailable",
+			err:         errors.New("plugin unavailable"),
+			expRetry:    true,
+			expMsgError: "plugin unavailable",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			settingsJSON, err := simplejson.NewJson([]byte(`{"channel": "alerts"}`))
+			require.NoError(t, err)
+
+			client := &fakePluginClient{status: c.status, err: c.err}
+			factory := PluginFactory("test-notifier", client)
+			n, err := factory(FactoryConfig{
+				Config: &NotificationChannelConfig{
+					OrgID:          1,
+					UID:            "uid",
+					Name:           "plugin_testing",
+					Type:           "test-notifier",
+					Settings:       settingsJSON,
+					SecureSettings: map[string][]byte{"token": []byte("secret")},
+				},
+				DecryptFunc: func(_ context.Context, sjd map[string][]byte, key string, _ string) string {
+					return string(sjd[key])
+				},
+				Template: tmpl,
+			})
+			require.NoError(t, err)
+
+			ctx := notify.WithGroupKey(context.Background(), "alertname")
+			ctx = notify.WithGroupLabels(ctx, model.LabelSet{"alertname": ""})
+			retry, err := n.Notify(ctx, alerts...)
+			require.Equal(t, c.expRetry, retry)
+			if c.expMsgError != "" {
+				require.EqualError(t, err, c.expMsgError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Len(t, client.reqs, 1)
+			req := client.reqs[0]
+			require.Equal(t, http.MethodPost, req.Method)
+			require.Equal(t, PluginNotifierNotifyPath, req.Path)
+			require.Equal(t, int64(1), req.PluginContext.OrgID)
+			require.Equal(t, "test-notifier", req.PluginContext.PluginID)
+
+			var body PluginNotifyRequest
+			require.NoError(t, json.Unmarshal(req.Body, &body))
+			require.Equal(t, "uid", body.UID)
+			require.Equal(t, "plugin_testing", body.Name)
+			require.JSONEq(t, `{"channel": "alerts"}`, string(body.Settings))
+			require.Equal(t, map[string]string{"token": "secret"}, body.SecureSettings)
+			require.Equal(t, "[FIRING:1]  (val1)", body.Title)
+			require.Equal(t, "firing", body.Data.Status)
+			require.Len(t, body.Data.Alerts, 1)
+		})
+	}
+}
+
+func TestRegisterPluginNotifier(t *testing.T) {
+	t.Cleanup(func() {
+		pluginFactoriesMtx.Lock()
+		defer pluginFactoriesMtx.Unlock()
+		delete(pluginFactories, "test-notifier")
+		delete(pluginSecretKeys, "test-notifier")
+	})
+
+	require.Error(t, RegisterPluginNotifier("slack", &fakePluginClient{}, nil))
+	require.NoError(t, RegisterPluginNotifier("test-notifier", &fakePluginClient{}, []string{"token"}))
+
+	_, ok := Factory("test-notifier")
+	require.True(t, ok)
+	keys, ok := PluginSecretKeys("test-notifier")
+	require.True(t, ok)
+	require.Equal(t, []string{"token"}, keys)
+	_, ok = PluginSecretKeys("slack")
+	require.False(t, ok)
+}
@@ -0,0 +1,166 @@
+package channels
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/log"
+)
+
+// ErrPreviewNotSupported is returned when the notifications of an integration cannot be previewed.
+var ErrPreviewNotSupported = errors.New("integration does not render templates")
+
+// NotificationPreview is the title and the body of a notification as the notifier of an integration sends it.
+type NotificationPreview struct {
+	Title string
+	Body  string
+	// Errors are the errors of the templates that failed to execute. Failed templates render as empty strings.
+	Errors []string
+}
+
+// notificationPreviewer renders templates the same way as a notifier, but collects all template errors instead of
+// stopping at the first one.
+type notificationPreviewer struct {
+	tmpl     *template.Template
+	data     *ExtendedData
+	settings *simplejson.Json
+	log      log.Logger
+	errors   []string
+}
+
+func (p *notificationPreviewer) text(name string) string {
+	s, err := p.tmpl.ExecuteTextString(name, p.data)
+	if err != nil {
+		p.errors = append(p.errors, err.Error())
+		return ""
+	}
+	return s
+}
+
+// setting returns the template in the setting with the given key, or the default template if it is not set.
+func (p *notificationPreviewer) setting(key, defaultTemplate string) string {
+	return p.settings.Get(key).MustString(defaultTemplate)
+}
+
+// notificationPreviews render the title and the body of the notifications of the integrations with the render
+// functions of their notifiers. The title is empty if the notifications of the integration do not have one.
+var notificationPreviews = map[string]func(p *notificationPreviewer) (string, string){
+	"dingding": func(p *notificationPreviewer) (string, string) {
+		return dingDingNotification(p.text, p.setting("message", DefaultMessageEmbed))
+	},
+	"discord": func(p *notificationPreviewer) (string, string) {
+		return discordNotification(p.text, p.setting("message", DefaultMessageEmbed))
+	},
+	"email": func(p *notificationPreviewer) (string, string) {
+		return emailNotification(p.text, p.setting("subject", DefaultMessageTitleEmbed), p.setting("message", ""))
+	},
+	"googlechat": func(p *notificationPreviewer) (string, string) {
+		return googleChatNotification(p.text, p.setting("message", DefaultMessageEmbed))
+	},
+	"jira": func(p *notificationPreviewer) (string, string) {
+		return jiraNotification(p.text, p.setting("summary", DefaultMessageTitleEmbed), p.setting("description", DefaultMessageEmbed))
+	},
+	"kafka": func(p *notificationPreviewer) (string, string) {
+		return kafkaNotification(p.text)
+	},
+	"line": func(p *notificationPreviewer) (string, string) {
+		return "", lineMessage(p.text, path.Join(p.tmpl.ExternalURL.String(), "/alerting/list"))
+	},
+	"mattermost": func(p *notificationPreviewer) (string, string) {
+		return mattermostNotification(p.text, p.setting("title", DefaultMessageTitleEmbed), p.setting("message", DefaultMessageEmbed))
+	},
+	"opsgenie": func(p *notificationPreviewer) (string, string) {
+		ruleURL := joinUrlPath(p.tmpl.ExternalURL.String(), "/alerting/list", p.log)
+		return opsgenieNotification(p.text, p.setting("message", DefaultMessageTitleEmbed), p.setting("description", ""), ruleURL)
+	},
+	"pagerduty": func(p *notificationPreviewer) (string, string) {
+		_, summary := pagerdutyNotification(p.text, p.setting("summary", DefaultMessageTitleEmbed))
+		return summary, p.text(pagerdutyFiringDetails)
+	},
+	"pushover": func(p *notificationPreviewer) (string, string) {
+		return pushoverNotification(p.text, p.setting("message", DefaultMessageEmbed))
+	},
+	"sensugo": func(p *notificationPreviewer) (string, string) {
+		return "", p.text(p.setting("message", DefaultMessageEmbed))
+	},
+	"servicenow": func(p *notificationPreviewer) (string, string) {
+		return serviceNowNotification(p.text, p.setting("shortDescription", DefaultMessageTitleEmbed), p.setting("description", DefaultMessageEmbed))
+	},
+	"slack": func(p *notificationPreviewer) (string, string) {
+		return slackNotification(p.text, p.setting("title", DefaultMessageTitleEmbed), p.setting("text", DefaultMessageEmbed))
+	},
+	"teams": func(p *notificationPreviewer) (string, string) {
+		title, _, message := teamsNotification(p.text, p.setting("title", DefaultMessageTitleEmbed), p.setting("sectiontitle", ""), p.setting("message", teamsDefaultMessage))
+		return title, message
+	},
+	"telegram": func(p *notificationPreviewer) (string, string) {
+		return "", p.text(p.setting("message", DefaultMessageEmbed))
+	},
+	"threema": func(p *notificationPreviewer) (string, string) {
+		return "", threemaMessage(p.text, model.AlertStatus(p.data.Status), path.Join(p.tmpl.ExternalURL.String(), "/alerting/list"))
+	},
+	"victorops": func(p *notificationPreviewer) (string, string) {
+		return victorOpsNotification(p.text)
+	},
+	"webhook": func(p *notificationPreviewer) (string, string) {
+		// A custom body replaces the Grafana webhook JSON with its title and message.
+		if body := p.setting("body", ""); body != "" {
+			return "", p.text(body)
+		}
+		return webhookNotification(p.text)
+	},
+	"wecom": func(p *notificationPreviewer) (string, string) {
+		return weComNotification(p.text, p.setting("message", DefaultMessageEmbed))
+	},
+	"zulip": func(p *notificationPreviewer) (string, string) {
+		ruleURL := joinUrlPath(p.tmpl.ExternalURL.String(), "/alerting/list", p.log)
+		return zulipNotification(p.text, p.setting("topic", zulipDefaultTopic), p.setting("message", DefaultMessageEmbed), ruleURL)
+	},
+}
+
+// pluginNotificationPreview renders the notifications of the contact point types provided by backend plugins.
+func pluginNotificationPreview(p *notificationPreviewer) (string, string) {
+	return pluginNotification(p.text)
+}
+
+// PreviewIntegrations returns the sorted types of the built-in integrations whose notifications can be previewed.
+func PreviewIntegrations() []string {
+	integrations := make([]string, 0, len(notificationPreviews))
+	for t := range notificationPreviews {
+		integrations = append(integrations, t)
+	}
+	sort.Strings(integrations)
+	return integrations
+}
+
+// PreviewNotification renders the title and the body of the notification that the integration sends for the alerts.
+// The settings are those of a contact point of the integration and may override its default templates.
+// The context must carry the receiver name and the group labels, as it does when the notifier is called.
+func PreviewNotification(ctx context.Context, tmpl *template.Template, integrationType string, settings *simplejson.Json, alerts []*types.Alert, l log.Logger) (NotificationPreview, error) {
+	integrationType = strings.ToLower(integrationType)
+	preview, ok := notificationPreviews[integrationType]
+	if !ok {
+		if _, ok := pluginFactory(integrationType); !ok {
+			return NotificationPreview{}, fmt.Errorf("%w: %s", ErrPreviewNotSupported, integrationType)
+		}
+		preview = pluginNotificationPreview
+	}
+	if settings == nil {
+		settings = simplejson.New()
+	}
+
+	var tmplErr error
+	_, data := TmplText(ctx, tmpl, alerts, l, &tmplErr)
+	p := &notificationPreviewer{tmpl: tmpl, data: data, settings: settings, log: l}
+	title, body := preview(p)
+	return NotificationPreview{Title: title, Body: body, Errors: p.errors}, nil
+}
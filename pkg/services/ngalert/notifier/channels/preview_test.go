@@ -0,0 +1,101 @@
+package channels
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/log"
+)
+
+func TestPreviewNotification(t *testing.T) {
+	tmpl := templateForTests(t)
+	externalURL, err := url.Parse("http://localhost")
+	require.NoError(t, err)
+	tmpl.ExternalURL = externalURL
+
+	ctx := notify.WithGroupKey(context.Background(), "alertname")
+	ctx = notify.WithGroupLabels(ctx, model.LabelSet{"alertname": "HighCPU"})
+	alerts := []*types.Alert{{
+		Alert: model.Alert{
+			Labels:      model.LabelSet{"alertname": "HighCPU", "lbl1": "val1"},
+			Annotations: model.LabelSet{"ann1": "annv1", "__dashboardUid__": "abcd", "__panelId__": "efgh"},
+		},
+	}}
+
+	cases := []struct {
+		name        string
+		integration string
+		settings    string
+		expTitle    string
+		expBody     string
+		expErrors   int
+	}{
+		{
+			name:        "default templates",
+			integration: "slack",
+			settings:    `{}`,
+			expTitle:    "[FIRING:1] HighCPU (val1)",
+			expBody:     "**Firing**\n\nValue: [no value]\nLabels:\n - alertname = HighCPU\n - lbl1 = val1\nAnnotations:\n - ann1 = annv1\nSilence: http://localhost/alerting/silence/new?alertmanager=grafana&matcher=alertname%3DHighCPU&matcher=lbl1%3Dval1\nDashboard: http://localhost/d/abcd\nPanel: http://localhost/d/abcd?viewPanel=efgh\n",
+		},
+		{
+			name:        "templates in settings",
+			integration: "Mattermost",
+			settings:    `{"title": "{{ .CommonLabels.alertname }} is {{ .Status }}", "message": "{{ len .Alerts.Firing }} firing"}`,
+			expTitle:    "HighCPU is firing",
+			expBody:     "1 firing",
+		},
+		{
+			name:        "fallback of the notifier",
+			integration: "zulip",
+			settings:    `{"topic": "  ", "message": "{{ .CommonLabels.lbl1 }}"}`,
+			expTitle:    "Grafana alerts",
+			expBody:     "**[FIRING:1] HighCPU (val1)**\nval1\n[Alerts](http://localhost/alerting/list)",
+		},
+		{
+			name:        "custom webhook body",
+			integration: "webhook",
+			settings:    `{"body": "{\"alert\": \"{{ .CommonLabels.alertname }}\"}"}`,
+			expTitle:    "",
+			expBody:     `{"alert": "HighCPU"}`,
+		},
+		{
+			name:        "shortened like the notifier",
+			integration: "jira",
+			settings:    `{"summary": "` + strings.Repeat("ä", jiraMaxSummaryLen+1) + `"}`,
+			expTitle:    strings.Repeat("ä", jiraMaxSummaryLen-3) + "...",
+			expBody:     "**Firing**\n\nValue: [no value]\nLabels:\n - alertname = HighCPU\n - lbl1 = val1\nAnnotations:\n - ann1 = annv1\nSilence: http://localhost/alerting/silence/new?alertmanager=grafana&matcher=alertname%3DHighCPU&matcher=lbl1%3Dval1\nDashboard: http://localhost/d/abcd\nPanel: http://localhost/d/abcd?viewPanel=efgh\n",
+		},
+		{
+			name:        "all template errors",
+			integration: "teams",
+			settings:    `{"title": "{{ template \"missing\" . }}", "message": "{{ .Missing }}"}`,
+			expErrors:   2,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			settings, err := simplejson.NewJson([]byte(c.settings))
+			require.NoError(t, err)
+
+			preview, err := PreviewNotification(ctx, tmpl, c.integration, settings, alerts, log.New("test"))
+			require.NoError(t, err)
+			require.Equal(t, c.expTitle, preview.Title)
+			require.Equal(t, c.expBody, preview.Body)
+			require.Len(t, preview.Errors, c.expErrors)
+		})
+	}
+
+	t.Run("integration without templates", func(t *testing.T) {
+		_, err := PreviewNotification(ctx, tmpl, "prometheus-alertmanager", nil, alerts, log.New("test"))
+		require.ErrorIs(t, err, ErrPreviewNotSupported)
+	})
+}
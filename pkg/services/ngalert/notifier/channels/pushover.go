@@ -98,7 +98,7 @@ func NewPushoverConfig(config *NotificationChannelConfig, decryptFunc GetDecrypt
 		AlertingSound:             config.Settings.Get("sound").MustString(),
 		OKSound:                   config.Settings.Get("okSound").MustString(),
 		Upload:                    config.Settings.Get("uploadImage").MustBool(true),
-		Message:                   config.Settings.Get("message").MustString(`{{ template "default.message" .}}`),
+		Message:                   config.Settings.Get("message").MustString(DefaultMessageEmbed),
 	}, nil
 }
 
@@ -229,8 +229,10 @@ func (pn *PushoverNotifier) genPushoverBody(ctx context.Context, as ...*types.Al
 		}
 	}
 
+	title, message := pushoverNotification(tmpl, pn.Message)
+
 	// Add title
-	err = w.WriteField("title", tmpl(DefaultMessageTitleEmbed))
+	err = w.WriteField("title", title)
 	if err != nil {
 		return nil, b, err
 	}
@@ -247,7 +249,7 @@ func (pn *PushoverNotifier) genPushoverBody(ctx context.Context, as ...*types.Al
 	}
 
 	// Add message
-	err = w.WriteField("message", tmpl(pn.Message))
+	err = w.WriteField("message", message)
 	if err != nil {
 		return nil, b, err
 	}
@@ -271,3 +273,9 @@ func (pn *PushoverNotifier) genPushoverBody(ctx context.Context, as ...*types.Al
 
 	return headers, b, nil
 }
+
+// pushoverNotification renders the title and the message of a notification.
+func pushoverNotification(tmpl func(string) string, message string) (string, string) {
+	t := tmpl(DefaultMessageTitleEmbed)
+	return t, tmpl(message)
+}
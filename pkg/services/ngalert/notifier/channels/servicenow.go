@@ -0,0 +1,222 @@
+package channels
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/models"
+)
+
+const (
+	serviceNowMaxShortDescriptionLen = 160
+	// serviceNowStateResolved is the state of a resolved incident in ServiceNow.
+	serviceNowStateResolved = "6"
+)
+
+// ServiceNowNotifier is responsible for opening an incident in a ServiceNow table when an alert group starts
+// firing, and for updating and resolving the same incident.
+type ServiceNowNotifier struct {
+	*Base
+	URL              string
+	User             string
+	Password         string
+	Table            string
+	ShortDescription string
+	Description      string
+	AssignmentGroup  string
+	CloseCode        string
+	tmpl             *template.Template
+	log              log.Logger
+}
+
+type ServiceNowConfig struct {
+	*NotificationChannelConfig
+	URL              string
+	User             string
+	Password         string
+	Table            string
+	ShortDescription string
+	Description      string
+	AssignmentGroup  string
+	CloseCode        string
+}
+
+func ServiceNowFactory(fc FactoryConfig) (NotificationChannel, error) {
+	cfg, err := NewServiceNowConfig(fc.Config, fc.DecryptFunc)
+	if err != nil {
+		return nil, receiverInitError{
+			Reason: err.Error(),
+			Cfg:    *fc.Config,
+		}
+	}
+	return NewServiceNowNotifier(cfg, fc.Template), nil
+}
+
+func NewServiceNowConfig(config *NotificationChannelConfig, decryptFunc GetDecryptedValueFn) (*ServiceNowConfig, error) {
+	instanceURL := config.Settings.Get("url").MustString()
+	if instanceURL == "" {
+		return nil, errors.New("could not find ServiceNow instance URL in settings")
+	}
+	user := config.Settings.Get("user").MustString()
+	if user == "" {
+		return nil, errors.New("could not find ServiceNow user in settings")
+	}
+	password := decryptFunc(context.Background(), config.SecureSettings, "password", config.Settings.Get("password").MustString())
+	if password == "" {
+		return nil, errors.New("could not find ServiceNow password in settings")
+	}
+	return &ServiceNowConfig{
+		NotificationChannelConfig: config,
+		URL:                       instanceURL,
+		User:                      user,
+		Password:                  password,
+		Table:                     config.Settings.Get("table").MustString("incident"),
+		ShortDescription:          config.Settings.Get("shortDescription").MustString(DefaultMessageTitleEmbed),
+		Description:               config.Settings.Get("description").MustString(DefaultMessageEmbed),
+		AssignmentGroup:           config.Settings.Get("assignmentGroup").MustString(),
+		CloseCode:                 config.Settings.Get("closeCode").MustString("Solved (Permanently)"),
+	}, nil
+}
+
+// NewServiceNowNotifier is the constructor for the ServiceNow notifier
+func NewServiceNowNotifier(config *ServiceNowConfig, t *template.Template) *ServiceNowNotifier {
+	return &ServiceNowNotifier{
+		Base: NewBase(&models.AlertNotification{
+			Uid:                   config.UID,
+			Name:                  config.Name,
+			Type:                  config.Type,
+			DisableResolveMessage: config.DisableResolveMessage,
+			Settings:              config.Settings,
+		}),
+		URL:              config.URL,
+		User:             config.User,
+		Password:         config.Password,
+		Table:            config.Table,
+		ShortDescription: config.ShortDescription,
+		Description:      config.Description,
+		AssignmentGroup:  config.AssignmentGroup,
+		CloseCode:        config.CloseCode,
+		tmpl:             t,
+		log:              log.New("alerting.notifier.servicenow"),
+	}
+}
+
+// Notify opens, updates or resolves the incident of the alert group. The incident is found by its correlation ID,
+// which is derived from the group key, so repeated notifications of the same alert group update the same incident.
+func (sn *ServiceNowNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	key, err := notify.ExtractGroupKey(ctx)
+	if err != nil {
+		return false, err
+	}
+	correlationID := key.Hash()
+	sn.log.Debug("executing ServiceNow notification", "notification", sn.Name, "correlationId", correlationID)
+
+	var tmplErr error
+	tmpl, _ := TmplText(ctx, sn.tmpl, as, sn.log, &tmplErr)
+	shortDescription, description := serviceNowNotification(tmpl, sn.ShortDescription, sn.Description)
+	if tmplErr != nil {
+		sn.log.Warn("failed to template ServiceNow message", "err", tmplErr.Error())
+	}
+
+	sysID, err := sn.findActiveIncident(ctx, correlationID)
+	if err != nil {
+		return false, fmt.Errorf("search ServiceNow incident: %w", err)
+	}
+
+	record := map[string]string{
+		"short_description": shortDescription,
+		"description":       description,
+	}
+	if types.Alerts(as...).Status() == model.AlertResolved {
+		if sysID == "" {
+			sn.log.Debug("no active ServiceNow incident to resolve", "correlationId", correlationID)
+			return true, nil
+		}
+		record["state"] = serviceNowStateResolved
+		record["close_code"] = sn.CloseCode
+		record["close_notes"] = description
+	}
+
+	if sysID != "" {
+		if _, err := sn.send(ctx, http.MethodPatch, "/"+sysID, nil, record); err != nil {
+			return false, fmt.Errorf("update ServiceNow incident %s: %w", sysID, err)
+		}
+		return true, nil
+	}
+
+	record["correlation_id"] = correlationID
+	record["correlation_display"] = "Grafana"
+	if sn.AssignmentGroup != "" {
+		record["assignment_group"] = sn.AssignmentGroup
+	}
+	if _, err := sn.send(ctx, http.MethodPost, "", nil, record); err != nil {
+		return false, fmt.Errorf("create ServiceNow incident: %w", err)
+	}
+	return true, nil
+}
+
+// findActiveIncident returns the sys_id of the active incident of the alert group,
+// or an empty string if there is none.
+func (sn *ServiceNowNotifier) findActiveIncident(ctx context.Context, correlationID string) (string, error) {
+	query := url.Values{}
+	query.Set("sysparm_query", fmt.Sprintf("correlation_id=%s^active=true", correlationID))
+	query.Set("sysparm_fields", "sys_id")
+	query.Set("sysparm_limit", "1")
+	resp, err := sn.send(ctx, http.MethodGet, "", query, nil)
+	if err != nil {
+		return "", err
+	}
+	var result struct {
+		Result []struct {
+			SysID string `json:"sys_id"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return "", fmt.Errorf("failed to parse the response of ServiceNow: %w", err)
+	}
+	if len(result.Result) == 0 {
+		return "", nil
+	}
+	return result.Result[0].SysID, nil
+}
+
+// send calls the Table API of ServiceNow. The path is relative to the table.
+func (sn *ServiceNowNotifier) send(ctx context.Context, method, path string, query url.Values, record map[string]string) ([]byte, error) {
+	u, err := url.Parse(joinUrlPath(sn.URL, "/api/now/table/"+sn.Table+path, sn.log))
+	if err != nil {
+		return nil, err
+	}
+	u.RawQuery = query.Encode()
+	cfg := httpCfg{
+		method:   method,
+		user:     sn.User,
+		password: sn.Password,
+	}
+	if record != nil {
+		if cfg.body, err = json.Marshal(record); err != nil {
+			return nil, err
+		}
+	}
+	return sendHTTPRequest(ctx, u, cfg, sn.log)
+}
+
+func (sn *ServiceNowNotifier) SendResolved() bool {
+	return !sn.GetDisableResolveMessage()
+}
+
+// serviceNowNotification renders the short description and the description of a record. The short description is
+// shortened to the length of the field.
+func serviceNowNotification(tmpl func(string) string, shortDescription, description string) (string, string) {
+	s := truncateRunes(tmpl(shortDescription), serviceNowMaxShortDescriptionLen)
+	return s, tmpl(description)
+}
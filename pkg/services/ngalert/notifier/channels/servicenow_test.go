@@ -0,0 +1,199 @@
+package channels
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/secrets/fakes"
+	secretsManager "github.com/grafana/grafana/pkg/services/secrets/manager"
+)
+
+func TestServiceNowNotifier(t *testing.T) {
+	tmpl := templateForTests(t)
+
+	externalURL, err := url.Parse("http://localhost")
+	require.NoError(t, err)
+	tmpl.ExternalURL = externalURL
+
+	firing := []*types.Alert{
+		{
+			Alert: model.Alert{
+				Labels:      model.LabelSet{"alertname": "alert1", "lbl1": "val1"},
+				Annotations: model.LabelSet{"ann1": "annv1"},
+			},
+		},
+	}
+	resolved := []*types.Alert{
+		{
+			Alert: model.Alert{
+				Labels:      model.LabelSet{"alertname": "alert1", "lbl1": "val1"},
+				Annotations: model.LabelSet{"ann1": "annv1"},
+				StartsAt:    time.Now().Add(-time.Hour),
+				EndsAt:      time.Now().Add(-time.Minute),
+			},
+		},
+	}
+	correlationID := notify.Key("alertname").Hash()
+
+	cases := []struct {
+		name         string
+		settings     string
+		alerts       []*types.Alert
+		activeSysID  string
+		expRequests  []string
+		expLastBody  string
+		expInitError string
+	}{
+		{
+			name: "Opens an incident when the alert group starts firing",
+			settings: `{
+				"user": "grafana",
+				"password": "secret",
+				"assignmentGroup": "Operations",
+				"description": "{{ len .Alerts.Firing }} firing"
+			}`,
+			alerts:      firing,
+			expRequests: []string{"GET /api/now/table/incident", "POST /api/now/table/incident"},
+			expLastBody: `{
+				"short_description": "[FIRING:1]  (val1)",
+				"description": "1 firing",
+				"correlation_id": "` + correlationID + `",
+				"correlation_display": "Grafana",
+				"assignment_group": "Operations"
+			}`,
+		}, {
+			name: "Updates the active incident of the alert group",
+			settings: `{
+				"user": "grafana",
+				"password": "secret",
+				"table": "u_alerts",
+				"description": "{{ len .Alerts.Firing }} firing"
+			}`,
+			alerts:      firing,
+			activeSysID: "abc123",
+			expRequests: []string{"GET /api/now/table/u_alerts", "PATCH /api/now/table/u_alerts/abc123"},
+			expLastBody: `{"short_description": "[FIRING:1]  (val1)", "description": "1 firing"}`,
+		}, {
+			name: "Resolves the active incident when the alert group resolves",
+			settings: `{
+				"user": "grafana",
+				"password": "secret",
+				"description": "{{ len .Alerts.Resolved }} resolved"
+			}`,
+			alerts:      resolved,
+			activeSysID: "abc123",
+			expRequests: []string{"GET /api/now/table/incident", "PATCH /api/now/table/incident/abc123"},
+			expLastBody: `{
+				"short_description": "[RESOLVED]  (val1)",
+				"description": "1 resolved",
+				"state": "6",
+				"close_code": "Solved (Permanently)",
+				"close_notes": "1 resolved"
+			}`,
+		}, {
+			name: "Does nothing when a resolved alert group has no active incident",
+			settings: `{
+				"user": "grafana",
+				"password": "secret"
+			}`,
+			alerts:      resolved,
+			expRequests: []string{"GET /api/now/table/incident"},
+		}, {
+			name: "Truncates a long short description by characters",
+			settings: `{
+				"user": "grafana",
+				"password": "secret",
+				"shortDescription": "` + strings.Repeat("ä", 200) + `",
+				"description": "{{ len .Alerts.Firing }} firing"
+			}`,
+			alerts:      firing,
+			activeSysID: "abc123",
+			expRequests: []string{"GET /api/now/table/incident", "PATCH /api/now/table/incident/abc123"},
+			expLastBody: `{"short_description": "` + strings.Repeat("ä", 157) + `...", "description": "1 firing"}`,
+		}, {
+			name:         "Error in initing: missing password",
+			settings:     `{"url": "https://example.service-now.com", "user": "grafana"}`,
+			expInitError: `could not find ServiceNow password in settings`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var requests, bodies []string
+			var query url.Values
+			var user, password string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				requests = append(requests, r.Method+" "+r.URL.Path)
+				bodies = append(bodies, string(b))
+				user, password, _ = r.BasicAuth()
+				switch {
+				case r.Method == http.MethodGet && c.activeSysID != "":
+					query = r.URL.Query()
+					_, _ = fmt.Fprintf(w, `{"result": [{"sys_id": %q}]}`, c.activeSysID)
+				case r.Method == http.MethodGet:
+					query = r.URL.Query()
+					_, _ = w.Write([]byte(`{"result": []}`))
+				case r.Method == http.MethodPost:
+					w.WriteHeader(http.StatusCreated)
+					_, _ = w.Write([]byte(`{"result": {"sys_id": "def456", "number": "INC0010001"}}`))
+				default:
+					_, _ = w.Write([]byte(`{"result": {}}`))
+				}
+			}))
+			defer server.Close()
+
+			settingsJSON, err := simplejson.NewJson([]byte(c.settings))
+			require.NoError(t, err)
+			if _, ok := settingsJSON.CheckGet("url"); !ok {
+				settingsJSON.Set("url", server.URL)
+			}
+			secureSettings := make(map[string][]byte)
+
+			m := &NotificationChannelConfig{
+				Name:           "servicenow_testing",
+				Type:           "servicenow",
+				Settings:       settingsJSON,
+				SecureSettings: secureSettings,
+			}
+
+			secretsService := secretsManager.SetupTestService(t, fakes.NewFakeSecretsStore())
+			decryptFn := secretsService.GetDecryptedValue
+			cfg, err := NewServiceNowConfig(m, decryptFn)
+			if c.expInitError != "" {
+				require.Error(t, err)
+				require.Equal(t, c.expInitError, err.Error())
+				return
+			}
+			require.NoError(t, err)
+
+			ctx := notify.WithGroupKey(context.Background(), "alertname")
+			ctx = notify.WithGroupLabels(ctx, model.LabelSet{"alertname": ""})
+			pn := NewServiceNowNotifier(cfg, tmpl)
+			ok, err := pn.Notify(ctx, c.alerts...)
+			require.NoError(t, err)
+			require.True(t, ok)
+
+			require.Equal(t, c.expRequests, requests)
+			require.Equal(t, "correlation_id="+correlationID+"^active=true", query.Get("sysparm_query"))
+			if c.expLastBody != "" {
+				require.JSONEq(t, c.expLastBody, bodies[len(bodies)-1])
+			}
+			require.Equal(t, "grafana", user)
+			require.Equal(t, "secret", password)
+		})
+	}
+}
@@ -126,7 +126,7 @@ func NewSlackConfig(factoryConfig FactoryConfig) (*SlackConfig, error) {
 		IconEmoji:                 channelConfig.Settings.Get("icon_emoji").MustString(),
 		IconURL:                   channelConfig.Settings.Get("icon_url").MustString(),
 		Token:                     token,
-		Text:                      channelConfig.Settings.Get("text").MustString(`{{ template "default.message" . }}`),
+		Text:                      channelConfig.Settings.Get("text").MustString(DefaultMessageEmbed),
 		Title:                     channelConfig.Settings.Get("title").MustString(DefaultMessageTitleEmbed),
 	}, nil
 }
@@ -262,6 +262,7 @@ var sendSlackRequest = func(request *http.Request, logger log.Logger) (retErr er
 	if err != nil {
 		return fmt.Errorf("failed to read response body: %w", err)
 	}
+	notifications.RecordWebhookResponse(request.Context(), resp.StatusCode, body)
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		logger.Error("Slack API request failed", "url", request.URL.String(), "statusCode", resp.Status, "body", string(body))
@@ -298,6 +299,7 @@ func (sn *SlackNotifier) buildSlackMessage(ctx context.Context, alrts []*types.A
 
 	ruleURL := joinUrlPath(sn.tmpl.ExternalURL.String(), "/alerting/list", sn.log)
 
+	title, text := slackNotification(tmpl, sn.Title, sn.Text)
 	req := &slackMessage{
 		Channel:   tmpl(sn.Recipient),
 		Username:  tmpl(sn.Username),
@@ -308,13 +310,13 @@ func (sn *SlackNotifier) buildSlackMessage(ctx context.Context, alrts []*types.A
 		Attachments: []attachment{
 			{
 				Color:      getAlertStatusColor(alerts.Status()),
-				Title:      tmpl(sn.Title),
-				Fallback:   tmpl(sn.Title),
+				Title:      title,
+				Fallback:   title,
 				Footer:     "Grafana v" + setting.BuildVersion,
 				FooterIcon: FooterIconURL,
 				Ts:         time.Now().Unix(),
 				TitleLink:  ruleURL,
-				Text:       tmpl(sn.Text),
+				Text:       text,
 				Fields:     nil, // TODO. Should be a config.
 			},
 		},
@@ -355,7 +357,7 @@ func (sn *SlackNotifier) buildSlackMessage(ctx context.Context, alrts []*types.A
 			mentionsBuilder.WriteString(fmt.Sprintf("<@%s>", tmpl(u)))
 		}
 		// When mentioning a user, we need to provide text for notifications
-		req.Text = tmpl(sn.Title)
+		req.Text = title
 	}
 
 	if mentionsBuilder.Len() > 0 {
@@ -376,3 +378,9 @@ func (sn *SlackNotifier) buildSlackMessage(ctx context.Context, alrts []*types.A
 func (sn *SlackNotifier) SendResolved() bool {
 	return !sn.GetDisableResolveMessage()
 }
+
+// slackNotification renders the title and the text of an attachment.
+func slackNotification(tmpl func(string) string, title, text string) (string, string) {
+	t := tmpl(title)
+	return t, tmpl(text)
+}
@@ -14,6 +14,9 @@ import (
 	"github.com/grafana/grafana/pkg/services/notifications"
 )
 
+// teamsDefaultMessage is the default template of the text of the section of a message card.
+const teamsDefaultMessage = `{{ template "teams.default.message" .}}`
+
 // TeamsNotifier is responsible for sending
 // alert notifications to Microsoft teams.
 type TeamsNotifier struct {
@@ -55,7 +58,7 @@ func NewTeamsConfig(config *NotificationChannelConfig) (*TeamsConfig, error) {
 	return &TeamsConfig{
 		NotificationChannelConfig: config,
 		URL:                       URL,
-		Message:                   config.Settings.Get("message").MustString(`{{ template "teams.default.message" .}}`),
+		Message:                   config.Settings.Get("message").MustString(teamsDefaultMessage),
 		Title:                     config.Settings.Get("title").MustString(DefaultMessageTitleEmbed),
 		SectionTitle:              config.Settings.Get("sectiontitle").MustString(""),
 	}, nil
@@ -103,12 +106,11 @@ func (tn *TeamsNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool,
 		},
 		as...)
 
-	// Note: these template calls must remain in this order
-	title := tmpl(tn.Title)
+	title, sectionTitle, message := teamsNotification(tmpl, tn.Title, tn.SectionTitle, tn.Message)
 	sections := []map[string]interface{}{
 		{
-			"title": tmpl(tn.SectionTitle),
-			"text":  tmpl(tn.Message),
+			"title": sectionTitle,
+			"text":  message,
 		},
 	}
 
@@ -167,3 +169,11 @@ func (tn *TeamsNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool,
 func (tn *TeamsNotifier) SendResolved() bool {
 	return !tn.GetDisableResolveMessage()
 }
+
+// teamsNotification renders the title of a message card and the title and the text of its section.
+func teamsNotification(tmpl func(string) string, title, sectionTitle, message string) (string, string, string) {
+	// Note: these template calls must remain in this order
+	t := tmpl(title)
+	st := tmpl(sectionTitle)
+	return t, st, tmpl(message)
+}
@@ -113,20 +113,7 @@ func (tn *ThreemaNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool
 	data.Set("to", tn.RecipientID)
 	data.Set("secret", tn.APISecret)
 
-	// Determine emoji
-	stateEmoji := "\u26A0\uFE0F " // Warning sign
-	alerts := types.Alerts(as...)
-	if alerts.Status() == model.AlertResolved {
-		stateEmoji = "\u2705 " // Check Mark Button
-	}
-
-	// Build message
-	message := fmt.Sprintf("%s%s\n\n*Message:*\n%s\n*URL:* %s\n",
-		stateEmoji,
-		tmpl(DefaultMessageTitleEmbed),
-		tmpl(`{{ template "default.message" . }}`),
-		path.Join(tn.tmpl.ExternalURL.String(), "/alerting/list"),
-	)
+	message := threemaMessage(tmpl, types.Alerts(as...).Status(), path.Join(tn.tmpl.ExternalURL.String(), "/alerting/list"))
 	data.Set("text", message)
 
 	if tmplErr != nil {
@@ -152,3 +139,19 @@ func (tn *ThreemaNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool
 func (tn *ThreemaNotifier) SendResolved() bool {
 	return !tn.GetDisableResolveMessage()
 }
+
+// threemaMessage renders the message of a notification.
+func threemaMessage(tmpl func(string) string, status model.AlertStatus, ruleURL string) string {
+	// Determine emoji
+	stateEmoji := "\u26A0\uFE0F " // Warning sign
+	if status == model.AlertResolved {
+		stateEmoji = "\u2705 " // Check Mark Button
+	}
+
+	return fmt.Sprintf("%s%s\n\n*Message:*\n%s\n*URL:* %s\n",
+		stateEmoji,
+		tmpl(DefaultMessageTitleEmbed),
+		tmpl(DefaultMessageEmbed),
+		ruleURL,
+	)
+}
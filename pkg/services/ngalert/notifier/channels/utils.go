@@ -14,6 +14,7 @@ import (
 	"path"
 	"path/filepath"
 	"time"
+	"unicode/utf8"
 
 	"github.com/prometheus/alertmanager/notify"
 	"github.com/prometheus/alertmanager/types"
@@ -21,6 +22,7 @@ import (
 
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/notifications"
 	"github.com/grafana/grafana/pkg/util"
 
 	"github.com/grafana/grafana/pkg/components/simplejson"
@@ -115,6 +117,26 @@ func getTokenFromAnnotations(annotations model.LabelSet) string {
 	return ""
 }
 
+// truncateRunes shortens s to n characters, replacing the end with an ellipsis.
+func truncateRunes(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n-3]) + "..."
+	}
+	return s
+}
+
+// truncateBytes shortens s to at most n bytes, replacing the end with an ellipsis. It does not split characters.
+func truncateBytes(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	end := n - 3
+	for end > 0 && !utf8.RuneStart(s[end]) {
+		end--
+	}
+	return s[:end] + "..."
+}
+
 type UnavailableImageStore struct{}
 
 // Get returns the image with the corresponding token, or ErrImageNotFound.
@@ -166,6 +188,8 @@ type NotificationChannelConfig struct {
 }
 
 type httpCfg struct {
+	// method defaults to POST.
+	method   string
 	body     []byte
 	user     string
 	password string
@@ -178,7 +202,11 @@ var sendHTTPRequest = func(ctx context.Context, url *url.URL, cfg httpCfg, logge
 	if len(cfg.body) > 0 {
 		reader = bytes.NewReader(cfg.body)
 	}
-	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url.String(), reader)
+	method := cfg.method
+	if method == "" {
+		method = http.MethodPost
+	}
+	request, err := http.NewRequestWithContext(ctx, method, url.String(), reader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
 	}
@@ -187,6 +215,7 @@ var sendHTTPRequest = func(ctx context.Context, url *url.URL, cfg httpCfg, logge
 	}
 
 	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept", "application/json")
 	request.Header.Set("User-Agent", "Grafana")
 	netTransport := &http.Transport{
 		TLSClientConfig: &tls.Config{
@@ -216,6 +245,7 @@ var sendHTTPRequest = func(ctx context.Context, url *url.URL, cfg httpCfg, logge
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
+	notifications.RecordWebhookResponse(ctx, resp.StatusCode, respBody)
 
 	if resp.StatusCode/100 != 2 {
 		logger.Warn("HTTP request failed", "url", request.URL.String(), "statusCode", resp.Status, "body",
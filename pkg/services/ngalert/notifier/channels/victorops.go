@@ -107,12 +107,13 @@ func (vn *VictoropsNotifier) Notify(ctx context.Context, as ...*types.Alert) (bo
 		return false, err
 	}
 
+	title, message := victorOpsNotification(tmpl)
 	bodyJSON := simplejson.New()
 	bodyJSON.Set("message_type", messageType)
 	bodyJSON.Set("entity_id", groupKey.Hash())
-	bodyJSON.Set("entity_display_name", tmpl(DefaultMessageTitleEmbed))
+	bodyJSON.Set("entity_display_name", title)
 	bodyJSON.Set("timestamp", time.Now().Unix())
-	bodyJSON.Set("state_message", tmpl(`{{ template "default.message" . }}`))
+	bodyJSON.Set("state_message", message)
 	bodyJSON.Set("monitoring_tool", "Grafana v"+setting.BuildVersion)
 
 	ruleURL := joinUrlPath(vn.tmpl.ExternalURL.String(), "/alerting/list", vn.log)
@@ -149,3 +150,9 @@ func (vn *VictoropsNotifier) Notify(ctx context.Context, as ...*types.Alert) (bo
 func (vn *VictoropsNotifier) SendResolved() bool {
 	return !vn.GetDisableResolveMessage()
 }
+
+// victorOpsNotification renders the display name and the message of an alert.
+func victorOpsNotification(tmpl func(string) string) (string, string) {
+	t := tmpl(DefaultMessageTitleEmbed)
+	return t, tmpl(DefaultMessageEmbed)
+}
@@ -2,8 +2,17 @@ package channels
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/models"
@@ -24,20 +33,45 @@ type WebhookNotifier struct {
 	Password   string
 	HTTPMethod string
 	MaxAlerts  int
-	log        log.Logger
-	ns         notifications.WebhookSender
-	images     ImageStore
-	tmpl       *template.Template
-	orgID      int64
+	// Body is the template of the request body. The Grafana webhook JSON is sent if it is empty.
+	Body            string
+	ContentType     string
+	Headers         map[string]string
+	HMACSecret      string
+	SignatureHeader string
+	TimestampHeader string
+	client          *http.Client
+	log             log.Logger
+	ns              notifications.WebhookSender
+	images          ImageStore
+	tmpl            *template.Template
+	orgID           int64
+	now             func() time.Time
 }
 
+// Default names of the headers of the signature of the request body.
+const (
+	webhookDefaultSignatureHeader = "X-Grafana-Alerting-Signature"
+	webhookDefaultTimestampHeader = "X-Grafana-Alerting-Timestamp"
+)
+
 type WebhookConfig struct {
 	*NotificationChannelConfig
-	URL        string
-	User       string
-	Password   string
-	HTTPMethod string
-	MaxAlerts  int
+	URL         string
+	User        string
+	Password    string
+	HTTPMethod  string
+	MaxAlerts   int
+	Body        string
+	ContentType string
+	Headers     map[string]string
+	// HMACSecret signs the request with HMAC-SHA256 if set. The signature is computed over the timestamp
+	// of the request, a dot and the body, so that receivers can reject replayed requests.
+	HMACSecret      string
+	SignatureHeader string
+	TimestampHeader string
+	// TLSConfig has the client certificate and the CA certificate of mTLS if they are set.
+	TLSConfig *tls.Config
 }
 
 func WebHookFactory(fc FactoryConfig) (NotificationChannel, error) {
@@ -56,6 +90,14 @@ func NewWebHookConfig(config *NotificationChannelConfig, decryptFunc GetDecrypte
 	if url == "" {
 		return nil, errors.New("could not find url property in settings")
 	}
+	headers, err := webhookHeaders(config)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := webhookTLSConfig(config, decryptFunc)
+	if err != nil {
+		return nil, err
+	}
 	return &WebhookConfig{
 		NotificationChannelConfig: config,
 		URL:                       url,
@@ -63,12 +105,78 @@ func NewWebHookConfig(config *NotificationChannelConfig, decryptFunc GetDecrypte
 		Password:                  decryptFunc(context.Background(), config.SecureSettings, "password", config.Settings.Get("password").MustString()),
 		HTTPMethod:                config.Settings.Get("httpMethod").MustString("POST"),
 		MaxAlerts:                 config.Settings.Get("maxAlerts").MustInt(0),
+		Body:                      config.Settings.Get("body").MustString(),
+		ContentType:               config.Settings.Get("contentType").MustString("application/json"),
+		Headers:                   headers,
+		HMACSecret:                decryptFunc(context.Background(), config.SecureSettings, "hmacSecret", config.Settings.Get("hmacSecret").MustString()),
+		SignatureHeader:           config.Settings.Get("signatureHeader").MustString(webhookDefaultSignatureHeader),
+		TimestampHeader:           config.Settings.Get("timestampHeader").MustString(webhookDefaultTimestampHeader),
+		TLSConfig:                 tlsConfig,
 	}, nil
 }
 
+// webhookHeaders returns the custom headers of the settings. They are either an object or a JSON string of an object.
+func webhookHeaders(config *NotificationChannelConfig) (map[string]string, error) {
+	raw := config.Settings.Get("httpHeaders")
+	if s, err := raw.String(); err == nil {
+		if s == "" {
+			return nil, nil
+		}
+		var headers map[string]string
+		if err := json.Unmarshal([]byte(s), &headers); err != nil {
+			return nil, fmt.Errorf("invalid httpHeaders: %w", err)
+		}
+		return headers, nil
+	}
+	values := raw.MustMap()
+	headers := make(map[string]string, len(values))
+	for k, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid httpHeaders: value of header %s is not a string", k)
+		}
+		headers[k] = s
+	}
+	return headers, nil
+}
+
+// webhookTLSConfig returns the TLS configuration of mTLS, or nil if neither a client certificate nor a CA certificate is set.
+func webhookTLSConfig(config *NotificationChannelConfig, decryptFunc GetDecryptedValueFn) (*tls.Config, error) {
+	clientCert := config.Settings.Get("tlsClientCert").MustString()
+	clientKey := decryptFunc(context.Background(), config.SecureSettings, "tlsClientKey", config.Settings.Get("tlsClientKey").MustString())
+	caCert := config.Settings.Get("tlsCACert").MustString()
+	if clientCert == "" && clientKey == "" && caCert == "" {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		Renegotiation: tls.RenegotiateFreelyAsClient,
+	}
+	if clientCert != "" || clientKey != "" {
+		cert, err := tls.X509KeyPair([]byte(clientCert), []byte(clientKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid TLS client certificate or key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	if caCert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(caCert)) {
+			return nil, errors.New("invalid TLS CA certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return tlsConfig, nil
+}
+
 // NewWebHookNotifier is the constructor for
 // the WebHook notifier.
 func NewWebHookNotifier(config *WebhookConfig, ns notifications.WebhookSender, images ImageStore, t *template.Template) *WebhookNotifier {
+	// The client is created once per configuration so that its connections are reused.
+	var client *http.Client
+	if config.TLSConfig != nil {
+		client = notifications.NewWebhookClient(config.TLSConfig)
+	}
 	return &WebhookNotifier{
 		Base: NewBase(&models.AlertNotification{
 			Uid:                   config.UID,
@@ -77,16 +185,24 @@ func NewWebHookNotifier(config *WebhookConfig, ns notifications.WebhookSender, i
 			DisableResolveMessage: config.DisableResolveMessage,
 			Settings:              config.Settings,
 		}),
-		orgID:      config.OrgID,
-		URL:        config.URL,
-		User:       config.User,
-		Password:   config.Password,
-		HTTPMethod: config.HTTPMethod,
-		MaxAlerts:  config.MaxAlerts,
-		log:        log.New("alerting.notifier.webhook"),
-		ns:         ns,
-		images:     images,
-		tmpl:       t,
+		orgID:           config.OrgID,
+		URL:             config.URL,
+		User:            config.User,
+		Password:        config.Password,
+		HTTPMethod:      config.HTTPMethod,
+		MaxAlerts:       config.MaxAlerts,
+		Body:            config.Body,
+		ContentType:     config.ContentType,
+		Headers:         config.Headers,
+		HMACSecret:      config.HMACSecret,
+		SignatureHeader: config.SignatureHeader,
+		TimestampHeader: config.TimestampHeader,
+		client:          client,
+		log:             log.New("alerting.notifier.webhook"),
+		ns:              ns,
+		images:          images,
+		tmpl:            t,
+		now:             time.Now,
 	}
 }
 
@@ -128,36 +244,42 @@ func (wn *WebhookNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool
 		},
 		as...)
 
-	msg := &webhookMessage{
-		Version:         "1",
-		ExtendedData:    data,
-		GroupKey:        groupKey.String(),
-		TruncatedAlerts: numTruncated,
-		OrgID:           wn.orgID,
-		Title:           tmpl(DefaultMessageTitleEmbed),
-		Message:         tmpl(`{{ template "default.message" . }}`),
-	}
-	if types.Alerts(as...).Status() == model.AlertFiring {
-		msg.State = string(models.AlertStateAlerting)
+	var body string
+	if wn.Body != "" {
+		body = tmpl(wn.Body)
+		if tmplErr != nil {
+			return false, fmt.Errorf("failed to template webhook body: %w", tmplErr)
+		}
 	} else {
-		msg.State = string(models.AlertStateOK)
+		b, err := wn.defaultBody(data, groupKey.String(), numTruncated, tmpl, as)
+		if err != nil {
+			return false, err
+		}
+		if tmplErr != nil {
+			wn.log.Warn("failed to template webhook message", "err", tmplErr.Error())
+		}
+		body = string(b)
 	}
 
-	if tmplErr != nil {
-		wn.log.Warn("failed to template webhook message", "err", tmplErr.Error())
+	headers := make(map[string]string, len(wn.Headers)+2)
+	for k, v := range wn.Headers {
+		headers[k] = v
 	}
-
-	body, err := json.Marshal(msg)
-	if err != nil {
-		return false, err
+	if wn.HMACSecret != "" {
+		timestamp := strconv.FormatInt(wn.now().Unix(), 10)
+		headers[wn.TimestampHeader] = timestamp
+		headers[wn.SignatureHeader] = webhookSignature(wn.HMACSecret, timestamp, body)
 	}
 
 	cmd := &models.SendWebhookSync{
-		Url:        wn.URL,
-		User:       wn.User,
-		Password:   wn.Password,
-		Body:       string(body),
-		HttpMethod: wn.HTTPMethod,
+		Url:         wn.URL,
+		User:        wn.User,
+		Password:    wn.Password,
+		Body:        body,
+		HttpMethod:  wn.HTTPMethod,
+		HttpHeader:  headers,
+		ContentType: wn.ContentType,
+		Client:      wn.client,
 	}
 
 	if err := wn.ns.SendWebhookSync(ctx, cmd); err != nil {
@@ -167,6 +289,35 @@ func (wn *WebhookNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool
 	return true, nil
 }
 
+// defaultBody returns the Grafana webhook JSON.
+func (wn *WebhookNotifier) defaultBody(data *ExtendedData, groupKey string, numTruncated int, tmpl func(string) string, as []*types.Alert) ([]byte, error) {
+	title, message := webhookNotification(tmpl)
+	msg := &webhookMessage{
+		Version:         "1",
+		ExtendedData:    data,
+		GroupKey:        groupKey,
+		TruncatedAlerts: numTruncated,
+		OrgID:           wn.orgID,
+		Title:           title,
+		Message:         message,
+	}
+	if types.Alerts(as...).Status() == model.AlertFiring {
+		msg.State = string(models.AlertStateAlerting)
+	} else {
+		msg.State = string(models.AlertStateOK)
+	}
+	return json.Marshal(msg)
+}
+
+// webhookSignature returns the hex encoded HMAC-SHA256 of the timestamp and the body of a request.
+func webhookSignature(secret, timestamp, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write([]byte(body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
 func truncateAlerts(maxAlerts int, alerts []*types.Alert) ([]*types.Alert, int) {
 	if maxAlerts > 0 && len(alerts) > maxAlerts {
 		return alerts[:maxAlerts], len(alerts) - maxAlerts
@@ -178,3 +329,9 @@ func truncateAlerts(maxAlerts int, alerts []*types.Alert) ([]*types.Alert, int)
 func (wn *WebhookNotifier) SendResolved() bool {
 	return !wn.GetDisableResolveMessage()
 }
+
+// webhookNotification renders the title and the message of the Grafana webhook JSON.
+func webhookNotification(tmpl func(string) string) (string, string) {
+	t := tmpl(DefaultMessageTitleEmbed)
+	return t, tmpl(DefaultMessageEmbed)
+}
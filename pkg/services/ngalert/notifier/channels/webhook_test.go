@@ -2,9 +2,20 @@ package channels
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
 	"encoding/json"
+	"encoding/pem"
+	"math/big"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/grafana/grafana/pkg/components/simplejson"
 	"github.com/grafana/grafana/pkg/services/secrets/fakes"
@@ -226,3 +237,142 @@ func TestWebhookNotifier(t *testing.T) {
 		})
 	}
 }
+
+func TestWebhookNotifierCustomRequest(t *testing.T) {
+	tmpl := templateForTests(t)
+	externalURL, err := url.Parse("http://localhost")
+	require.NoError(t, err)
+	tmpl.ExternalURL = externalURL
+
+	alerts := []*types.Alert{{
+		Alert: model.Alert{
+			Labels:      model.LabelSet{"alertname": "alert1", "lbl1": "val1"},
+			Annotations: model.LabelSet{"ann1": "annv1"},
+		},
+	}}
+	secretsService := secretsManager.SetupTestService(t, fakes.NewFakeSecretsStore())
+	newNotifier := func(t *testing.T, settings string) (*WebhookNotifier, *notificationServiceMock) {
+		t.Helper()
+		settingsJSON, err := simplejson.NewJson([]byte(settings))
+		require.NoError(t, err)
+		cfg, err := NewWebHookConfig(&NotificationChannelConfig{
+			OrgID:          1,
+			Name:           "webhook_testing",
+			Type:           "webhook",
+			Settings:       settingsJSON,
+			SecureSettings: map[string][]byte{},
+		}, secretsService.GetDecryptedValue)
+		require.NoError(t, err)
+		sender := mockNotificationService()
+		n := NewWebHookNotifier(cfg, sender, &UnavailableImageStore{}, tmpl)
+		n.now = func() time.Time { return time.Unix(1654000000, 0) }
+		return n, sender
+	}
+	ctx := notify.WithGroupKey(context.Background(), "alertname")
+	ctx = notify.WithGroupLabels(ctx, model.LabelSet{"alertname": ""})
+	ctx = notify.WithReceiverName(ctx, "my_receiver")
+
+	t.Run("templates the body and sets the content type and headers", func(t *testing.T) {
+		n, sender := newNotifier(t, `{
+			"url": "http://localhost/test",
+			"body": "{{ range .Alerts }}{{ .Labels.alertname }}={{ .Status }};{{ end }}",
+			"contentType": "text/plain",
+			"httpHeaders": {"X-Tenant": "team-a"}
+		}`)
+		ok, err := n.Notify(ctx, alerts...)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, "alert1=firing;", sender.Webhook.Body)
+		require.Equal(t, "text/plain", sender.Webhook.ContentType)
+		require.Equal(t, map[string]string{"X-Tenant": "team-a"}, sender.Webhook.HttpHeader)
+	})
+
+	t.Run("fails if the body cannot be templated", func(t *testing.T) {
+		n, _ := newNotifier(t, `{"url": "http://localhost/test", "body": "{{ .Missing.Field }}"}`)
+		ok, err := n.Notify(ctx, alerts...)
+		require.Error(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("signs the body", func(t *testing.T) {
+		n, sender := newNotifier(t, `{"url": "http://localhost/test", "hmacSecret": "s3cr3t", "httpHeaders": "{\"X-Tenant\": \"team-a\"}"}`)
+		ok, err := n.Notify(ctx, alerts...)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		headers := sender.Webhook.HttpHeader
+		require.Equal(t, "team-a", headers["X-Tenant"])
+		require.Equal(t, "1654000000", headers[webhookDefaultTimestampHeader])
+		mac := hmac.New(sha256.New, []byte("s3cr3t"))
+		mac.Write([]byte("1654000000." + sender.Webhook.Body))
+		require.Equal(t, hex.EncodeToString(mac.Sum(nil)), headers[webhookDefaultSignatureHeader])
+		require.Equal(t, "application/json", sender.Webhook.ContentType)
+	})
+}
+
+func TestNewWebHookConfigTLS(t *testing.T) {
+	cert, key := certificateForTests(t)
+	secretsService := secretsManager.SetupTestService(t, fakes.NewFakeSecretsStore())
+	newConfig := func(settings map[string]interface{}) (*WebhookConfig, error) {
+		settings["url"] = "https://localhost/test"
+		return NewWebHookConfig(&NotificationChannelConfig{
+			Name:           "webhook_testing",
+			Type:           "webhook",
+			Settings:       simplejson.NewFromAny(settings),
+			SecureSettings: map[string][]byte{},
+		}, secretsService.GetDecryptedValue)
+	}
+
+	cfg, err := newConfig(map[string]interface{}{})
+	require.NoError(t, err)
+	require.Nil(t, cfg.TLSConfig)
+
+	cfg, err = newConfig(map[string]interface{}{"tlsClientCert": cert, "tlsClientKey": key, "tlsCACert": cert})
+	require.NoError(t, err)
+	require.Len(t, cfg.TLSConfig.Certificates, 1)
+	require.NotNil(t, cfg.TLSConfig.RootCAs)
+
+	// The notifier sends every request with the same client.
+	tmpl := templateForTests(t)
+	tmpl.ExternalURL, err = url.Parse("http://localhost")
+	require.NoError(t, err)
+	sender := &notificationServiceMock{}
+	n := NewWebHookNotifier(cfg, sender, &UnavailableImageStore{}, tmpl)
+	ctx := notify.WithGroupKey(context.Background(), "alertname")
+	ctx = notify.WithGroupLabels(ctx, model.LabelSet{"alertname": ""})
+	alert := &types.Alert{Alert: model.Alert{Labels: model.LabelSet{"alertname": "alert1"}}}
+	_, err = n.Notify(ctx, alert)
+	require.NoError(t, err)
+	client := sender.Webhook.Client
+	require.NotNil(t, client)
+	_, err = n.Notify(ctx, alert)
+	require.NoError(t, err)
+	require.Same(t, client, sender.Webhook.Client)
+
+	_, err = newConfig(map[string]interface{}{"tlsClientCert": cert})
+	require.Error(t, err)
+	_, err = newConfig(map[string]interface{}{"tlsCACert": "not a certificate"})
+	require.Error(t, err)
+	_, err = newConfig(map[string]interface{}{"httpHeaders": "not JSON"})
+	require.Error(t, err)
+}
+
+// certificateForTests returns a PEM encoded self-signed certificate and its key.
+func certificateForTests(t *testing.T) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "grafana"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
@@ -39,7 +39,7 @@ func NewWeComConfig(config *NotificationChannelConfig, decryptFunc GetDecryptedV
 	return &WeComConfig{
 		NotificationChannelConfig: config,
 		URL:                       url,
-		Message:                   config.Settings.Get("message").MustString(`{{ template "default.message" .}}`),
+		Message:                   config.Settings.Get("message").MustString(DefaultMessageEmbed),
 	}, nil
 }
 
@@ -81,10 +81,8 @@ func (w *WeComNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool, e
 	bodyMsg := map[string]interface{}{
 		"msgtype": "markdown",
 	}
-	content := fmt.Sprintf("# %s\n%s\n",
-		tmpl(DefaultMessageTitleEmbed),
-		tmpl(w.Message),
-	)
+	title, message := weComNotification(tmpl, w.Message)
+	content := fmt.Sprintf("# %s\n%s\n", title, message)
 
 	bodyMsg["markdown"] = map[string]interface{}{
 		"content": content,
@@ -111,3 +109,9 @@ func (w *WeComNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool, e
 func (w *WeComNotifier) SendResolved() bool {
 	return !w.GetDisableResolveMessage()
 }
+
+// weComNotification renders the title and the message of a markdown message.
+func weComNotification(tmpl func(string) string, message string) (string, string) {
+	t := tmpl(DefaultMessageTitleEmbed)
+	return t, tmpl(message)
+}
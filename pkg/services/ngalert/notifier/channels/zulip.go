@@ -0,0 +1,169 @@
+package channels
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/notifications"
+)
+
+const (
+	// zulipMaxTopicLen is the maximum number of characters of a Zulip topic.
+	zulipMaxTopicLen = 60
+	// zulipMaxContentLen is the maximum size of a Zulip message in bytes.
+	zulipMaxContentLen = 10000
+	// zulipDefaultTopic keeps the notifications of an alert group in one topic, including the resolved one.
+	zulipDefaultTopic = `{{ .GroupLabels.SortedPairs.Values | join " " }}`
+)
+
+// ZulipNotifier is responsible for sending alert notifications to a Zulip stream.
+type ZulipNotifier struct {
+	*Base
+	URL      string
+	BotEmail string
+	APIKey   string
+	Stream   string
+	Topic    string
+	Message  string
+	tmpl     *template.Template
+	log      log.Logger
+	ns       notifications.WebhookSender
+}
+
+type ZulipConfig struct {
+	*NotificationChannelConfig
+	URL      string
+	BotEmail string
+	APIKey   string
+	Stream   string
+	Topic    string
+	Message  string
+}
+
+func ZulipFactory(fc FactoryConfig) (NotificationChannel, error) {
+	cfg, err := NewZulipConfig(fc.Config, fc.DecryptFunc)
+	if err != nil {
+		return nil, receiverInitError{
+			Reason: err.Error(),
+			Cfg:    *fc.Config,
+		}
+	}
+	return NewZulipNotifier(cfg, fc.NotificationService, fc.Template), nil
+}
+
+func NewZulipConfig(config *NotificationChannelConfig, decryptFunc GetDecryptedValueFn) (*ZulipConfig, error) {
+	serverURL := config.Settings.Get("url").MustString()
+	if serverURL == "" {
+		return nil, errors.New("could not find Zulip server URL in settings")
+	}
+	botEmail := config.Settings.Get("botEmail").MustString()
+	if botEmail == "" {
+		return nil, errors.New("could not find Zulip bot email in settings")
+	}
+	apiKey := decryptFunc(context.Background(), config.SecureSettings, "apiKey", config.Settings.Get("apiKey").MustString())
+	if apiKey == "" {
+		return nil, errors.New("could not find Zulip API key in settings")
+	}
+	stream := config.Settings.Get("stream").MustString()
+	if stream == "" {
+		return nil, errors.New("could not find Zulip stream in settings")
+	}
+	return &ZulipConfig{
+		NotificationChannelConfig: config,
+		URL:                       serverURL,
+		BotEmail:                  botEmail,
+		APIKey:                    apiKey,
+		Stream:                    stream,
+		Topic:                     config.Settings.Get("topic").MustString(zulipDefaultTopic),
+		Message:                   config.Settings.Get("message").MustString(DefaultMessageEmbed),
+	}, nil
+}
+
+// NewZulipNotifier is the constructor for the Zulip notifier
+func NewZulipNotifier(config *ZulipConfig, ns notifications.WebhookSender, t *template.Template) *ZulipNotifier {
+	return &ZulipNotifier{
+		Base: NewBase(&models.AlertNotification{
+			Uid:                   config.UID,
+			Name:                  config.Name,
+			Type:                  config.Type,
+			DisableResolveMessage: config.DisableResolveMessage,
+			Settings:              config.Settings,
+		}),
+		URL:      config.URL,
+		BotEmail: config.BotEmail,
+		APIKey:   config.APIKey,
+		Stream:   config.Stream,
+		Topic:    config.Topic,
+		Message:  config.Message,
+		tmpl:     t,
+		log:      log.New("alerting.notifier.zulip"),
+		ns:       ns,
+	}
+}
+
+// Notify sends an alert notification to a topic of a Zulip stream.
+func (zn *ZulipNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	zn.log.Debug("executing Zulip notification", "notification", zn.Name)
+
+	var tmplErr error
+	tmpl, _ := TmplText(ctx, zn.tmpl, as, zn.log, &tmplErr)
+
+	topic, content := zulipNotification(tmpl, zn.Topic, zn.Message, joinUrlPath(zn.tmpl.ExternalURL.String(), "/alerting/list", zn.log))
+	if tmplErr != nil {
+		zn.log.Warn("failed to template Zulip message", "err", tmplErr.Error())
+	}
+
+	data := url.Values{}
+	data.Set("type", "stream")
+	data.Set("to", zn.Stream)
+	data.Set("topic", topic)
+	data.Set("content", content)
+
+	cmd := &models.SendWebhookSync{
+		Url:        joinUrlPath(zn.URL, "/api/v1/messages", zn.log),
+		User:       zn.BotEmail,
+		Password:   zn.APIKey,
+		Body:       data.Encode(),
+		HttpMethod: "POST",
+		HttpHeader: map[string]string{
+			"Content-Type": "application/x-www-form-urlencoded",
+		},
+	}
+	if err := zn.ns.SendWebhookSync(ctx, cmd); err != nil {
+		zn.log.Error("failed to send Zulip notification", "err", err, "notification", zn.Name)
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (zn *ZulipNotifier) SendResolved() bool {
+	return !zn.GetDisableResolveMessage()
+}
+
+// zulipNotification renders the topic and the content of a message, shortened to the maximum length of a Zulip
+// topic and the maximum size of a Zulip message.
+func zulipNotification(tmpl func(string) string, topic, message, ruleURL string) (string, string) {
+	t := strings.TrimSpace(tmpl(topic))
+	if t == "" {
+		t = "Grafana alerts"
+	}
+	if r := []rune(t); len(r) > zulipMaxTopicLen {
+		t = string(r[:zulipMaxTopicLen-1]) + "…"
+	}
+
+	content := fmt.Sprintf("**%s**\n%s\n[Alerts](%s)",
+		tmpl(DefaultMessageTitleEmbed),
+		tmpl(message),
+		ruleURL,
+	)
+	return t, truncateBytes(content, zulipMaxContentLen)
+}
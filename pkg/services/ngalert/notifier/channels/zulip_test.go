@@ -0,0 +1,162 @@
+package channels
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/secrets/fakes"
+	secretsManager "github.com/grafana/grafana/pkg/services/secrets/manager"
+)
+
+func TestZulipNotifier(t *testing.T) {
+	tmpl := templateForTests(t)
+
+	externalURL, err := url.Parse("http://localhost")
+	require.NoError(t, err)
+	tmpl.ExternalURL = externalURL
+
+	cases := []struct {
+		name         string
+		settings     string
+		alerts       []*types.Alert
+		groupLabels  model.LabelSet
+		expMsg       url.Values
+		expInitError string
+	}{
+		{
+			name: "One alert with the default settings",
+			settings: `{
+				"url": "https://zulip.localhost",
+				"botEmail": "grafana-bot@zulip.localhost",
+				"apiKey": "secret",
+				"stream": "alerts"
+			}`,
+			alerts: []*types.Alert{
+				{
+					Alert: model.Alert{
+						Labels:      model.LabelSet{"alertname": "alert1", "lbl1": "val1"},
+						Annotations: model.LabelSet{"ann1": "annv1", "__dashboardUid__": "abcd", "__panelId__": "efgh"},
+					},
+				},
+			},
+			groupLabels: model.LabelSet{"alertname": "alert1", "cluster": "prod"},
+			expMsg: url.Values{
+				"type":    {"stream"},
+				"to":      {"alerts"},
+				"topic":   {"alert1 prod"},
+				"content": {"**[FIRING:1] alert1 prod **\n**Firing**\n\nValue: [no value]\nLabels:\n - alertname = alert1\n - lbl1 = val1\nAnnotations:\n - ann1 = annv1\nSilence: http://localhost/alerting/silence/new?alertmanager=grafana&matcher=alertname%3Dalert1&matcher=lbl1%3Dval1\nDashboard: http://localhost/d/abcd\nPanel: http://localhost/d/abcd?viewPanel=efgh\n\n[Alerts](http://localhost/alerting/list)"},
+			},
+		}, {
+			name: "Custom topic and message",
+			settings: `{
+				"url": "https://zulip.localhost",
+				"botEmail": "grafana-bot@zulip.localhost",
+				"apiKey": "secret",
+				"stream": "alerts",
+				"topic": "{{ .CommonLabels.lbl1 }} alerts",
+				"message": "{{ len .Alerts.Firing }} firing"
+			}`,
+			alerts: []*types.Alert{
+				{
+					Alert: model.Alert{
+						Labels:      model.LabelSet{"alertname": "alert1", "lbl1": "val1"},
+						Annotations: model.LabelSet{"ann1": "annv1"},
+					},
+				},
+			},
+			groupLabels: model.LabelSet{"alertname": "alert1"},
+			expMsg: url.Values{
+				"type":    {"stream"},
+				"to":      {"alerts"},
+				"topic":   {"val1 alerts"},
+				"content": {"**[FIRING:1] alert1 (val1)**\n1 firing\n[Alerts](http://localhost/alerting/list)"},
+			},
+		}, {
+			name: "Truncates long topics",
+			settings: `{
+				"url": "https://zulip.localhost",
+				"botEmail": "grafana-bot@zulip.localhost",
+				"apiKey": "secret",
+				"stream": "alerts",
+				"message": "{{ len .Alerts.Firing }} firing"
+			}`,
+			alerts: []*types.Alert{
+				{
+					Alert: model.Alert{
+						Labels: model.LabelSet{"alertname": "alert1", "lbl1": "val1"},
+					},
+				},
+			},
+			groupLabels: model.LabelSet{"alertname": "a-very-long-alert-name-that-does-not-fit-into-the-topic-of-zulip"},
+			expMsg: url.Values{
+				"type":    {"stream"},
+				"to":      {"alerts"},
+				"topic":   {"a-very-long-alert-name-that-does-not-fit-into-the-topic-of-…"},
+				"content": {"**[FIRING:1] a-very-long-alert-name-that-does-not-fit-into-the-topic-of-zulip (val1)**\n1 firing\n[Alerts](http://localhost/alerting/list)"},
+			},
+		}, {
+			name: "Error in initing: missing stream",
+			settings: `{
+				"url": "https://zulip.localhost",
+				"botEmail": "grafana-bot@zulip.localhost",
+				"apiKey": "secret"
+			}`,
+			expInitError: `could not find Zulip stream in settings`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			settingsJSON, err := simplejson.NewJson([]byte(c.settings))
+			require.NoError(t, err)
+			secureSettings := make(map[string][]byte)
+
+			m := &NotificationChannelConfig{
+				Name:           "zulip_testing",
+				Type:           "zulip",
+				Settings:       settingsJSON,
+				SecureSettings: secureSettings,
+			}
+
+			webhookSender := mockNotificationService()
+			secretsService := secretsManager.SetupTestService(t, fakes.NewFakeSecretsStore())
+			decryptFn := secretsService.GetDecryptedValue
+			cfg, err := NewZulipConfig(m, decryptFn)
+			if c.expInitError != "" {
+				require.Error(t, err)
+				require.Equal(t, c.expInitError, err.Error())
+				return
+			}
+			require.NoError(t, err)
+
+			ctx := notify.WithGroupKey(context.Background(), "alertname")
+			ctx = notify.WithGroupLabels(ctx, c.groupLabels)
+			pn := NewZulipNotifier(cfg, webhookSender, tmpl)
+			ok, err := pn.Notify(ctx, c.alerts...)
+			require.NoError(t, err)
+			require.True(t, ok)
+
+			require.Equal(t, "https://zulip.localhost/api/v1/messages", webhookSender.Webhook.Url)
+			require.Equal(t, "grafana-bot@zulip.localhost", webhookSender.Webhook.User)
+			require.Equal(t, "secret", webhookSender.Webhook.Password)
+			require.Equal(t, c.expMsg.Encode(), webhookSender.Webhook.Body)
+		})
+	}
+}
+
+func TestZulipContent_Truncated(t *testing.T) {
+	tmpl := func(s string) string { return s }
+	_, content := zulipNotification(tmpl, zulipDefaultTopic, strings.Repeat("ä", zulipMaxContentLen), "http://localhost/alerting/list")
+	require.LessOrEqual(t, len(content), zulipMaxContentLen)
+	require.True(t, utf8.ValidString(content))
+	require.True(t, strings.HasSuffix(content, "ä..."))
+}
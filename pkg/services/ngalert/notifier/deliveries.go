@@ -0,0 +1,280 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/notifier/channels"
+	"github.com/grafana/grafana/pkg/services/ngalert/store"
+	"github.com/grafana/grafana/pkg/services/notifications"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+const (
+	// deliveryRetryInterval is how often the retry queue is checked for deliveries that are due.
+	deliveryRetryInterval = 10 * time.Second
+	// deliveryRetryBatchSize is the maximum number of deliveries retried at once.
+	deliveryRetryBatchSize = 100
+	// deliveryClaimTimeout is how long a delivery claimed for a retry is hidden from other Grafana instances.
+	deliveryClaimTimeout = 5 * time.Minute
+	// deliveryTimeout is the timeout of a retry or replay of a delivery.
+	deliveryTimeout = time.Minute
+)
+
+var (
+	ErrDeliveryLogDisabled         = errors.New("the notification delivery log is disabled")
+	ErrDeliveryIntegrationNotFound = errors.New("the integration of the notification delivery does not exist anymore")
+)
+
+// deliveryLog records the notifications sent by the integrations of an Alertmanager, and retries those
+// that failed with a transient error if the retry queue is enabled.
+type deliveryLog struct {
+	orgID    int64
+	store    store.NotificationDeliveryStore
+	settings setting.UnifiedAlertingDeliveryLogSettings
+	logger   log.Logger
+	now      func() time.Time
+}
+
+func newDeliveryLog(orgID int64, s store.NotificationDeliveryStore, settings setting.UnifiedAlertingDeliveryLogSettings, logger log.Logger) *deliveryLog {
+	return &deliveryLog{
+		orgID:    orgID,
+		store:    s,
+		settings: settings,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+func (l *deliveryLog) retryEnabled() bool {
+	return l.settings.RetryMaxAttempts > 0
+}
+
+// backoff returns how long to wait before the next attempt of a delivery that has been attempted the given number of times.
+func (l *deliveryLog) backoff(attempts int) time.Duration {
+	b := l.settings.RetryInitialBackoff
+	for i := 1; i < attempts && b < l.settings.RetryMaxBackoff; i++ {
+		b *= 2
+	}
+	if b > l.settings.RetryMaxBackoff {
+		b = l.settings.RetryMaxBackoff
+	}
+	return b
+}
+
+// wrap returns a notifier that records the deliveries of the integration of a receiver.
+func (l *deliveryLog) wrap(receiver string, idx int, uid, typ string, n channels.NotificationChannel) *deliveryNotifier {
+	return &deliveryNotifier{
+		log:      l,
+		receiver: receiver,
+		idx:      idx,
+		uid:      uid,
+		typ:      typ,
+		notifier: n,
+	}
+}
+
+type deliveryKey struct{}
+
+// withDelivery returns a context that makes a deliveryNotifier attempt the given delivery again
+// instead of recording a new one.
+func withDelivery(ctx context.Context, d *ngmodels.NotificationDelivery) context.Context {
+	return context.WithValue(ctx, deliveryKey{}, d)
+}
+
+// deliveryNotifier records every attempt of the notifier it wraps. If the retry queue is enabled, failures that
+// can be retried are queued and reported as successful to the Alertmanager so that it does not retry them as well.
+type deliveryNotifier struct {
+	log      *deliveryLog
+	receiver string
+	idx      int
+	uid      string
+	typ      string
+	notifier channels.NotificationChannel
+}
+
+func (n *deliveryNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	d, ok := ctx.Value(deliveryKey{}).(*ngmodels.NotificationDelivery)
+	if !ok {
+		d = n.newDelivery(ctx, as)
+	} else if d.IntegrationUID != n.uid {
+		return false, ErrDeliveryIntegrationNotFound
+	}
+
+	ctx, resp := notifications.WithWebhookResponse(ctx)
+	retry, err := n.notifier.Notify(ctx, as...)
+
+	now := n.log.now()
+	d.Attempts++
+	d.LastAttemptAt = now.UnixMilli()
+	d.ResponseCode, d.ResponseBody = resp.Get()
+	d.NextAttemptAt = 0
+	d.Error = ""
+	switch {
+	case err == nil:
+		d.Status = ngmodels.NotificationDeliverySuccess
+	case retry && n.log.retryEnabled() && d.ReplayOf == 0 && d.Attempts < n.log.settings.RetryMaxAttempts:
+		d.Status = ngmodels.NotificationDeliveryRetrying
+		d.Error = err.Error()
+		d.NextAttemptAt = now.Add(n.log.backoff(d.Attempts)).UnixMilli()
+	default:
+		d.Status = ngmodels.NotificationDeliveryFailed
+		d.Error = err.Error()
+	}
+
+	if saveErr := n.log.store.SaveNotificationDelivery(ctx, d); saveErr != nil {
+		n.log.logger.Error("failed to save notification delivery", "receiver", n.receiver, "integration", n.uid, "err", saveErr)
+		return retry, err
+	}
+	if d.Status == ngmodels.NotificationDeliveryRetrying {
+		n.log.logger.Warn("notification failed, queued for retry", "receiver", n.receiver, "integration", n.uid, "attempts", d.Attempts, "err", err)
+		return false, nil
+	}
+	return retry, err
+}
+
+func (n *deliveryNotifier) SendResolved() bool {
+	return n.notifier.SendResolved()
+}
+
+func (n *deliveryNotifier) newDelivery(ctx context.Context, as []*types.Alert) *ngmodels.NotificationDelivery {
+	d := &ngmodels.NotificationDelivery{
+		OrgID:            n.log.orgID,
+		Receiver:         n.receiver,
+		IntegrationUID:   n.uid,
+		IntegrationType:  n.typ,
+		IntegrationIndex: n.idx,
+		CreatedAt:        n.log.now().UnixMilli(),
+	}
+	if key, err := notify.ExtractGroupKey(ctx); err == nil {
+		d.GroupKey = key.String()
+	}
+	if groupLabels, ok := notify.GroupLabels(ctx); ok {
+		d.GroupLabels = make(map[string]string, len(groupLabels))
+		for k, v := range groupLabels {
+			d.GroupLabels[string(k)] = string(v)
+		}
+	}
+	d.AlertFingerprints = make([]string, 0, len(as))
+	for _, a := range as {
+		d.AlertFingerprints = append(d.AlertFingerprints, a.Fingerprint().String())
+	}
+	if b, err := json.Marshal(as); err != nil {
+		n.log.logger.Warn("failed to encode the alerts of a notification delivery", "receiver", n.receiver, "err", err)
+	} else {
+		d.Alerts = string(b)
+	}
+	return d
+}
+
+// deliveryContext returns a context and the alerts to attempt the delivery again.
+func deliveryContext(ctx context.Context, d *ngmodels.NotificationDelivery) (context.Context, []*types.Alert, error) {
+	var alerts []*types.Alert
+	if err := json.Unmarshal([]byte(d.Alerts), &alerts); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode the alerts of the notification delivery: %w", err)
+	}
+	groupLabels := make(model.LabelSet, len(d.GroupLabels))
+	for k, v := range d.GroupLabels {
+		groupLabels[model.LabelName(k)] = model.LabelValue(v)
+	}
+	ctx = notify.WithGroupKey(ctx, d.GroupKey)
+	ctx = notify.WithGroupLabels(ctx, groupLabels)
+	ctx = notify.WithReceiverName(ctx, d.Receiver)
+	ctx = notify.WithNow(ctx, time.Now())
+	return withDelivery(ctx, d), alerts, nil
+}
+
+// run retries the deliveries in the retry queue that are due until stopc is closed.
+func (l *deliveryLog) run(stopc <-chan struct{}, integrationFor func(receiver string, idx int) (notify.Integration, bool)) {
+	ticker := time.NewTicker(deliveryRetryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopc:
+			return
+		case <-ticker.C:
+			l.retryDue(context.Background(), integrationFor)
+		}
+	}
+}
+
+func (l *deliveryLog) retryDue(ctx context.Context, integrationFor func(receiver string, idx int) (notify.Integration, bool)) {
+	due, err := l.store.GetDueNotificationDeliveries(ctx, l.orgID, l.now(), deliveryRetryBatchSize)
+	if err != nil {
+		l.logger.Error("failed to get notification deliveries to retry", "err", err)
+		return
+	}
+	for _, d := range due {
+		claimed, err := l.store.ClaimNotificationDelivery(ctx, d, l.now().Add(deliveryClaimTimeout))
+		if err != nil {
+			l.logger.Error("failed to claim notification delivery", "id", d.ID, "err", err)
+			continue
+		}
+		if !claimed {
+			// another Grafana instance retries it
+			continue
+		}
+		if err := l.attempt(ctx, d, integrationFor); err != nil {
+			l.logger.Warn("failed to retry notification delivery", "id", d.ID, "receiver", d.Receiver, "err", err)
+			d.Status = ngmodels.NotificationDeliveryFailed
+			d.Error = err.Error()
+			d.NextAttemptAt = 0
+			if err := l.store.SaveNotificationDelivery(ctx, d); err != nil {
+				l.logger.Error("failed to save notification delivery", "id", d.ID, "err", err)
+			}
+		}
+	}
+}
+
+// attempt sends the delivery again with the integration it was sent with. It only returns an error if the
+// delivery could not be attempted, the result of the attempt is recorded by the integration.
+func (l *deliveryLog) attempt(ctx context.Context, d *ngmodels.NotificationDelivery, integrationFor func(receiver string, idx int) (notify.Integration, bool)) error {
+	integration, ok := integrationFor(d.Receiver, d.IntegrationIndex)
+	if !ok || integration.Name() != d.IntegrationType {
+		return ErrDeliveryIntegrationNotFound
+	}
+	ctx, alerts, err := deliveryContext(ctx, d)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, deliveryTimeout)
+	defer cancel()
+	if _, err := integration.Notify(ctx, alerts...); errors.Is(err, ErrDeliveryIntegrationNotFound) {
+		return err
+	}
+	return nil
+}
+
+// replay sends the delivery with the given ID again and returns the new delivery.
+func (l *deliveryLog) replay(ctx context.Context, id int64, integrationFor func(receiver string, idx int) (notify.Integration, bool)) (*ngmodels.NotificationDelivery, error) {
+	original, err := l.store.GetNotificationDelivery(ctx, l.orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	d := &ngmodels.NotificationDelivery{
+		OrgID:             original.OrgID,
+		Receiver:          original.Receiver,
+		IntegrationUID:    original.IntegrationUID,
+		IntegrationType:   original.IntegrationType,
+		IntegrationIndex:  original.IntegrationIndex,
+		GroupKey:          original.GroupKey,
+		GroupLabels:       original.GroupLabels,
+		AlertFingerprints: original.AlertFingerprints,
+		Alerts:            original.Alerts,
+		CreatedAt:         l.now().UnixMilli(),
+		ReplayOf:          original.ID,
+	}
+	if err := l.attempt(ctx, d, integrationFor); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
@@ -0,0 +1,199 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/store"
+	"github.com/grafana/grafana/pkg/services/notifications"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+// fakeDeliveryChannel returns the results in order, and the last one once there are no more results.
+type fakeDeliveryChannel struct {
+	results []error
+	calls   []model.LabelSet
+}
+
+var errTransient = errors.New("service unavailable")
+
+func (c *fakeDeliveryChannel) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	groupLabels, _ := notify.GroupLabels(ctx)
+	c.calls = append(c.calls, groupLabels)
+	err := c.results[0]
+	if len(c.results) > 1 {
+		c.results = c.results[1:]
+	}
+	if err != nil {
+		notifications.RecordWebhookResponse(ctx, http.StatusServiceUnavailable, []byte("try again later"))
+		return true, err
+	}
+	notifications.RecordWebhookResponse(ctx, http.StatusOK, []byte("ok"))
+	return true, nil
+}
+
+func (c *fakeDeliveryChannel) SendResolved() bool {
+	return true
+}
+
+func TestDeliveryNotifier(t *testing.T) {
+	now := time.Unix(1000, 0)
+	alerts := []*types.Alert{{Alert: model.Alert{Labels: model.LabelSet{"alertname": "HighCPU", "host": "a"}}}}
+	ctx := notify.WithGroupKey(context.Background(), "{}:{alertname=\"HighCPU\"}")
+	ctx = notify.WithGroupLabels(ctx, model.LabelSet{"alertname": "HighCPU"})
+
+	setup := func(maxAttempts int, results ...error) (*deliveryLog, *store.FakeNotificationDeliveryStore, *fakeDeliveryChannel, notify.Integration) {
+		s := &store.FakeNotificationDeliveryStore{}
+		l := newDeliveryLog(1, s, setting.UnifiedAlertingDeliveryLogSettings{
+			Enabled:             true,
+			RetryMaxAttempts:    maxAttempts,
+			RetryInitialBackoff: 30 * time.Second,
+			RetryMaxBackoff:     time.Minute,
+		}, log.NewNopLogger())
+		l.now = func() time.Time { return now }
+		ch := &fakeDeliveryChannel{results: results}
+		dn := l.wrap("team-a", 0, "uid-1", "webhook", ch)
+		return l, s, ch, notify.NewIntegration(dn, dn, "webhook", 0)
+	}
+	integrationFor := func(i notify.Integration) func(string, int) (notify.Integration, bool) {
+		return func(receiver string, idx int) (notify.Integration, bool) {
+			return i, receiver == "team-a" && idx == 0
+		}
+	}
+
+	t.Run("records a successful delivery", func(t *testing.T) {
+		_, s, _, integration := setup(0, nil)
+		retry, err := integration.Notify(ctx, alerts...)
+		require.NoError(t, err)
+		require.True(t, retry)
+
+		deliveries := s.GetDeliveries()
+		require.Len(t, deliveries, 1)
+		d := deliveries[0]
+		require.Equal(t, int64(1), d.OrgID)
+		require.Equal(t, "team-a", d.Receiver)
+		require.Equal(t, "uid-1", d.IntegrationUID)
+		require.Equal(t, "webhook", d.IntegrationType)
+		require.Equal(t, "{}:{alertname=\"HighCPU\"}", d.GroupKey)
+		require.Equal(t, map[string]string{"alertname": "HighCPU"}, d.GroupLabels)
+		require.Equal(t, []string{alerts[0].Fingerprint().String()}, d.AlertFingerprints)
+		require.Equal(t, ngmodels.NotificationDeliverySuccess, d.Status)
+		require.Equal(t, 1, d.Attempts)
+		require.Equal(t, http.StatusOK, d.ResponseCode)
+		require.Equal(t, "ok", d.ResponseBody)
+		require.Equal(t, now.UnixMilli(), d.LastAttemptAt)
+	})
+
+	t.Run("records a failed delivery and leaves retries to the Alertmanager if the retry queue is disabled", func(t *testing.T) {
+		_, s, _, integration := setup(0, errTransient)
+		retry, err := integration.Notify(ctx, alerts...)
+		require.ErrorIs(t, err, errTransient)
+		require.True(t, retry)
+
+		d := s.GetDeliveries()[0]
+		require.Equal(t, ngmodels.NotificationDeliveryFailed, d.Status)
+		require.Equal(t, errTransient.Error(), d.Error)
+		require.Equal(t, http.StatusServiceUnavailable, d.ResponseCode)
+		require.Equal(t, "try again later", d.ResponseBody)
+		require.Zero(t, d.NextAttemptAt)
+	})
+
+	t.Run("queues transient failures and retries them until they succeed", func(t *testing.T) {
+		l, s, ch, integration := setup(3, errTransient, nil)
+		retry, err := integration.Notify(ctx, alerts...)
+		require.NoError(t, err)
+		require.False(t, retry)
+
+		d := s.GetDeliveries()[0]
+		require.Equal(t, ngmodels.NotificationDeliveryRetrying, d.Status)
+		require.Equal(t, now.Add(30*time.Second).UnixMilli(), d.NextAttemptAt)
+
+		// not due yet
+		l.retryDue(context.Background(), integrationFor(integration))
+		require.Len(t, ch.calls, 1)
+
+		now = now.Add(30 * time.Second)
+		l.retryDue(context.Background(), integrationFor(integration))
+		require.Len(t, ch.calls, 2)
+		require.Equal(t, model.LabelSet{"alertname": "HighCPU"}, ch.calls[1])
+
+		deliveries := s.GetDeliveries()
+		require.Len(t, deliveries, 1)
+		require.Equal(t, ngmodels.NotificationDeliverySuccess, deliveries[0].Status)
+		require.Equal(t, 2, deliveries[0].Attempts)
+		require.Empty(t, deliveries[0].Error)
+		require.Zero(t, deliveries[0].NextAttemptAt)
+	})
+
+	t.Run("marks a delivery failed once it reaches the maximum number of attempts", func(t *testing.T) {
+		l, s, ch, integration := setup(2, errTransient)
+		_, err := integration.Notify(ctx, alerts...)
+		require.NoError(t, err)
+
+		now = now.Add(time.Minute)
+		l.retryDue(context.Background(), integrationFor(integration))
+		require.Len(t, ch.calls, 2)
+
+		d := s.GetDeliveries()[0]
+		require.Equal(t, ngmodels.NotificationDeliveryFailed, d.Status)
+		require.Equal(t, 2, d.Attempts)
+		require.Zero(t, d.NextAttemptAt)
+	})
+
+	t.Run("marks a delivery failed if its integration does not exist anymore", func(t *testing.T) {
+		l, s, ch, integration := setup(3, errTransient)
+		_, err := integration.Notify(ctx, alerts...)
+		require.NoError(t, err)
+
+		now = now.Add(time.Minute)
+		l.retryDue(context.Background(), func(string, int) (notify.Integration, bool) {
+			return notify.Integration{}, false
+		})
+		require.Len(t, ch.calls, 1)
+
+		d := s.GetDeliveries()[0]
+		require.Equal(t, ngmodels.NotificationDeliveryFailed, d.Status)
+		require.Equal(t, ErrDeliveryIntegrationNotFound.Error(), d.Error)
+	})
+
+	t.Run("replays a delivery as a new delivery that is not queued", func(t *testing.T) {
+		l, s, ch, integration := setup(3, nil, errTransient)
+		_, err := integration.Notify(ctx, alerts...)
+		require.NoError(t, err)
+		original := s.GetDeliveries()[0]
+
+		d, err := l.replay(context.Background(), original.ID, integrationFor(integration))
+		require.NoError(t, err)
+		require.Len(t, ch.calls, 2)
+		require.Equal(t, original.ID, d.ReplayOf)
+		require.NotEqual(t, original.ID, d.ID)
+		require.Equal(t, ngmodels.NotificationDeliveryFailed, d.Status)
+		require.Equal(t, original.AlertFingerprints, d.AlertFingerprints)
+		require.Len(t, s.GetDeliveries(), 2)
+
+		_, err = l.replay(context.Background(), 100, integrationFor(integration))
+		require.ErrorIs(t, err, ngmodels.ErrNotificationDeliveryNotFound)
+	})
+}
+
+func TestDeliveryLogBackoff(t *testing.T) {
+	l := newDeliveryLog(1, nil, setting.UnifiedAlertingDeliveryLogSettings{
+		RetryInitialBackoff: 30 * time.Second,
+		RetryMaxBackoff:     3 * time.Minute,
+	}, log.NewNopLogger())
+	require.Equal(t, 30*time.Second, l.backoff(1))
+	require.Equal(t, time.Minute, l.backoff(2))
+	require.Equal(t, 2*time.Minute, l.backoff(3))
+	require.Equal(t, 3*time.Minute, l.backoff(4))
+	require.Equal(t, 3*time.Minute, l.backoff(10))
+}
@@ -44,9 +44,10 @@ type MultiOrgAlertmanager struct {
 	peer         ClusterPeer
 	settleCancel context.CancelFunc
 
-	configStore AlertingStore
-	orgStore    store.OrgStore
-	kvStore     kvstore.KVStore
+	configStore   AlertingStore
+	orgStore      store.OrgStore
+	kvStore       kvstore.KVStore
+	deliveryStore store.NotificationDeliveryStore
 
 	decryptFn channels.GetDecryptedValueFn
 
@@ -55,7 +56,7 @@ type MultiOrgAlertmanager struct {
 }
 
 func NewMultiOrgAlertmanager(cfg *setting.Cfg, configStore AlertingStore, orgStore store.OrgStore,
-	kvStore kvstore.KVStore, provStore provisioning.ProvisioningStore, decryptFn channels.GetDecryptedValueFn,
+	kvStore kvstore.KVStore, provStore provisioning.ProvisioningStore, deliveryStore store.NotificationDeliveryStore, decryptFn channels.GetDecryptedValueFn,
 	m *metrics.MultiOrgAlertmanager, ns notifications.Service, l log.Logger, s secrets.Service,
 ) (*MultiOrgAlertmanager, error) {
 	moa := &MultiOrgAlertmanager{
@@ -68,6 +69,7 @@ func NewMultiOrgAlertmanager(cfg *setting.Cfg, configStore AlertingStore, orgSto
 		configStore:   configStore,
 		orgStore:      orgStore,
 		kvStore:       kvStore,
+		deliveryStore: deliveryStore,
 		decryptFn:     decryptFn,
 		metrics:       m,
 		ns:            ns,
@@ -182,7 +184,7 @@ func (moa *MultiOrgAlertmanager) SyncAlertmanagersForOrgs(ctx context.Context, o
 			// To export them, we need to translate the metrics from each individual registry and,
 			// then aggregate them on the main registry.
 			m := metrics.NewAlertmanagerMetrics(moa.metrics.GetOrCreateOrgRegistry(orgID))
-			am, err := newAlertmanager(ctx, orgID, moa.settings, moa.configStore, moa.kvStore, moa.peer, moa.decryptFn, moa.ns, m)
+			am, err := newAlertmanager(ctx, orgID, moa.settings, moa.configStore, moa.kvStore, moa.deliveryStore, moa.peer, moa.decryptFn, moa.ns, m)
 			if err != nil {
 				moa.logger.Error("unable to create Alertmanager for org", "org", orgID, "err", err)
 			}
@@ -328,6 +330,21 @@ func (moa *MultiOrgAlertmanager) AlertmanagerFor(orgID int64) (*Alertmanager, er
 	return orgAM, nil
 }
 
+// ClusterMembers returns the name of this instance in the HA cluster and the names of all alive members,
+// including this instance. Without HA, the name is empty and there are no members.
+func (moa *MultiOrgAlertmanager) ClusterMembers() (string, []string) {
+	p, ok := moa.peer.(*cluster.Peer)
+	if !ok {
+		return "", nil
+	}
+	peers := p.Peers()
+	members := make([]string, 0, len(peers))
+	for _, m := range peers {
+		members = append(members, m.Name())
+	}
+	return p.Name(), members
+}
+
 // NilPeer and NilChannel implements the Alertmanager clustering interface.
 type NilPeer struct{}
 
@@ -0,0 +1,87 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/plugins"
+	"github.com/grafana/grafana/pkg/services/alerting"
+	"github.com/grafana/grafana/pkg/services/ngalert/notifier/channels"
+)
+
+var (
+	pluginNotifiersMtx    sync.RWMutex
+	pluginNotifierSchemas = map[string]*alerting.NotifierPlugin{}
+)
+
+// RegisterPluginNotifiers registers the contact point types of the backend plugins that set notifier in their
+// plugin.json. The type of a contact point is the ID of its plugin, and the settings schema is fetched from the
+// notifier/schema resource of the plugin.
+func RegisterPluginNotifiers(ctx context.Context, store plugins.Store, client plugins.Client, logger log.Logger) {
+	for _, p := range store.Plugins(ctx) {
+		if !p.Notifier || !p.Backend {
+			continue
+		}
+		schema, err := fetchPluginNotifierSchema(ctx, client, p.ID)
+		if err != nil {
+			// The contact point type can still be used, but the UI cannot render its settings.
+			logger.Error("failed to fetch the contact point schema of plugin", "plugin", p.ID, "err", err)
+			schema = &alerting.NotifierPlugin{}
+		}
+		schema.Type = p.ID
+		if schema.Name == "" {
+			schema.Name = p.Name
+		}
+		if schema.Heading == "" {
+			schema.Heading = fmt.Sprintf("%s settings", schema.Name)
+		}
+
+		var secretKeys []string
+		for _, o := range schema.Options {
+			if o.Secure {
+				secretKeys = append(secretKeys, o.PropertyName)
+			}
+		}
+		if err := channels.RegisterPluginNotifier(p.ID, client, secretKeys); err != nil {
+			logger.Error("failed to register the contact point type of plugin", "plugin", p.ID, "err", err)
+			continue
+		}
+
+		pluginNotifiersMtx.Lock()
+		pluginNotifierSchemas[p.ID] = schema
+		pluginNotifiersMtx.Unlock()
+		logger.Info("registered contact point type of plugin", "plugin", p.ID)
+	}
+}
+
+func fetchPluginNotifierSchema(ctx context.Context, client plugins.Client, pluginID string) (*alerting.NotifierPlugin, error) {
+	resp, err := channels.CallPluginResource(ctx, client, pluginID, 0, http.MethodGet, channels.PluginNotifierSchemaPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Status != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.Status)
+	}
+	var schema alerting.NotifierPlugin
+	if err := json.Unmarshal(resp.Body, &schema); err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
+// pluginNotifiers returns the settings schemas of the contact point types provided by plugins, ordered by type.
+func pluginNotifiers() []*alerting.NotifierPlugin {
+	pluginNotifiersMtx.RLock()
+	defer pluginNotifiersMtx.RUnlock()
+	result := make([]*alerting.NotifierPlugin, 0, len(pluginNotifierSchemas))
+	for _, schema := range pluginNotifierSchemas {
+		result = append(result, schema)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Type < result[j].Type })
+	return result
+}
@@ -0,0 +1,87 @@
+package notifier
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/plugins"
+	"github.com/grafana/grafana/pkg/services/alerting"
+	"github.com/grafana/grafana/pkg/services/ngalert/notifier/channels"
+)
+
+type fakePluginStore struct {
+	plugins.Store
+
+	plugins []plugins.PluginDTO
+}
+
+func (s fakePluginStore) Plugins(_ context.Context, _ ...plugins.Type) []plugins.PluginDTO {
+	return s.plugins
+}
+
+type fakePluginClient struct {
+	plugins.Client
+
+	schemas map[string]string
+}
+
+func (c fakePluginClient) CallResource(_ context.Context, req *backend.CallResourceRequest, sender backend.CallResourceResponseSender) error {
+	schema, ok := c.schemas[req.PluginContext.PluginID]
+	if !ok || req.Path != channels.PluginNotifierSchemaPath {
+		return sender.Send(&backend.CallResourceResponse{Status: http.StatusNotFound})
+	}
+	return sender.Send(&backend.CallResourceResponse{Status: http.StatusOK, Body: []byte(schema)})
+}
+
+func TestRegisterPluginNotifiers(t *testing.T) {
+	t.Cleanup(func() {
+		pluginNotifiersMtx.Lock()
+		defer pluginNotifiersMtx.Unlock()
+		pluginNotifierSchemas = map[string]*alerting.NotifierPlugin{}
+	})
+
+	store := fakePluginStore{plugins: []plugins.PluginDTO{
+		{JSONData: plugins.JSONData{ID: "with-schema", Name: "With schema", Backend: true, Notifier: true}},
+		{JSONData: plugins.JSONData{ID: "without-schema", Name: "Without schema", Backend: true, Notifier: true}},
+		{JSONData: plugins.JSONData{ID: "not-a-notifier", Name: "Not a notifier", Backend: true}},
+		{JSONData: plugins.JSONData{ID: "slack", Name: "Slack", Backend: true, Notifier: true}},
+	}}
+	client := fakePluginClient{schemas: map[string]string{
+		"with-schema": `{
+			"name": "Custom",
+			"description": "Sends notifications to a custom service",
+			"options": [
+				{"propertyName": "url", "label": "URL", "required": true},
+				{"propertyName": "token", "label": "Token", "secure": true}
+			]
+		}`,
+	}}
+
+	RegisterPluginNotifiers(context.Background(), store, client, log.NewNopLogger())
+
+	schemas := pluginNotifiers()
+	require.Len(t, schemas, 2)
+	require.Equal(t, "with-schema", schemas[0].Type)
+	require.Equal(t, "Custom", schemas[0].Name)
+	require.Len(t, schemas[0].Options, 2)
+	require.Equal(t, "without-schema", schemas[1].Type)
+	require.Equal(t, "Without schema", schemas[1].Name)
+	require.Empty(t, schemas[1].Options)
+
+	keys, ok := channels.PluginSecretKeys("with-schema")
+	require.True(t, ok)
+	require.Equal(t, []string{"token"}, keys)
+	_, ok = channels.Factory("without-schema")
+	require.True(t, ok)
+	_, ok = channels.PluginSecretKeys("not-a-notifier")
+	require.False(t, ok)
+
+	available := GetAvailableNotifiers()
+	require.Equal(t, "with-schema", available[len(available)-2].Type)
+	require.Equal(t, "without-schema", available[len(available)-1].Type)
+}
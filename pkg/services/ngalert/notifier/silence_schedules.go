@@ -0,0 +1,288 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/robfig/cron/v3"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/store"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+const (
+	silenceScheduleInterval = time.Minute
+	// maxTimeIntervalWindow is how far ahead the end of a window of time intervals is searched.
+	maxTimeIntervalWindow = 7 * 24 * time.Hour
+)
+
+// ErrSilenceScheduleInvalid is returned when a silence schedule cannot be saved because it is invalid.
+var ErrSilenceScheduleInvalid = errors.New("invalid silence schedule")
+
+// SilenceScheduler creates the silences of the silence schedules in the Alertmanagers of the organizations
+// when their windows begin. The silences end with the windows, so they do not need to be expired.
+type SilenceScheduler struct {
+	store           store.SilenceScheduleStore
+	alertmanagerFor func(orgID int64) (*Alertmanager, error)
+	// isLeader returns true if this instance applies the silence schedules. In HA mode only the first member of
+	// the cluster does, the silences are propagated to the other members.
+	isLeader func() bool
+	now      func() time.Time
+	log      log.Logger
+}
+
+func NewSilenceScheduler(moa *MultiOrgAlertmanager, store store.SilenceScheduleStore, l log.Logger) *SilenceScheduler {
+	return &SilenceScheduler{
+		store:           store,
+		alertmanagerFor: moa.AlertmanagerFor,
+		isLeader: func() bool {
+			return moa.peer.Position() == 0
+		},
+		now: time.Now,
+		log: l,
+	}
+}
+
+// Run applies the silence schedules of all organizations every minute until the context is done.
+func (s *SilenceScheduler) Run(ctx context.Context) error {
+	ticker := time.NewTicker(silenceScheduleInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if !s.isLeader() {
+				continue
+			}
+			schedules, err := s.store.ListAllSilenceSchedules(ctx)
+			if err != nil {
+				s.log.Error("failed to list silence schedules", "err", err)
+				continue
+			}
+			for _, schedule := range schedules {
+				if err := s.apply(ctx, schedule); err != nil {
+					s.log.Error("failed to apply silence schedule", "org", schedule.OrgID, "uid", schedule.UID, "err", err)
+				}
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// List returns the silence schedules of the organization.
+func (s *SilenceScheduler) List(ctx context.Context, orgID int64) ([]*ngmodels.SilenceSchedule, error) {
+	return s.store.ListSilenceSchedules(ctx, orgID)
+}
+
+// Get returns the silence schedule with the given UID, or models.ErrSilenceScheduleNotFound.
+func (s *SilenceScheduler) Get(ctx context.Context, orgID int64, uid string) (*ngmodels.SilenceSchedule, error) {
+	return s.store.GetSilenceSchedule(ctx, orgID, uid)
+}
+
+// Create saves a new silence schedule and silences its alerts right away if its window is active.
+func (s *SilenceScheduler) Create(ctx context.Context, schedule *ngmodels.SilenceSchedule) (*ngmodels.SilenceSchedule, error) {
+	if err := ValidateSilenceSchedule(schedule); err != nil {
+		return nil, err
+	}
+	if schedule.UID == "" {
+		schedule.UID = util.GenerateShortUID()
+	}
+	schedule.SilenceID = ""
+	schedule.Updated = s.now()
+	if err := s.store.InsertSilenceSchedule(ctx, schedule); err != nil {
+		return nil, err
+	}
+	if err := s.apply(ctx, schedule); err != nil {
+		s.log.Error("failed to apply silence schedule", "org", schedule.OrgID, "uid", schedule.UID, "err", err)
+	}
+	return schedule, nil
+}
+
+// Update replaces the silence schedule with the same UID. The silence of the previous definition is expired,
+// and a new one is created if the window of the new definition is active.
+func (s *SilenceScheduler) Update(ctx context.Context, schedule *ngmodels.SilenceSchedule) (*ngmodels.SilenceSchedule, error) {
+	if err := ValidateSilenceSchedule(schedule); err != nil {
+		return nil, err
+	}
+	existing, err := s.store.GetSilenceSchedule(ctx, schedule.OrgID, schedule.UID)
+	if err != nil {
+		return nil, err
+	}
+	s.expire(existing)
+	schedule.SilenceID = ""
+	schedule.Updated = s.now()
+	if err := s.store.UpdateSilenceSchedule(ctx, schedule); err != nil {
+		return nil, err
+	}
+	if err := s.apply(ctx, schedule); err != nil {
+		s.log.Error("failed to apply silence schedule", "org", schedule.OrgID, "uid", schedule.UID, "err", err)
+	}
+	return schedule, nil
+}
+
+// Delete deletes the silence schedule and expires its silence.
+func (s *SilenceScheduler) Delete(ctx context.Context, orgID int64, uid string) error {
+	existing, err := s.store.GetSilenceSchedule(ctx, orgID, uid)
+	if err != nil {
+		return err
+	}
+	if err := s.store.DeleteSilenceSchedule(ctx, orgID, uid); err != nil {
+		return err
+	}
+	s.expire(existing)
+	return nil
+}
+
+// apply creates the silence of the current window of the silence schedule, unless it already exists.
+func (s *SilenceScheduler) apply(ctx context.Context, schedule *ngmodels.SilenceSchedule) error {
+	now := s.now()
+	active, end, err := SilenceScheduleWindow(schedule, now)
+	if err != nil || !active {
+		return err
+	}
+
+	am, err := s.alertmanagerFor(schedule.OrgID)
+	if err != nil {
+		return err
+	}
+	if schedule.SilenceID != "" {
+		current, err := am.GetSilence(schedule.SilenceID)
+		if err != nil && !errors.Is(err, ErrSilenceNotFound) {
+			return err
+		}
+		if err == nil && *current.Status.State != string(types.SilenceStateExpired) && time.Time(*current.EndsAt).Equal(end) {
+			return nil
+		}
+	}
+
+	id, err := am.CreateSilence(silenceOfSchedule(schedule, now, end))
+	if err != nil {
+		return err
+	}
+	s.log.Debug("created silence of silence schedule", "org", schedule.OrgID, "uid", schedule.UID, "silence_id", id, "ends_at", end)
+	schedule.SilenceID = id
+	return s.store.SetSilenceScheduleSilenceID(ctx, schedule.OrgID, schedule.UID, id)
+}
+
+// expire expires the silence of the silence schedule, if there is one.
+func (s *SilenceScheduler) expire(schedule *ngmodels.SilenceSchedule) {
+	if schedule.SilenceID == "" {
+		return
+	}
+	am, err := s.alertmanagerFor(schedule.OrgID)
+	if err != nil {
+		s.log.Warn("failed to expire silence of silence schedule", "org", schedule.OrgID, "uid", schedule.UID, "err", err)
+		return
+	}
+	if err := am.DeleteSilence(schedule.SilenceID); err != nil && !errors.Is(err, ErrSilenceNotFound) {
+		s.log.Warn("failed to expire silence of silence schedule", "org", schedule.OrgID, "uid", schedule.UID, "err", err)
+	}
+}
+
+func silenceOfSchedule(schedule *ngmodels.SilenceSchedule, start, end time.Time) *apimodels.PostableSilence {
+	matchers := make(amv2.Matchers, 0, len(schedule.Matchers))
+	for _, m := range schedule.Matchers {
+		m := m
+		matchers = append(matchers, &amv2.Matcher{
+			Name:    &m.Name,
+			Value:   &m.Value,
+			IsRegex: &m.IsRegex,
+			IsEqual: &m.IsEqual,
+		})
+	}
+	startsAt := strfmt.DateTime(start)
+	endsAt := strfmt.DateTime(end)
+	comment := schedule.Comment
+	if comment == "" {
+		comment = fmt.Sprintf("Silence schedule %s", schedule.UID)
+	}
+	createdBy := schedule.CreatedBy
+	return &apimodels.PostableSilence{
+		Silence: amv2.Silence{
+			Matchers:  matchers,
+			StartsAt:  &startsAt,
+			EndsAt:    &endsAt,
+			Comment:   &comment,
+			CreatedBy: &createdBy,
+		},
+	}
+}
+
+// ValidateSilenceSchedule returns an error wrapping ErrSilenceScheduleInvalid if the silence schedule is invalid.
+func ValidateSilenceSchedule(schedule *ngmodels.SilenceSchedule) error {
+	if len(schedule.Matchers) == 0 {
+		return fmt.Errorf("%w: at least one matcher is required", ErrSilenceScheduleInvalid)
+	}
+	for _, m := range schedule.Matchers {
+		if m.Name == "" {
+			return fmt.Errorf("%w: matcher without label name", ErrSilenceScheduleInvalid)
+		}
+	}
+	if schedule.CreatedBy == "" {
+		return fmt.Errorf("%w: creator is required", ErrSilenceScheduleInvalid)
+	}
+	if _, err := time.LoadLocation(schedule.Timezone); err != nil {
+		return fmt.Errorf("%w: invalid time zone: %s", ErrSilenceScheduleInvalid, err)
+	}
+	switch {
+	case schedule.Cron != "" && len(schedule.TimeIntervals) > 0:
+		return fmt.Errorf("%w: either a cron expression or time intervals are required, not both", ErrSilenceScheduleInvalid)
+	case schedule.Cron != "":
+		if _, err := cron.ParseStandard(schedule.Cron); err != nil {
+			return fmt.Errorf("%w: invalid cron expression: %s", ErrSilenceScheduleInvalid, err)
+		}
+		if schedule.Duration <= 0 {
+			return fmt.Errorf("%w: the duration of the windows must be positive", ErrSilenceScheduleInvalid)
+		}
+	case len(schedule.TimeIntervals) == 0:
+		return fmt.Errorf("%w: a cron expression or time intervals are required", ErrSilenceScheduleInvalid)
+	}
+	return nil
+}
+
+// SilenceScheduleWindow returns whether a window of the silence schedule is active at the given time,
+// and when the active window ends. The windows are evaluated in the time zone of the schedule.
+func SilenceScheduleWindow(schedule *ngmodels.SilenceSchedule, now time.Time) (bool, time.Time, error) {
+	loc, err := time.LoadLocation(schedule.Timezone)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+	now = now.In(loc).Truncate(time.Second)
+	if schedule.Cron != "" {
+		sched, err := cron.ParseStandard(schedule.Cron)
+		if err != nil {
+			return false, time.Time{}, err
+		}
+		// The window that could still be active began at most Duration ago.
+		start := sched.Next(now.Add(-schedule.Duration))
+		if start.After(now) {
+			return false, time.Time{}, nil
+		}
+		return true, start.Add(schedule.Duration), nil
+	}
+
+	contains := func(t time.Time) bool {
+		for _, ti := range schedule.TimeIntervals {
+			if ti.ContainsTime(t) {
+				return true
+			}
+		}
+		return false
+	}
+	if !contains(now) {
+		return false, time.Time{}, nil
+	}
+	end := now.Truncate(time.Minute).Add(time.Minute)
+	for limit := now.Add(maxTimeIntervalWindow); end.Before(limit) && contains(end); {
+		end = end.Add(time.Minute)
+	}
+	return true, end, nil
+}
@@ -0,0 +1,270 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/alertmanager/timeinterval"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+type fakeSilenceScheduleStore struct {
+	schedules map[string]*ngmodels.SilenceSchedule
+}
+
+func (f *fakeSilenceScheduleStore) ListSilenceSchedules(_ context.Context, orgID int64) ([]*ngmodels.SilenceSchedule, error) {
+	var result []*ngmodels.SilenceSchedule
+	for _, s := range f.schedules {
+		if s.OrgID == orgID {
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeSilenceScheduleStore) ListAllSilenceSchedules(_ context.Context) ([]*ngmodels.SilenceSchedule, error) {
+	var result []*ngmodels.SilenceSchedule
+	for _, s := range f.schedules {
+		result = append(result, s)
+	}
+	return result, nil
+}
+
+func (f *fakeSilenceScheduleStore) GetSilenceSchedule(_ context.Context, _ int64, uid string) (*ngmodels.SilenceSchedule, error) {
+	s, ok := f.schedules[uid]
+	if !ok {
+		return nil, ngmodels.ErrSilenceScheduleNotFound
+	}
+	c := *s
+	return &c, nil
+}
+
+func (f *fakeSilenceScheduleStore) InsertSilenceSchedule(_ context.Context, schedule *ngmodels.SilenceSchedule) error {
+	c := *schedule
+	f.schedules[schedule.UID] = &c
+	return nil
+}
+
+func (f *fakeSilenceScheduleStore) UpdateSilenceSchedule(_ context.Context, schedule *ngmodels.SilenceSchedule) error {
+	if _, ok := f.schedules[schedule.UID]; !ok {
+		return ngmodels.ErrSilenceScheduleNotFound
+	}
+	c := *schedule
+	f.schedules[schedule.UID] = &c
+	return nil
+}
+
+func (f *fakeSilenceScheduleStore) DeleteSilenceSchedule(_ context.Context, _ int64, uid string) error {
+	if _, ok := f.schedules[uid]; !ok {
+		return ngmodels.ErrSilenceScheduleNotFound
+	}
+	delete(f.schedules, uid)
+	return nil
+}
+
+func (f *fakeSilenceScheduleStore) SetSilenceScheduleSilenceID(_ context.Context, _ int64, uid string, silenceID string) error {
+	f.schedules[uid].SilenceID = silenceID
+	return nil
+}
+
+func timeIntervalsForTests(t *testing.T, in string) []timeinterval.TimeInterval {
+	t.Helper()
+	var intervals []timeinterval.TimeInterval
+	require.NoError(t, yaml.Unmarshal([]byte(in), &intervals))
+	return intervals
+}
+
+func TestSilenceScheduleWindow(t *testing.T) {
+	// Saturday.
+	now := time.Date(2022, 6, 4, 10, 30, 15, 0, time.UTC)
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	cases := []struct {
+		name      string
+		schedule  ngmodels.SilenceSchedule
+		expActive bool
+		expEnd    time.Time
+	}{
+		{
+			name:      "cron window is active",
+			schedule:  ngmodels.SilenceSchedule{Cron: "0 10 * * *", Duration: time.Hour},
+			expActive: true,
+			expEnd:    time.Date(2022, 6, 4, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "cron window has ended",
+			schedule: ngmodels.SilenceSchedule{Cron: "0 10 * * *", Duration: 30 * time.Minute},
+		},
+		{
+			name:     "cron window has not begun",
+			schedule: ngmodels.SilenceSchedule{Cron: "0 11 * * *", Duration: time.Hour},
+		},
+		{
+			name:      "cron window began the day before",
+			schedule:  ngmodels.SilenceSchedule{Cron: "0 22 * * 5", Duration: 14 * time.Hour},
+			expActive: true,
+			expEnd:    time.Date(2022, 6, 4, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "cron window in a time zone is active",
+			schedule:  ngmodels.SilenceSchedule{Cron: "0 12 * * *", Duration: time.Hour, Timezone: "Europe/Berlin"},
+			expActive: true,
+			expEnd:    time.Date(2022, 6, 4, 13, 0, 0, 0, berlin),
+		},
+		{
+			name:     "cron window in a time zone has not begun",
+			schedule: ngmodels.SilenceSchedule{Cron: "0 10 * * *", Duration: time.Hour, Timezone: "America/New_York"},
+		},
+		{
+			name: "time interval is active",
+			schedule: ngmodels.SilenceSchedule{TimeIntervals: timeIntervalsForTests(t, `
+- weekdays: ["saturday"]
+  times: [{start_time: "10:00", end_time: "12:00"}]
+`)},
+			expActive: true,
+			expEnd:    time.Date(2022, 6, 4, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "adjacent time intervals form one window",
+			schedule: ngmodels.SilenceSchedule{TimeIntervals: timeIntervalsForTests(t, `
+- weekdays: ["saturday"]
+  times: [{start_time: "10:00", end_time: "11:00"}]
+- weekdays: ["saturday"]
+  times: [{start_time: "11:00", end_time: "13:00"}]
+`)},
+			expActive: true,
+			expEnd:    time.Date(2022, 6, 4, 13, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "time interval in a time zone is active",
+			schedule: ngmodels.SilenceSchedule{Timezone: "America/New_York", TimeIntervals: timeIntervalsForTests(t, `
+- weekdays: ["saturday"]
+  times: [{start_time: "06:00", end_time: "07:00"}]
+`)},
+			expActive: true,
+			expEnd:    time.Date(2022, 6, 4, 7, 0, 0, 0, newYork),
+		},
+		{
+			name: "time interval is not active",
+			schedule: ngmodels.SilenceSchedule{TimeIntervals: timeIntervalsForTests(t, `
+- weekdays: ["sunday"]
+`)},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			active, end, err := SilenceScheduleWindow(&c.schedule, now)
+			require.NoError(t, err)
+			require.Equal(t, c.expActive, active)
+			require.Equal(t, c.expEnd, end)
+		})
+	}
+}
+
+func TestValidateSilenceSchedule(t *testing.T) {
+	valid := func() *ngmodels.SilenceSchedule {
+		return &ngmodels.SilenceSchedule{
+			CreatedBy: "tests",
+			Matchers:  []ngmodels.SilenceMatcher{{Name: "env", Value: "prod", IsEqual: true}},
+			Cron:      "0 22 * * 5",
+			Duration:  time.Hour,
+		}
+	}
+	require.NoError(t, ValidateSilenceSchedule(valid()))
+
+	cases := map[string]func(s *ngmodels.SilenceSchedule){
+		"without matchers":        func(s *ngmodels.SilenceSchedule) { s.Matchers = nil },
+		"without creator":         func(s *ngmodels.SilenceSchedule) { s.CreatedBy = "" },
+		"invalid cron expression": func(s *ngmodels.SilenceSchedule) { s.Cron = "every friday" },
+		"without duration":        func(s *ngmodels.SilenceSchedule) { s.Duration = 0 },
+		"without windows":         func(s *ngmodels.SilenceSchedule) { s.Cron = "" },
+		"invalid time zone":       func(s *ngmodels.SilenceSchedule) { s.Timezone = "Mars/Olympus_Mons" },
+		"cron and time intervals": func(s *ngmodels.SilenceSchedule) {
+			s.TimeIntervals = timeIntervalsForTests(t, `[{weekdays: ["sunday"]}]`)
+		},
+	}
+	for name, mutate := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := valid()
+			mutate(s)
+			require.ErrorIs(t, ValidateSilenceSchedule(s), ErrSilenceScheduleInvalid)
+		})
+	}
+}
+
+func TestSilenceScheduler(t *testing.T) {
+	am := setupAMTest(t)
+	store := &fakeSilenceScheduleStore{schedules: map[string]*ngmodels.SilenceSchedule{}}
+	now := time.Now().UTC().Truncate(time.Minute)
+	scheduler := &SilenceScheduler{
+		store:           store,
+		alertmanagerFor: func(int64) (*Alertmanager, error) { return am, nil },
+		isLeader:        func() bool { return true },
+		now:             func() time.Time { return now },
+		log:             log.New("test"),
+	}
+	ctx := context.Background()
+
+	// A window that began 10 minutes ago and lasts an hour.
+	cronExpr := func(start time.Time) string {
+		return start.Format("4 15 * * *")
+	}
+	schedule, err := scheduler.Create(ctx, &ngmodels.SilenceSchedule{
+		OrgID:     1,
+		CreatedBy: "tests",
+		Matchers:  []ngmodels.SilenceMatcher{{Name: "env", Value: "prod", IsEqual: true}},
+		Cron:      cronExpr(now.Add(-10 * time.Minute)),
+		Duration:  time.Hour,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, schedule.UID)
+	require.NotEmpty(t, schedule.SilenceID)
+
+	silence, err := am.GetSilence(schedule.SilenceID)
+	require.NoError(t, err)
+	require.Equal(t, string(types.SilenceStateActive), *silence.Status.State)
+	require.Equal(t, now.Add(50*time.Minute), time.Time(*silence.EndsAt).UTC())
+	require.Equal(t, "Silence schedule "+schedule.UID, *silence.Comment)
+
+	// Applying the schedule again keeps the silence of the window.
+	stored, err := store.GetSilenceSchedule(ctx, 1, schedule.UID)
+	require.NoError(t, err)
+	require.Equal(t, schedule.SilenceID, stored.SilenceID)
+	require.NoError(t, scheduler.apply(ctx, stored))
+	require.Equal(t, schedule.SilenceID, stored.SilenceID)
+
+	// Updating the schedule to a window that has not begun expires the silence.
+	oldSilenceID := schedule.SilenceID
+	schedule.Cron = cronExpr(now.Add(10 * time.Minute))
+	schedule, err = scheduler.Update(ctx, schedule)
+	require.NoError(t, err)
+	require.Empty(t, schedule.SilenceID)
+	silence, err = am.GetSilence(oldSilenceID)
+	require.NoError(t, err)
+	require.Equal(t, string(types.SilenceStateExpired), *silence.Status.State)
+
+	// The silence is created when the window begins.
+	now = now.Add(10 * time.Minute)
+	stored, err = store.GetSilenceSchedule(ctx, 1, schedule.UID)
+	require.NoError(t, err)
+	require.NoError(t, scheduler.apply(ctx, stored))
+	require.NotEmpty(t, stored.SilenceID)
+
+	// Deleting the schedule expires its silence.
+	require.NoError(t, scheduler.Delete(ctx, 1, schedule.UID))
+	silence, err = am.GetSilence(stored.SilenceID)
+	require.NoError(t, err)
+	require.Equal(t, string(types.SilenceStateExpired), *silence.Status.State)
+	_, err = scheduler.Get(ctx, 1, schedule.UID)
+	require.ErrorIs(t, err, ngmodels.ErrSilenceScheduleNotFound)
+}
@@ -3,19 +3,24 @@ package notifier
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
 	v2 "github.com/prometheus/alertmanager/api/v2"
+	"github.com/prometheus/alertmanager/pkg/labels"
 	"github.com/prometheus/alertmanager/silence"
+	"github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/prometheus/alertmanager/types"
 )
 
 var (
-	ErrGetSilencesInternal     = fmt.Errorf("unable to retrieve silence(s) due to an internal error")
-	ErrDeleteSilenceInternal   = fmt.Errorf("unable to delete silence due to an internal error")
-	ErrCreateSilenceBadPayload = fmt.Errorf("unable to create silence")
-	ErrListSilencesBadPayload  = fmt.Errorf("unable to list silences")
-	ErrSilenceNotFound         = silence.ErrNotFound
+	ErrGetSilencesInternal      = fmt.Errorf("unable to retrieve silence(s) due to an internal error")
+	ErrDeleteSilenceInternal    = fmt.Errorf("unable to delete silence due to an internal error")
+	ErrCreateSilenceBadPayload  = fmt.Errorf("unable to create silence")
+	ErrListSilencesBadPayload   = fmt.Errorf("unable to list silences")
+	ErrExpireSilencesBadPayload = fmt.Errorf("unable to expire silences")
+	ErrSilenceNotFound          = silence.ErrNotFound
 )
 
 // ListSilences retrieves a list of stored silences. It supports a set of labels as filters.
@@ -117,3 +122,133 @@ func (am *Alertmanager) DeleteSilence(silenceID string) error {
 
 	return nil
 }
+
+// CreateSilences persists the provided new silences and returns their IDs in the same order. Either all silences are
+// created or none: if one of them cannot be created, the ones created before it are expired again.
+func (am *Alertmanager) CreateSilences(pss []*apimodels.PostableSilence) ([]string, error) {
+	ids := make([]string, 0, len(pss))
+	for i, ps := range pss {
+		if ps.ID != "" {
+			return nil, fmt.Errorf("silence %d: bulk creation cannot update existing silences: %w", i, ErrCreateSilenceBadPayload)
+		}
+	}
+	for i, ps := range pss {
+		id, err := am.CreateSilence(ps)
+		if err != nil {
+			for _, created := range ids {
+				if err := am.DeleteSilence(created); err != nil {
+					am.logger.Error("failed to expire silence of failed bulk creation", "silence_id", created, "err", err)
+				}
+			}
+			return nil, fmt.Errorf("silence %d: %w", i, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// ExpireSilences expires the active and pending silences that match the set of labels of the filter,
+// and returns the IDs of the expired silences. The filter must not be empty.
+func (am *Alertmanager) ExpireSilences(filter []string) ([]string, error) {
+	if len(filter) == 0 {
+		return nil, fmt.Errorf("%w: at least one matcher is required", ErrExpireSilencesBadPayload)
+	}
+	matchers, err := parseFilter(filter)
+	if err != nil {
+		am.logger.Error("failed to parse matchers", "err", err)
+		return nil, fmt.Errorf("%w: %s", ErrExpireSilencesBadPayload, err.Error())
+	}
+
+	psils, _, err := am.silences.Query(silence.QState(types.SilenceStateActive, types.SilenceStatePending))
+	if err != nil {
+		am.logger.Error(ErrGetSilencesInternal.Error(), "err", err)
+		return nil, fmt.Errorf("%s: %w", ErrGetSilencesInternal.Error(), err)
+	}
+
+	ids := make([]string, 0)
+	for _, ps := range psils {
+		if !v2.CheckSilenceMatchesFilterLabels(ps, matchers) {
+			continue
+		}
+		if err := am.DeleteSilence(ps.Id); err != nil {
+			return ids, err
+		}
+		ids = append(ids, ps.Id)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// PreviewSilence returns the alerts that are currently firing and would be muted by the provided silence.
+// The silence is not persisted.
+func (am *Alertmanager) PreviewSilence(ps *apimodels.PostableSilence) (apimodels.GettableAlerts, error) {
+	res := apimodels.GettableAlerts{}
+	if !am.Ready() {
+		return res, ErrGetAlertsUnavailable
+	}
+
+	sil, err := v2.PostableSilenceToProto(ps)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to convert API silence to internal silence: %w",
+			ErrCreateSilenceBadPayload.Error(), err)
+	}
+	matchers, err := silenceMatchers(sil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrCreateSilenceBadPayload.Error(), err)
+	}
+
+	alerts := am.alerts.GetPending()
+	defer alerts.Close()
+
+	am.reloadConfigMtx.RLock()
+	for a := range alerts.Next() {
+		if err = alerts.Err(); err != nil {
+			break
+		}
+		if a.Resolved() || !matchers.Matches(a.Labels) {
+			continue
+		}
+		routes := am.route.Match(a.Labels)
+		receivers := make([]string, 0, len(routes))
+		for _, r := range routes {
+			receivers = append(receivers, r.RouteOpts.Receiver)
+		}
+		res = append(res, v2.AlertToOpenAPIAlert(a, am.marker.Status(a.Fingerprint()), receivers))
+	}
+	am.reloadConfigMtx.RUnlock()
+
+	if err != nil {
+		am.logger.Error("failed to iterate through the alerts", "err", err)
+		return nil, fmt.Errorf("%s: %w", err.Error(), ErrGetAlertsInternal)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return *res[i].Fingerprint < *res[j].Fingerprint
+	})
+	return res, nil
+}
+
+// silenceMatchers converts the matchers of the silence to label matchers.
+func silenceMatchers(sil *silencepb.Silence) (labels.Matchers, error) {
+	ms := make(labels.Matchers, 0, len(sil.Matchers))
+	for _, m := range sil.Matchers {
+		var mt labels.MatchType
+		switch m.Type {
+		case silencepb.Matcher_EQUAL:
+			mt = labels.MatchEqual
+		case silencepb.Matcher_NOT_EQUAL:
+			mt = labels.MatchNotEqual
+		case silencepb.Matcher_REGEXP:
+			mt = labels.MatchRegexp
+		case silencepb.Matcher_NOT_REGEXP:
+			mt = labels.MatchNotRegexp
+		default:
+			return nil, fmt.Errorf("unknown matcher type %q", m.Type)
+		}
+		matcher, err := labels.NewMatcher(mt, m.Name, m.Pattern)
+		if err != nil {
+			return nil, err
+		}
+		ms = append(ms, matcher)
+	}
+	return ms, nil
+}
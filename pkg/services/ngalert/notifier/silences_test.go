@@ -0,0 +1,151 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/stretchr/testify/require"
+
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+)
+
+func silenceForTests(start, end time.Time, matchers ...*models.Matcher) *apimodels.PostableSilence {
+	comment := "maintenance"
+	createdBy := "tests"
+	startsAt := strfmt.DateTime(start)
+	endsAt := strfmt.DateTime(end)
+	return &apimodels.PostableSilence{
+		Silence: models.Silence{
+			Comment:   &comment,
+			CreatedBy: &createdBy,
+			StartsAt:  &startsAt,
+			EndsAt:    &endsAt,
+			Matchers:  matchers,
+		},
+	}
+}
+
+func matcherForTests(name, value string, isEqual, isRegex bool) *models.Matcher {
+	return &models.Matcher{Name: &name, Value: &value, IsEqual: &isEqual, IsRegex: &isRegex}
+}
+
+func TestCreateSilences(t *testing.T) {
+	am := setupAMTest(t)
+	now := time.Now()
+
+	t.Run("creates all silences", func(t *testing.T) {
+		ids, err := am.CreateSilences([]*apimodels.PostableSilence{
+			silenceForTests(now, now.Add(time.Hour), matcherForTests("host", "a", true, false)),
+			silenceForTests(now, now.Add(time.Hour), matcherForTests("host", "b", true, false)),
+		})
+		require.NoError(t, err)
+		require.Len(t, ids, 2)
+		for _, id := range ids {
+			_, err := am.GetSilence(id)
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("creates none if one is invalid", func(t *testing.T) {
+		before, err := am.ListSilences([]string{"host=c"})
+		require.NoError(t, err)
+		require.Empty(t, before)
+
+		_, err = am.CreateSilences([]*apimodels.PostableSilence{
+			silenceForTests(now, now.Add(time.Hour), matcherForTests("host", "c", true, false)),
+			silenceForTests(now, now.Add(-time.Hour), matcherForTests("host", "c", true, false)),
+		})
+		require.ErrorIs(t, err, ErrCreateSilenceBadPayload)
+
+		after, err := am.ListSilences([]string{"host=c"})
+		require.NoError(t, err)
+		require.Len(t, after, 1)
+		require.Equal(t, string(types.SilenceStateExpired), *after[0].Status.State)
+	})
+
+	t.Run("rejects updates", func(t *testing.T) {
+		s := silenceForTests(now, now.Add(time.Hour), matcherForTests("host", "d", true, false))
+		s.ID = "existing"
+		_, err := am.CreateSilences([]*apimodels.PostableSilence{s})
+		require.ErrorIs(t, err, ErrCreateSilenceBadPayload)
+	})
+}
+
+func TestExpireSilences(t *testing.T) {
+	am := setupAMTest(t)
+	now := time.Now()
+
+	ids, err := am.CreateSilences([]*apimodels.PostableSilence{
+		silenceForTests(now, now.Add(time.Hour), matcherForTests("host", "a", true, false), matcherForTests("env", "prod", true, false)),
+		silenceForTests(now.Add(time.Hour), now.Add(2*time.Hour), matcherForTests("host", "b", true, false), matcherForTests("env", "prod", true, false)),
+		silenceForTests(now, now.Add(time.Hour), matcherForTests("host", "c", true, false), matcherForTests("env", "dev", true, false)),
+	})
+	require.NoError(t, err)
+
+	_, err = am.ExpireSilences(nil)
+	require.ErrorIs(t, err, ErrExpireSilencesBadPayload)
+	_, err = am.ExpireSilences([]string{"env=~("})
+	require.ErrorIs(t, err, ErrExpireSilencesBadPayload)
+
+	expired, err := am.ExpireSilences([]string{"env=prod"})
+	require.NoError(t, err)
+	require.ElementsMatch(t, ids[:2], expired)
+
+	for i, id := range ids {
+		s, err := am.GetSilence(id)
+		require.NoError(t, err)
+		if i < 2 {
+			require.Equal(t, string(types.SilenceStateExpired), *s.Status.State)
+		} else {
+			require.Equal(t, string(types.SilenceStateActive), *s.Status.State)
+		}
+	}
+
+	expired, err = am.ExpireSilences([]string{"env=prod"})
+	require.NoError(t, err)
+	require.Empty(t, expired)
+}
+
+func TestPreviewSilence(t *testing.T) {
+	am := setupAMTest(t)
+	// The receiver does not have integrations, so that the alerts are not sent anywhere.
+	cfg, err := Load([]byte(`{"alertmanager_config": {"route": {"receiver": "maintenance"}, "receivers": [{"name": "maintenance"}]}}`))
+	require.NoError(t, err)
+	require.NoError(t, am.SaveAndApplyConfig(context.Background(), cfg, ""))
+
+	now := time.Now()
+	require.NoError(t, am.PutAlerts(apimodels.PostableAlerts{
+		PostableAlerts: []models.PostableAlert{
+			{Alert: models.Alert{Labels: models.LabelSet{"alertname": "HighCPU", "host": "a"}}},
+			{Alert: models.Alert{Labels: models.LabelSet{"alertname": "HighCPU", "host": "b"}}},
+			{Alert: models.Alert{Labels: models.LabelSet{"alertname": "DiskFull", "host": "a"}}},
+			{
+				Alert:    models.Alert{Labels: models.LabelSet{"alertname": "HighCPU", "host": "c"}},
+				StartsAt: strfmt.DateTime(now.Add(-2 * time.Hour)),
+				EndsAt:   strfmt.DateTime(now.Add(-time.Hour)),
+			},
+		},
+	}))
+
+	alerts, err := am.PreviewSilence(silenceForTests(now, now.Add(time.Hour), matcherForTests("alertname", "HighCPU", true, false)))
+	require.NoError(t, err)
+	require.Len(t, alerts, 2)
+	for _, a := range alerts {
+		require.Equal(t, "HighCPU", a.Labels["alertname"])
+		require.NotEqual(t, "c", a.Labels["host"])
+		require.Len(t, a.Receivers, 1)
+	}
+
+	alerts, err = am.PreviewSilence(silenceForTests(now, now.Add(time.Hour), matcherForTests("host", "a|b", true, true), matcherForTests("alertname", "DiskFull", false, false)))
+	require.NoError(t, err)
+	require.Len(t, alerts, 2)
+
+	// The preview does not create the silence.
+	silences, err := am.ListSilences(nil)
+	require.NoError(t, err)
+	require.Empty(t, silences)
+}
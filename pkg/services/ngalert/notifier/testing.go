@@ -16,6 +16,8 @@ import (
 
 type FakeConfigStore struct {
 	configs map[int64]*models.AlertConfiguration
+	// history has every saved configuration, the oldest first.
+	history []*models.AlertConfiguration
 }
 
 // Saves the image or returns an error.
@@ -57,24 +59,56 @@ func (f *FakeConfigStore) GetLatestAlertmanagerConfiguration(_ context.Context,
 	return nil
 }
 
+func (f *FakeConfigStore) GetAlertmanagerConfigurationHistory(_ context.Context, orgID int64, limit int) ([]*models.AlertConfiguration, error) {
+	var result []*models.AlertConfiguration
+	for i := len(f.history) - 1; i >= 0; i-- {
+		if f.history[i].OrgID != orgID {
+			continue
+		}
+		if limit > 0 && len(result) == limit {
+			break
+		}
+		result = append(result, f.history[i])
+	}
+	return result, nil
+}
+
+func (f *FakeConfigStore) GetAlertmanagerConfigurationVersion(_ context.Context, orgID int64, id int64) (*models.AlertConfiguration, error) {
+	for _, c := range f.history {
+		if c.OrgID == orgID && c.ID == id {
+			return c, nil
+		}
+	}
+	return nil, store.ErrAlertmanagerConfigurationVersionNotFound
+}
+
+// save makes config the latest configuration of its organization and adds it to the history.
+func (f *FakeConfigStore) save(config *models.AlertConfiguration) {
+	config.ID = int64(len(f.history) + 1)
+	f.history = append(f.history, config)
+	f.configs[config.OrgID] = config
+}
+
 func (f *FakeConfigStore) SaveAlertmanagerConfiguration(_ context.Context, cmd *models.SaveAlertmanagerConfigurationCmd) error {
-	f.configs[cmd.OrgID] = &models.AlertConfiguration{
+	f.save(&models.AlertConfiguration{
 		AlertmanagerConfiguration: cmd.AlertmanagerConfiguration,
 		OrgID:                     cmd.OrgID,
 		ConfigurationVersion:      "v1",
 		Default:                   cmd.Default,
-	}
+		CreatedBy:                 cmd.CreatedBy,
+	})
 
 	return nil
 }
 
 func (f *FakeConfigStore) SaveAlertmanagerConfigurationWithCallback(_ context.Context, cmd *models.SaveAlertmanagerConfigurationCmd, callback store.SaveCallback) error {
-	f.configs[cmd.OrgID] = &models.AlertConfiguration{
+	f.save(&models.AlertConfiguration{
 		AlertmanagerConfiguration: cmd.AlertmanagerConfiguration,
 		OrgID:                     cmd.OrgID,
 		ConfigurationVersion:      "v1",
 		Default:                   cmd.Default,
-	}
+		CreatedBy:                 cmd.CreatedBy,
+	})
 
 	if err := callback(); err != nil {
 		return err
@@ -85,13 +119,14 @@ func (f *FakeConfigStore) SaveAlertmanagerConfigurationWithCallback(_ context.Co
 
 func (f *FakeConfigStore) UpdateAlertmanagerConfiguration(_ context.Context, cmd *models.SaveAlertmanagerConfigurationCmd) error {
 	if config, exists := f.configs[cmd.OrgID]; exists && config.ConfigurationHash == cmd.FetchedConfigurationHash {
-		f.configs[cmd.OrgID] = &models.AlertConfiguration{
+		f.save(&models.AlertConfiguration{
 			AlertmanagerConfiguration: cmd.AlertmanagerConfiguration,
 			OrgID:                     cmd.OrgID,
 			ConfigurationHash:         fmt.Sprintf("%x", md5.Sum([]byte(cmd.AlertmanagerConfiguration))),
 			ConfigurationVersion:      "v1",
 			Default:                   cmd.Default,
-		}
+			CreatedBy:                 cmd.CreatedBy,
+		})
 		return nil
 	}
 	return errors.New("config not found or hash not valid")
@@ -10,6 +10,8 @@ import (
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
 	"github.com/grafana/grafana/pkg/services/ngalert/store"
 	"github.com/grafana/grafana/pkg/services/sqlstore"
+	storage "github.com/grafana/grafana/pkg/services/store"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -63,6 +65,29 @@ func TestAlertRuleService(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, interval, rule.IntervalSeconds)
 	})
+	t.Run("alert rule changes should be recorded as entity events", func(t *testing.T) {
+		var orgID int64 = 1
+		entityEvents := storage.NewMockEntityEventsService(t)
+		ruleService := createAlertRuleService(t)
+		ruleStore := ruleService.ruleStore.(store.DBstore)
+		ruleStore.EntityEvents = entityEvents
+		ruleService.ruleStore = ruleStore
+
+		rule := dummyRule("test#5", orgID)
+		rule.UID = "test-5"
+		entityID := storage.CreateDatabaseEntityId(rule.UID, orgID, storage.EntityTypeAlertRule)
+		for _, eventType := range []storage.EntityEventType{storage.EntityEventTypeCreate, storage.EntityEventTypeUpdate, storage.EntityEventTypeDelete} {
+			entityEvents.On("SaveEvent", mock.Anything, storage.SaveEventCmd{EntityId: entityID, EventType: eventType}).Return(nil).Once()
+		}
+
+		rule, err := ruleService.CreateAlertRule(context.Background(), rule, models.ProvenanceAPI)
+		require.NoError(t, err)
+		rule.Title = "test#5-1"
+		_, err = ruleService.UpdateAlertRule(context.Background(), rule, models.ProvenanceAPI)
+		require.NoError(t, err)
+		err = ruleService.DeleteAlertRule(context.Background(), orgID, rule.UID, models.ProvenanceAPI)
+		require.NoError(t, err)
+	})
 	t.Run("alert rule provenace should be correctly checked", func(t *testing.T) {
 		tests := []struct {
 			name   string
@@ -131,6 +156,7 @@ func createAlertRuleService(t *testing.T) AlertRuleService {
 	store := store.DBstore{
 		SQLStore:     sqlStore,
 		BaseInterval: time.Second * 10,
+		Logger:       log.New("testing"),
 	}
 	return AlertRuleService{
 		ruleStore:       store,
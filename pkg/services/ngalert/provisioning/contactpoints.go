@@ -77,14 +77,24 @@ func (ecp *ContactPointService) GetContactPoints(ctx context.Context, orgID int6
 
 // internal only
 func (ecp *ContactPointService) getContactPointDecrypted(ctx context.Context, orgID int64, uid string) (apimodels.EmbeddedContactPoint, error) {
-	revision, err := getLastConfiguration(ctx, orgID, ecp.amStore)
+	contactPoints, err := ecp.getContactPointsDecrypted(ctx, orgID)
 	if err != nil {
 		return apimodels.EmbeddedContactPoint{}, err
 	}
+	if contactPoint, ok := contactPoints[uid]; ok {
+		return contactPoint, nil
+	}
+	return apimodels.EmbeddedContactPoint{}, fmt.Errorf("contact point with uid '%s' not found", uid)
+}
+
+// internal only
+func (ecp *ContactPointService) getContactPointsDecrypted(ctx context.Context, orgID int64) (map[string]apimodels.EmbeddedContactPoint, error) {
+	revision, err := getLastConfiguration(ctx, orgID, ecp.amStore)
+	if err != nil {
+		return nil, err
+	}
+	contactPoints := make(map[string]apimodels.EmbeddedContactPoint)
 	for _, receiver := range revision.cfg.GetGrafanaReceiverMap() {
-		if receiver.UID != uid {
-			continue
-		}
 		embeddedContactPoint := apimodels.EmbeddedContactPoint{
 			UID:                   receiver.UID,
 			Type:                  receiver.Type,
@@ -103,9 +113,9 @@ func (ecp *ContactPointService) getContactPointDecrypted(ctx context.Context, or
 			}
 			embeddedContactPoint.Settings.Set(k, decryptedValue)
 		}
-		return embeddedContactPoint, nil
+		contactPoints[receiver.UID] = embeddedContactPoint
 	}
-	return apimodels.EmbeddedContactPoint{}, fmt.Errorf("contact point with uid '%s' not found", uid)
+	return contactPoints, nil
 }
 
 func (ecp *ContactPointService) CreateContactPoint(ctx context.Context, orgID int64,
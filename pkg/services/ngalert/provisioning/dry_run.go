@@ -0,0 +1,649 @@
+package provisioning
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/prometheus/alertmanager/config"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/store"
+)
+
+// ignoredRuleFields are the fields of stored rules that are not set by provisioning files.
+var ignoredRuleFields = []string{"ID", "Version", "Updated", "IntervalSeconds", "DashboardUID", "PanelID"}
+
+// DryRunService reports the changes that provisioning files would make, without persisting anything.
+type DryRunService struct {
+	ruleStore       store.RuleStore
+	contactPoints   *ContactPointService
+	defaultInterval int64
+	log             log.Logger
+}
+
+func NewDryRunService(ruleStore store.RuleStore, contactPoints *ContactPointService, defaultInterval int64, log log.Logger) *DryRunService {
+	return &DryRunService{
+		ruleStore:       ruleStore,
+		contactPoints:   contactPoints,
+		defaultInterval: defaultInterval,
+		log:             log,
+	}
+}
+
+// Diff compares the resources of the provisioning file with the stored ones. The rules of a provisioned
+// rule group replace the stored rules of the group, so stored rules that are missing in the file are deleted.
+// Mute timings and templates are matched by name and are never deleted.
+func (s *DryRunService) Diff(ctx context.Context, file definitions.ProvisioningFile) (definitions.ProvisioningDiff, error) {
+	result := definitions.ProvisioningDiff{
+		RuleGroups:    []definitions.RuleGroupDiff{},
+		ContactPoints: []definitions.ContactPointDiff{},
+		Policies:      []definitions.PolicyTreeDiff{},
+		MuteTimings:   []definitions.MuteTimingDiff{},
+		Templates:     []definitions.TemplateDiff{},
+	}
+
+	groups, err := s.diffRuleGroups(ctx, file)
+	if err != nil {
+		return result, err
+	}
+	result.RuleGroups = append(result.RuleGroups, groups...)
+
+	contactPoints, err := s.diffContactPoints(ctx, file)
+	if err != nil {
+		return result, err
+	}
+	result.ContactPoints = append(result.ContactPoints, contactPoints...)
+
+	policies, err := s.diffPolicies(ctx, file)
+	if err != nil {
+		return result, err
+	}
+	result.Policies = append(result.Policies, policies...)
+
+	muteTimings, err := s.diffMuteTimings(ctx, file)
+	if err != nil {
+		return result, err
+	}
+	result.MuteTimings = append(result.MuteTimings, muteTimings...)
+
+	templates, err := s.diffTemplates(ctx, file)
+	if err != nil {
+		return result, err
+	}
+	result.Templates = append(result.Templates, templates...)
+
+	s.log.Debug("provisioning dry run finished", "ruleGroups", len(result.RuleGroups), "contactPoints", len(result.ContactPoints), "policies", len(result.Policies),
+		"muteTimings", len(result.MuteTimings), "templates", len(result.Templates))
+	return result, nil
+}
+
+type orgRules struct {
+	list  []*models.AlertRule
+	byUID map[string]*models.AlertRule
+}
+
+func (s *DryRunService) diffRuleGroups(ctx context.Context, file definitions.ProvisioningFile) ([]definitions.RuleGroupDiff, error) {
+	rulesByOrg := make(map[int64]orgRules)
+	getRules := func(orgID int64) (orgRules, error) {
+		if rules, ok := rulesByOrg[orgID]; ok {
+			return rules, nil
+		}
+		q := models.ListAlertRulesQuery{OrgID: orgID}
+		if err := s.ruleStore.ListAlertRules(ctx, &q); err != nil {
+			return orgRules{}, err
+		}
+		rules := orgRules{list: q.Result, byUID: make(map[string]*models.AlertRule, len(q.Result))}
+		for _, rule := range q.Result {
+			rules.byUID[rule.UID] = rule
+		}
+		rulesByOrg[orgID] = rules
+		return rules, nil
+	}
+
+	diffs := make(map[models.AlertRuleGroupKey]*definitions.RuleGroupDiff)
+	keys := make([]models.AlertRuleGroupKey, 0)
+	diffFor := func(key models.AlertRuleGroupKey) *definitions.RuleGroupDiff {
+		if d, ok := diffs[key]; ok {
+			return d
+		}
+		d := &definitions.RuleGroupDiff{OrgID: key.OrgID, FolderUID: key.NamespaceUID, RuleGroup: key.RuleGroup}
+		diffs[key] = d
+		keys = append(keys, key)
+		return d
+	}
+
+	provisioned := make(map[models.AlertRuleKey]struct{})
+	provisionedGroups := make([]models.AlertRuleGroupKey, 0, len(file.Groups))
+	for _, group := range file.Groups {
+		if group.Name == "" || group.FolderUID == "" {
+			return nil, fmt.Errorf("%w: rule group must have a name and a folder", ErrValidation)
+		}
+		key := models.AlertRuleGroupKey{OrgID: group.OrgID, NamespaceUID: group.FolderUID, RuleGroup: group.Name}
+		if _, ok := diffs[key]; ok {
+			return nil, fmt.Errorf("%w: rule group %s in folder %s is provisioned more than once", ErrValidation, key.RuleGroup, key.NamespaceUID)
+		}
+		provisionedGroups = append(provisionedGroups, key)
+		d := diffFor(key)
+
+		rules, err := getRules(group.OrgID)
+		if err != nil {
+			return nil, err
+		}
+		interval := group.Interval
+		if interval == 0 {
+			interval = s.defaultInterval
+		}
+		for _, rule := range rules.list {
+			if rule.GetGroupKey() == key && rule.IntervalSeconds != interval {
+				d.IntervalChange = &definitions.IntervalChange{From: rule.IntervalSeconds, To: interval}
+				break
+			}
+		}
+
+		for idx, r := range group.Rules {
+			proposed, err := provisionedRuleToModel(group, idx, interval)
+			if err != nil {
+				return nil, err
+			}
+			existing, ok := rules.byUID[proposed.UID]
+			if proposed.UID == "" || !ok {
+				d.Created = append(d.Created, definitions.ResourceDiff{UID: r.UID, Name: r.Title})
+				continue
+			}
+			if _, ok := provisioned[existing.GetKey()]; ok {
+				return nil, fmt.Errorf("%w: rule %s is provisioned more than once", ErrValidation, existing.UID)
+			}
+			provisioned[existing.GetKey()] = struct{}{}
+			if changes := ruleChanges(existing, &proposed); len(changes) > 0 {
+				d.Updated = append(d.Updated, definitions.ResourceDiff{UID: existing.UID, Name: proposed.Title, Changes: changes})
+			}
+		}
+	}
+
+	deleted := make(map[models.AlertRuleKey]struct{})
+	for _, key := range provisionedGroups {
+		for _, rule := range rulesByOrg[key.OrgID].list {
+			if rule.GetGroupKey() != key {
+				continue
+			}
+			if _, ok := provisioned[rule.GetKey()]; ok {
+				continue
+			}
+			deleted[rule.GetKey()] = struct{}{}
+			d := diffFor(key)
+			d.Deleted = append(d.Deleted, definitions.ResourceDiff{UID: rule.UID, Name: rule.Title})
+		}
+	}
+	for _, ref := range file.DeleteRules {
+		rules, err := getRules(ref.OrgID)
+		if err != nil {
+			return nil, err
+		}
+		rule, ok := rules.byUID[ref.UID]
+		if !ok {
+			continue
+		}
+		if _, ok := provisioned[rule.GetKey()]; ok {
+			return nil, fmt.Errorf("%w: rule %s is both provisioned and deleted", ErrValidation, rule.UID)
+		}
+		if _, ok := deleted[rule.GetKey()]; ok {
+			continue
+		}
+		deleted[rule.GetKey()] = struct{}{}
+		d := diffFor(rule.GetGroupKey())
+		d.Deleted = append(d.Deleted, definitions.ResourceDiff{UID: rule.UID, Name: rule.Title})
+	}
+
+	result := make([]definitions.RuleGroupDiff, 0, len(keys))
+	for _, key := range keys {
+		d := diffs[key]
+		if d.IntervalChange == nil && len(d.Created) == 0 && len(d.Updated) == 0 && len(d.Deleted) == 0 {
+			continue
+		}
+		result = append(result, *d)
+	}
+	return result, nil
+}
+
+func provisionedRuleToModel(group definitions.ProvisionedRuleGroup, idx int, interval int64) (models.AlertRule, error) {
+	r := group.Rules[idx]
+	if r.Title == "" {
+		return models.AlertRule{}, fmt.Errorf("%w: rule %d of group %s has no title", ErrValidation, idx, group.Name)
+	}
+	if len(r.Data) == 0 {
+		return models.AlertRule{}, fmt.Errorf("%w: rule %s has no queries", ErrValidation, r.Title)
+	}
+	if r.Record != nil {
+		if err := r.Record.Validate(r.Data); err != nil {
+			return models.AlertRule{}, fmt.Errorf("%w: rule %s: %s", ErrValidation, r.Title, err.Error())
+		}
+	} else if r.Condition == "" {
+		return models.AlertRule{}, fmt.Errorf("%w: rule %s has no condition", ErrValidation, r.Title)
+	}
+	noDataState := r.NoDataState
+	if noDataState == "" {
+		noDataState = models.NoData
+	}
+	execErrState := r.ExecErrState
+	if execErrState == "" {
+		execErrState = models.AlertingErrState
+	}
+	return models.AlertRule{
+		OrgID:           group.OrgID,
+		UID:             r.UID,
+		Title:           r.Title,
+		Condition:       r.Condition,
+		Data:            r.Data,
+		IntervalSeconds: interval,
+		NamespaceUID:    group.FolderUID,
+		RuleGroup:       group.Name,
+		RuleGroupIndex:  idx + 1,
+		NoDataState:     noDataState,
+		ExecErrState:    execErrState,
+		For:             time.Duration(r.For),
+		Annotations:     r.Annotations,
+		Labels:          r.Labels,
+		Record:          r.Record,
+	}, nil
+}
+
+// ruleChanges returns the paths of the fields that differ between the stored and the proposed rule.
+func ruleChanges(existing *models.AlertRule, proposed *models.AlertRule) []string {
+	// the models of the queries are compared as strings, so they are normalized first.
+	current := *existing
+	current.Data = normalizeQueries(existing.Data)
+	next := *proposed
+	next.Data = normalizeQueries(proposed.Data)
+
+	var changes []string
+	seen := make(map[string]struct{})
+	for _, diff := range current.Diff(&next, ignoredRuleFields...) {
+		if _, ok := seen[diff.Path]; ok {
+			continue
+		}
+		seen[diff.Path] = struct{}{}
+		changes = append(changes, diff.Path)
+	}
+	return changes
+}
+
+func normalizeQueries(data []models.AlertQuery) []models.AlertQuery {
+	result := make([]models.AlertQuery, 0, len(data))
+	for _, q := range data {
+		var m interface{}
+		if err := json.Unmarshal(q.Model, &m); err == nil {
+			if b, err := json.Marshal(m); err == nil {
+				q.Model = b
+			}
+		}
+		result = append(result, q)
+	}
+	return result
+}
+
+func (s *DryRunService) diffContactPoints(ctx context.Context, file definitions.ProvisioningFile) ([]definitions.ContactPointDiff, error) {
+	contactPointsByOrg := make(map[int64]map[string]definitions.EmbeddedContactPoint)
+	getContactPoints := func(orgID int64) (map[string]definitions.EmbeddedContactPoint, error) {
+		if cps, ok := contactPointsByOrg[orgID]; ok {
+			return cps, nil
+		}
+		cps, err := s.contactPoints.getContactPointsDecrypted(ctx, orgID)
+		if err != nil {
+			return nil, err
+		}
+		contactPointsByOrg[orgID] = cps
+		return cps, nil
+	}
+
+	diffs := make(map[int64]*definitions.ContactPointDiff)
+	orgs := make([]int64, 0)
+	diffFor := func(orgID int64) *definitions.ContactPointDiff {
+		if d, ok := diffs[orgID]; ok {
+			return d
+		}
+		d := &definitions.ContactPointDiff{OrgID: orgID}
+		diffs[orgID] = d
+		orgs = append(orgs, orgID)
+		return d
+	}
+
+	provisioned := make(map[int64]map[string]struct{})
+	for _, cp := range file.ContactPoints {
+		if cp.Name == "" || cp.Type == "" {
+			return nil, fmt.Errorf("%w: contact point must have a name and a type", ErrValidation)
+		}
+		current, err := getContactPoints(cp.OrgID)
+		if err != nil {
+			return nil, err
+		}
+		d := diffFor(cp.OrgID)
+		existing, ok := current[cp.UID]
+		if cp.UID == "" || !ok {
+			d.Created = append(d.Created, definitions.ResourceDiff{UID: cp.UID, Name: cp.Name})
+			continue
+		}
+		if provisioned[cp.OrgID] == nil {
+			provisioned[cp.OrgID] = make(map[string]struct{})
+		}
+		provisioned[cp.OrgID][cp.UID] = struct{}{}
+		proposed, err := s.resolveSecrets(cp, existing)
+		if err != nil {
+			return nil, err
+		}
+		if changes := contactPointChanges(existing, proposed); len(changes) > 0 {
+			d.Updated = append(d.Updated, definitions.ResourceDiff{UID: cp.UID, Name: cp.Name, Changes: changes})
+		}
+	}
+	for _, ref := range file.DeleteContactPoints {
+		current, err := getContactPoints(ref.OrgID)
+		if err != nil {
+			return nil, err
+		}
+		existing, ok := current[ref.UID]
+		if !ok {
+			continue
+		}
+		if _, ok := provisioned[ref.OrgID][ref.UID]; ok {
+			return nil, fmt.Errorf("%w: contact point %s is both provisioned and deleted", ErrValidation, ref.UID)
+		}
+		d := diffFor(ref.OrgID)
+		d.Deleted = append(d.Deleted, definitions.ResourceDiff{UID: existing.UID, Name: existing.Name})
+	}
+
+	result := make([]definitions.ContactPointDiff, 0, len(orgs))
+	for _, orgID := range orgs {
+		d := diffs[orgID]
+		if len(d.Created) == 0 && len(d.Updated) == 0 && len(d.Deleted) == 0 {
+			continue
+		}
+		result = append(result, *d)
+	}
+	return result, nil
+}
+
+// resolveSecrets returns the provisioned contact point with its encrypted secure settings decrypted and
+// its redacted settings replaced by the stored ones, so that only actual changes of secrets are reported.
+func (s *DryRunService) resolveSecrets(cp definitions.ProvisionedContactPoint, current definitions.EmbeddedContactPoint) (definitions.EmbeddedContactPoint, error) {
+	result := cp.EmbeddedContactPoint()
+	settings := simplejson.New()
+	if cp.Settings != nil {
+		b, err := cp.Settings.MarshalJSON()
+		if err != nil {
+			return result, err
+		}
+		if settings, err = simplejson.NewJson(b); err != nil {
+			return result, err
+		}
+	}
+	for k, v := range cp.SecureSettings {
+		decrypted, err := s.contactPoints.decryptValue(v)
+		if err != nil {
+			return result, fmt.Errorf("%w: failed to decrypt secure setting %s of contact point %s", ErrValidation, k, cp.Name)
+		}
+		if decrypted == "" {
+			continue
+		}
+		settings.Set(k, decrypted)
+	}
+	for k, v := range settings.MustMap() {
+		if v != definitions.RedactedValue || current.Settings == nil {
+			continue
+		}
+		// A redacted secret that is not stored was exported from an empty secure setting.
+		if stored, ok := current.Settings.CheckGet(k); ok {
+			settings.Set(k, stored.Interface())
+		} else {
+			settings.Del(k)
+		}
+	}
+	result.Settings = settings
+	return result, nil
+}
+
+// contactPointChanges returns the paths of the fields that differ between the stored and the proposed contact point.
+func contactPointChanges(current, proposed definitions.EmbeddedContactPoint) []string {
+	var changes []string
+	if current.Name != proposed.Name {
+		changes = append(changes, "name")
+	}
+	if current.Type != proposed.Type {
+		changes = append(changes, "type")
+	}
+	if current.DisableResolveMessage != proposed.DisableResolveMessage {
+		changes = append(changes, "disableResolveMessage")
+	}
+	return append(changes, jsonChanges("settings", settingsToJSON(current.Settings), settingsToJSON(proposed.Settings))...)
+}
+
+func settingsToJSON(settings *simplejson.Json) interface{} {
+	if settings == nil {
+		return map[string]interface{}{}
+	}
+	b, err := settings.MarshalJSON()
+	if err != nil {
+		return nil
+	}
+	var result interface{}
+	if err := json.Unmarshal(b, &result); err != nil || result == nil {
+		return map[string]interface{}{}
+	}
+	return result
+}
+
+func (s *DryRunService) diffPolicies(ctx context.Context, file definitions.ProvisioningFile) ([]definitions.PolicyTreeDiff, error) {
+	result := make([]definitions.PolicyTreeDiff, 0)
+	seen := make(map[int64]struct{})
+	for _, p := range file.Policies {
+		if _, ok := seen[p.OrgID]; ok {
+			return nil, fmt.Errorf("%w: notification policy tree of organization %d is provisioned more than once", ErrValidation, p.OrgID)
+		}
+		seen[p.OrgID] = struct{}{}
+
+		proposed := p.Policy
+		if err := proposed.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrValidation, err.Error())
+		}
+		revision, err := getLastConfiguration(ctx, p.OrgID, s.contactPoints.amStore)
+		if err != nil {
+			return nil, err
+		}
+		current := definitions.Route{}
+		if revision.cfg.AlertmanagerConfig.Config.Route != nil {
+			current = *revision.cfg.AlertmanagerConfig.Config.Route
+		}
+		changes, err := policyChanges(current, proposed)
+		if err != nil {
+			return nil, err
+		}
+		if len(changes) > 0 {
+			result = append(result, definitions.PolicyTreeDiff{OrgID: p.OrgID, Changes: changes})
+		}
+	}
+	return result, nil
+}
+
+func (s *DryRunService) diffMuteTimings(ctx context.Context, file definitions.ProvisioningFile) ([]definitions.MuteTimingDiff, error) {
+	byOrg := make(map[int64][]definitions.ProvisionedMuteTiming)
+	orgs := make([]int64, 0)
+	for _, mt := range file.MuteTimes {
+		if mt.Name == "" {
+			return nil, fmt.Errorf("%w: mute timing must have a name", ErrValidation)
+		}
+		interval := definitions.MuteTimeInterval{MuteTimeInterval: config.MuteTimeInterval{Name: mt.Name, TimeIntervals: mt.TimeIntervals}}
+		if err := interval.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: mute timing %s: %s", ErrValidation, mt.Name, err.Error())
+		}
+		if _, ok := byOrg[mt.OrgID]; !ok {
+			orgs = append(orgs, mt.OrgID)
+		}
+		byOrg[mt.OrgID] = append(byOrg[mt.OrgID], mt)
+	}
+
+	result := make([]definitions.MuteTimingDiff, 0, len(orgs))
+	for _, orgID := range orgs {
+		revision, err := getLastConfiguration(ctx, orgID, s.contactPoints.amStore)
+		if err != nil {
+			return nil, err
+		}
+		current := make(map[string]interface{}, len(revision.cfg.AlertmanagerConfig.MuteTimeIntervals))
+		for _, mt := range revision.cfg.AlertmanagerConfig.MuteTimeIntervals {
+			current[mt.Name] = toJSONValue(mt.TimeIntervals)
+		}
+
+		d := definitions.MuteTimingDiff{OrgID: orgID}
+		seen := make(map[string]struct{})
+		for _, mt := range byOrg[orgID] {
+			if _, ok := seen[mt.Name]; ok {
+				return nil, fmt.Errorf("%w: mute timing %s is provisioned more than once", ErrValidation, mt.Name)
+			}
+			seen[mt.Name] = struct{}{}
+			existing, ok := current[mt.Name]
+			if !ok {
+				d.Created = append(d.Created, definitions.ResourceDiff{Name: mt.Name})
+				continue
+			}
+			if changes := jsonChanges("time_intervals", existing, toJSONValue(mt.TimeIntervals)); len(changes) > 0 {
+				d.Updated = append(d.Updated, definitions.ResourceDiff{Name: mt.Name, Changes: changes})
+			}
+		}
+		if len(d.Created) > 0 || len(d.Updated) > 0 {
+			result = append(result, d)
+		}
+	}
+	return result, nil
+}
+
+func (s *DryRunService) diffTemplates(ctx context.Context, file definitions.ProvisioningFile) ([]definitions.TemplateDiff, error) {
+	byOrg := make(map[int64][]definitions.ProvisionedTemplate)
+	orgs := make([]int64, 0)
+	for _, tmpl := range file.Templates {
+		if tmpl.Name == "" {
+			return nil, fmt.Errorf("%w: template must have a name", ErrValidation)
+		}
+		if _, ok := byOrg[tmpl.OrgID]; !ok {
+			orgs = append(orgs, tmpl.OrgID)
+		}
+		byOrg[tmpl.OrgID] = append(byOrg[tmpl.OrgID], tmpl)
+	}
+
+	result := make([]definitions.TemplateDiff, 0, len(orgs))
+	for _, orgID := range orgs {
+		revision, err := getLastConfiguration(ctx, orgID, s.contactPoints.amStore)
+		if err != nil {
+			return nil, err
+		}
+
+		d := definitions.TemplateDiff{OrgID: orgID}
+		seen := make(map[string]struct{})
+		for _, tmpl := range byOrg[orgID] {
+			if _, ok := seen[tmpl.Name]; ok {
+				return nil, fmt.Errorf("%w: template %s is provisioned more than once", ErrValidation, tmpl.Name)
+			}
+			seen[tmpl.Name] = struct{}{}
+			existing, ok := revision.cfg.TemplateFiles[tmpl.Name]
+			if !ok {
+				d.Created = append(d.Created, definitions.ResourceDiff{Name: tmpl.Name})
+				continue
+			}
+			if existing != tmpl.Template {
+				d.Updated = append(d.Updated, definitions.ResourceDiff{Name: tmpl.Name, Changes: []string{"template"}})
+			}
+		}
+		if len(d.Created) > 0 || len(d.Updated) > 0 {
+			result = append(result, d)
+		}
+	}
+	return result, nil
+}
+
+// toJSONValue converts v to its decoded JSON representation, so that it can be compared with jsonChanges.
+func toJSONValue(v interface{}) interface{} {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var result interface{}
+	if err := json.Unmarshal(b, &result); err != nil {
+		return nil
+	}
+	return result
+}
+
+func policyChanges(current, proposed definitions.Route) ([]string, error) {
+	current.Provenance = ""
+	proposed.Provenance = ""
+	toJSON := func(r definitions.Route) (interface{}, error) {
+		b, err := json.Marshal(r)
+		if err != nil {
+			return nil, err
+		}
+		var result interface{}
+		err = json.Unmarshal(b, &result)
+		return result, err
+	}
+	c, err := toJSON(current)
+	if err != nil {
+		return nil, err
+	}
+	p, err := toJSON(proposed)
+	if err != nil {
+		return nil, err
+	}
+	return jsonChanges("", c, p), nil
+}
+
+// jsonChanges returns the paths at which two decoded JSON values differ. Objects are compared key by key
+// and arrays of the same length element by element.
+func jsonChanges(path string, a, b interface{}) []string {
+	join := func(key string) string {
+		if path == "" {
+			return key
+		}
+		return path + "." + key
+	}
+
+	am, aok := a.(map[string]interface{})
+	bm, bok := b.(map[string]interface{})
+	if aok && bok {
+		keys := make([]string, 0, len(am)+len(bm))
+		for k := range am {
+			keys = append(keys, k)
+		}
+		for k := range bm {
+			if _, ok := am[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		var changes []string
+		for _, k := range keys {
+			changes = append(changes, jsonChanges(join(k), am[k], bm[k])...)
+		}
+		return changes
+	}
+
+	as, aok := a.([]interface{})
+	bs, bok := b.([]interface{})
+	if aok && bok && len(as) == len(bs) {
+		var changes []string
+		for i := range as {
+			changes = append(changes, jsonChanges(fmt.Sprintf("%s[%d]", path, i), as[i], bs[i])...)
+		}
+		return changes
+	}
+
+	if reflect.DeepEqual(a, b) {
+		return nil
+	}
+	if path == "" {
+		path = "."
+	}
+	return []string{path}
+}
@@ -0,0 +1,238 @@
+package provisioning
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/prometheus/alertmanager/timeinterval"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/store"
+	"github.com/grafana/grafana/pkg/services/secrets/fakes"
+	secretsManager "github.com/grafana/grafana/pkg/services/secrets/manager"
+)
+
+const dryRunTestFile = `
+apiVersion: 1
+groups:
+  - folderUid: folder
+    name: group-1
+    interval: 120
+    rules:
+      - uid: rule-1
+        title: rule 1 renamed
+        condition: A
+        data:
+          - refId: A
+            datasourceUid: "-100"
+            relativeTimeRange: {from: 600, to: 0}
+            model: {type: math, expression: "1"}
+      - title: new rule
+        condition: A
+        data:
+          - refId: A
+            datasourceUid: "-100"
+            relativeTimeRange: {from: 600, to: 0}
+            model: {type: math, expression: "2"}
+deleteRules:
+  - uid: rule-3
+  - uid: unknown
+contactPoints:
+  - uid: cp-1
+    name: test-contact-point
+    type: slack
+    settings: {recipient: changed, token: value_token}
+  - name: new contact point
+    type: email
+    settings: {addresses: test@example.com}
+deleteContactPoints:
+  - uid: unknown
+policies:
+  - policy:
+      receiver: a new receiver
+      group_by: ["..."]
+      routes:
+        - receiver: grafana-default-email
+          object_matchers: [["a", "=", "b"]]
+muteTimes:
+  - name: mute-timing-1
+    time_intervals:
+      - weekdays: [tuesday]
+  - name: new mute timing
+    time_intervals:
+      - weekdays: [monday]
+templates:
+  - name: template-1
+    template: '{{ define "template-1" }}changed{{ end }}'
+  - name: new template
+    template: '{{ define "new template" }}new{{ end }}'
+`
+
+func TestDryRunService(t *testing.T) {
+	setup := func(t *testing.T) *DryRunService {
+		t.Helper()
+		ruleStore := store.NewFakeRuleStore(t)
+		ruleStore.PutRule(context.Background(),
+			dryRunTestRule("rule-1", "rule 1", "group-1", 1),
+			dryRunTestRule("rule-2", "rule 2", "group-1", 2),
+			dryRunTestRule("rule-3", "rule 3", "group-2", 1),
+		)
+
+		contactPoints := createContactPointServiceSut(secretsManager.SetupTestService(t, fakes.NewFakeSecretsStore()))
+		cp := createTestContactPoint()
+		cp.UID = "cp-1"
+		_, err := contactPoints.CreateContactPoint(context.Background(), 1, cp, models.ProvenanceFile)
+		require.NoError(t, err)
+
+		muteTimings := &MuteTimingService{config: contactPoints.amStore, prov: NewFakeProvisioningStore(), xact: newNopTransactionManager(), log: log.NewNopLogger()}
+		mt := createMuteTiming()
+		mt.Name = "mute-timing-1"
+		weekdays := []timeinterval.WeekdayRange{}
+		require.NoError(t, yaml.Unmarshal([]byte("[monday]"), &weekdays))
+		mt.TimeIntervals = []timeinterval.TimeInterval{{Weekdays: weekdays}}
+		_, err = muteTimings.CreateMuteTiming(context.Background(), mt, 1)
+		require.NoError(t, err)
+
+		templates := &TemplateService{config: contactPoints.amStore, prov: NewFakeProvisioningStore(), xact: newNopTransactionManager(), log: log.NewNopLogger()}
+		_, err = templates.SetTemplate(context.Background(), 1, definitions.MessageTemplate{Name: "template-1", Template: `{{ define "template-1" }}original{{ end }}`})
+		require.NoError(t, err)
+
+		return NewDryRunService(ruleStore, contactPoints, 60, log.NewNopLogger())
+	}
+
+	t.Run("reports the changes of the provisioning file", func(t *testing.T) {
+		sut := setup(t)
+		file, err := ParseProvisioningFile([]byte(dryRunTestFile))
+		require.NoError(t, err)
+
+		diff, err := sut.Diff(context.Background(), file)
+		require.NoError(t, err)
+		require.True(t, diff.HasChanges())
+
+		require.Equal(t, []definitions.RuleGroupDiff{
+			{
+				OrgID:          1,
+				FolderUID:      "folder",
+				RuleGroup:      "group-1",
+				IntervalChange: &definitions.IntervalChange{From: 60, To: 120},
+				Created:        []definitions.ResourceDiff{{Name: "new rule"}},
+				Updated:        []definitions.ResourceDiff{{UID: "rule-1", Name: "rule 1 renamed", Changes: []string{"Title"}}},
+				Deleted:        []definitions.ResourceDiff{{UID: "rule-2", Name: "rule 2"}},
+			},
+			{
+				OrgID:     1,
+				FolderUID: "folder",
+				RuleGroup: "group-2",
+				Deleted:   []definitions.ResourceDiff{{UID: "rule-3", Name: "rule 3"}},
+			},
+		}, diff.RuleGroups)
+
+		require.Equal(t, []definitions.ContactPointDiff{
+			{
+				OrgID:   1,
+				Created: []definitions.ResourceDiff{{Name: "new contact point"}},
+				Updated: []definitions.ResourceDiff{{UID: "cp-1", Name: "test-contact-point", Changes: []string{"settings.recipient"}}},
+			},
+		}, diff.ContactPoints)
+
+		require.Equal(t, []definitions.PolicyTreeDiff{
+			{OrgID: 1, Changes: []string{"receiver"}},
+		}, diff.Policies)
+
+		require.Equal(t, []definitions.MuteTimingDiff{
+			{
+				OrgID:   1,
+				Created: []definitions.ResourceDiff{{Name: "new mute timing"}},
+				Updated: []definitions.ResourceDiff{{Name: "mute-timing-1", Changes: []string{"time_intervals[0].weekdays[0]"}}},
+			},
+		}, diff.MuteTimings)
+
+		require.Equal(t, []definitions.TemplateDiff{
+			{
+				OrgID:   1,
+				Created: []definitions.ResourceDiff{{Name: "new template"}},
+				Updated: []definitions.ResourceDiff{{Name: "template-1", Changes: []string{"template"}}},
+			},
+		}, diff.Templates)
+	})
+
+	t.Run("reports nothing if the stored resources match", func(t *testing.T) {
+		sut := setup(t)
+		file := definitions.ProvisioningFile{
+			APIVersion: 1,
+			Groups: []definitions.ProvisionedRuleGroup{
+				{
+					OrgID:     1,
+					FolderUID: "folder",
+					Name:      "group-2",
+					Interval:  60,
+					Rules: []definitions.ProvisionedAlertRule{
+						{
+							UID:       "rule-3",
+							Title:     "rule 3",
+							Condition: "A",
+							Data:      dryRunTestRule("rule-3", "rule 3", "group-2", 1).Data,
+						},
+					},
+				},
+			},
+		}
+
+		diff, err := sut.Diff(context.Background(), file)
+		require.NoError(t, err)
+		require.False(t, diff.HasChanges())
+	})
+
+	t.Run("fails on invalid resources", func(t *testing.T) {
+		sut := setup(t)
+		files := []definitions.ProvisioningFile{
+			{Groups: []definitions.ProvisionedRuleGroup{{OrgID: 1, Name: "group-1"}}},
+			{Groups: []definitions.ProvisionedRuleGroup{{OrgID: 1, Name: "group-1", FolderUID: "folder", Rules: []definitions.ProvisionedAlertRule{{Title: "no queries", Condition: "A"}}}}},
+			{ContactPoints: []definitions.ProvisionedContactPoint{{OrgID: 1, Name: "no type"}}},
+			{Policies: []definitions.ProvisionedNotificationPolicy{{OrgID: 1}}},
+			{MuteTimes: []definitions.ProvisionedMuteTiming{{OrgID: 1}}},
+			{Templates: []definitions.ProvisionedTemplate{{OrgID: 1}}},
+		}
+		for _, file := range files {
+			_, err := sut.Diff(context.Background(), file)
+			require.ErrorIs(t, err, ErrValidation)
+		}
+	})
+}
+
+func TestJSONChanges(t *testing.T) {
+	var a, b interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"a": 1, "b": {"c": [1, 2]}, "d": [1], "e": "x"}`), &a))
+	require.NoError(t, json.Unmarshal([]byte(`{"a": 1, "b": {"c": [1, 3]}, "d": [1, 2], "f": "x"}`), &b))
+	require.Equal(t, []string{"b.c[1]", "d", "e", "f"}, jsonChanges("", a, b))
+	require.Empty(t, jsonChanges("", a, a))
+}
+
+func dryRunTestRule(uid, title, group string, idx int) *models.AlertRule {
+	return &models.AlertRule{
+		OrgID:     1,
+		UID:       uid,
+		Title:     title,
+		Condition: "A",
+		Data: []models.AlertQuery{
+			{
+				RefID:             "A",
+				DatasourceUID:     "-100",
+				RelativeTimeRange: models.RelativeTimeRange{From: models.Duration(10 * time.Minute)},
+				Model:             json.RawMessage(`{"expression": "1", "type": "math"}`),
+			},
+		},
+		IntervalSeconds: 60,
+		NamespaceUID:    "folder",
+		RuleGroup:       group,
+		RuleGroupIndex:  idx,
+		NoDataState:     models.NoData,
+		ExecErrState:    models.AlertingErrState,
+	}
+}
@@ -0,0 +1,180 @@
+package provisioning
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	prommodel "github.com/prometheus/common/model"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/store"
+)
+
+// SecretsExport defines how the secure settings of contact points are exported. With either option, the exported
+// contact points can only be compared with the stored ones by the DryRunService of the same Grafana instance; they
+// cannot be imported into another instance.
+type SecretsExport string
+
+const (
+	// SecretsRedact replaces the secure settings with a placeholder. The dry run treats the placeholder as the
+	// stored value.
+	SecretsRedact SecretsExport = "redact"
+	// SecretsEncrypt exports the secure settings encrypted with the secret key of the Grafana instance. Only that
+	// instance can decrypt them.
+	SecretsEncrypt SecretsExport = "encrypt"
+)
+
+// ExportService renders the alerting resources of an organization in the format of provisioning files.
+type ExportService struct {
+	ruleStore store.RuleStore
+	amStore   AMConfigStore
+	log       log.Logger
+}
+
+func NewExportService(ruleStore store.RuleStore, amStore AMConfigStore, log log.Logger) *ExportService {
+	return &ExportService{
+		ruleStore: ruleStore,
+		amStore:   amStore,
+		log:       log,
+	}
+}
+
+// ExportRules returns the rule groups of the organization, ordered by folder and name. If folderUIDs or group
+// are set, only the matching rule groups are exported.
+func (s *ExportService) ExportRules(ctx context.Context, orgID int64, folderUIDs []string, group string) (definitions.ProvisioningFile, error) {
+	result := definitions.ProvisioningFile{APIVersion: 1}
+
+	q := models.ListAlertRulesQuery{OrgID: orgID, NamespaceUIDs: folderUIDs, RuleGroup: group}
+	if err := s.ruleStore.ListAlertRules(ctx, &q); err != nil {
+		return result, err
+	}
+	rules := q.Result
+	sort.SliceStable(rules, func(i, j int) bool {
+		if rules[i].NamespaceUID != rules[j].NamespaceUID {
+			return rules[i].NamespaceUID < rules[j].NamespaceUID
+		}
+		if rules[i].RuleGroup != rules[j].RuleGroup {
+			return rules[i].RuleGroup < rules[j].RuleGroup
+		}
+		return rules[i].RuleGroupIndex < rules[j].RuleGroupIndex
+	})
+
+	for _, rule := range rules {
+		if len(result.Groups) == 0 || rule.GetGroupKey() != provisionedGroupKey(result.Groups[len(result.Groups)-1]) {
+			result.Groups = append(result.Groups, definitions.ProvisionedRuleGroup{
+				OrgID:     rule.OrgID,
+				Name:      rule.RuleGroup,
+				FolderUID: rule.NamespaceUID,
+				Interval:  rule.IntervalSeconds,
+			})
+		}
+		g := &result.Groups[len(result.Groups)-1]
+		g.Rules = append(g.Rules, definitions.ProvisionedAlertRule{
+			UID:          rule.UID,
+			Title:        rule.Title,
+			Condition:    rule.Condition,
+			Data:         rule.Data,
+			NoDataState:  rule.NoDataState,
+			ExecErrState: rule.ExecErrState,
+			For:          prommodel.Duration(rule.For),
+			Annotations:  rule.Annotations,
+			Labels:       rule.Labels,
+			Record:       rule.Record,
+		})
+	}
+	return result, nil
+}
+
+// Export returns all alerting resources of the organization: the rule groups, the contact points,
+// the notification policy tree, the mute timings and the templates.
+func (s *ExportService) Export(ctx context.Context, orgID int64, secrets SecretsExport) (definitions.ProvisioningFile, error) {
+	if secrets != SecretsRedact && secrets != SecretsEncrypt {
+		return definitions.ProvisioningFile{}, fmt.Errorf("%w: unknown secrets export option %s", ErrValidation, secrets)
+	}
+
+	result, err := s.ExportRules(ctx, orgID, nil, "")
+	if err != nil {
+		return result, err
+	}
+
+	revision, err := getLastConfiguration(ctx, orgID, s.amStore)
+	if err != nil {
+		return result, err
+	}
+
+	for _, receiver := range revision.cfg.GetGrafanaReceiverMap() {
+		settings := simplejson.New()
+		if receiver.Settings != nil {
+			b, err := receiver.Settings.MarshalJSON()
+			if err != nil {
+				return result, err
+			}
+			if settings, err = simplejson.NewJson(b); err != nil {
+				return result, err
+			}
+		}
+		cp := definitions.ProvisionedContactPoint{
+			OrgID:                 orgID,
+			UID:                   receiver.UID,
+			Name:                  receiver.Name,
+			Type:                  receiver.Type,
+			Settings:              settings,
+			DisableResolveMessage: receiver.DisableResolveMessage,
+		}
+		for k, v := range receiver.SecureSettings {
+			if secrets == SecretsEncrypt {
+				if cp.SecureSettings == nil {
+					cp.SecureSettings = make(map[string]string, len(receiver.SecureSettings))
+				}
+				cp.SecureSettings[k] = v
+				continue
+			}
+			settings.Set(k, definitions.RedactedValue)
+		}
+		result.ContactPoints = append(result.ContactPoints, cp)
+	}
+	sort.SliceStable(result.ContactPoints, func(i, j int) bool {
+		if result.ContactPoints[i].Name != result.ContactPoints[j].Name {
+			return result.ContactPoints[i].Name < result.ContactPoints[j].Name
+		}
+		return result.ContactPoints[i].UID < result.ContactPoints[j].UID
+	})
+
+	if route := revision.cfg.AlertmanagerConfig.Config.Route; route != nil {
+		policy := *route
+		policy.Provenance = ""
+		result.Policies = append(result.Policies, definitions.ProvisionedNotificationPolicy{OrgID: orgID, Policy: policy})
+	}
+
+	for _, mt := range revision.cfg.AlertmanagerConfig.MuteTimeIntervals {
+		result.MuteTimes = append(result.MuteTimes, definitions.ProvisionedMuteTiming{
+			OrgID:         orgID,
+			Name:          mt.Name,
+			TimeIntervals: mt.TimeIntervals,
+		})
+	}
+
+	names := make([]string, 0, len(revision.cfg.TemplateFiles))
+	for name := range revision.cfg.TemplateFiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		result.Templates = append(result.Templates, definitions.ProvisionedTemplate{
+			OrgID:    orgID,
+			Name:     name,
+			Template: revision.cfg.TemplateFiles[name],
+		})
+	}
+
+	s.log.Debug("alerting resources exported", "org", orgID, "ruleGroups", len(result.Groups), "contactPoints", len(result.ContactPoints))
+	return result, nil
+}
+
+func provisionedGroupKey(g definitions.ProvisionedRuleGroup) models.AlertRuleGroupKey {
+	return models.AlertRuleGroupKey{OrgID: g.OrgID, NamespaceUID: g.FolderUID, RuleGroup: g.Name}
+}
@@ -0,0 +1,117 @@
+package provisioning
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/store"
+	"github.com/grafana/grafana/pkg/services/secrets/fakes"
+	secretsManager "github.com/grafana/grafana/pkg/services/secrets/manager"
+)
+
+func TestExportService(t *testing.T) {
+	setup := func(t *testing.T) (*ExportService, *DryRunService) {
+		t.Helper()
+		ctx := context.Background()
+		ruleStore := store.NewFakeRuleStore(t)
+		ruleStore.PutRule(ctx,
+			dryRunTestRule("rule-2", "rule 2", "group-1", 2),
+			dryRunTestRule("rule-1", "rule 1", "group-1", 1),
+			dryRunTestRule("rule-3", "rule 3", "group-2", 1),
+		)
+
+		contactPoints := createContactPointServiceSut(secretsManager.SetupTestService(t, fakes.NewFakeSecretsStore()))
+		cp := createTestContactPoint()
+		cp.UID = "cp-1"
+		_, err := contactPoints.CreateContactPoint(ctx, 1, cp, models.ProvenanceFile)
+		require.NoError(t, err)
+
+		templates := NewTemplateService(contactPoints.amStore, NewFakeProvisioningStore(), newNopTransactionManager(), nil, log.NewNopLogger())
+		_, err = templates.SetTemplate(ctx, 1, definitions.MessageTemplate{Name: "tmpl", Template: `{{ define "tmpl" }}text{{ end }}`})
+		require.NoError(t, err)
+		muteTimings := NewMuteTimingService(contactPoints.amStore, NewFakeProvisioningStore(), newNopTransactionManager(), log.NewNopLogger())
+		_, err = muteTimings.CreateMuteTiming(ctx, definitions.MuteTimeInterval{MuteTimeInterval: config.MuteTimeInterval{Name: "weekends"}}, 1)
+		require.NoError(t, err)
+
+		return NewExportService(ruleStore, contactPoints.amStore, log.NewNopLogger()),
+			NewDryRunService(ruleStore, contactPoints, 60, log.NewNopLogger())
+	}
+
+	t.Run("exports the rule groups ordered by folder and name", func(t *testing.T) {
+		sut, _ := setup(t)
+
+		file, err := sut.ExportRules(context.Background(), 1, nil, "")
+		require.NoError(t, err)
+		require.Len(t, file.Groups, 2)
+		require.Equal(t, "group-1", file.Groups[0].Name)
+		require.Equal(t, int64(60), file.Groups[0].Interval)
+		require.Equal(t, "rule-1", file.Groups[0].Rules[0].UID)
+		require.Equal(t, "rule-2", file.Groups[0].Rules[1].UID)
+		require.Equal(t, "group-2", file.Groups[1].Name)
+
+		file, err = sut.ExportRules(context.Background(), 1, []string{"folder"}, "group-2")
+		require.NoError(t, err)
+		require.Len(t, file.Groups, 1)
+		require.Equal(t, "rule-3", file.Groups[0].Rules[0].UID)
+	})
+
+	t.Run("redacts the secure settings by default", func(t *testing.T) {
+		sut, _ := setup(t)
+
+		file, err := sut.Export(context.Background(), 1, SecretsRedact)
+		require.NoError(t, err)
+
+		var cp *definitions.ProvisionedContactPoint
+		for i := range file.ContactPoints {
+			if file.ContactPoints[i].UID == "cp-1" {
+				cp = &file.ContactPoints[i]
+			}
+		}
+		require.NotNil(t, cp)
+		require.Equal(t, definitions.RedactedValue, cp.Settings.Get("token").MustString())
+		require.Empty(t, cp.SecureSettings)
+		require.Len(t, file.Policies, 1)
+		require.Len(t, file.MuteTimes, 1)
+		require.Equal(t, "weekends", file.MuteTimes[0].Name)
+		require.Len(t, file.Templates, 1)
+		require.Equal(t, "tmpl", file.Templates[0].Name)
+	})
+
+	t.Run("exports a file the dry run reports no changes for", func(t *testing.T) {
+		for _, secrets := range []SecretsExport{SecretsRedact, SecretsEncrypt} {
+			sut, dryRun := setup(t)
+
+			file, err := sut.Export(context.Background(), 1, secrets)
+			require.NoError(t, err)
+			b, err := MarshalProvisioningFileYAML(file)
+			require.NoError(t, err)
+			parsed, err := ParseProvisioningFile(b)
+			require.NoError(t, err)
+			// The receivers of the test configuration have no UID and cannot be matched.
+			var contactPoints []definitions.ProvisionedContactPoint
+			for _, cp := range parsed.ContactPoints {
+				if cp.UID != "" {
+					contactPoints = append(contactPoints, cp)
+				}
+			}
+			parsed.ContactPoints = contactPoints
+
+			diff, err := dryRun.Diff(context.Background(), parsed)
+			require.NoError(t, err)
+			require.False(t, diff.HasChanges(), "secrets %s: %+v", secrets, diff)
+		}
+	})
+
+	t.Run("fails on unknown secrets option", func(t *testing.T) {
+		sut, _ := setup(t)
+
+		_, err := sut.Export(context.Background(), 1, "plain")
+		require.ErrorIs(t, err, ErrValidation)
+	})
+}
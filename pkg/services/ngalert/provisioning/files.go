@@ -0,0 +1,120 @@
+package provisioning
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+)
+
+const defaultProvisioningOrgID = 1
+
+// ReadProvisioningFiles reads the alerting provisioning files in a directory and merges them into one.
+// Files with the extensions .yaml, .yml and .json are read, other files are ignored. Grafana does not apply
+// these files, they are only compared with the stored resources by the DryRunService.
+func ReadProvisioningFiles(path string) (definitions.ProvisioningFile, error) {
+	result := definitions.ProvisioningFile{APIVersion: 1}
+
+	files, err := ioutil.ReadDir(path)
+	if err != nil {
+		return result, fmt.Errorf("failed to read alerting provisioning directory: %w", err)
+	}
+	for _, file := range files {
+		ext := strings.ToLower(filepath.Ext(file.Name()))
+		if file.IsDir() || (ext != ".yaml" && ext != ".yml" && ext != ".json") {
+			continue
+		}
+		filename := filepath.Join(path, file.Name())
+		// nolint:gosec
+		// We can ignore the gosec G304 warning on this one because `filename` comes from the provisioning path
+		content, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return result, err
+		}
+		f, err := ParseProvisioningFile(content)
+		if err != nil {
+			return result, fmt.Errorf("failed to parse %s: %w", filename, err)
+		}
+		result.Merge(f)
+	}
+	return result, nil
+}
+
+// ParseProvisioningFile parses a provisioning file in the YAML or JSON format. Resources without an
+// organization are assigned to the main organization.
+func ParseProvisioningFile(content []byte) (definitions.ProvisioningFile, error) {
+	result := definitions.ProvisioningFile{}
+
+	// The definitions are only tagged for JSON, so YAML is converted to JSON first.
+	var raw interface{}
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return result, err
+	}
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return result, err
+	}
+	if err := json.Unmarshal(b, &result); err != nil {
+		return result, err
+	}
+	if result.APIVersion != 1 {
+		return result, fmt.Errorf("unsupported apiVersion %d", result.APIVersion)
+	}
+
+	defaultOrg := func(orgID *int64) {
+		if *orgID == 0 {
+			*orgID = defaultProvisioningOrgID
+		}
+	}
+	for i := range result.Groups {
+		defaultOrg(&result.Groups[i].OrgID)
+	}
+	for i := range result.DeleteRules {
+		defaultOrg(&result.DeleteRules[i].OrgID)
+	}
+	for i := range result.ContactPoints {
+		defaultOrg(&result.ContactPoints[i].OrgID)
+	}
+	for i := range result.DeleteContactPoints {
+		defaultOrg(&result.DeleteContactPoints[i].OrgID)
+	}
+	for i := range result.Policies {
+		defaultOrg(&result.Policies[i].OrgID)
+	}
+	for i := range result.MuteTimes {
+		defaultOrg(&result.MuteTimes[i].OrgID)
+	}
+	for i := range result.Templates {
+		defaultOrg(&result.Templates[i].OrgID)
+	}
+	return result, nil
+}
+
+// MarshalProvisioningFileYAML renders a provisioning file in the YAML format. The result can be read back
+// with ParseProvisioningFile.
+func MarshalProvisioningFileYAML(file definitions.ProvisioningFile) ([]byte, error) {
+	// The definitions are only tagged for JSON, so the file is converted to JSON first. JSON is a subset
+	// of YAML, the styles of the decoded nodes are reset to get block style YAML.
+	b, err := json.Marshal(file)
+	if err != nil {
+		return nil, err
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(b, &node); err != nil {
+		return nil, err
+	}
+	resetNodeStyle(&node)
+	return yaml.Marshal(&node)
+}
+
+func resetNodeStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, n := range node.Content {
+		resetNodeStyle(n)
+	}
+}
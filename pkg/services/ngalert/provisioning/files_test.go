@@ -0,0 +1,88 @@
+package provisioning
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProvisioningFile(t *testing.T) {
+	t.Run("assigns resources without organization to the main organization", func(t *testing.T) {
+		file, err := ParseProvisioningFile([]byte(`
+apiVersion: 1
+groups:
+  - name: group
+    folderUid: folder
+  - name: other
+    folderUid: folder
+    orgId: 2
+deleteRules:
+  - uid: rule
+contactPoints:
+  - name: cp
+    type: email
+deleteContactPoints:
+  - uid: cp
+policies:
+  - policy:
+      receiver: cp
+`))
+		require.NoError(t, err)
+		require.Equal(t, int64(1), file.Groups[0].OrgID)
+		require.Equal(t, int64(2), file.Groups[1].OrgID)
+		require.Equal(t, int64(1), file.DeleteRules[0].OrgID)
+		require.Equal(t, int64(1), file.ContactPoints[0].OrgID)
+		require.Equal(t, int64(1), file.DeleteContactPoints[0].OrgID)
+		require.Equal(t, int64(1), file.Policies[0].OrgID)
+		require.Equal(t, "cp", file.Policies[0].Policy.Receiver)
+	})
+
+	t.Run("fails on unsupported version", func(t *testing.T) {
+		_, err := ParseProvisioningFile([]byte(`apiVersion: 2`))
+		require.Error(t, err)
+		_, err = ParseProvisioningFile([]byte(`groups: []`))
+		require.Error(t, err)
+	})
+
+	t.Run("reads JSON", func(t *testing.T) {
+		file, err := ParseProvisioningFile([]byte(`{"apiVersion": 1, "deleteRules": [{"orgId": 3, "uid": "rule"}]}`))
+		require.NoError(t, err)
+		require.Equal(t, int64(3), file.DeleteRules[0].OrgID)
+	})
+}
+
+func TestReadProvisioningFiles(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+	}
+	write("a.yaml", "apiVersion: 1\ndeleteRules:\n  - uid: a\n")
+	write("b.json", `{"apiVersion": 1, "deleteRules": [{"uid": "b"}]}`)
+	write("c.txt", "not a provisioning file")
+
+	file, err := ReadProvisioningFiles(dir)
+	require.NoError(t, err)
+	require.Len(t, file.DeleteRules, 2)
+	require.Equal(t, "a", file.DeleteRules[0].UID)
+	require.Equal(t, "b", file.DeleteRules[1].UID)
+
+	write("d.yml", "apiVersion: 1\ngroups: {")
+	_, err = ReadProvisioningFiles(dir)
+	require.Error(t, err)
+}
+
+func TestMarshalProvisioningFileYAML(t *testing.T) {
+	file, err := ParseProvisioningFile([]byte(dryRunTestFile))
+	require.NoError(t, err)
+
+	b, err := MarshalProvisioningFileYAML(file)
+	require.NoError(t, err)
+	// no flow style mappings, the braces of the templates are quoted
+	require.NotRegexp(t, `:\s+\{`, string(b))
+
+	parsed, err := ParseProvisioningFile(b)
+	require.NoError(t, err)
+	require.Equal(t, file, parsed)
+}
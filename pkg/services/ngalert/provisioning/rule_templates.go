@@ -0,0 +1,414 @@
+package provisioning
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	prommodel "github.com/prometheus/common/model"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/store"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+var (
+	ruleTemplateParameterName   = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	ruleTemplatePlaceholder     = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+	errRuleTemplateRuleNotFound = errors.New("alert rule of the template does not exist anymore")
+)
+
+// RuleTemplateService manages alert rule templates and the alert rules created from them. The alert rules
+// are provisioned through the API and are rendered again whenever their template changes.
+type RuleTemplateService struct {
+	templateStore store.RuleTemplateStore
+	ruleStore     store.RuleStore
+	alertRules    *AlertRuleService
+	xact          TransactionManager
+	log           log.Logger
+}
+
+func NewRuleTemplateService(templateStore store.RuleTemplateStore, ruleStore store.RuleStore, alertRules *AlertRuleService,
+	xact TransactionManager, log log.Logger) *RuleTemplateService {
+	return &RuleTemplateService{
+		templateStore: templateStore,
+		ruleStore:     ruleStore,
+		alertRules:    alertRules,
+		xact:          xact,
+		log:           log,
+	}
+}
+
+func (s *RuleTemplateService) GetRuleTemplates(ctx context.Context, orgID int64) ([]models.AlertRuleTemplate, error) {
+	templates, err := s.templateStore.ListRuleTemplates(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]models.AlertRuleTemplate, 0, len(templates))
+	for _, t := range templates {
+		result = append(result, *t)
+	}
+	return result, nil
+}
+
+func (s *RuleTemplateService) GetRuleTemplate(ctx context.Context, orgID int64, uid string) (models.AlertRuleTemplate, error) {
+	t, err := s.templateStore.GetRuleTemplate(ctx, orgID, uid)
+	if err != nil {
+		return models.AlertRuleTemplate{}, err
+	}
+	return *t, nil
+}
+
+func (s *RuleTemplateService) CreateRuleTemplate(ctx context.Context, orgID int64, t models.AlertRuleTemplate) (models.AlertRuleTemplate, error) {
+	if err := validateRuleTemplate(t); err != nil {
+		return models.AlertRuleTemplate{}, err
+	}
+	if t.UID == "" {
+		t.UID = util.GenerateShortUID()
+	}
+	t.ID = 0
+	t.OrgID = orgID
+	t.Version = 1
+	t.Updated = time.Now()
+	if err := s.templateStore.InsertRuleTemplate(ctx, &t); err != nil {
+		return models.AlertRuleTemplate{}, err
+	}
+	return t, nil
+}
+
+// UpdateRuleTemplate replaces the template and renders the alert rules created from it again. If the template
+// has a version, the update fails with models.ErrRuleTemplateVersionConflict unless it is the stored version.
+// Nothing is changed if one of the alert rules cannot be rendered with the new template.
+func (s *RuleTemplateService) UpdateRuleTemplate(ctx context.Context, orgID int64, t models.AlertRuleTemplate) (models.AlertRuleTemplate, error) {
+	if err := validateRuleTemplate(t); err != nil {
+		return models.AlertRuleTemplate{}, err
+	}
+	err := s.xact.InTransaction(ctx, func(ctx context.Context) error {
+		stored, err := s.templateStore.GetRuleTemplate(ctx, orgID, t.UID)
+		if err != nil {
+			return err
+		}
+		if t.Version != 0 && t.Version != stored.Version {
+			return models.ErrRuleTemplateVersionConflict
+		}
+		t.ID = stored.ID
+		t.OrgID = orgID
+		t.Version = stored.Version + 1
+		t.Updated = time.Now()
+		if err := s.templateStore.UpdateRuleTemplate(ctx, &t); err != nil {
+			return err
+		}
+
+		instances, err := s.templateStore.ListRuleTemplateInstances(ctx, orgID, t.UID)
+		if err != nil {
+			return err
+		}
+		for _, instance := range instances {
+			_, err := s.renderRule(ctx, t, instance)
+			if errors.Is(err, errRuleTemplateRuleNotFound) {
+				s.log.Debug("unlinking deleted alert rule from its template", "template", t.UID, "rule", instance.RuleUID)
+				if err := s.templateStore.DeleteRuleTemplateInstance(ctx, orgID, instance.RuleUID); err != nil {
+					return err
+				}
+				continue
+			}
+			if err != nil {
+				return err
+			}
+		}
+		s.log.Info("updated alert rule template", "template", t.UID, "version", t.Version, "rules", len(instances))
+		return nil
+	})
+	if err != nil {
+		return models.AlertRuleTemplate{}, err
+	}
+	return t, nil
+}
+
+// DeleteRuleTemplate deletes the template. The alert rules created from it are kept as they are.
+func (s *RuleTemplateService) DeleteRuleTemplate(ctx context.Context, orgID int64, uid string) error {
+	return s.templateStore.DeleteRuleTemplate(ctx, orgID, uid)
+}
+
+// GetRuleTemplateInstances returns the links of the existing alert rules created from the template.
+func (s *RuleTemplateService) GetRuleTemplateInstances(ctx context.Context, orgID int64, uid string) ([]models.AlertRuleTemplateInstance, error) {
+	if _, err := s.templateStore.GetRuleTemplate(ctx, orgID, uid); err != nil {
+		return nil, err
+	}
+	instances, err := s.templateStore.ListRuleTemplateInstances(ctx, orgID, uid)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]models.AlertRuleTemplateInstance, 0, len(instances))
+	if len(instances) == 0 {
+		return result, nil
+	}
+
+	// alert rules can be deleted through other APIs, so the links are only cleaned up when the template changes.
+	q := models.ListAlertRulesQuery{OrgID: orgID}
+	if err := s.ruleStore.ListAlertRules(ctx, &q); err != nil {
+		return nil, err
+	}
+	exists := make(map[string]struct{}, len(q.Result))
+	for _, rule := range q.Result {
+		exists[rule.UID] = struct{}{}
+	}
+	for _, instance := range instances {
+		if _, ok := exists[instance.RuleUID]; ok {
+			result = append(result, *instance)
+		}
+	}
+	return result, nil
+}
+
+// CreateRuleFromTemplate renders the template with the parameters of the instance and creates the alert rule
+// in the given folder and rule group.
+func (s *RuleTemplateService) CreateRuleFromTemplate(ctx context.Context, instance models.AlertRuleTemplateInstance, folderUID, ruleGroup string) (models.AlertRule, error) {
+	if folderUID == "" || ruleGroup == "" {
+		return models.AlertRule{}, fmt.Errorf("%w: the folder and the rule group of the alert rule are required", ErrValidation)
+	}
+	t, err := s.templateStore.GetRuleTemplate(ctx, instance.OrgID, instance.TemplateUID)
+	if err != nil {
+		return models.AlertRule{}, err
+	}
+	rendered, err := renderRuleTemplate(*t, instance.Parameters)
+	if err != nil {
+		return models.AlertRule{}, err
+	}
+	rendered.UID = instance.RuleUID
+	group := definitions.ProvisionedRuleGroup{OrgID: instance.OrgID, Name: ruleGroup, FolderUID: folderUID, Rules: []definitions.ProvisionedAlertRule{rendered}}
+	rule, err := provisionedRuleToModel(group, 0, 0)
+	if err != nil {
+		return models.AlertRule{}, err
+	}
+
+	err = s.xact.InTransaction(ctx, func(ctx context.Context) error {
+		rule, err = s.alertRules.CreateAlertRule(ctx, rule, models.ProvenanceAPI)
+		if err != nil {
+			return err
+		}
+		return s.templateStore.SaveRuleTemplateInstance(ctx, &models.AlertRuleTemplateInstance{
+			OrgID:           instance.OrgID,
+			TemplateUID:     t.UID,
+			RuleUID:         rule.UID,
+			Parameters:      instance.Parameters,
+			TemplateVersion: t.Version,
+		})
+	})
+	if err != nil {
+		return models.AlertRule{}, err
+	}
+	return rule, nil
+}
+
+// UpdateRuleTemplateInstance changes the parameters of an alert rule created from the template and renders it again.
+func (s *RuleTemplateService) UpdateRuleTemplateInstance(ctx context.Context, instance models.AlertRuleTemplateInstance) (models.AlertRule, error) {
+	var rule models.AlertRule
+	err := s.xact.InTransaction(ctx, func(ctx context.Context) error {
+		stored, err := s.templateStore.GetRuleTemplateInstance(ctx, instance.OrgID, instance.RuleUID)
+		if err != nil {
+			return err
+		}
+		if stored.TemplateUID != instance.TemplateUID {
+			return models.ErrRuleTemplateInstanceNotFound
+		}
+		t, err := s.templateStore.GetRuleTemplate(ctx, instance.OrgID, instance.TemplateUID)
+		if err != nil {
+			return err
+		}
+		stored.Parameters = instance.Parameters
+		rule, err = s.renderRule(ctx, *t, stored)
+		if errors.Is(err, errRuleTemplateRuleNotFound) {
+			return models.ErrAlertRuleNotFound
+		}
+		return err
+	})
+	if err != nil {
+		return models.AlertRule{}, err
+	}
+	return rule, nil
+}
+
+// renderRule replaces the alert rule of the instance with the rendered template. The rule keeps its folder,
+// rule group and position in the group.
+func (s *RuleTemplateService) renderRule(ctx context.Context, t models.AlertRuleTemplate, instance *models.AlertRuleTemplateInstance) (models.AlertRule, error) {
+	existing, _, err := s.alertRules.GetAlertRule(ctx, instance.OrgID, instance.RuleUID)
+	if errors.Is(err, models.ErrAlertRuleNotFound) {
+		return models.AlertRule{}, errRuleTemplateRuleNotFound
+	}
+	if err != nil {
+		return models.AlertRule{}, err
+	}
+	rendered, err := renderRuleTemplate(t, instance.Parameters)
+	if err != nil {
+		return models.AlertRule{}, fmt.Errorf("alert rule %s: %w", instance.RuleUID, err)
+	}
+	rendered.UID = existing.UID
+	group := definitions.ProvisionedRuleGroup{OrgID: existing.OrgID, Name: existing.RuleGroup, FolderUID: existing.NamespaceUID, Rules: []definitions.ProvisionedAlertRule{rendered}}
+	rule, err := provisionedRuleToModel(group, 0, existing.IntervalSeconds)
+	if err != nil {
+		return models.AlertRule{}, fmt.Errorf("alert rule %s: %w", instance.RuleUID, err)
+	}
+	rule.RuleGroupIndex = existing.RuleGroupIndex
+	rule.DashboardUID = existing.DashboardUID
+	rule.PanelID = existing.PanelID
+
+	rule, err = s.alertRules.UpdateAlertRule(ctx, rule, models.ProvenanceAPI)
+	if err != nil {
+		return models.AlertRule{}, err
+	}
+	instance.TemplateVersion = t.Version
+	if err := s.templateStore.SaveRuleTemplateInstance(ctx, instance); err != nil {
+		return models.AlertRule{}, err
+	}
+	return rule, nil
+}
+
+func validateRuleTemplate(t models.AlertRuleTemplate) error {
+	if t.Title == "" {
+		return fmt.Errorf("%w: the title of the alert rule template is required", ErrValidation)
+	}
+	declared := make(map[string]struct{}, len(t.Parameters))
+	for _, p := range t.Parameters {
+		if !ruleTemplateParameterName.MatchString(p.Name) {
+			return fmt.Errorf("%w: invalid parameter name '%s'", ErrValidation, p.Name)
+		}
+		if _, ok := declared[p.Name]; ok {
+			return fmt.Errorf("%w: parameter %s is declared more than once", ErrValidation, p.Name)
+		}
+		declared[p.Name] = struct{}{}
+		switch p.Type {
+		case models.RuleTemplateParameterString, models.RuleTemplateParameterNumber,
+			models.RuleTemplateParameterDuration, models.RuleTemplateParameterDatasource:
+		default:
+			return fmt.Errorf("%w: parameter %s has the unknown type '%s'", ErrValidation, p.Name, p.Type)
+		}
+		if p.Default != nil {
+			if _, err := parseRuleTemplateValue(p, *p.Default); err != nil {
+				return fmt.Errorf("%w: invalid default of parameter %s: %s", ErrValidation, p.Name, err.Error())
+			}
+		}
+	}
+
+	var rule map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(t.Rule), &rule); err != nil || rule == nil {
+		return fmt.Errorf("%w: the rule of the alert rule template must be a JSON object", ErrValidation)
+	}
+	for _, m := range ruleTemplatePlaceholder.FindAllStringSubmatch(t.Rule, -1) {
+		if _, ok := declared[m[1]]; !ok {
+			return fmt.Errorf("%w: the rule refers to the undeclared parameter %s", ErrValidation, m[1])
+		}
+	}
+	return nil
+}
+
+// parseRuleTemplateValue returns the value of the parameter as the JSON value it is rendered as.
+func parseRuleTemplateValue(p models.RuleTemplateParameter, value string) (interface{}, error) {
+	switch p.Type {
+	case models.RuleTemplateParameterString:
+		return value, nil
+	case models.RuleTemplateParameterNumber:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a number", value)
+		}
+		return f, nil
+	case models.RuleTemplateParameterDuration:
+		if _, err := prommodel.ParseDuration(value); err != nil {
+			return nil, fmt.Errorf("%s is not a duration", value)
+		}
+		return value, nil
+	case models.RuleTemplateParameterDatasource:
+		if value == "" {
+			return nil, errors.New("the data source UID is empty")
+		}
+		return value, nil
+	default:
+		return nil, fmt.Errorf("unknown type '%s'", p.Type)
+	}
+}
+
+type ruleTemplateValue struct {
+	text  string
+	typed interface{}
+}
+
+// renderRuleTemplate replaces the placeholders in the rule of the template with the given values of its parameters,
+// or with their defaults, and returns the rendered rule.
+func renderRuleTemplate(t models.AlertRuleTemplate, values map[string]string) (definitions.ProvisionedAlertRule, error) {
+	declared := make(map[string]ruleTemplateValue, len(t.Parameters))
+	for _, p := range t.Parameters {
+		text, ok := values[p.Name]
+		if !ok {
+			if p.Default == nil {
+				return definitions.ProvisionedAlertRule{}, fmt.Errorf("%w: missing value of parameter %s", ErrValidation, p.Name)
+			}
+			text = *p.Default
+		}
+		typed, err := parseRuleTemplateValue(p, text)
+		if err != nil {
+			return definitions.ProvisionedAlertRule{}, fmt.Errorf("%w: invalid value of parameter %s: %s", ErrValidation, p.Name, err.Error())
+		}
+		declared[p.Name] = ruleTemplateValue{text: text, typed: typed}
+	}
+	for name := range values {
+		if _, ok := declared[name]; !ok {
+			return definitions.ProvisionedAlertRule{}, fmt.Errorf("%w: the template has no parameter %s", ErrValidation, name)
+		}
+	}
+
+	var body interface{}
+	dec := json.NewDecoder(strings.NewReader(t.Rule))
+	dec.UseNumber()
+	if err := dec.Decode(&body); err != nil {
+		return definitions.ProvisionedAlertRule{}, fmt.Errorf("failed to decode the rule of alert rule template %s: %w", t.UID, err)
+	}
+	b, err := json.Marshal(renderRuleTemplateValue(body, declared))
+	if err != nil {
+		return definitions.ProvisionedAlertRule{}, err
+	}
+	var rule definitions.ProvisionedAlertRule
+	if err := json.Unmarshal(b, &rule); err != nil {
+		return definitions.ProvisionedAlertRule{}, fmt.Errorf("%w: the rendered rule is invalid: %s", ErrValidation, err.Error())
+	}
+	return rule, nil
+}
+
+// renderRuleTemplateValue replaces the placeholders in the strings and keys of the decoded JSON value.
+// The placeholders are checked when the template is saved, so all of them refer to parameters of the template.
+func renderRuleTemplateValue(v interface{}, values map[string]ruleTemplateValue) interface{} {
+	replace := func(s string) string {
+		return ruleTemplatePlaceholder.ReplaceAllStringFunc(s, func(placeholder string) string {
+			return values[placeholder[2:len(placeholder)-1]].text
+		})
+	}
+	switch v := v.(type) {
+	case string:
+		// a string that is a single placeholder is replaced by the typed value, so that numbers stay numbers
+		if m := ruleTemplatePlaceholder.FindStringSubmatch(v); m != nil && m[0] == v {
+			return values[m[1]].typed
+		}
+		return replace(v)
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			result[replace(key)] = renderRuleTemplateValue(value, values)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, 0, len(v))
+		for _, value := range v {
+			result = append(result, renderRuleTemplateValue(value, values))
+		}
+		return result
+	default:
+		return v
+	}
+}
@@ -0,0 +1,198 @@
+package provisioning
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/store"
+	"github.com/grafana/grafana/pkg/services/sqlstore"
+)
+
+const cpuRuleTemplate = `{
+	"title": "High CPU of ${service}",
+	"condition": "B",
+	"for": "${for}",
+	"data": [{
+		"refId": "A",
+		"datasourceUid": "${datasource}",
+		"relativeTimeRange": {"from": 600, "to": 0},
+		"model": {"expr": "cpu{service=\"${service}\"}"}
+	}, {
+		"refId": "B",
+		"datasourceUid": "-100",
+		"relativeTimeRange": {"from": 0, "to": 0},
+		"model": {"type": "threshold", "expression": "A", "conditions": [{"evaluator": {"params": ["${threshold}"], "type": "gt"}}]}
+	}],
+	"labels": {"service": "${service}"}
+}`
+
+func TestRuleTemplateService(t *testing.T) {
+	ctx := context.Background()
+	sut := createRuleTemplateService(t)
+	var orgID int64 = 1
+
+	defaultFor := "5m"
+	defaultThreshold := "80"
+	template, err := sut.CreateRuleTemplate(ctx, orgID, models.AlertRuleTemplate{
+		Title: "High CPU",
+		Parameters: []models.RuleTemplateParameter{
+			{Name: "service", Type: models.RuleTemplateParameterString},
+			{Name: "datasource", Type: models.RuleTemplateParameterDatasource},
+			{Name: "threshold", Type: models.RuleTemplateParameterNumber, Default: &defaultThreshold},
+			{Name: "for", Type: models.RuleTemplateParameterDuration, Default: &defaultFor},
+		},
+		Rule: cpuRuleTemplate,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, template.UID)
+	require.Equal(t, int64(1), template.Version)
+
+	var ruleUID string
+	t.Run("creates a rule from the template", func(t *testing.T) {
+		rule, err := sut.CreateRuleFromTemplate(ctx, models.AlertRuleTemplateInstance{
+			OrgID:       orgID,
+			TemplateUID: template.UID,
+			Parameters:  map[string]string{"service": "checkout", "datasource": "prometheus"},
+		}, "folder", "cpu")
+		require.NoError(t, err)
+		ruleUID = rule.UID
+
+		rule, provenance, err := sut.alertRules.GetAlertRule(ctx, orgID, ruleUID)
+		require.NoError(t, err)
+		require.Equal(t, models.ProvenanceAPI, provenance)
+		require.Equal(t, "High CPU of checkout", rule.Title)
+		require.Equal(t, "folder", rule.NamespaceUID)
+		require.Equal(t, "cpu", rule.RuleGroup)
+		require.Equal(t, 5*time.Minute, rule.For)
+		require.Equal(t, "prometheus", rule.Data[0].DatasourceUID)
+		require.Contains(t, string(rule.Data[0].Model), `"expr":"cpu{service=\"checkout\"}"`)
+		require.Contains(t, string(rule.Data[1].Model), `"params":[80]`)
+		require.Equal(t, map[string]string{"service": "checkout"}, rule.Labels)
+
+		instances, err := sut.GetRuleTemplateInstances(ctx, orgID, template.UID)
+		require.NoError(t, err)
+		require.Len(t, instances, 1)
+		require.Equal(t, ruleUID, instances[0].RuleUID)
+		require.Equal(t, int64(1), instances[0].TemplateVersion)
+	})
+
+	t.Run("renders the rules again when the template changes", func(t *testing.T) {
+		defaultThreshold := "90"
+		update := template
+		update.Parameters[2].Default = &defaultThreshold
+		update.Rule = `{"title": "CPU of ${service} above ${threshold}%", "condition": "A",
+			"data": [{"refId": "A", "datasourceUid": "${datasource}", "relativeTimeRange": {"from": 600, "to": 0}, "model": {"expr": "cpu{service=\"${service}\"} > ${threshold}"}}]}`
+		updated, err := sut.UpdateRuleTemplate(ctx, orgID, update)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), updated.Version)
+
+		rule, _, err := sut.alertRules.GetAlertRule(ctx, orgID, ruleUID)
+		require.NoError(t, err)
+		require.Equal(t, "CPU of checkout above 90%", rule.Title)
+		require.Equal(t, "folder", rule.NamespaceUID)
+		require.Len(t, rule.Data, 1)
+
+		instances, err := sut.GetRuleTemplateInstances(ctx, orgID, template.UID)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), instances[0].TemplateVersion)
+
+		_, err = sut.UpdateRuleTemplate(ctx, orgID, update)
+		require.ErrorIs(t, err, models.ErrRuleTemplateVersionConflict)
+		template = updated
+	})
+
+	t.Run("changes the parameters of a rule", func(t *testing.T) {
+		rule, err := sut.UpdateRuleTemplateInstance(ctx, models.AlertRuleTemplateInstance{
+			OrgID:       orgID,
+			TemplateUID: template.UID,
+			RuleUID:     ruleUID,
+			Parameters:  map[string]string{"service": "checkout", "datasource": "prometheus", "threshold": "95"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "CPU of checkout above 95%", rule.Title)
+
+		_, err = sut.UpdateRuleTemplateInstance(ctx, models.AlertRuleTemplateInstance{OrgID: orgID, TemplateUID: "other", RuleUID: ruleUID})
+		require.ErrorIs(t, err, models.ErrRuleTemplateInstanceNotFound)
+	})
+
+	t.Run("does not update the template if a rule cannot be rendered", func(t *testing.T) {
+		update := template
+		update.Parameters = append(update.Parameters, models.RuleTemplateParameter{Name: "team", Type: models.RuleTemplateParameterString})
+		_, err := sut.UpdateRuleTemplate(ctx, orgID, update)
+		require.ErrorIs(t, err, ErrValidation)
+
+		stored, err := sut.GetRuleTemplate(ctx, orgID, template.UID)
+		require.NoError(t, err)
+		require.Equal(t, template.Version, stored.Version)
+	})
+
+	t.Run("validates the parameters", func(t *testing.T) {
+		create := func(params map[string]string) error {
+			_, err := sut.CreateRuleFromTemplate(ctx, models.AlertRuleTemplateInstance{OrgID: orgID, TemplateUID: template.UID, Parameters: params}, "folder", "cpu")
+			return err
+		}
+		require.ErrorIs(t, create(map[string]string{"service": "checkout"}), ErrValidation)
+		require.ErrorIs(t, create(map[string]string{"service": "checkout", "datasource": "prometheus", "threshold": "high"}), ErrValidation)
+		require.ErrorIs(t, create(map[string]string{"service": "checkout", "datasource": "prometheus", "team": "a"}), ErrValidation)
+	})
+
+	t.Run("validates the template", func(t *testing.T) {
+		tests := map[string]models.AlertRuleTemplate{
+			"no title":             {Rule: `{}`},
+			"invalid name":         {Title: "t", Rule: `{}`, Parameters: []models.RuleTemplateParameter{{Name: "a-b", Type: models.RuleTemplateParameterString}}},
+			"unknown type":         {Title: "t", Rule: `{}`, Parameters: []models.RuleTemplateParameter{{Name: "a", Type: "bool"}}},
+			"duplicate parameter":  {Title: "t", Rule: `{}`, Parameters: []models.RuleTemplateParameter{{Name: "a", Type: "string"}, {Name: "a", Type: "string"}}},
+			"invalid default":      {Title: "t", Rule: `{}`, Parameters: []models.RuleTemplateParameter{{Name: "a", Type: "duration", Default: &defaultThreshold}}},
+			"rule is not object":   {Title: "t", Rule: `[]`},
+			"undeclared parameter": {Title: "t", Rule: `{"title": "${a}"}`},
+		}
+		for name, tmpl := range tests {
+			t.Run(name, func(t *testing.T) {
+				_, err := sut.CreateRuleTemplate(ctx, orgID, tmpl)
+				require.ErrorIs(t, err, ErrValidation)
+			})
+		}
+	})
+
+	t.Run("unlinks deleted rules and keeps the rules of deleted templates", func(t *testing.T) {
+		other, err := sut.CreateRuleFromTemplate(ctx, models.AlertRuleTemplateInstance{
+			OrgID:       orgID,
+			TemplateUID: template.UID,
+			Parameters:  map[string]string{"service": "cart", "datasource": "prometheus"},
+		}, "folder", "cpu")
+		require.NoError(t, err)
+		require.NoError(t, sut.alertRules.DeleteAlertRule(ctx, orgID, other.UID, models.ProvenanceAPI))
+
+		instances, err := sut.GetRuleTemplateInstances(ctx, orgID, template.UID)
+		require.NoError(t, err)
+		require.Len(t, instances, 1)
+
+		_, err = sut.UpdateRuleTemplate(ctx, orgID, template)
+		require.NoError(t, err)
+		_, err = sut.templateStore.GetRuleTemplateInstance(ctx, orgID, other.UID)
+		require.ErrorIs(t, err, models.ErrRuleTemplateInstanceNotFound)
+
+		require.NoError(t, sut.DeleteRuleTemplate(ctx, orgID, template.UID))
+		_, _, err = sut.alertRules.GetAlertRule(ctx, orgID, ruleUID)
+		require.NoError(t, err)
+		_, err = sut.GetRuleTemplateInstances(ctx, orgID, template.UID)
+		require.ErrorIs(t, err, models.ErrRuleTemplateNotFound)
+	})
+}
+
+func createRuleTemplateService(t *testing.T) *RuleTemplateService {
+	t.Helper()
+	sqlStore := sqlstore.InitTestDB(t)
+	store := store.DBstore{
+		SQLStore:     sqlStore,
+		BaseInterval: time.Second * 10,
+		Logger:       log.New("testing"),
+	}
+	alertRules := NewAlertRuleService(store, store, sqlStore, 60, log.New("testing"))
+	return NewRuleTemplateService(store, store, alertRules, sqlStore, log.New("testing"))
+}
@@ -2,26 +2,43 @@ package provisioning
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
 
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/notifier/channels"
 )
 
+// ErrTemplateNotFound is returned when a notification template does not exist.
+var ErrTemplateNotFound = errors.New("template not found")
+
 type TemplateService struct {
-	config AMConfigStore
-	prov   ProvisioningStore
-	xact   TransactionManager
-	log    log.Logger
+	config      AMConfigStore
+	prov        ProvisioningStore
+	xact        TransactionManager
+	externalURL *url.URL
+	log         log.Logger
 }
 
-func NewTemplateService(config AMConfigStore, prov ProvisioningStore, xact TransactionManager, log log.Logger) *TemplateService {
+func NewTemplateService(config AMConfigStore, prov ProvisioningStore, xact TransactionManager, externalURL *url.URL, log log.Logger) *TemplateService {
 	return &TemplateService{
-		config: config,
-		prov:   prov,
-		xact:   xact,
-		log:    log,
+		config:      config,
+		prov:        prov,
+		xact:        xact,
+		externalURL: externalURL,
+		log:         log,
 	}
 }
 
@@ -123,3 +140,138 @@ func (t *TemplateService) DeleteTemplate(ctx context.Context, orgID int64, name
 
 	return nil
 }
+
+// PreviewTemplate renders the notifications of the integrations of the request with the templates of the organization,
+// where the template with the given name is replaced by the template of the request, if there is one. Nothing is sent.
+// Template execution errors are returned as part of the previews, so that all of them can be shown at once.
+func (t *TemplateService) PreviewTemplate(ctx context.Context, orgID int64, name string, req definitions.TemplatePreviewRequest) (definitions.TemplatePreviews, error) {
+	templates, err := t.GetTemplates(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Template != "" {
+		tmpl := definitions.MessageTemplate{Name: name, Template: req.Template}
+		if err := tmpl.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrValidation, err.Error())
+		}
+		templates[name] = tmpl.Template
+	} else if _, ok := templates[name]; !ok {
+		return nil, ErrTemplateNotFound
+	}
+
+	integrations := channels.PreviewIntegrations()
+	if req.Integration != "" {
+		integrations = []string{req.Integration}
+	}
+
+	tmpl, err := t.buildTemplate(templates)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrValidation, err.Error())
+	}
+
+	alerts := previewAlerts(req.Alerts, time.Now())
+	ctx = notify.WithReceiverName(ctx, "preview")
+	ctx = notify.WithGroupLabels(ctx, previewGroupLabels(alerts))
+
+	previews := make(definitions.TemplatePreviews, 0, len(integrations))
+	for _, integration := range integrations {
+		preview, err := channels.PreviewNotification(ctx, tmpl, integration, req.Settings, alerts, t.log)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrValidation, err.Error())
+		}
+		previews = append(previews, definitions.TemplatePreview{
+			Integration: integration,
+			Title:       preview.Title,
+			Body:        preview.Body,
+			Errors:      preview.Errors,
+		})
+	}
+	return previews, nil
+}
+
+// buildTemplate parses the templates the same way as the Alertmanager of the organization, which reads them from files.
+func (t *TemplateService) buildTemplate(templates map[string]string) (*template.Template, error) {
+	dir, err := ioutil.TempDir("", "template-preview")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			t.log.Warn("failed to remove the files of a template preview", "dir", dir, "err", err)
+		}
+	}()
+
+	paths := make([]string, 0, len(templates)+1)
+	files := map[string]string{"__default__.tmpl": channels.DefaultTemplateString}
+	for name, content := range templates {
+		files[name] = content
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.Base(name))
+		if err := ioutil.WriteFile(p, []byte(content), 0600); err != nil {
+			return nil, err
+		}
+		paths = append(paths, p)
+	}
+
+	tmpl, err := template.FromGlobs(paths...)
+	if err != nil {
+		return nil, err
+	}
+	tmpl.ExternalURL = t.externalURL
+	if tmpl.ExternalURL == nil {
+		tmpl.ExternalURL = &url.URL{}
+	}
+	return tmpl, nil
+}
+
+// previewAlerts returns the alerts of a preview request, or a sample firing alert if there are none.
+func previewAlerts(alerts []definitions.TemplatePreviewAlert, now time.Time) []*types.Alert {
+	if len(alerts) == 0 {
+		alerts = []definitions.TemplatePreviewAlert{{
+			Labels: model.LabelSet{"alertname": "TestAlert", "instance": "Grafana"},
+			Annotations: model.LabelSet{
+				"summary":          "Notification test",
+				"__value_string__": "[ metric='foo' labels={instance=bar} value=10 ]",
+			},
+		}}
+	}
+	result := make([]*types.Alert, 0, len(alerts))
+	for _, a := range alerts {
+		alert := &types.Alert{
+			Alert: model.Alert{
+				Labels:       a.Labels,
+				Annotations:  a.Annotations,
+				StartsAt:     a.StartsAt,
+				EndsAt:       a.EndsAt,
+				GeneratorURL: a.GeneratorURL,
+			},
+			UpdatedAt: now,
+		}
+		if alert.Labels == nil {
+			alert.Labels = model.LabelSet{}
+		}
+		if alert.Annotations == nil {
+			alert.Annotations = model.LabelSet{}
+		}
+		if alert.StartsAt.IsZero() {
+			alert.StartsAt = now
+		}
+		result = append(result, alert)
+	}
+	return result
+}
+
+// previewGroupLabels returns the labels the alerts would be grouped by with the default notification policy.
+func previewGroupLabels(alerts []*types.Alert) model.LabelSet {
+	name := alerts[0].Labels[model.AlertNameLabel]
+	for _, a := range alerts[1:] {
+		if a.Labels[model.AlertNameLabel] != name {
+			return model.LabelSet{}
+		}
+	}
+	if name == "" {
+		return model.LabelSet{}
+	}
+	return model.LabelSet{model.AlertNameLabel: name}
+}
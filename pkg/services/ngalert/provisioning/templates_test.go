@@ -4,10 +4,15 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/notifier/channels"
 	"github.com/grafana/grafana/pkg/setting"
 	mock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -379,6 +384,77 @@ func TestTemplateService(t *testing.T) {
 	})
 }
 
+func TestTemplateServicePreview(t *testing.T) {
+	preview := func(name string, req definitions.TemplatePreviewRequest) (definitions.TemplatePreviews, error) {
+		sut := createTemplateServiceSut()
+		sut.config.(*MockAMConfigStore).EXPECT().
+			getsConfig(models.AlertConfiguration{
+				AlertmanagerConfiguration: configWithTemplates,
+			})
+		return sut.PreviewTemplate(context.Background(), 1, name, req)
+	}
+
+	t.Run("renders an unsaved template for an integration", func(t *testing.T) {
+		result, err := preview("custom", definitions.TemplatePreviewRequest{
+			Template:    `{{ define "custom.title" }}{{ .CommonLabels.alertname }} on {{ .CommonLabels.instance }}{{ end }}`,
+			Integration: "slack",
+			Settings:    simplejson.NewFromAny(map[string]interface{}{"title": `{{ template "custom.title" . }}`, "text": `{{ template "a" . }}`}),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		require.Equal(t, "slack", result[0].Integration)
+		require.Equal(t, "TestAlert on Grafana", result[0].Title)
+		require.Equal(t, "template", result[0].Body)
+		require.Empty(t, result[0].Errors)
+	})
+
+	t.Run("renders caller supplied alerts for all integrations", func(t *testing.T) {
+		result, err := preview("a", definitions.TemplatePreviewRequest{
+			Alerts: []definitions.TemplatePreviewAlert{
+				{Labels: model.LabelSet{"alertname": "HighCPU"}, EndsAt: time.Now().Add(-time.Minute)},
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, result, len(channels.PreviewIntegrations()))
+		for _, p := range result {
+			require.Empty(t, p.Errors, p.Integration)
+			if p.Integration == "mattermost" {
+				require.Equal(t, "[RESOLVED] HighCPU ", p.Title)
+			}
+		}
+	})
+
+	t.Run("returns the errors of the templates", func(t *testing.T) {
+		result, err := preview("a", definitions.TemplatePreviewRequest{
+			Integration: "email",
+			Settings:    simplejson.NewFromAny(map[string]interface{}{"subject": `{{ template "missing" . }}`, "message": `{{ .Missing }}`}),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, result[0].Errors, 2)
+	})
+
+	t.Run("fails if the template does not exist", func(t *testing.T) {
+		_, err := preview("missing", definitions.TemplatePreviewRequest{})
+
+		require.ErrorIs(t, err, ErrTemplateNotFound)
+	})
+
+	t.Run("fails if the template is invalid", func(t *testing.T) {
+		_, err := preview("custom", definitions.TemplatePreviewRequest{Template: "{{ .Labels"})
+
+		require.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("fails for unknown integrations", func(t *testing.T) {
+		_, err := preview("a", definitions.TemplatePreviewRequest{Integration: "carrier-pigeon"})
+
+		require.ErrorIs(t, err, ErrValidation)
+	})
+}
+
 func createTemplateServiceSut() *TemplateService {
 	return &TemplateService{
 		config: &MockAMConfigStore{},
@@ -91,18 +91,24 @@ func newAlertRuleInfo(parent context.Context) *alertRuleInfo {
 //   - false when the send operation is stopped
 // the second element contains a dropped message that was sent by a concurrent sender.
 func (a *alertRuleInfo) eval(t time.Time, version int64) (bool, *evaluation) {
+	return a.send(&evaluation{
+		scheduledAt: t,
+		version:     version,
+	})
+}
+
+// send works like eval but sends the given evaluation. The evaluation is finished if it is dropped.
+func (a *alertRuleInfo) send(e *evaluation) (bool, *evaluation) {
 	// read the channel in unblocking manner to make sure that there is no concurrent send operation.
 	var droppedMsg *evaluation
 	select {
 	case droppedMsg = <-a.evalCh:
+		droppedMsg.finish()
 	default:
 	}
 
 	select {
-	case a.evalCh <- &evaluation{
-		scheduledAt: t,
-		version:     version,
-	}:
+	case a.evalCh <- e:
 		return true, droppedMsg
 	case <-a.ctx.Done():
 		return false, droppedMsg
@@ -122,6 +128,15 @@ func (a *alertRuleInfo) update() bool {
 type evaluation struct {
 	scheduledAt time.Time
 	version     int64
+	// done is closed when the evaluation is finished, if it is not nil.
+	done chan struct{}
+}
+
+// finish signals that the evaluation is finished, whether it succeeded or not.
+func (e *evaluation) finish() {
+	if e.done != nil {
+		close(e.done)
+	}
 }
 
 type schedulableAlertRulesRegistry struct {
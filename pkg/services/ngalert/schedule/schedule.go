@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"sort"
 	"sync"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/services/alerting"
 	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/enrichment"
 	"github.com/grafana/grafana/pkg/services/ngalert/eval"
 	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
@@ -19,8 +21,10 @@ import (
 	"github.com/grafana/grafana/pkg/services/ngalert/sender"
 	"github.com/grafana/grafana/pkg/services/ngalert/state"
 	"github.com/grafana/grafana/pkg/services/ngalert/store"
+	"github.com/grafana/grafana/pkg/services/ngalert/writer"
 
 	"github.com/benbjohnson/clock"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -84,6 +88,11 @@ type schedule struct {
 
 	stateManager *state.Manager
 
+	// recordingWriter writes the results of recording rules.
+	recordingWriter writer.Writer
+	// enricher adds labels and annotations from external lookups to the alerts before they are sent.
+	enricher enrichment.Enricher
+
 	appURL *url.URL
 
 	multiOrgNotifier *notifier.MultiOrgAlertmanager
@@ -103,6 +112,15 @@ type schedule struct {
 	// current tick depends on its evaluation interval and when it was
 	// last evaluated.
 	schedulableAlertRules schedulableAlertRulesRegistry
+
+	// sharder decides which alert rules this instance evaluates if the evaluation is spread across the
+	// members of the HA cluster. It is nil if every instance evaluates every rule.
+	sharder *ruleSharder
+	// handedOff contains the keys of the alert rules whose evaluation moved to another member of the HA cluster
+	// and whose routines are stopping.
+	handedOff sync.Map
+	// handedOffSync decides when the states of the rules evaluated by other members are loaded.
+	handedOffSync handedOffStatesSync
 }
 
 // SchedulerCfg is the scheduler configuration.
@@ -123,6 +141,10 @@ type SchedulerCfg struct {
 	AdminConfigPollInterval time.Duration
 	DisabledOrgs            map[int64]struct{}
 	MinRuleInterval         time.Duration
+	RecordingWriter         writer.Writer
+	Enricher                enrichment.Enricher
+	// ClusterMembership spreads the evaluation of the alert rules across the members of the HA cluster if set.
+	ClusterMembership ClusterMembership
 }
 
 // NewScheduler returns a new schedule.
@@ -154,8 +176,19 @@ func NewScheduler(cfg SchedulerCfg, expressionService *expr.Service, appURL *url
 		adminConfigPollInterval: cfg.AdminConfigPollInterval,
 		disabledOrgs:            cfg.DisabledOrgs,
 		minRuleInterval:         cfg.MinRuleInterval,
+		recordingWriter:         cfg.RecordingWriter,
+		enricher:                cfg.Enricher,
 		schedulableAlertRules:   schedulableAlertRulesRegistry{rules: make(map[models.AlertRuleKey]*models.SchedulableAlertRule)},
 	}
+	if sch.recordingWriter == nil {
+		sch.recordingWriter = writer.NoopWriter{}
+	}
+	if sch.enricher == nil {
+		sch.enricher = enrichment.NoopEnricher{}
+	}
+	if cfg.ClusterMembership != nil {
+		sch.sharder = newRuleSharder(cfg.ClusterMembership, cfg.Logger)
+	}
 	return &sch
 }
 
@@ -345,6 +378,39 @@ func (sch *schedule) DeleteAlertRule(key models.AlertRuleKey) {
 	sch.metrics.SchedulableAlertRulesHash.Set(float64(hashUIDs(alertRules)))
 }
 
+// handOffAlertRule stops the evaluation of an alert rule that another member of the HA cluster evaluates.
+// Unlike DeleteAlertRule, the alerts of the rule are not resolved and the rule stays schedulable. The states
+// of the rule stay in the cache as a read-only copy that is refreshed by syncHandedOffStates.
+func (sch *schedule) handOffAlertRule(key models.AlertRuleKey) {
+	ruleInfo, ok := sch.registry.del(key)
+	if !ok {
+		return
+	}
+	sch.log.Debug("alert rule handed off to another instance", "uid", key.UID, "org_id", key.OrgID)
+	sch.handedOff.Store(key, struct{}{})
+	ruleInfo.stop()
+}
+
+// warmAlertRule loads the stored states of an alert rule whose evaluation moved to this instance.
+func (sch *schedule) warmAlertRule(ctx context.Context, key models.AlertRuleKey) {
+	q := models.GetAlertRuleByUIDQuery{OrgID: key.OrgID, UID: key.UID}
+	if err := sch.ruleStore.GetAlertRuleByUID(ctx, &q); err != nil {
+		sch.log.Error("failed to fetch alert rule", "uid", key.UID, "org_id", key.OrgID, "err", err)
+		return
+	}
+	sch.stateManager.WarmRule(ctx, q.Result)
+}
+
+// syncHandedOffStates loads the states of the alert rules that other members of the HA cluster evaluate from
+// the database, so that the APIs of this instance return the same states as the instance evaluating the rules.
+func (sch *schedule) syncHandedOffStates(ctx context.Context, handedOff map[int64]map[string]struct{}) {
+	for orgID, ruleUIDs := range handedOff {
+		if err := sch.stateManager.LoadStoredStates(ctx, orgID, ruleUIDs); err != nil {
+			sch.log.Error("failed to load the states of the alert rules evaluated by other instances", "org_id", orgID, "err", err)
+		}
+	}
+}
+
 func (sch *schedule) adminConfigSync(ctx context.Context) error {
 	for {
 		select {
@@ -384,6 +450,10 @@ func (sch *schedule) schedulePeriodic(ctx context.Context) error {
 				disabledOrgs = append(disabledOrgs, disabledOrg)
 			}
 
+			if sch.sharder != nil {
+				sch.sharder.refresh()
+			}
+
 			if err := sch.updateSchedulableAlertRules(ctx, disabledOrgs); err != nil {
 				sch.log.Error("scheduler failed to update alert rules", "err", err)
 			}
@@ -402,17 +472,22 @@ func (sch *schedule) schedulePeriodic(ctx context.Context) error {
 			sch.metrics.SchedulableAlertRules.Set(float64(len(alertRules)))
 			sch.metrics.SchedulableAlertRulesHash.Set(float64(hashUIDs(alertRules)))
 
-			type readyToRunItem struct {
-				key      models.AlertRuleKey
-				ruleName string
-				ruleInfo *alertRuleInfo
-				version  int64
-			}
-
 			readyToRun := make([]readyToRunItem, 0)
+			handedOff := make(map[int64]map[string]struct{})
 			for _, item := range alertRules {
 				key := item.GetKey()
 				itemVersion := item.Version
+
+				if sch.sharder != nil && !sch.sharder.owns(item.GetGroupKey()) {
+					sch.handOffAlertRule(key)
+					delete(registeredDefinitions, key)
+					if handedOff[key.OrgID] == nil {
+						handedOff[key.OrgID] = make(map[string]struct{})
+					}
+					handedOff[key.OrgID][key.UID] = struct{}{}
+					continue
+				}
+
 				ruleInfo, newRoutine := sch.registry.getOrCreateInfo(ctx, key)
 
 				// enforce minimum evaluation interval
@@ -425,6 +500,10 @@ func (sch *schedule) schedulePeriodic(ctx context.Context) error {
 
 				if newRoutine && !invalidInterval {
 					dispatcherGroup.Go(func() error {
+						if sch.sharder != nil {
+							// The rule could have been evaluated by another instance until now.
+							sch.warmAlertRule(ruleInfo.ctx, key)
+						}
 						return sch.ruleRoutine(ruleInfo.ctx, key, ruleInfo.evalCh, ruleInfo.updateCh)
 					})
 				}
@@ -438,31 +517,40 @@ func (sch *schedule) schedulePeriodic(ctx context.Context) error {
 
 				itemFrequency := item.IntervalSeconds / int64(sch.baseInterval.Seconds())
 				if item.IntervalSeconds != 0 && tickNum%itemFrequency == 0 {
-					readyToRun = append(readyToRun, readyToRunItem{key: key, ruleName: item.Title, ruleInfo: ruleInfo, version: itemVersion})
+					readyToRun = append(readyToRun, readyToRunItem{
+						key:        key,
+						groupKey:   item.GetGroupKey(),
+						groupIndex: item.RuleGroupIndex,
+						recording:  item.Record != nil,
+						ruleName:   item.Title,
+						ruleInfo:   ruleInfo,
+						version:    itemVersion,
+					})
 				}
 
 				// remove the alert rule from the registered alert rules
 				delete(registeredDefinitions, key)
 			}
 
+			// Rules of a group with recording rules are evaluated one after another so that a rule
+			// can depend on the metrics of the recording rules before it. All other rules are
+			// evaluated independently. The groups are spread over the base interval.
+			groups := groupReadyToRun(readyToRun)
 			var step int64 = 0
-			if len(readyToRun) > 0 {
-				step = sch.baseInterval.Nanoseconds() / int64(len(readyToRun))
+			if len(groups) > 0 {
+				step = sch.baseInterval.Nanoseconds() / int64(len(groups))
 			}
 
-			for i := range readyToRun {
-				item := readyToRun[i]
+			for i := range groups {
+				group := groups[i]
 
 				time.AfterFunc(time.Duration(int64(i)*step), func() {
-					success, dropped := item.ruleInfo.eval(tick, item.version)
-					if !success {
-						sch.log.Debug("scheduled evaluation was canceled because evaluation routine was stopped", "uid", item.key.UID, "org", item.key.OrgID, "time", tick)
-						return
-					}
-					if dropped != nil {
-						sch.log.Warn("Alert rule evaluation is too slow - dropped tick", "uid", item.key.UID, "org", item.key.OrgID, "time", tick)
-						orgID := fmt.Sprint(item.key.OrgID)
-						sch.metrics.EvaluationMissed.WithLabelValues(orgID, item.ruleName).Inc()
+					for j, item := range group {
+						e := sch.sendEval(tick, item)
+						// there is no need to wait for the last rule of the group
+						if e != nil && j < len(group)-1 {
+							sch.waitEval(ctx, e, item)
+						}
 					}
 				})
 			}
@@ -472,6 +560,10 @@ func (sch *schedule) schedulePeriodic(ctx context.Context) error {
 				sch.DeleteAlertRule(key)
 			}
 
+			if sch.sharder != nil && sch.handedOffSync.due(handedOff, tick) {
+				sch.syncHandedOffStates(ctx, handedOff)
+			}
+
 			sch.metrics.SchedulePeriodicDuration.Observe(time.Since(start).Seconds())
 		case <-ctx.Done():
 			waitErr := dispatcherGroup.Wait()
@@ -482,7 +574,18 @@ func (sch *schedule) schedulePeriodic(ctx context.Context) error {
 			}
 
 			for _, v := range orgIds {
-				sch.saveAlertStates(ctx, sch.stateManager.GetAll(v))
+				states := sch.stateManager.GetAll(v)
+				if sch.sharder != nil {
+					// the states of the rules evaluated by other instances are read-only copies
+					owned := make([]*state.State, 0, len(states))
+					for _, s := range states {
+						if sch.registry.exists(models.AlertRuleKey{OrgID: s.OrgID, UID: s.AlertRuleUID}) {
+							owned = append(owned, s)
+						}
+					}
+					states = owned
+				}
+				sch.saveAlertStates(ctx, states)
 			}
 
 			sch.stateManager.Close()
@@ -491,6 +594,78 @@ func (sch *schedule) schedulePeriodic(ctx context.Context) error {
 	}
 }
 
+type readyToRunItem struct {
+	key        models.AlertRuleKey
+	groupKey   models.AlertRuleGroupKey
+	groupIndex int
+	recording  bool
+	ruleName   string
+	ruleInfo   *alertRuleInfo
+	version    int64
+}
+
+// groupReadyToRun groups the rules of rule groups with recording rules, ordered by their index in the group.
+// Every other rule is put in a group of its own.
+func groupReadyToRun(items []readyToRunItem) [][]readyToRunItem {
+	recordingGroups := make(map[models.AlertRuleGroupKey]struct{})
+	for _, item := range items {
+		if item.recording {
+			recordingGroups[item.groupKey] = struct{}{}
+		}
+	}
+
+	result := make([][]readyToRunItem, 0, len(items))
+	byGroup := make(map[models.AlertRuleGroupKey][]readyToRunItem)
+	keys := make([]models.AlertRuleGroupKey, 0)
+	for _, item := range items {
+		if _, ok := recordingGroups[item.groupKey]; !ok {
+			result = append(result, []readyToRunItem{item})
+			continue
+		}
+		if _, ok := byGroup[item.groupKey]; !ok {
+			keys = append(keys, item.groupKey)
+		}
+		byGroup[item.groupKey] = append(byGroup[item.groupKey], item)
+	}
+
+	for _, k := range keys {
+		group := byGroup[k]
+		sort.SliceStable(group, func(i, j int) bool {
+			if group[i].groupIndex != group[j].groupIndex {
+				return group[i].groupIndex < group[j].groupIndex
+			}
+			return group[i].key.UID < group[j].key.UID
+		})
+		result = append(result, group)
+	}
+	return result
+}
+
+// sendEval signals the rule routine to evaluate the rule. It returns nil if the routine was stopped.
+func (sch *schedule) sendEval(tick time.Time, item readyToRunItem) *evaluation {
+	e := &evaluation{scheduledAt: tick, version: item.version, done: make(chan struct{})}
+	success, dropped := item.ruleInfo.send(e)
+	if !success {
+		sch.log.Debug("scheduled evaluation was canceled because evaluation routine was stopped", "uid", item.key.UID, "org", item.key.OrgID, "time", tick)
+		return nil
+	}
+	if dropped != nil {
+		sch.log.Warn("Alert rule evaluation is too slow - dropped tick", "uid", item.key.UID, "org", item.key.OrgID, "time", tick)
+		orgID := fmt.Sprint(item.key.OrgID)
+		sch.metrics.EvaluationMissed.WithLabelValues(orgID, item.ruleName).Inc()
+	}
+	return e
+}
+
+// waitEval waits until the evaluation is finished.
+func (sch *schedule) waitEval(ctx context.Context, e *evaluation, item readyToRunItem) {
+	select {
+	case <-e.done:
+	case <-item.ruleInfo.ctx.Done():
+	case <-ctx.Done():
+	}
+}
+
 func (sch *schedule) ruleRoutine(grafanaCtx context.Context, key models.AlertRuleKey, evalCh <-chan *evaluation, updateCh <-chan struct{}) error {
 	logger := sch.log.New("uid", key.UID, "org", key.OrgID)
 	logger.Debug("alert rule routine started")
@@ -505,6 +680,7 @@ func (sch *schedule) ruleRoutine(grafanaCtx context.Context, key models.AlertRul
 			logger.Debug("no alerts to put in the notifier or to send to external Alertmanager(s)")
 			return
 		}
+		sch.enricher.Enrich(key.OrgID, &alerts)
 
 		// Send alerts to local notifier if they need to be handled internally
 		// or if no external AMs have been discovered yet.
@@ -568,6 +744,20 @@ func (sch *schedule) ruleRoutine(grafanaCtx context.Context, key models.AlertRul
 		logger := logger.New("version", r.Version, "attempt", attempt, "now", e.scheduledAt)
 		start := sch.clock.Now()
 
+		if r.IsRecordingRule() {
+			err := sch.recordRule(ctx, r, e.scheduledAt)
+			dur := sch.clock.Now().Sub(start)
+			evalTotal.Inc()
+			evalDuration.Observe(dur.Seconds())
+			if err != nil {
+				evalTotalFailures.Inc()
+				logger.Error("failed to evaluate recording rule", "duration", dur, "err", err)
+				return err
+			}
+			logger.Debug("recording rule evaluated", "duration", dur)
+			return nil
+		}
+
 		condition := models.Condition{
 			Condition: r.Condition,
 			OrgID:     r.OrgID,
@@ -632,6 +822,7 @@ func (sch *schedule) ruleRoutine(grafanaCtx context.Context, key models.AlertRul
 				return nil
 			}
 			if evalRunning {
+				ctx.finish()
 				continue
 			}
 
@@ -640,6 +831,7 @@ func (sch *schedule) ruleRoutine(grafanaCtx context.Context, key models.AlertRul
 				defer func() {
 					evalRunning = false
 					sch.evalApplied(key, ctx.scheduledAt)
+					ctx.finish()
 				}()
 
 				err := retryIfError(func(attempt int64) error {
@@ -659,13 +851,57 @@ func (sch *schedule) ruleRoutine(grafanaCtx context.Context, key models.AlertRul
 				}
 			}()
 		case <-grafanaCtx.Done():
-			clearState()
+			// The alerts of a rule handed off to another instance are not resolved, that instance
+			// continues to evaluate the rule and its states are kept as a read-only copy.
+			if _, ok := sch.handedOff.LoadAndDelete(key); !ok {
+				clearState()
+			}
 			logger.Debug("stopping alert rule routine")
 			return nil
 		}
 	}
 }
 
+// recordRule evaluates the queries and expressions of a recording rule and writes the result of the recorded one.
+func (sch *schedule) recordRule(ctx context.Context, r *models.AlertRule, now time.Time) error {
+	resp, err := sch.evaluator.QueriesAndExpressionsEval(r.OrgID, r.Data, now, sch.expressionService)
+	if err != nil {
+		return err
+	}
+	res, ok := resp.Responses[r.Record.From]
+	if !ok {
+		return fmt.Errorf("no result for the recorded query or expression %s", r.Record.From)
+	}
+	if res.Error != nil {
+		return fmt.Errorf("failed to evaluate the recorded query or expression %s: %w", r.Record.From, res.Error)
+	}
+	// the labels of the rule are expanded for every recorded series, like the labels of an alert instance
+	for _, frame := range res.Frames {
+		for _, field := range frame.Fields {
+			if !field.Type().Numeric() {
+				continue
+			}
+			value, ok := writer.LastValue(field)
+			if !ok {
+				continue
+			}
+			expanded, err := state.ExpandRecordedLabels(ctx, r, field.Labels, value, now, sch.appURL)
+			if err != nil {
+				sch.log.Error("error in expanding template", "uid", r.UID, "org", r.OrgID, "err", err)
+			}
+			lbls := make(data.Labels, len(field.Labels)+len(expanded))
+			for k, v := range field.Labels {
+				lbls[k] = v
+			}
+			for k, v := range expanded {
+				lbls[k] = v
+			}
+			field.Labels = lbls
+		}
+	}
+	return sch.recordingWriter.Write(ctx, r.Record.Metric, now, res.Frames, nil)
+}
+
 func (sch *schedule) saveAlertStates(ctx context.Context, states []*state.State) {
 	sch.log.Debug("saving alert states", "count", len(states))
 	for _, s := range states {
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math/rand"
 	"net/url"
+	"strconv"
 	"sync"
 	"testing"
 	"time"
@@ -31,6 +32,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/ngalert/sender"
 	"github.com/grafana/grafana/pkg/services/ngalert/state"
 	"github.com/grafana/grafana/pkg/services/ngalert/store"
+	"github.com/grafana/grafana/pkg/services/ngalert/writer"
 	"github.com/grafana/grafana/pkg/services/secrets/fakes"
 	secretsManager "github.com/grafana/grafana/pkg/services/secrets/manager"
 	"github.com/grafana/grafana/pkg/services/sqlstore/mockstore"
@@ -816,6 +818,116 @@ func TestSchedule_ruleRoutine(t *testing.T) {
 		// TODO needs some mocking/stubbing for Alertmanager and Sender to make sure it was not called
 		t.Skip()
 	})
+
+	t.Run("when the rule is a recording rule", func(t *testing.T) {
+		evalChan := make(chan *evaluation)
+		evalAppliedChan := make(chan time.Time)
+		sch, ruleStore, instanceStore, _, _ := createSchedule(evalAppliedChan)
+		recordingWriter := &fakeWriter{}
+		sch.recordingWriter = recordingWriter
+
+		rule := CreateTestAlertRule(t, ruleStore, 10, rand.Int63(), eval.Alerting)
+		rule.Record = &models.Record{Metric: "test_metric", From: "A"}
+		rule.Labels = map[string]string{"static": "label", "value": "{{ $value }}"}
+
+		go func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			t.Cleanup(cancel)
+			_ = sch.ruleRoutine(ctx, rule.GetKey(), evalChan, make(chan struct{}))
+		}()
+
+		expectedTime := time.UnixMicro(rand.Int63())
+		e := &evaluation{
+			scheduledAt: expectedTime,
+			version:     rule.Version,
+			done:        make(chan struct{}),
+		}
+		evalChan <- e
+
+		actualTime := waitForTimeChannel(t, evalAppliedChan)
+		require.Equal(t, expectedTime, actualTime)
+
+		t.Run("it should signal that the evaluation is finished", func(t *testing.T) {
+			select {
+			case <-e.done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("evaluation was not finished")
+			}
+		})
+		t.Run("it should write the result of the recorded expression", func(t *testing.T) {
+			writes := recordingWriter.getWrites()
+			require.Len(t, writes, 1)
+			require.Equal(t, "test_metric", writes[0].name)
+			require.Equal(t, expectedTime, writes[0].time)
+			require.Nil(t, writes[0].extraLabels)
+			require.NotEmpty(t, writes[0].frames)
+		})
+		t.Run("it should expand the templates in the labels of the rule", func(t *testing.T) {
+			writes := recordingWriter.getWrites()
+			require.Len(t, writes, 1)
+			field := writes[0].frames[0].Fields[0]
+			value, ok := writer.LastValue(field)
+			require.True(t, ok)
+			require.Equal(t, "label", field.Labels["static"])
+			require.Equal(t, strconv.FormatFloat(value, 'f', -1, 64), field.Labels["value"])
+		})
+		t.Run("it should not process results via state manager", func(t *testing.T) {
+			require.Empty(t, sch.stateManager.GetStatesForRuleUID(rule.OrgID, rule.UID))
+			require.Empty(t, instanceStore.RecordedOps)
+		})
+	})
+}
+
+func TestGroupReadyToRun(t *testing.T) {
+	groupA := models.AlertRuleGroupKey{OrgID: 1, NamespaceUID: "ns", RuleGroup: "a"}
+	groupB := models.AlertRuleGroupKey{OrgID: 1, NamespaceUID: "ns", RuleGroup: "b"}
+	item := func(uid string, group models.AlertRuleGroupKey, idx int, recording bool) readyToRunItem {
+		return readyToRunItem{key: models.AlertRuleKey{OrgID: 1, UID: uid}, groupKey: group, groupIndex: idx, recording: recording}
+	}
+
+	groups := groupReadyToRun([]readyToRunItem{
+		item("a3", groupA, 3, false),
+		item("b2", groupB, 2, false),
+		item("b1", groupB, 1, false),
+		item("a1", groupA, 1, true),
+		item("a2-2", groupA, 2, false),
+		item("a2-1", groupA, 2, false),
+	})
+
+	uids := make([][]string, 0, len(groups))
+	for _, group := range groups {
+		var g []string
+		for _, i := range group {
+			g = append(g, i.key.UID)
+		}
+		uids = append(uids, g)
+	}
+	require.Equal(t, [][]string{{"b2"}, {"b1"}, {"a1", "a2-1", "a2-2", "a3"}}, uids)
+}
+
+type writeCall struct {
+	name        string
+	time        time.Time
+	frames      data.Frames
+	extraLabels map[string]string
+}
+
+type fakeWriter struct {
+	mtx    sync.Mutex
+	writes []writeCall
+}
+
+func (w *fakeWriter) Write(_ context.Context, name string, t time.Time, frames data.Frames, extraLabels map[string]string) error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	w.writes = append(w.writes, writeCall{name: name, time: t, frames: frames, extraLabels: extraLabels})
+	return nil
+}
+
+func (w *fakeWriter) getWrites() []writeCall {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	return append([]writeCall(nil), w.writes...)
 }
 
 func TestSchedule_UpdateAlertRule(t *testing.T) {
@@ -915,7 +1027,7 @@ func setupScheduler(t *testing.T, rs store.RuleStore, is store.InstanceStore, ac
 	m := metrics.NewNGAlert(registry)
 	secretsService := secretsManager.SetupTestService(t, fakes.NewFakeSecretsStore())
 	decryptFn := secretsService.GetDecryptedValue
-	moa, err := notifier.NewMultiOrgAlertmanager(&setting.Cfg{}, &notifier.FakeConfigStore{}, &notifier.FakeOrgStore{}, &notifier.FakeKVStore{}, provisioning.NewFakeProvisioningStore(), decryptFn, m.GetMultiOrgAlertmanagerMetrics(), nil, log.New("testlogger"), secretsService)
+	moa, err := notifier.NewMultiOrgAlertmanager(&setting.Cfg{}, &notifier.FakeConfigStore{}, &notifier.FakeOrgStore{}, &notifier.FakeKVStore{}, provisioning.NewFakeProvisioningStore(), nil, decryptFn, m.GetMultiOrgAlertmanagerMetrics(), nil, log.New("testlogger"), secretsService)
 	require.NoError(t, err)
 
 	schedCfg := SchedulerCfg{
@@ -931,7 +1043,7 @@ func setupScheduler(t *testing.T, rs store.RuleStore, is store.InstanceStore, ac
 		Metrics:                 m.GetSchedulerMetrics(),
 		AdminConfigPollInterval: 10 * time.Minute, // do not poll in unit tests.
 	}
-	st := state.NewManager(schedCfg.Logger, m.GetStateMetrics(), nil, rs, is, mockstore.NewSQLStoreMock(), &dashboards.FakeDashboardService{}, &image.NoopImageService{})
+	st := state.NewManager(schedCfg.Logger, m.GetStateMetrics(), nil, rs, is, mockstore.NewSQLStoreMock(), &dashboards.FakeDashboardService{}, &image.NoopImageService{}, &store.FakeStateHistoryStore{})
 	appUrl := &url.URL{
 		Scheme: "http",
 		Host:   "localhost",
@@ -0,0 +1,165 @@
+package schedule
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// ringReplicas is the number of points each member has on the hash ring. More points spread the rule groups
+// more evenly across the members.
+const ringReplicas = 128
+
+// handedOffStatesSyncInterval is how often the states of the alert rules that other members of the HA cluster
+// evaluate are loaded from the database, unless the rules handed off to other members change.
+const handedOffStatesSyncInterval = time.Minute
+
+// ClusterMembership provides the members of the HA cluster that share the evaluation of the alert rules.
+type ClusterMembership interface {
+	// ClusterMembers returns the name of this instance and the names of all members, including this instance.
+	ClusterMembers() (string, []string)
+}
+
+// hashRing assigns keys to members by consistent hashing, so that only the keys of a member move when it joins
+// or leaves.
+type hashRing struct {
+	hashes  []uint64
+	members map[uint64]string
+}
+
+func newHashRing(members []string) *hashRing {
+	r := &hashRing{
+		hashes:  make([]uint64, 0, len(members)*ringReplicas),
+		members: make(map[uint64]string, len(members)*ringReplicas),
+	}
+	for _, m := range members {
+		for i := 0; i < ringReplicas; i++ {
+			h := hashKey(m + "#" + strconv.Itoa(i))
+			if _, ok := r.members[h]; ok {
+				continue
+			}
+			r.members[h] = m
+			r.hashes = append(r.hashes, h)
+		}
+	}
+	sort.Slice(r.hashes, func(i, j int) bool { return r.hashes[i] < r.hashes[j] })
+	return r
+}
+
+// owner returns the member the key is assigned to, or an empty string if the ring has no members.
+func (r *hashRing) owner(key string) string {
+	if len(r.hashes) == 0 {
+		return ""
+	}
+	h := hashKey(key)
+	i := sort.Search(len(r.hashes), func(i int) bool { return r.hashes[i] >= h })
+	if i == len(r.hashes) {
+		i = 0
+	}
+	return r.members[r.hashes[i]]
+}
+
+func hashKey(key string) uint64 {
+	h := fnv.New64a()
+	// We can ignore err as fnv64 does not return an error
+	// nolint:errcheck,gosec
+	h.Write([]byte(key))
+	// FNV does not spread similar keys well enough over the ring, so the bits are mixed
+	// with the finalizer of MurmurHash3.
+	x := h.Sum64()
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
+
+// ruleSharder decides which alert rules this instance evaluates. The hash ring is rebuilt when the members of
+// the cluster change, which rebalances the rules across the members.
+type ruleSharder struct {
+	membership ClusterMembership
+	log        log.Logger
+
+	mu      sync.Mutex
+	self    string
+	members string
+	ring    *hashRing
+}
+
+func newRuleSharder(membership ClusterMembership, logger log.Logger) *ruleSharder {
+	return &ruleSharder{membership: membership, log: logger}
+}
+
+// refresh rebuilds the hash ring if the members of the cluster changed since the last call.
+func (s *ruleSharder) refresh() {
+	self, members := s.membership.ClusterMembers()
+	sorted := append([]string(nil), members...)
+	sort.Strings(sorted)
+	joined := strings.Join(sorted, ",")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ring != nil && self == s.self && joined == s.members {
+		return
+	}
+	s.log.Info("cluster members changed, rebalancing alert rules", "self", self, "members", len(sorted))
+	s.self = self
+	s.members = joined
+	s.ring = newHashRing(sorted)
+}
+
+// owns returns true if this instance evaluates the alert rule. The rules of a group are kept on one instance
+// because they are evaluated one after another. Without cluster members, the instance owns every rule.
+func (s *ruleSharder) owns(key models.AlertRuleGroupKey) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ring == nil || len(s.ring.hashes) == 0 || s.self == "" {
+		return true
+	}
+	owner := s.ring.owner(fmt.Sprintf("%d/%s/%s", key.OrgID, key.NamespaceUID, key.RuleGroup))
+	return owner == s.self
+}
+
+// handedOffStatesSync decides when to load the states of the alert rules that other members of the HA cluster
+// evaluate. Loading them on every tick would query the database of every member on every tick.
+type handedOffStatesSync struct {
+	rules    map[int64]map[string]struct{}
+	lastSync time.Time
+}
+
+// due returns true if the states of the handed off rules should be loaded now, because the rules changed since
+// they were last loaded or because handedOffStatesSyncInterval has passed.
+func (s *handedOffStatesSync) due(rules map[int64]map[string]struct{}, now time.Time) bool {
+	if !sameHandedOffRules(s.rules, rules) || now.Sub(s.lastSync) >= handedOffStatesSyncInterval {
+		s.rules = rules
+		s.lastSync = now
+		return true
+	}
+	return false
+}
+
+func sameHandedOffRules(a, b map[int64]map[string]struct{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for orgID, uidsA := range a {
+		uidsB, ok := b[orgID]
+		if !ok || len(uidsA) != len(uidsB) {
+			return false
+		}
+		for uid := range uidsA {
+			if _, ok := uidsB[uid]; !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
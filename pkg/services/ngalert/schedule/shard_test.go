@@ -0,0 +1,150 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/state"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+type fakeClusterMembership struct {
+	self    string
+	members []string
+}
+
+func (f *fakeClusterMembership) ClusterMembers() (string, []string) {
+	return f.self, f.members
+}
+
+func TestHashRing(t *testing.T) {
+	keys := make([]string, 0, 3000)
+	for i := 0; i < cap(keys); i++ {
+		keys = append(keys, fmt.Sprintf("1/folder/group-%d", i))
+	}
+
+	t.Run("spreads the keys across the members", func(t *testing.T) {
+		ring := newHashRing([]string{"a", "b", "c"})
+		counts := map[string]int{}
+		for _, k := range keys {
+			counts[ring.owner(k)]++
+		}
+		require.Len(t, counts, 3)
+		for m, c := range counts {
+			require.Greaterf(t, c, len(keys)/6, "member %s owns too few keys", m)
+		}
+	})
+
+	t.Run("moves only the keys of the new member when a member joins", func(t *testing.T) {
+		before := newHashRing([]string{"a", "b", "c"})
+		after := newHashRing([]string{"a", "b", "c", "d"})
+		moved := 0
+		for _, k := range keys {
+			if before.owner(k) != after.owner(k) {
+				require.Equal(t, "d", after.owner(k))
+				moved++
+			}
+		}
+		require.Greater(t, moved, 0)
+	})
+
+	t.Run("has no owner without members", func(t *testing.T) {
+		require.Equal(t, "", newHashRing(nil).owner("key"))
+	})
+}
+
+func TestRuleSharder(t *testing.T) {
+	groups := make([]models.AlertRuleGroupKey, 0, 100)
+	for i := 0; i < cap(groups); i++ {
+		groups = append(groups, models.AlertRuleGroupKey{OrgID: 1, NamespaceUID: "folder", RuleGroup: fmt.Sprintf("group-%d", i)})
+	}
+
+	t.Run("owns every rule without a cluster", func(t *testing.T) {
+		sharder := newRuleSharder(&fakeClusterMembership{}, log.NewNopLogger())
+		sharder.refresh()
+		for _, g := range groups {
+			require.True(t, sharder.owns(g))
+		}
+	})
+
+	t.Run("assigns every rule group to exactly one member and rebalances when a member leaves", func(t *testing.T) {
+		members := []string{"a", "b", "c"}
+		memberships := map[string]*fakeClusterMembership{}
+		sharders := map[string]*ruleSharder{}
+		for _, m := range members {
+			memberships[m] = &fakeClusterMembership{self: m, members: members}
+			sharders[m] = newRuleSharder(memberships[m], log.NewNopLogger())
+			sharders[m].refresh()
+		}
+		assertOneOwner := func(active []string) {
+			for _, g := range groups {
+				owners := 0
+				for _, m := range active {
+					if sharders[m].owns(g) {
+						owners++
+					}
+				}
+				require.Equalf(t, 1, owners, "group %s", g.RuleGroup)
+			}
+		}
+		assertOneOwner(members)
+
+		for _, m := range []string{"a", "b"} {
+			memberships[m].members = []string{"a", "b"}
+			sharders[m].refresh()
+		}
+		assertOneOwner([]string{"a", "b"})
+	})
+}
+
+func TestSchedule_handOffAlertRule(t *testing.T) {
+	sch := setupSchedulerWithFakeStores(t)
+	key := generateRuleKey()
+	sch.stateManager.Put([]*state.State{{
+		AlertRuleUID: key.UID,
+		OrgID:        key.OrgID,
+		CacheId:      util.GenerateShortUID(),
+		State:        eval.Alerting,
+	}})
+
+	info, _ := sch.registry.getOrCreateInfo(context.Background(), key)
+	stopped := make(chan struct{})
+	go func() {
+		_ = sch.ruleRoutine(info.ctx, key, info.evalCh, info.updateCh)
+		close(stopped)
+	}()
+
+	sch.handOffAlertRule(key)
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("rule routine did not stop")
+	}
+	require.False(t, sch.registry.exists(key))
+	// the states are kept as a read-only copy, they are not resolved
+	states := sch.stateManager.GetStatesForRuleUID(key.OrgID, key.UID)
+	require.Len(t, states, 1)
+	require.Equal(t, eval.Alerting, states[0].State)
+	_, pending := sch.handedOff.Load(key)
+	require.False(t, pending)
+}
+
+func TestHandedOffStatesSync(t *testing.T) {
+	now := time.Now()
+	rules := map[int64]map[string]struct{}{1: {"a": {}, "b": {}}}
+	s := handedOffStatesSync{}
+
+	require.True(t, s.due(rules, now))
+	require.False(t, s.due(map[int64]map[string]struct{}{1: {"b": {}, "a": {}}}, now.Add(10*time.Second)))
+	require.True(t, s.due(map[int64]map[string]struct{}{1: {"a": {}}}, now.Add(20*time.Second)))
+	require.False(t, s.due(map[int64]map[string]struct{}{1: {"a": {}}}, now.Add(30*time.Second)))
+	require.True(t, s.due(map[int64]map[string]struct{}{1: {"a": {}}}, now.Add(20*time.Second+handedOffStatesSyncInterval)))
+}
@@ -0,0 +1,109 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	ngModels "github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// BacktestEvaluateFunc evaluates the condition of a rule as it would have been evaluated at the given time.
+type BacktestEvaluateFunc func(now time.Time) (eval.Results, error)
+
+// InstanceTimeline is the history of state changes of a single alert instance.
+type InstanceTimeline struct {
+	Labels      data.Labels
+	Transitions []Transition
+}
+
+// Transition is a change of the state of an alert instance.
+type Transition struct {
+	Time   time.Time
+	State  eval.State
+	Reason string
+	// Resolved is true if the instance went from Alerting to Normal.
+	Resolved bool
+}
+
+// Backtest evaluates the rule at every interval of the rule between from and to, and replays the results
+// through the same state transitions as the Manager, including the pending period of the rule.
+// It returns the timeline of every alert instance that was seen during the range, sorted by labels.
+// Nothing is persisted and no annotations or screenshots are created.
+func Backtest(ctx context.Context, logger log.Logger, alertRule *ngModels.AlertRule, from, to time.Time, evaluate BacktestEvaluateFunc) ([]*InstanceTimeline, error) {
+	interval := time.Duration(alertRule.IntervalSeconds) * time.Second
+	if interval <= 0 {
+		return nil, errors.New("interval must be greater than 0")
+	}
+	if !from.Before(to) {
+		return nil, errors.New("the start of the range must be before its end")
+	}
+
+	c := newCache(logger, nil, nil)
+	timelines := make(map[string]*InstanceTimeline)
+	record := func(s *State, now time.Time) {
+		tl, ok := timelines[s.CacheId]
+		if !ok {
+			tl = &InstanceTimeline{Labels: s.Labels}
+			timelines[s.CacheId] = tl
+		}
+		if n := len(tl.Transitions); n > 0 {
+			last := tl.Transitions[n-1]
+			if last.State == s.State && last.Reason == s.StateReason {
+				return
+			}
+		}
+		tl.Transitions = append(tl.Transitions, Transition{
+			Time:     now,
+			State:    s.State,
+			Reason:   s.StateReason,
+			Resolved: s.Resolved,
+		})
+	}
+
+	for now := from; !now.After(to); now = now.Add(interval) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		results, err := evaluate(now)
+		if err != nil {
+			return nil, err
+		}
+
+		processed := make(map[string]struct{}, len(results))
+		for _, result := range results {
+			// Results are attributed to the time of the step rather than to when they were computed.
+			result.EvaluatedAt = now
+			s := c.getOrCreate(ctx, alertRule, result)
+			s.applyResult(alertRule, result)
+			processed[s.CacheId] = struct{}{}
+			record(s, now)
+		}
+
+		// Instances that were not returned for two intervals are removed, like the Manager does.
+		// If they were firing this resolves them.
+		for _, s := range c.getStatesForRuleUID(alertRule.OrgID, alertRule.UID) {
+			if _, ok := processed[s.CacheId]; ok || !s.LastEvaluationTime.Add(2*interval).Before(now) {
+				continue
+			}
+			c.deleteEntry(s.OrgID, s.AlertRuleUID, s.CacheId)
+			if s.State == eval.Alerting {
+				record(&State{CacheId: s.CacheId, State: eval.Normal, Resolved: true}, now)
+			}
+		}
+	}
+
+	result := make([]*InstanceTimeline, 0, len(timelines))
+	for _, tl := range timelines {
+		result = append(result, tl)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Labels.String() < result[j].Labels.String()
+	})
+	return result, nil
+}
@@ -0,0 +1,83 @@
+package state_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/state"
+)
+
+func TestBacktest(t *testing.T) {
+	from := time.Unix(0, 0).UTC()
+	rule := &models.AlertRule{
+		OrgID:           1,
+		UID:             "test_uid",
+		Title:           "test_title",
+		NamespaceUID:    "test_namespace_uid",
+		IntervalSeconds: 60,
+		For:             2 * time.Minute,
+		NoDataState:     models.NoData,
+		ExecErrState:    models.ErrorErrState,
+	}
+	at := func(minutes int) time.Time {
+		return from.Add(time.Duration(minutes) * time.Minute)
+	}
+
+	t.Run("should respect the pending period and resolve firing instances", func(t *testing.T) {
+		states := []eval.State{eval.Normal, eval.Alerting, eval.Alerting, eval.Alerting, eval.Alerting, eval.Normal, eval.NoData}
+		evaluate := func(now time.Time) (eval.Results, error) {
+			return eval.Results{{
+				Instance: data.Labels{"host": "a"},
+				State:    states[int(now.Sub(from)/time.Minute)],
+			}}, nil
+		}
+
+		res, err := state.Backtest(context.Background(), log.NewNopLogger(), rule, from, at(6), evaluate)
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		require.Equal(t, "a", res[0].Labels["host"])
+		require.Equal(t, []state.Transition{
+			{Time: at(0), State: eval.Normal},
+			{Time: at(1), State: eval.Pending},
+			{Time: at(3), State: eval.Alerting},
+			{Time: at(5), State: eval.Normal, Resolved: true},
+			{Time: at(6), State: eval.NoData},
+		}, res[0].Transitions)
+	})
+
+	t.Run("should resolve instances that are no longer returned", func(t *testing.T) {
+		rule := *rule
+		rule.For = 0
+		evaluate := func(now time.Time) (eval.Results, error) {
+			results := eval.Results{{Instance: data.Labels{"host": "a"}, State: eval.Alerting}}
+			if now.Before(at(2)) {
+				results = append(results, eval.Result{Instance: data.Labels{"host": "b"}, State: eval.Alerting})
+			}
+			return results, nil
+		}
+
+		res, err := state.Backtest(context.Background(), log.NewNopLogger(), &rule, from, at(5), evaluate)
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+		require.Equal(t, []state.Transition{{Time: at(0), State: eval.Alerting}}, res[0].Transitions)
+		require.Equal(t, []state.Transition{
+			{Time: at(0), State: eval.Alerting},
+			{Time: at(4), State: eval.Normal, Resolved: true},
+		}, res[1].Transitions)
+	})
+
+	t.Run("should fail on an invalid range", func(t *testing.T) {
+		evaluate := func(now time.Time) (eval.Results, error) {
+			return nil, nil
+		}
+		_, err := state.Backtest(context.Background(), log.NewNopLogger(), rule, at(1), from, evaluate)
+		require.Error(t, err)
+	})
+}
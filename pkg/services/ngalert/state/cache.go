@@ -167,6 +167,24 @@ func (c *cache) removeByRuleUID(orgID int64, uid string) {
 	delete(c.states[orgID], uid)
 }
 
+// replaceRuleStates replaces all entries of the given rule in the state cache.
+func (c *cache) replaceRuleStates(orgID int64, uid string, states []*State) {
+	c.mtxStates.Lock()
+	defer c.mtxStates.Unlock()
+	if len(states) == 0 {
+		delete(c.states[orgID], uid)
+		return
+	}
+	if _, ok := c.states[orgID]; !ok {
+		c.states[orgID] = make(map[string]map[string]*State)
+	}
+	ruleStates := make(map[string]*State, len(states))
+	for _, s := range states {
+		ruleStates[s.CacheId] = s
+	}
+	c.states[orgID][uid] = ruleStates
+}
+
 func (c *cache) reset() {
 	c.mtxStates.Lock()
 	defer c.mtxStates.Unlock()
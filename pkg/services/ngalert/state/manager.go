@@ -45,11 +45,29 @@ type Manager struct {
 	sqlStore         sqlstore.Store
 	dashboardService dashboards.DashboardService
 	imageService     image.ImageService
+	historyStore     store.StateHistoryStore
+
+	// historyQueue holds the transitions waiting to be saved to historyStore.
+	historyQueue   chan *ngModels.AlertStateHistory
+	historyDone    chan struct{}
+	historyFlushed chan struct{}
 }
 
+const (
+	// stateHistoryQueueSize is the number of transitions that can wait to be saved. Transitions are
+	// dropped when the queue is full, so that a slow database does not hold up evaluation.
+	stateHistoryQueueSize = 10000
+	// stateHistoryBatchSize is the maximum number of transitions saved at once.
+	stateHistoryBatchSize = 100
+	// loadStoredStatesBatchSize is the maximum number of rules whose stored states are loaded at once.
+	loadStoredStatesBatchSize = 500
+	// stateHistoryFlushTimeout is how long closing the manager waits for the queued transitions to be saved.
+	stateHistoryFlushTimeout = 10 * time.Second
+)
+
 func NewManager(logger log.Logger, metrics *metrics.State, externalURL *url.URL,
 	ruleStore store.RuleStore, instanceStore store.InstanceStore, sqlStore sqlstore.Store,
-	dashboardService dashboards.DashboardService, imageService image.ImageService) *Manager {
+	dashboardService dashboards.DashboardService, imageService image.ImageService, historyStore store.StateHistoryStore) *Manager {
 	manager := &Manager{
 		cache:            newCache(logger, metrics, externalURL),
 		quit:             make(chan struct{}),
@@ -61,13 +79,30 @@ func NewManager(logger log.Logger, metrics *metrics.State, externalURL *url.URL,
 		sqlStore:         sqlStore,
 		dashboardService: dashboardService,
 		imageService:     imageService,
+		historyStore:     historyStore,
 	}
 	go manager.recordMetrics()
+	if historyStore != nil {
+		manager.historyQueue = make(chan *ngModels.AlertStateHistory, stateHistoryQueueSize)
+		manager.historyDone = make(chan struct{})
+		manager.historyFlushed = make(chan struct{})
+		go manager.saveStateHistory()
+	}
 	return manager
 }
 
+// Close stops the manager. The queued transitions are saved to the state history store before it returns,
+// unless that takes longer than stateHistoryFlushTimeout.
 func (st *Manager) Close() {
 	st.quit <- struct{}{}
+	if st.historyDone != nil {
+		close(st.historyDone)
+		select {
+		case <-st.historyFlushed:
+		case <-time.After(stateHistoryFlushTimeout):
+			st.log.Warn("timed out saving the queued state history", "transitions", len(st.historyQueue))
+		}
+	}
 }
 
 func (st *Manager) Warm(ctx context.Context) {
@@ -109,25 +144,7 @@ func (st *Manager) Warm(ctx context.Context) {
 				continue
 			}
 
-			lbs := map[string]string(entry.Labels)
-			cacheId, err := entry.Labels.StringKey()
-			if err != nil {
-				st.log.Error("error getting cacheId for entry", "msg", err.Error())
-			}
-			stateForEntry := &State{
-				AlertRuleUID:         entry.RuleUID,
-				OrgID:                entry.RuleOrgID,
-				CacheId:              cacheId,
-				Labels:               lbs,
-				State:                translateInstanceState(entry.CurrentState),
-				StateReason:          entry.CurrentReason,
-				LastEvaluationString: "",
-				StartsAt:             entry.CurrentStateSince,
-				EndsAt:               entry.CurrentStateEnd,
-				LastEvaluationTime:   entry.LastEvalTime,
-				Annotations:          ruleForEntry.Annotations,
-			}
-			states = append(states, stateForEntry)
+			states = append(states, st.stateFromInstance(entry, ruleForEntry))
 		}
 	}
 
@@ -136,6 +153,101 @@ func (st *Manager) Warm(ctx context.Context) {
 	}
 }
 
+// WarmRule replaces the cached states of one alert rule with its stored states, e.g. when the evaluation of
+// the rule moves to this instance from another member of the HA cluster.
+func (st *Manager) WarmRule(ctx context.Context, rule *ngModels.AlertRule) {
+	cmd := ngModels.ListAlertInstancesQuery{
+		RuleOrgID: rule.OrgID,
+		RuleUID:   rule.UID,
+	}
+	if err := st.instanceStore.ListAlertInstances(ctx, &cmd); err != nil {
+		st.log.Error("unable to fetch previous state", "uid", rule.UID, "msg", err.Error())
+		return
+	}
+	states := make([]*State, 0, len(cmd.Result))
+	for _, entry := range cmd.Result {
+		states = append(states, st.stateFromInstance(entry, rule))
+	}
+	st.cache.replaceRuleStates(rule.OrgID, rule.UID, states)
+}
+
+// LoadStoredStates replaces the cached states of the given alert rules of an organization with their stored
+// states. The rules that another member of the HA cluster evaluates are not evaluated on this instance, their
+// states are loaded from the database instead so that every instance serves the same states.
+func (st *Manager) LoadStoredStates(ctx context.Context, orgID int64, ruleUIDs map[string]struct{}) error {
+	uids := make([]string, 0, len(ruleUIDs))
+	for uid := range ruleUIDs {
+		uids = append(uids, uid)
+	}
+	// The rules are queried in batches to stay below the limit of query parameters of the databases.
+	for len(uids) > 0 {
+		n := len(uids)
+		if n > loadStoredStatesBatchSize {
+			n = loadStoredStatesBatchSize
+		}
+		if err := st.loadStoredStates(ctx, orgID, uids[:n]); err != nil {
+			return err
+		}
+		uids = uids[n:]
+	}
+	return nil
+}
+
+func (st *Manager) loadStoredStates(ctx context.Context, orgID int64, ruleUIDs []string) error {
+	ruleCmd := ngModels.ListAlertRulesQuery{
+		OrgID:    orgID,
+		RuleUIDs: ruleUIDs,
+	}
+	if err := st.ruleStore.ListAlertRules(ctx, &ruleCmd); err != nil {
+		return err
+	}
+	cmd := ngModels.ListAlertInstancesQuery{
+		RuleOrgID: orgID,
+		RuleUIDs:  ruleUIDs,
+	}
+	if err := st.instanceStore.ListAlertInstances(ctx, &cmd); err != nil {
+		return err
+	}
+
+	rules := make(map[string]*ngModels.AlertRule, len(ruleCmd.Result))
+	for _, rule := range ruleCmd.Result {
+		rules[rule.UID] = rule
+	}
+	states := make(map[string][]*State, len(rules))
+	for _, entry := range cmd.Result {
+		rule, ok := rules[entry.RuleUID]
+		if !ok {
+			continue
+		}
+		states[rule.UID] = append(states[rule.UID], st.stateFromInstance(entry, rule))
+	}
+	for _, uid := range ruleUIDs {
+		st.cache.replaceRuleStates(orgID, uid, states[uid])
+	}
+	return nil
+}
+
+func (st *Manager) stateFromInstance(entry *ngModels.AlertInstance, rule *ngModels.AlertRule) *State {
+	lbs := map[string]string(entry.Labels)
+	cacheId, err := entry.Labels.StringKey()
+	if err != nil {
+		st.log.Error("error getting cacheId for entry", "msg", err.Error())
+	}
+	return &State{
+		AlertRuleUID:         entry.RuleUID,
+		OrgID:                entry.RuleOrgID,
+		CacheId:              cacheId,
+		Labels:               lbs,
+		State:                translateInstanceState(entry.CurrentState),
+		StateReason:          entry.CurrentReason,
+		LastEvaluationString: "",
+		StartsAt:             entry.CurrentStateSince,
+		EndsAt:               entry.CurrentStateEnd,
+		LastEvaluationTime:   entry.LastEvalTime,
+		Annotations:          rule.Annotations,
+	}
+}
+
 func (st *Manager) getOrCreate(ctx context.Context, alertRule *ngModels.AlertRule, result eval.Result) *State {
 	return st.cache.getOrCreate(ctx, alertRule, result)
 }
@@ -206,44 +318,11 @@ func (st *Manager) maybeTakeScreenshot(
 func (st *Manager) setNextState(ctx context.Context, alertRule *ngModels.AlertRule, result eval.Result) *State {
 	currentState := st.getOrCreate(ctx, alertRule, result)
 
-	currentState.LastEvaluationTime = result.EvaluatedAt
-	currentState.EvaluationDuration = result.EvaluationDuration
-	currentState.Results = append(currentState.Results, Evaluation{
-		EvaluationTime:  result.EvaluatedAt,
-		EvaluationState: result.State,
-		Values:          NewEvaluationValues(result.Values),
-		Condition:       alertRule.Condition,
-	})
-	currentState.LastEvaluationString = result.EvaluationString
-	currentState.TrimResults(alertRule)
 	oldState := currentState.State
 	oldReason := currentState.StateReason
 
 	st.log.Debug("setting alert state", "uid", alertRule.UID)
-	switch result.State {
-	case eval.Normal:
-		currentState.resultNormal(alertRule, result)
-	case eval.Alerting:
-		currentState.resultAlerting(alertRule, result)
-	case eval.Error:
-		currentState.resultError(alertRule, result)
-	case eval.NoData:
-		currentState.resultNoData(alertRule, result)
-	case eval.Pending: // we do not emit results with this state
-	}
-
-	// Set reason iff: result is different than state, reason is not Alerting or Normal
-	currentState.StateReason = ""
-
-	if currentState.State != result.State &&
-		result.State != eval.Normal &&
-		result.State != eval.Alerting {
-		currentState.StateReason = result.State.String()
-	}
-
-	// Set Resolved property so the scheduler knows to send a postable alert
-	// to Alertmanager.
-	currentState.Resolved = oldState == eval.Alerting && currentState.State == eval.Normal
+	currentState.applyResult(alertRule, result)
 
 	err := st.maybeTakeScreenshot(ctx, alertRule, currentState, oldState)
 	if err != nil {
@@ -258,7 +337,10 @@ func (st *Manager) setNextState(ctx context.Context, alertRule *ngModels.AlertRu
 
 	shouldUpdateAnnotation := oldState != currentState.State || oldReason != currentState.StateReason
 	if shouldUpdateAnnotation {
-		go st.annotateState(ctx, alertRule, currentState.Labels, result.EvaluatedAt, InstanceStateAndReason{State: currentState.State, Reason: currentState.StateReason}, InstanceStateAndReason{State: oldState, Reason: oldReason})
+		current := InstanceStateAndReason{State: currentState.State, Reason: currentState.StateReason}
+		previous := InstanceStateAndReason{State: oldState, Reason: oldReason}
+		go st.annotateState(ctx, alertRule, currentState.Labels, result.EvaluatedAt, current, previous)
+		st.recordStateHistory(alertRule, currentState.Labels, NewEvaluationValues(result.Values), result.EvaluatedAt, current, previous)
 	}
 	return currentState
 }
@@ -386,14 +468,95 @@ func (st *Manager) staleResultsHandler(ctx context.Context, alertRule *ngModels.
 			}
 
 			if s.State == eval.Alerting {
-				st.annotateState(ctx, alertRule, s.Labels, time.Now(),
-					InstanceStateAndReason{State: eval.Normal, Reason: ""},
-					InstanceStateAndReason{State: s.State, Reason: s.StateReason})
+				now := time.Now()
+				current := InstanceStateAndReason{State: eval.Normal, Reason: ""}
+				previous := InstanceStateAndReason{State: s.State, Reason: s.StateReason}
+				st.annotateState(ctx, alertRule, s.Labels, now, current, previous)
+				st.recordStateHistory(alertRule, s.Labels, nil, now, current, previous)
 			}
 		}
 	}
 }
 
+// recordStateHistory queues a transition of an alert instance to be saved to the state history store, if there is one.
+func (st *Manager) recordStateHistory(alertRule *ngModels.AlertRule, labels data.Labels, values map[string]*float64, evaluatedAt time.Time, currentData, previousData InstanceStateAndReason) {
+	if st.historyQueue == nil {
+		return
+	}
+
+	ilbs := ngModels.InstanceLabels(labels)
+	_, labelsHash, err := ilbs.StringAndHash()
+	if err != nil {
+		st.log.Error("unable to get labelsHash", "err", err.Error(), "orgID", alertRule.OrgID, "alertRuleUID", alertRule.UID)
+	}
+
+	entry := &ngModels.AlertStateHistory{
+		OrgID:          alertRule.OrgID,
+		RuleUID:        alertRule.UID,
+		NamespaceUID:   alertRule.NamespaceUID,
+		RuleVersion:    alertRule.Version,
+		Labels:         labels,
+		LabelsHash:     labelsHash,
+		PreviousState:  ngModels.InstanceStateType(previousData.State.String()),
+		PreviousReason: previousData.Reason,
+		State:          ngModels.InstanceStateType(currentData.State.String()),
+		Reason:         currentData.Reason,
+		Values:         values,
+		EvaluatedAt:    evaluatedAt.UnixMilli(),
+	}
+	select {
+	case st.historyQueue <- entry:
+	default:
+		st.log.Warn("state history queue is full, dropping transition", "orgID", alertRule.OrgID, "alertRuleUID", alertRule.UID)
+	}
+}
+
+// saveStateHistory saves the queued transitions in batches until the manager is closed, and then saves
+// the transitions that are still queued.
+func (st *Manager) saveStateHistory() {
+	for {
+		select {
+		case entry := <-st.historyQueue:
+			st.saveStateHistoryBatch(context.Background(), entry)
+		case <-st.historyDone:
+			st.flushStateHistory()
+			close(st.historyFlushed)
+			return
+		}
+	}
+}
+
+// flushStateHistory saves the queued transitions, for at most stateHistoryFlushTimeout.
+func (st *Manager) flushStateHistory() {
+	ctx, cancel := context.WithTimeout(context.Background(), stateHistoryFlushTimeout)
+	defer cancel()
+	for ctx.Err() == nil {
+		select {
+		case entry := <-st.historyQueue:
+			st.saveStateHistoryBatch(ctx, entry)
+		default:
+			return
+		}
+	}
+}
+
+// saveStateHistoryBatch saves the transition together with the transitions queued after it, up to stateHistoryBatchSize.
+func (st *Manager) saveStateHistoryBatch(ctx context.Context, entry *ngModels.AlertStateHistory) {
+	batch := []*ngModels.AlertStateHistory{entry}
+drain:
+	for len(batch) < stateHistoryBatchSize {
+		select {
+		case entry = <-st.historyQueue:
+			batch = append(batch, entry)
+		default:
+			break drain
+		}
+	}
+	if err := st.historyStore.SaveAlertStateHistory(ctx, batch); err != nil {
+		st.log.Error("error saving alert state history", "transitions", len(batch), "err", err.Error())
+	}
+}
+
 func isItStale(lastEval time.Time, intervalSeconds int64) bool {
 	return lastEval.Add(2 * time.Duration(intervalSeconds) * time.Second).Before(time.Now())
 }
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"math/rand"
 	"testing"
+	"time"
 
+	"github.com/grafana/grafana-plugin-sdk-go/data"
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/services/dashboards"
 	"github.com/grafana/grafana/pkg/services/ngalert/eval"
@@ -92,7 +94,7 @@ func Test_maybeNewImage(t *testing.T) {
 			imageService := &CountingImageService{}
 			mgr := NewManager(log.NewNopLogger(), &metrics.State{}, nil,
 				&store.FakeRuleStore{}, &store.FakeInstanceStore{}, mockstore.NewSQLStoreMock(),
-				&dashboards.FakeDashboardService{}, imageService)
+				&dashboards.FakeDashboardService{}, imageService, &store.FakeStateHistoryStore{})
 			err := mgr.maybeTakeScreenshot(context.Background(), &ngmodels.AlertRule{}, test.state, test.oldState)
 			require.NoError(t, err)
 			if !test.shouldScreenshot {
@@ -104,3 +106,19 @@ func Test_maybeNewImage(t *testing.T) {
 		})
 	}
 }
+
+func TestManager_CloseSavesQueuedStateHistory(t *testing.T) {
+	historyStore := &store.FakeStateHistoryStore{}
+	mgr := NewManager(log.NewNopLogger(), &metrics.State{}, nil,
+		&store.FakeRuleStore{}, &store.FakeInstanceStore{}, mockstore.NewSQLStoreMock(),
+		&dashboards.FakeDashboardService{}, &CountingImageService{}, historyStore)
+
+	rule := &ngmodels.AlertRule{OrgID: 1, UID: "rule"}
+	transitions := 3 * stateHistoryBatchSize
+	for i := 0; i < transitions; i++ {
+		mgr.recordStateHistory(rule, data.Labels{"instance": fmt.Sprint(i)}, nil, time.Now(),
+			InstanceStateAndReason{State: eval.Alerting}, InstanceStateAndReason{State: eval.Normal})
+	}
+	mgr.Close()
+	require.Len(t, historyStore.GetHistory(), transitions)
+}
@@ -37,7 +37,7 @@ func TestDashboardAnnotations(t *testing.T) {
 	_, dbstore := tests.SetupTestEnv(t, 1)
 
 	sqlStore := mockstore.NewSQLStoreMock()
-	st := state.NewManager(log.New("test_stale_results_handler"), testMetrics.GetStateMetrics(), nil, dbstore, dbstore, sqlStore, &dashboards.FakeDashboardService{}, &image.NoopImageService{})
+	st := state.NewManager(log.New("test_stale_results_handler"), testMetrics.GetStateMetrics(), nil, dbstore, dbstore, sqlStore, &dashboards.FakeDashboardService{}, &image.NoopImageService{}, &store.FakeStateHistoryStore{})
 
 	fakeAnnoRepo := store.NewFakeAnnotationsRepo()
 	annotations.SetRepository(fakeAnnoRepo)
@@ -1779,7 +1779,8 @@ func TestProcessEvalResults(t *testing.T) {
 
 	for _, tc := range testCases {
 		ss := mockstore.NewSQLStoreMock()
-		st := state.NewManager(log.New("test_state_manager"), testMetrics.GetStateMetrics(), nil, nil, &store.FakeInstanceStore{}, ss, &dashboards.FakeDashboardService{}, &image.NotAvailableImageService{})
+		historyStore := &store.FakeStateHistoryStore{}
+		st := state.NewManager(log.New("test_state_manager"), testMetrics.GetStateMetrics(), nil, nil, &store.FakeInstanceStore{}, ss, &dashboards.FakeDashboardService{}, &image.NotAvailableImageService{}, historyStore)
 		t.Run(tc.desc, func(t *testing.T) {
 			fakeAnnoRepo := store.NewFakeAnnotationsRepo()
 			annotations.SetRepository(fakeAnnoRepo)
@@ -1800,6 +1801,16 @@ func TestProcessEvalResults(t *testing.T) {
 			require.Eventuallyf(t, func() bool {
 				return tc.expectedAnnotations == fakeAnnoRepo.Len()
 			}, time.Second, 100*time.Millisecond, "%d annotations are present, expected %d. We have %+v", fakeAnnoRepo.Len(), tc.expectedAnnotations, printAllAnnotations(fakeAnnoRepo.Items))
+
+			// Every transition that is annotated is also recorded in the state history.
+			require.Eventuallyf(t, func() bool {
+				return tc.expectedAnnotations == len(historyStore.GetHistory())
+			}, time.Second, 100*time.Millisecond, "%d transitions are recorded, expected %d", len(historyStore.GetHistory()), tc.expectedAnnotations)
+			for _, h := range historyStore.GetHistory() {
+				require.Equal(t, tc.alertRule.UID, h.RuleUID)
+				require.Equal(t, tc.alertRule.NamespaceUID, h.NamespaceUID)
+				require.False(t, h.PreviousState == h.State && h.PreviousReason == h.Reason)
+			}
 		})
 	}
 }
@@ -1900,7 +1911,7 @@ func TestStaleResultsHandler(t *testing.T) {
 	for _, tc := range testCases {
 		ctx := context.Background()
 		sqlStore := mockstore.NewSQLStoreMock()
-		st := state.NewManager(log.New("test_stale_results_handler"), testMetrics.GetStateMetrics(), nil, dbstore, dbstore, sqlStore, &dashboards.FakeDashboardService{}, &image.NoopImageService{})
+		st := state.NewManager(log.New("test_stale_results_handler"), testMetrics.GetStateMetrics(), nil, dbstore, dbstore, sqlStore, &dashboards.FakeDashboardService{}, &image.NoopImageService{}, &store.FakeStateHistoryStore{})
 		st.Warm(ctx)
 		existingStatesForRule := st.GetStatesForRuleUID(rule.OrgID, rule.UID)
 
@@ -1920,3 +1931,43 @@ func TestStaleResultsHandler(t *testing.T) {
 		assert.Equal(t, tc.finalStateCount, len(existingStatesForRule))
 	}
 }
+
+func TestLoadStoredStates(t *testing.T) {
+	evaluationTime, err := time.Parse("2006-01-02", "2021-03-25")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, dbstore := tests.SetupTestEnv(t, 1)
+
+	const mainOrgID int64 = 1
+	rule := tests.CreateTestAlertRule(t, ctx, dbstore, 600, mainOrgID)
+	other := tests.CreateTestAlertRule(t, ctx, dbstore, 600, mainOrgID)
+
+	require.NoError(t, dbstore.SaveAlertInstance(ctx, &models.SaveAlertInstanceCommand{
+		RuleOrgID:         rule.OrgID,
+		RuleUID:           rule.UID,
+		Labels:            models.InstanceLabels{"test1": "testValue1"},
+		State:             models.InstanceStateFiring,
+		LastEvalTime:      evaluationTime,
+		CurrentStateSince: evaluationTime.Add(-1 * time.Minute),
+		CurrentStateEnd:   evaluationTime.Add(1 * time.Minute),
+	}))
+
+	sqlStore := mockstore.NewSQLStoreMock()
+	st := state.NewManager(log.New("test_load_stored_states"), testMetrics.GetStateMetrics(), nil, dbstore, dbstore, sqlStore, &dashboards.FakeDashboardService{}, &image.NoopImageService{}, &store.FakeStateHistoryStore{})
+	st.Put([]*state.State{
+		{AlertRuleUID: rule.UID, OrgID: rule.OrgID, CacheId: "stale", State: eval.Alerting},
+		{AlertRuleUID: other.UID, OrgID: other.OrgID, CacheId: "other", State: eval.Alerting},
+	})
+
+	require.NoError(t, st.LoadStoredStates(ctx, mainOrgID, map[string]struct{}{rule.UID: {}}))
+
+	states := st.GetStatesForRuleUID(rule.OrgID, rule.UID)
+	require.Len(t, states, 1)
+	require.Equal(t, eval.Alerting, states[0].State)
+	require.Equal(t, data.Labels{"test1": "testValue1"}, states[0].Labels)
+	require.Equal(t, rule.Annotations, states[0].Annotations)
+
+	// the states of the other rules are not touched
+	require.Len(t, st.GetStatesForRuleUID(other.OrgID, other.UID), 1)
+}
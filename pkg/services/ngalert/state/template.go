@@ -12,6 +12,7 @@ import (
 	text_template "text/template"
 
 	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	ngModels "github.com/grafana/grafana/pkg/services/ngalert/models"
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/pkg/timestamp"
 	"github.com/prometheus/prometheus/promql"
@@ -70,6 +71,33 @@ func expandTemplate(ctx context.Context, name, text string, labels map[string]st
 	return expander.Expand()
 }
 
+// ExpandRecordedLabels expands the templates in the labels of a recording rule for one of the recorded series.
+// $labels are the labels of the series, and $value and $values the recorded value. The original template
+// is kept for the labels that fail to expand, and the first error is returned.
+func ExpandRecordedLabels(ctx context.Context, alertRule *ngModels.AlertRule, seriesLabels map[string]string, value float64, evaluatedAt time.Time, externalURL *url.URL) (map[string]string, error) {
+	result := eval.Result{
+		Values: map[string]eval.NumberValueCapture{
+			alertRule.Record.From: {Var: alertRule.Record.From, Labels: seriesLabels, Value: &value},
+		},
+		EvaluatedAt:      evaluatedAt,
+		EvaluationString: strconv.FormatFloat(value, 'f', -1, 64),
+	}
+
+	var firstErr error
+	expanded := make(map[string]string, len(alertRule.Labels))
+	for k, v := range alertRule.Labels {
+		ev, err := expandTemplate(ctx, alertRule.Title, v, seriesLabels, result, externalURL)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to expand label %s: %w", k, err)
+			}
+			ev = v
+		}
+		expanded[k] = ev
+	}
+	return expanded, firstErr
+}
+
 func newTemplateCaptureValues(values map[string]eval.NumberValueCapture) map[string]templateCaptureValue {
 	m := make(map[string]templateCaptureValue)
 	for k, v := range values {
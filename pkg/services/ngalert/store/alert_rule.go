@@ -13,6 +13,7 @@ import (
 	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
 	"github.com/grafana/grafana/pkg/services/sqlstore"
 	"github.com/grafana/grafana/pkg/services/sqlstore/searchstore"
+	storage "github.com/grafana/grafana/pkg/services/store"
 	"github.com/grafana/grafana/pkg/util"
 )
 
@@ -74,7 +75,7 @@ func getAlertRuleByUID(sess *sqlstore.DBSession, alertRuleUID string, orgID int6
 // DeleteAlertRulesByUID is a handler for deleting an alert rule.
 func (st DBstore) DeleteAlertRulesByUID(ctx context.Context, orgID int64, ruleUID ...string) error {
 	logger := st.Logger.New("org_id", orgID, "rule_uids", ruleUID)
-	return st.SQLStore.WithTransactionalDbSession(ctx, func(sess *sqlstore.DBSession) error {
+	err := st.SQLStore.WithTransactionalDbSession(ctx, func(sess *sqlstore.DBSession) error {
 		rows, err := sess.Table("alert_rule").Where("org_id = ?", orgID).In("uid", ruleUID).Delete(ngmodels.AlertRule{})
 		if err != nil {
 			return err
@@ -92,8 +93,21 @@ func (st DBstore) DeleteAlertRulesByUID(ctx context.Context, orgID int64, ruleUI
 			return err
 		}
 		logger.Debug("deleted alert instances", "count", rows)
+
+		rows, err = sess.Table("alert_rule_template_instance").Where("org_id = ?", orgID).In("rule_uid", ruleUID).Delete(ngmodels.AlertRuleTemplateInstance{})
+		if err != nil {
+			return err
+		}
+		logger.Debug("deleted alert rule template instances", "count", rows)
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+	for _, uid := range ruleUID {
+		st.saveEntityEvent(ctx, orgID, uid, storage.EntityEventTypeDelete)
+	}
+	return nil
 }
 
 // DeleteAlertInstanceByRuleUID is a handler for deleting alert instances by alert rule UID when a rule has been updated
@@ -139,8 +153,9 @@ func (st DBstore) GetAlertRulesGroupByRuleUID(ctx context.Context, query *ngmode
 // InsertAlertRules is a handler for creating/updating alert rules.
 func (st DBstore) InsertAlertRules(ctx context.Context, rules []ngmodels.AlertRule) (map[string]int64, error) {
 	ids := make(map[string]int64, len(rules))
-	return ids, st.SQLStore.WithTransactionalDbSession(ctx, func(sess *sqlstore.DBSession) error {
-		newRules := make([]ngmodels.AlertRule, 0, len(rules))
+	var newRules []ngmodels.AlertRule
+	err := st.SQLStore.WithTransactionalDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		newRules = make([]ngmodels.AlertRule, 0, len(rules))
 		ruleVersions := make([]ngmodels.AlertRuleVersion, 0, len(rules))
 		for i := range rules {
 			r := rules[i]
@@ -176,6 +191,8 @@ func (st DBstore) InsertAlertRules(ctx context.Context, rules []ngmodels.AlertRu
 				For:              r.For,
 				Annotations:      r.Annotations,
 				Labels:           r.Labels,
+				RuleGroupIndex:   r.RuleGroupIndex,
+				Record:           r.Record,
 			})
 		}
 		if len(newRules) > 0 {
@@ -199,11 +216,18 @@ func (st DBstore) InsertAlertRules(ctx context.Context, rules []ngmodels.AlertRu
 		}
 		return nil
 	})
+	if err != nil {
+		return ids, err
+	}
+	for _, r := range newRules {
+		st.saveEntityEvent(ctx, r.OrgID, r.UID, storage.EntityEventTypeCreate)
+	}
+	return ids, nil
 }
 
 // UpdateAlertRules is a handler for updating alert rules.
 func (st DBstore) UpdateAlertRules(ctx context.Context, rules []UpdateRule) error {
-	return st.SQLStore.WithTransactionalDbSession(ctx, func(sess *sqlstore.DBSession) error {
+	err := st.SQLStore.WithTransactionalDbSession(ctx, func(sess *sqlstore.DBSession) error {
 		ruleVersions := make([]ngmodels.AlertRuleVersion, 0, len(rules))
 		for _, r := range rules {
 			var parentVersion int64
@@ -240,6 +264,8 @@ func (st DBstore) UpdateAlertRules(ctx context.Context, rules []UpdateRule) erro
 				For:              r.New.For,
 				Annotations:      r.New.Annotations,
 				Labels:           r.New.Labels,
+				RuleGroupIndex:   r.New.RuleGroupIndex,
+				Record:           r.New.Record,
 			})
 		}
 		if len(ruleVersions) > 0 {
@@ -249,6 +275,27 @@ func (st DBstore) UpdateAlertRules(ctx context.Context, rules []UpdateRule) erro
 		}
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+	for _, r := range rules {
+		st.saveEntityEvent(ctx, r.New.OrgID, r.New.UID, storage.EntityEventTypeUpdate)
+	}
+	return nil
+}
+
+// saveEntityEvent records a change of an alert rule so that the search index can be updated.
+// It is called with the context of the write, so the event is part of an enclosing transaction.
+func (st DBstore) saveEntityEvent(ctx context.Context, orgID int64, uid string, eventType storage.EntityEventType) {
+	if st.EntityEvents == nil {
+		return
+	}
+	if err := st.EntityEvents.SaveEvent(ctx, storage.SaveEventCmd{
+		EntityId:  storage.CreateDatabaseEntityId(uid, orgID, storage.EntityTypeAlertRule),
+		EventType: eventType,
+	}); err != nil {
+		st.Logger.Warn("failed to save alert rule entity event", "uid", uid, "error", err)
+	}
 }
 
 // GetOrgAlertRules is a handler for retrieving alert rules of specific organisation.
@@ -281,7 +328,11 @@ func (st DBstore) ListAlertRules(ctx context.Context, query *ngmodels.ListAlertR
 			q = q.Where("rule_group = ?", query.RuleGroup)
 		}
 
-		q = q.OrderBy("id ASC")
+		if len(query.RuleUIDs) > 0 {
+			q = q.In("uid", query.RuleUIDs)
+		}
+
+		q = q.OrderBy("rule_group_idx ASC, id ASC")
 
 		alertRules := make([]*ngmodels.AlertRule, 0)
 		if err := q.Find(&alertRules); err != nil {
@@ -475,5 +526,11 @@ func (st DBstore) validateAlertRule(alertRule ngmodels.AlertRule) error {
 		return err
 	}
 
+	if alertRule.Record != nil {
+		if err := alertRule.Record.Validate(alertRule.Data); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
@@ -17,6 +17,8 @@ var (
 	// ErrVersionLockedObjectNotFound is returned when an object is not
 	// found using the current hash.
 	ErrVersionLockedObjectNotFound = fmt.Errorf("could not find object using provided id and hash")
+	// ErrAlertmanagerConfigurationVersionNotFound is returned when a version of the alertmanager configuration does not exist.
+	ErrAlertmanagerConfigurationVersionNotFound = fmt.Errorf("could not find the version of the Alertmanager configuration")
 )
 
 // GetLatestAlertmanagerConfiguration returns the lastest version of the alertmanager configuration.
@@ -55,6 +57,43 @@ func (st *DBstore) GetAllLatestAlertmanagerConfiguration(ctx context.Context) ([
 	return result, nil
 }
 
+// GetAlertmanagerConfigurationHistory returns the versions of the alertmanager configuration of an organization, the latest first.
+// The configurations themselves are not loaded. If limit is positive, at most limit versions are returned.
+func (st *DBstore) GetAlertmanagerConfigurationHistory(ctx context.Context, orgID int64, limit int) ([]*models.AlertConfiguration, error) {
+	var result []*models.AlertConfiguration
+	err := st.SQLStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		q := sess.Table("alert_configuration").Omit("alertmanager_configuration").Where("org_id = ?", orgID).Desc("id")
+		if limit > 0 {
+			q = q.Limit(limit)
+		}
+		return q.Find(&result)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetAlertmanagerConfigurationVersion returns a version of the alertmanager configuration of an organization.
+// It returns ErrAlertmanagerConfigurationVersionNotFound if the version does not exist.
+func (st *DBstore) GetAlertmanagerConfigurationVersion(ctx context.Context, orgID int64, id int64) (*models.AlertConfiguration, error) {
+	c := &models.AlertConfiguration{}
+	err := st.SQLStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		ok, err := sess.Table("alert_configuration").Where("org_id = ? AND id = ?", orgID, id).Get(c)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrAlertmanagerConfigurationVersionNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
 // SaveAlertmanagerConfiguration creates an alertmanager configuration.
 func (st DBstore) SaveAlertmanagerConfiguration(ctx context.Context, cmd *models.SaveAlertmanagerConfigurationCmd) error {
 	return st.SaveAlertmanagerConfigurationWithCallback(ctx, cmd, func() error { return nil })
@@ -72,6 +111,7 @@ func (st DBstore) SaveAlertmanagerConfigurationWithCallback(ctx context.Context,
 			ConfigurationVersion:      cmd.ConfigurationVersion,
 			Default:                   cmd.Default,
 			OrgID:                     cmd.OrgID,
+			CreatedBy:                 cmd.CreatedBy,
 		}
 		if _, err := sess.Insert(config); err != nil {
 			return err
@@ -85,6 +125,9 @@ func (st DBstore) SaveAlertmanagerConfigurationWithCallback(ctx context.Context,
 	})
 }
 
+// UpdateAlertmanagerConfiguration inserts a new version of the alertmanager configuration if the hash of the latest
+// version is cmd.FetchedConfigurationHash, and returns ErrVersionLockedObjectNotFound otherwise. The previous versions,
+// including their authors, are kept as they are, so that the change is listed in the configuration history.
 func (st *DBstore) UpdateAlertmanagerConfiguration(ctx context.Context, cmd *models.SaveAlertmanagerConfigurationCmd) error {
 	return st.SQLStore.WithTransactionalDbSession(ctx, func(sess *sqlstore.DBSession) error {
 		config := models.AlertConfiguration{
@@ -93,6 +136,7 @@ func (st *DBstore) UpdateAlertmanagerConfiguration(ctx context.Context, cmd *mod
 			ConfigurationVersion:      cmd.ConfigurationVersion,
 			Default:                   cmd.Default,
 			OrgID:                     cmd.OrgID,
+			CreatedBy:                 cmd.CreatedBy,
 		}
 		rows, err := sess.Table("alert_configuration").Where(`
 			EXISTS (
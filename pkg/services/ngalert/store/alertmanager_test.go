@@ -79,3 +79,91 @@ func TestIntegrationAlertManagerHash(t *testing.T) {
 		require.EqualError(t, ErrVersionLockedObjectNotFound, err.Error())
 	})
 }
+
+func TestIntegrationAlertmanagerConfigurationHistory(t *testing.T) {
+	sqlStore := sqlstore.InitTestDB(t)
+	store := &DBstore{
+		SQLStore: sqlStore,
+	}
+	ctx := context.Background()
+	for _, c := range []struct {
+		orgID     int64
+		config    string
+		createdBy string
+	}{{1, "config-1", ""}, {1, "config-2", "admin"}, {2, "config-3", "editor"}} {
+		err := store.SaveAlertmanagerConfiguration(ctx, &models.SaveAlertmanagerConfigurationCmd{
+			AlertmanagerConfiguration: c.config,
+			ConfigurationVersion:      "v1",
+			OrgID:                     c.orgID,
+			CreatedBy:                 c.createdBy,
+		})
+		require.NoError(t, err)
+	}
+
+	history, err := store.GetAlertmanagerConfigurationHistory(ctx, 1, 0)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	require.Equal(t, "admin", history[0].CreatedBy)
+	require.Equal(t, "", history[1].CreatedBy)
+	require.Empty(t, history[0].AlertmanagerConfiguration)
+	require.Greater(t, history[0].ID, history[1].ID)
+
+	history, err = store.GetAlertmanagerConfigurationHistory(ctx, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+
+	version, err := store.GetAlertmanagerConfigurationVersion(ctx, 1, history[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, "config-2", version.AlertmanagerConfiguration)
+
+	_, err = store.GetAlertmanagerConfigurationVersion(ctx, 2, history[0].ID)
+	require.ErrorIs(t, err, ErrAlertmanagerConfigurationVersionNotFound)
+}
+
+func TestIntegrationAlertmanagerConfigurationHistoryOfProvisioning(t *testing.T) {
+	sqlStore := sqlstore.InitTestDB(t)
+	store := &DBstore{
+		SQLStore: sqlStore,
+	}
+	ctx := context.Background()
+	err := store.SaveAlertmanagerConfiguration(ctx, &models.SaveAlertmanagerConfigurationCmd{
+		AlertmanagerConfiguration: "config-1",
+		ConfigurationVersion:      "v1",
+		OrgID:                     1,
+		CreatedBy:                 "admin",
+	})
+	require.NoError(t, err)
+	latest := &models.GetLatestAlertmanagerConfigurationQuery{OrgID: 1}
+	require.NoError(t, store.GetLatestAlertmanagerConfiguration(ctx, latest))
+
+	// Provisioning changes the configuration without an author.
+	err = store.UpdateAlertmanagerConfiguration(ctx, &models.SaveAlertmanagerConfigurationCmd{
+		AlertmanagerConfiguration: "config-2",
+		ConfigurationVersion:      "v1",
+		FetchedConfigurationHash:  latest.Result.ConfigurationHash,
+		OrgID:                     1,
+	})
+	require.NoError(t, err)
+
+	// A change based on an outdated version is rejected and not listed.
+	err = store.UpdateAlertmanagerConfiguration(ctx, &models.SaveAlertmanagerConfigurationCmd{
+		AlertmanagerConfiguration: "config-3",
+		ConfigurationVersion:      "v1",
+		FetchedConfigurationHash:  latest.Result.ConfigurationHash,
+		OrgID:                     1,
+	})
+	require.ErrorIs(t, err, ErrVersionLockedObjectNotFound)
+
+	history, err := store.GetAlertmanagerConfigurationHistory(ctx, 1, 0)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	require.Equal(t, "", history[0].CreatedBy)
+	require.Equal(t, "admin", history[1].CreatedBy)
+
+	provisioned, err := store.GetAlertmanagerConfigurationVersion(ctx, 1, history[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, "config-2", provisioned.AlertmanagerConfiguration)
+	previous, err := store.GetAlertmanagerConfigurationVersion(ctx, 1, history[1].ID)
+	require.NoError(t, err)
+	require.Equal(t, "config-1", previous.AlertmanagerConfiguration)
+}
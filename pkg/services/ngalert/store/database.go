@@ -9,6 +9,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/dashboards"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
 	"github.com/grafana/grafana/pkg/services/sqlstore"
+	storage "github.com/grafana/grafana/pkg/services/store"
 )
 
 // TimeNow makes it possible to test usage of time
@@ -24,6 +25,8 @@ type AlertingStore interface {
 	SaveAlertmanagerConfiguration(ctx context.Context, cmd *models.SaveAlertmanagerConfigurationCmd) error
 	SaveAlertmanagerConfigurationWithCallback(ctx context.Context, cmd *models.SaveAlertmanagerConfigurationCmd, callback SaveCallback) error
 	UpdateAlertmanagerConfiguration(ctx context.Context, cmd *models.SaveAlertmanagerConfigurationCmd) error
+	GetAlertmanagerConfigurationHistory(ctx context.Context, orgID int64, limit int) ([]*models.AlertConfiguration, error)
+	GetAlertmanagerConfigurationVersion(ctx context.Context, orgID int64, id int64) (*models.AlertConfiguration, error)
 }
 
 // DBstore stores the alert definitions and instances in the database.
@@ -37,4 +40,6 @@ type DBstore struct {
 	FolderService    dashboards.FolderService
 	AccessControl    accesscontrol.AccessControl
 	DashboardService dashboards.DashboardService
+	// EntityEvents is optional and used to notify the search index about changed alert rules.
+	EntityEvents storage.EntityEventsService
 }
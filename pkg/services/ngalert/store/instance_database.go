@@ -70,6 +70,15 @@ func (st DBstore) ListAlertInstances(ctx context.Context, cmd *models.ListAlertI
 			addToQuery(` AND rule_uid = ?`, cmd.RuleUID)
 		}
 
+		if len(cmd.RuleUIDs) > 0 {
+			in := make([]string, 0, len(cmd.RuleUIDs))
+			for _, uid := range cmd.RuleUIDs {
+				in = append(in, "?")
+				params = append(params, uid)
+			}
+			addToQuery(` AND rule_uid IN (` + strings.Join(in, ",") + `)`)
+		}
+
 		if cmd.State != "" {
 			addToQuery(` AND current_state = ?`, cmd.State)
 		}
@@ -0,0 +1,143 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/sqlstore"
+)
+
+// NotificationDeliveryStore persists the deliveries of notifications by the integrations of receivers.
+type NotificationDeliveryStore interface {
+	SaveNotificationDelivery(ctx context.Context, delivery *models.NotificationDelivery) error
+	GetNotificationDelivery(ctx context.Context, orgID, id int64) (*models.NotificationDelivery, error)
+	ListNotificationDeliveries(ctx context.Context, query *models.ListNotificationDeliveriesQuery) error
+	GetDueNotificationDeliveries(ctx context.Context, orgID int64, now time.Time, limit int) ([]*models.NotificationDelivery, error)
+	ClaimNotificationDelivery(ctx context.Context, delivery *models.NotificationDelivery, until time.Time) (bool, error)
+	DeleteNotificationDeliveriesOlderThan(ctx context.Context, olderThan time.Time) (int64, error)
+}
+
+// ProvideNotificationDeliveryStore returns a NotificationDeliveryStore for services outside of ngalert, such as cleanup.
+func ProvideNotificationDeliveryStore(sqlStore *sqlstore.SQLStore) NotificationDeliveryStore {
+	return &DBstore{SQLStore: sqlStore}
+}
+
+// SaveNotificationDelivery inserts the delivery if it has no ID yet, otherwise it updates it.
+func (st DBstore) SaveNotificationDelivery(ctx context.Context, delivery *models.NotificationDelivery) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		if delivery.ID == 0 {
+			if _, err := sess.Insert(delivery); err != nil {
+				return fmt.Errorf("failed to insert notification delivery: %w", err)
+			}
+			return nil
+		}
+		if _, err := sess.ID(delivery.ID).AllCols().Update(delivery); err != nil {
+			return fmt.Errorf("failed to update notification delivery: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetNotificationDelivery returns the delivery with the given ID, or models.ErrNotificationDeliveryNotFound.
+func (st DBstore) GetNotificationDelivery(ctx context.Context, orgID, id int64) (*models.NotificationDelivery, error) {
+	var delivery models.NotificationDelivery
+	err := st.SQLStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		has, err := sess.Where("org_id = ? AND id = ?", orgID, id).Get(&delivery)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return models.ErrNotificationDeliveryNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// ListNotificationDeliveries returns a page of the deliveries that match the query, most recent first.
+func (st DBstore) ListNotificationDeliveries(ctx context.Context, query *models.ListNotificationDeliveriesQuery) error {
+	if query.Limit <= 0 {
+		return fmt.Errorf("limit must be greater than 0")
+	}
+	page := query.Page
+	if page < 1 {
+		page = 1
+	}
+
+	return st.SQLStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		q := sess.Where("org_id = ?", query.OrgID)
+		if query.Receiver != "" {
+			q = q.And("receiver = ?", query.Receiver)
+		}
+		if query.IntegrationUID != "" {
+			q = q.And("integration_uid = ?", query.IntegrationUID)
+		}
+		if query.Status != "" {
+			q = q.And("status = ?", query.Status)
+		}
+		if !query.From.IsZero() {
+			q = q.And("created_at >= ?", query.From.UnixMilli())
+		}
+		if !query.To.IsZero() {
+			q = q.And("created_at <= ?", query.To.UnixMilli())
+		}
+		var rows []*models.NotificationDelivery
+		if err := q.Desc("created_at", "id").Limit(query.Limit, (page-1)*query.Limit).Find(&rows); err != nil {
+			return err
+		}
+		query.Result = rows
+		return nil
+	})
+}
+
+// GetDueNotificationDeliveries returns the deliveries in the retry queue whose next attempt is due, oldest first.
+func (st DBstore) GetDueNotificationDeliveries(ctx context.Context, orgID int64, now time.Time, limit int) ([]*models.NotificationDelivery, error) {
+	var rows []*models.NotificationDelivery
+	err := st.SQLStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		return sess.Where("org_id = ? AND status = ? AND next_attempt_at <= ?", orgID, models.NotificationDeliveryRetrying, now.UnixMilli()).
+			Asc("next_attempt_at", "id").
+			Limit(limit).
+			Find(&rows)
+	})
+	return rows, err
+}
+
+// ClaimNotificationDelivery moves the next attempt of a delivery in the retry queue to until, unless another
+// Grafana instance has done so since the delivery was read. It returns true if the claim succeeded, in which
+// case the caller is the only one to retry the delivery until then.
+func (st DBstore) ClaimNotificationDelivery(ctx context.Context, delivery *models.NotificationDelivery, until time.Time) (bool, error) {
+	var claimed bool
+	err := st.SQLStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		n, err := sess.Table(&models.NotificationDelivery{}).
+			Where("id = ? AND status = ? AND next_attempt_at = ?", delivery.ID, models.NotificationDeliveryRetrying, delivery.NextAttemptAt).
+			Update(map[string]interface{}{"next_attempt_at": until.UnixMilli()})
+		if err != nil {
+			return err
+		}
+		claimed = n == 1
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	if claimed {
+		delivery.NextAttemptAt = until.UnixMilli()
+	}
+	return claimed, nil
+}
+
+// DeleteNotificationDeliveriesOlderThan deletes the deliveries created before olderThan and returns how many were deleted.
+func (st DBstore) DeleteNotificationDeliveriesOlderThan(ctx context.Context, olderThan time.Time) (int64, error) {
+	var deleted int64
+	err := st.SQLStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		n, err := sess.Where("created_at < ?", olderThan.UnixMilli()).Delete(&models.NotificationDelivery{})
+		deleted = n
+		return err
+	})
+	return deleted, err
+}
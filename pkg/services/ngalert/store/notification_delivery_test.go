@@ -0,0 +1,141 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/tests"
+)
+
+func TestIntegrationNotificationDelivery(t *testing.T) {
+	ctx := context.Background()
+	_, dbstore := tests.SetupTestEnv(t, baseIntervalSeconds)
+
+	start := time.Unix(1000, 0).UTC()
+	for i := 0; i < 6; i++ {
+		receiver := "team-a"
+		if i%2 == 1 {
+			receiver = "team-b"
+		}
+		status := models.NotificationDeliverySuccess
+		if i >= 4 {
+			status = models.NotificationDeliveryRetrying
+		}
+		require.NoError(t, dbstore.SaveNotificationDelivery(ctx, &models.NotificationDelivery{
+			OrgID:             1,
+			Receiver:          receiver,
+			IntegrationUID:    "uid-" + receiver,
+			IntegrationType:   "webhook",
+			GroupKey:          "{}:{alertname=\"test\"}",
+			GroupLabels:       map[string]string{"alertname": "test"},
+			AlertFingerprints: []string{"a", "b"},
+			Status:            status,
+			Attempts:          1,
+			CreatedAt:         start.Add(time.Duration(i) * time.Minute).UnixMilli(),
+			LastAttemptAt:     start.Add(time.Duration(i) * time.Minute).UnixMilli(),
+			NextAttemptAt:     start.Add(time.Duration(i) * time.Hour).UnixMilli(),
+		}))
+	}
+	require.NoError(t, dbstore.SaveNotificationDelivery(ctx, &models.NotificationDelivery{
+		OrgID:     2,
+		Receiver:  "team-a",
+		Status:    models.NotificationDeliveryFailed,
+		CreatedAt: start.UnixMilli(),
+	}))
+
+	createdAt := func(deliveries []*models.NotificationDelivery) []int64 {
+		result := make([]int64, 0, len(deliveries))
+		for _, d := range deliveries {
+			result = append(result, (d.CreatedAt-start.UnixMilli())/time.Minute.Milliseconds())
+		}
+		return result
+	}
+
+	t.Run("should list the most recent deliveries of the org first", func(t *testing.T) {
+		q := &models.ListNotificationDeliveriesQuery{OrgID: 1, Limit: 4}
+		require.NoError(t, dbstore.ListNotificationDeliveries(ctx, q))
+		require.Equal(t, []int64{5, 4, 3, 2}, createdAt(q.Result))
+		require.Equal(t, []string{"a", "b"}, q.Result[0].AlertFingerprints)
+		require.Equal(t, map[string]string{"alertname": "test"}, q.Result[0].GroupLabels)
+
+		q.Page = 2
+		require.NoError(t, dbstore.ListNotificationDeliveries(ctx, q))
+		require.Equal(t, []int64{1, 0}, createdAt(q.Result))
+	})
+
+	t.Run("should filter by receiver, status and time range", func(t *testing.T) {
+		q := &models.ListNotificationDeliveriesQuery{OrgID: 1, Receiver: "team-a", Limit: 10}
+		require.NoError(t, dbstore.ListNotificationDeliveries(ctx, q))
+		require.Equal(t, []int64{4, 2, 0}, createdAt(q.Result))
+
+		q = &models.ListNotificationDeliveriesQuery{OrgID: 1, Status: models.NotificationDeliveryRetrying, Limit: 10}
+		require.NoError(t, dbstore.ListNotificationDeliveries(ctx, q))
+		require.Equal(t, []int64{5, 4}, createdAt(q.Result))
+
+		q = &models.ListNotificationDeliveriesQuery{OrgID: 1, From: start.Add(time.Minute), To: start.Add(2 * time.Minute), Limit: 10}
+		require.NoError(t, dbstore.ListNotificationDeliveries(ctx, q))
+		require.Equal(t, []int64{2, 1}, createdAt(q.Result))
+	})
+
+	t.Run("should get a delivery of the org by ID", func(t *testing.T) {
+		q := &models.ListNotificationDeliveriesQuery{OrgID: 2, Limit: 1}
+		require.NoError(t, dbstore.ListNotificationDeliveries(ctx, q))
+		require.Len(t, q.Result, 1)
+
+		d, err := dbstore.GetNotificationDelivery(ctx, 2, q.Result[0].ID)
+		require.NoError(t, err)
+		require.Equal(t, models.NotificationDeliveryFailed, d.Status)
+
+		_, err = dbstore.GetNotificationDelivery(ctx, 1, q.Result[0].ID)
+		require.ErrorIs(t, err, models.ErrNotificationDeliveryNotFound)
+	})
+
+	t.Run("should return the due deliveries of the retry queue and claim them once", func(t *testing.T) {
+		due, err := dbstore.GetDueNotificationDeliveries(ctx, 1, start.Add(4*time.Hour), 10)
+		require.NoError(t, err)
+		require.Equal(t, []int64{4}, createdAt(due))
+
+		stale := *due[0]
+		claimed, err := dbstore.ClaimNotificationDelivery(ctx, due[0], start.Add(10*time.Hour))
+		require.NoError(t, err)
+		require.True(t, claimed)
+		require.Equal(t, start.Add(10*time.Hour).UnixMilli(), due[0].NextAttemptAt)
+
+		claimed, err = dbstore.ClaimNotificationDelivery(ctx, &stale, start.Add(10*time.Hour))
+		require.NoError(t, err)
+		require.False(t, claimed)
+
+		due, err = dbstore.GetDueNotificationDeliveries(ctx, 1, start.Add(4*time.Hour), 10)
+		require.NoError(t, err)
+		require.Empty(t, due)
+	})
+
+	t.Run("should update a delivery", func(t *testing.T) {
+		q := &models.ListNotificationDeliveriesQuery{OrgID: 1, Limit: 1}
+		require.NoError(t, dbstore.ListNotificationDeliveries(ctx, q))
+		d := q.Result[0]
+		d.Status = models.NotificationDeliveryFailed
+		d.Attempts = 3
+		d.NextAttemptAt = 0
+		d.Error = "connection refused"
+		require.NoError(t, dbstore.SaveNotificationDelivery(ctx, d))
+
+		updated, err := dbstore.GetNotificationDelivery(ctx, 1, d.ID)
+		require.NoError(t, err)
+		require.Equal(t, d, updated)
+	})
+
+	t.Run("should delete deliveries older than the given time", func(t *testing.T) {
+		deleted, err := dbstore.DeleteNotificationDeliveriesOlderThan(ctx, start.Add(3*time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, int64(4), deleted)
+
+		q := &models.ListNotificationDeliveriesQuery{OrgID: 1, Limit: 10}
+		require.NoError(t, dbstore.ListNotificationDeliveries(ctx, q))
+		require.Equal(t, []int64{5, 4, 3}, createdAt(q.Result))
+	})
+}
@@ -0,0 +1,143 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/sqlstore"
+)
+
+// RuleTemplateStore persists alert rule templates and the links of alert rules to the templates they are created from.
+type RuleTemplateStore interface {
+	ListRuleTemplates(ctx context.Context, orgID int64) ([]*models.AlertRuleTemplate, error)
+	GetRuleTemplate(ctx context.Context, orgID int64, uid string) (*models.AlertRuleTemplate, error)
+	InsertRuleTemplate(ctx context.Context, template *models.AlertRuleTemplate) error
+	UpdateRuleTemplate(ctx context.Context, template *models.AlertRuleTemplate) error
+	DeleteRuleTemplate(ctx context.Context, orgID int64, uid string) error
+	ListRuleTemplateInstances(ctx context.Context, orgID int64, templateUID string) ([]*models.AlertRuleTemplateInstance, error)
+	GetRuleTemplateInstance(ctx context.Context, orgID int64, ruleUID string) (*models.AlertRuleTemplateInstance, error)
+	SaveRuleTemplateInstance(ctx context.Context, instance *models.AlertRuleTemplateInstance) error
+	DeleteRuleTemplateInstance(ctx context.Context, orgID int64, ruleUID string) error
+}
+
+// ListRuleTemplates returns the alert rule templates of the organization ordered by title.
+func (st DBstore) ListRuleTemplates(ctx context.Context, orgID int64) ([]*models.AlertRuleTemplate, error) {
+	templates := make([]*models.AlertRuleTemplate, 0)
+	err := st.SQLStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		return sess.Where("org_id = ?", orgID).Asc("title").Find(&templates)
+	})
+	return templates, err
+}
+
+// GetRuleTemplate returns the alert rule template with the given UID, or models.ErrRuleTemplateNotFound.
+func (st DBstore) GetRuleTemplate(ctx context.Context, orgID int64, uid string) (*models.AlertRuleTemplate, error) {
+	var template models.AlertRuleTemplate
+	err := st.SQLStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		has, err := sess.Where("org_id = ? AND uid = ?", orgID, uid).Get(&template)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return models.ErrRuleTemplateNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (st DBstore) InsertRuleTemplate(ctx context.Context, template *models.AlertRuleTemplate) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		if _, err := sess.Insert(template); err != nil {
+			return fmt.Errorf("failed to insert alert rule template: %w", err)
+		}
+		return nil
+	})
+}
+
+// UpdateRuleTemplate replaces the stored template if its version is the one before the version of the given template,
+// otherwise it returns models.ErrRuleTemplateVersionConflict.
+func (st DBstore) UpdateRuleTemplate(ctx context.Context, template *models.AlertRuleTemplate) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		affected, err := sess.Where("org_id = ? AND uid = ? AND version = ?", template.OrgID, template.UID, template.Version-1).
+			Cols("title", "description", "parameters", "rule", "version", "updated").Update(template)
+		if err != nil {
+			return fmt.Errorf("failed to update alert rule template: %w", err)
+		}
+		if affected == 0 {
+			return models.ErrRuleTemplateVersionConflict
+		}
+		return nil
+	})
+}
+
+// DeleteRuleTemplate deletes the template and its links to alert rules. The alert rules themselves are kept.
+func (st DBstore) DeleteRuleTemplate(ctx context.Context, orgID int64, uid string) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		if _, err := sess.Exec("DELETE FROM alert_rule_template_instance WHERE org_id = ? AND template_uid = ?", orgID, uid); err != nil {
+			return err
+		}
+		affected, err := sess.Where("org_id = ? AND uid = ?", orgID, uid).Delete(&models.AlertRuleTemplate{})
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return models.ErrRuleTemplateNotFound
+		}
+		return nil
+	})
+}
+
+// ListRuleTemplateInstances returns the links of the alert rules created from the template ordered by rule UID.
+func (st DBstore) ListRuleTemplateInstances(ctx context.Context, orgID int64, templateUID string) ([]*models.AlertRuleTemplateInstance, error) {
+	instances := make([]*models.AlertRuleTemplateInstance, 0)
+	err := st.SQLStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		return sess.Where("org_id = ? AND template_uid = ?", orgID, templateUID).Asc("rule_uid").Find(&instances)
+	})
+	return instances, err
+}
+
+// GetRuleTemplateInstance returns the link of the alert rule to its template, or models.ErrRuleTemplateInstanceNotFound.
+func (st DBstore) GetRuleTemplateInstance(ctx context.Context, orgID int64, ruleUID string) (*models.AlertRuleTemplateInstance, error) {
+	var instance models.AlertRuleTemplateInstance
+	err := st.SQLStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		has, err := sess.Where("org_id = ? AND rule_uid = ?", orgID, ruleUID).Get(&instance)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return models.ErrRuleTemplateInstanceNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &instance, nil
+}
+
+// SaveRuleTemplateInstance inserts the link if it has no ID yet, otherwise it updates it.
+func (st DBstore) SaveRuleTemplateInstance(ctx context.Context, instance *models.AlertRuleTemplateInstance) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		if instance.ID == 0 {
+			if _, err := sess.Insert(instance); err != nil {
+				return fmt.Errorf("failed to insert alert rule template instance: %w", err)
+			}
+			return nil
+		}
+		if _, err := sess.ID(instance.ID).AllCols().Update(instance); err != nil {
+			return fmt.Errorf("failed to update alert rule template instance: %w", err)
+		}
+		return nil
+	})
+}
+
+func (st DBstore) DeleteRuleTemplateInstance(ctx context.Context, orgID int64, ruleUID string) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		_, err := sess.Exec("DELETE FROM alert_rule_template_instance WHERE org_id = ? AND rule_uid = ?", orgID, ruleUID)
+		return err
+	})
+}
@@ -0,0 +1,97 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/tests"
+)
+
+func TestIntegrationRuleTemplates(t *testing.T) {
+	ctx := context.Background()
+	_, dbstore := tests.SetupTestEnv(t, baseIntervalSeconds)
+
+	threshold := "80"
+	template := &models.AlertRuleTemplate{
+		OrgID: 1,
+		UID:   "cpu",
+		Title: "High CPU",
+		Parameters: []models.RuleTemplateParameter{
+			{Name: "service", Type: models.RuleTemplateParameterString},
+			{Name: "threshold", Type: models.RuleTemplateParameterNumber, Default: &threshold},
+		},
+		Rule:    `{"title": "High CPU of ${service}"}`,
+		Version: 1,
+		Updated: time.Unix(1000, 0).UTC(),
+	}
+	require.NoError(t, dbstore.InsertRuleTemplate(ctx, template))
+	require.NoError(t, dbstore.InsertRuleTemplate(ctx, &models.AlertRuleTemplate{OrgID: 2, UID: "cpu", Title: "Other org", Rule: "{}", Version: 1, Updated: time.Now()}))
+
+	t.Run("returns the templates of the organization", func(t *testing.T) {
+		templates, err := dbstore.ListRuleTemplates(ctx, 1)
+		require.NoError(t, err)
+		require.Len(t, templates, 1)
+		require.Equal(t, template.Parameters, templates[0].Parameters)
+
+		_, err = dbstore.GetRuleTemplate(ctx, 1, "memory")
+		require.ErrorIs(t, err, models.ErrRuleTemplateNotFound)
+	})
+
+	t.Run("updates a template only if it was not changed concurrently", func(t *testing.T) {
+		update := *template
+		update.Title = "Very high CPU"
+		update.Version = 2
+		require.NoError(t, dbstore.UpdateRuleTemplate(ctx, &update))
+
+		stored, err := dbstore.GetRuleTemplate(ctx, 1, "cpu")
+		require.NoError(t, err)
+		require.Equal(t, "Very high CPU", stored.Title)
+		require.Equal(t, int64(2), stored.Version)
+
+		require.ErrorIs(t, dbstore.UpdateRuleTemplate(ctx, &update), models.ErrRuleTemplateVersionConflict)
+	})
+
+	t.Run("links rules to templates", func(t *testing.T) {
+		for _, ruleUID := range []string{"rule-b", "rule-a"} {
+			require.NoError(t, dbstore.SaveRuleTemplateInstance(ctx, &models.AlertRuleTemplateInstance{
+				OrgID:           1,
+				TemplateUID:     "cpu",
+				RuleUID:         ruleUID,
+				Parameters:      map[string]string{"service": ruleUID},
+				TemplateVersion: 1,
+			}))
+		}
+
+		instance, err := dbstore.GetRuleTemplateInstance(ctx, 1, "rule-a")
+		require.NoError(t, err)
+		instance.TemplateVersion = 2
+		require.NoError(t, dbstore.SaveRuleTemplateInstance(ctx, instance))
+
+		instances, err := dbstore.ListRuleTemplateInstances(ctx, 1, "cpu")
+		require.NoError(t, err)
+		require.Len(t, instances, 2)
+		require.Equal(t, "rule-a", instances[0].RuleUID)
+		require.Equal(t, int64(2), instances[0].TemplateVersion)
+		require.Equal(t, map[string]string{"service": "rule-a"}, instances[0].Parameters)
+
+		require.NoError(t, dbstore.DeleteRuleTemplateInstance(ctx, 1, "rule-b"))
+		_, err = dbstore.GetRuleTemplateInstance(ctx, 1, "rule-b")
+		require.ErrorIs(t, err, models.ErrRuleTemplateInstanceNotFound)
+	})
+
+	t.Run("deleting a template deletes its links", func(t *testing.T) {
+		require.NoError(t, dbstore.DeleteRuleTemplate(ctx, 1, "cpu"))
+		require.ErrorIs(t, dbstore.DeleteRuleTemplate(ctx, 1, "cpu"), models.ErrRuleTemplateNotFound)
+
+		instances, err := dbstore.ListRuleTemplateInstances(ctx, 1, "cpu")
+		require.NoError(t, err)
+		require.Empty(t, instances)
+
+		_, err = dbstore.GetRuleTemplate(ctx, 2, "cpu")
+		require.NoError(t, err)
+	})
+}
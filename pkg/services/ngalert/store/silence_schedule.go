@@ -0,0 +1,102 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/sqlstore"
+)
+
+// SilenceScheduleStore persists the silence schedules of the organizations.
+type SilenceScheduleStore interface {
+	ListSilenceSchedules(ctx context.Context, orgID int64) ([]*models.SilenceSchedule, error)
+	ListAllSilenceSchedules(ctx context.Context) ([]*models.SilenceSchedule, error)
+	GetSilenceSchedule(ctx context.Context, orgID int64, uid string) (*models.SilenceSchedule, error)
+	InsertSilenceSchedule(ctx context.Context, schedule *models.SilenceSchedule) error
+	UpdateSilenceSchedule(ctx context.Context, schedule *models.SilenceSchedule) error
+	DeleteSilenceSchedule(ctx context.Context, orgID int64, uid string) error
+	SetSilenceScheduleSilenceID(ctx context.Context, orgID int64, uid string, silenceID string) error
+}
+
+// ListSilenceSchedules returns the silence schedules of the organization ordered by UID.
+func (st DBstore) ListSilenceSchedules(ctx context.Context, orgID int64) ([]*models.SilenceSchedule, error) {
+	schedules := make([]*models.SilenceSchedule, 0)
+	err := st.SQLStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		return sess.Where("org_id = ?", orgID).Asc("uid").Find(&schedules)
+	})
+	return schedules, err
+}
+
+// ListAllSilenceSchedules returns the silence schedules of all organizations.
+func (st DBstore) ListAllSilenceSchedules(ctx context.Context) ([]*models.SilenceSchedule, error) {
+	schedules := make([]*models.SilenceSchedule, 0)
+	err := st.SQLStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		return sess.Asc("org_id", "uid").Find(&schedules)
+	})
+	return schedules, err
+}
+
+// GetSilenceSchedule returns the silence schedule with the given UID, or models.ErrSilenceScheduleNotFound.
+func (st DBstore) GetSilenceSchedule(ctx context.Context, orgID int64, uid string) (*models.SilenceSchedule, error) {
+	var schedule models.SilenceSchedule
+	err := st.SQLStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		has, err := sess.Where("org_id = ? AND uid = ?", orgID, uid).Get(&schedule)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return models.ErrSilenceScheduleNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+func (st DBstore) InsertSilenceSchedule(ctx context.Context, schedule *models.SilenceSchedule) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		if _, err := sess.Insert(schedule); err != nil {
+			return fmt.Errorf("failed to insert silence schedule: %w", err)
+		}
+		return nil
+	})
+}
+
+// UpdateSilenceSchedule replaces the stored silence schedule with the same UID, or returns models.ErrSilenceScheduleNotFound.
+func (st DBstore) UpdateSilenceSchedule(ctx context.Context, schedule *models.SilenceSchedule) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		affected, err := sess.Where("org_id = ? AND uid = ?", schedule.OrgID, schedule.UID).
+			Cols("comment", "created_by", "matchers", "cron", "duration", "time_intervals", "timezone", "silence_id", "updated").Update(schedule)
+		if err != nil {
+			return fmt.Errorf("failed to update silence schedule: %w", err)
+		}
+		if affected == 0 {
+			return models.ErrSilenceScheduleNotFound
+		}
+		return nil
+	})
+}
+
+func (st DBstore) DeleteSilenceSchedule(ctx context.Context, orgID int64, uid string) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		affected, err := sess.Where("org_id = ? AND uid = ?", orgID, uid).Delete(&models.SilenceSchedule{})
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return models.ErrSilenceScheduleNotFound
+		}
+		return nil
+	})
+}
+
+// SetSilenceScheduleSilenceID records the silence created for the current window of the silence schedule.
+func (st DBstore) SetSilenceScheduleSilenceID(ctx context.Context, orgID int64, uid string, silenceID string) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		_, err := sess.Exec("UPDATE silence_schedule SET silence_id = ? WHERE org_id = ? AND uid = ?", silenceID, orgID, uid)
+		return err
+	})
+}
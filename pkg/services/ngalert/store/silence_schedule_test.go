@@ -0,0 +1,74 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/alertmanager/timeinterval"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/tests"
+)
+
+func TestIntegrationSilenceSchedules(t *testing.T) {
+	ctx := context.Background()
+	_, dbstore := tests.SetupTestEnv(t, baseIntervalSeconds)
+
+	var intervals []timeinterval.TimeInterval
+	require.NoError(t, yaml.Unmarshal([]byte(`[{weekdays: [saturday, sunday], times: [{start_time: "02:00", end_time: "04:00"}]}]`), &intervals))
+
+	schedule := &models.SilenceSchedule{
+		OrgID:         1,
+		UID:           "weekend",
+		Comment:       "Weekend maintenance",
+		CreatedBy:     "admin",
+		Matchers:      []models.SilenceMatcher{{Name: "service", Value: "checkout", IsEqual: true}},
+		TimeIntervals: intervals,
+		Updated:       time.Unix(1000, 0).UTC(),
+	}
+	require.NoError(t, dbstore.InsertSilenceSchedule(ctx, schedule))
+	require.NoError(t, dbstore.InsertSilenceSchedule(ctx, &models.SilenceSchedule{
+		OrgID: 2, UID: "nightly", CreatedBy: "admin", Cron: "0 2 * * *", Duration: time.Hour, Updated: time.Now(),
+	}))
+
+	t.Run("returns the silence schedules of the organization", func(t *testing.T) {
+		schedules, err := dbstore.ListSilenceSchedules(ctx, 1)
+		require.NoError(t, err)
+		require.Len(t, schedules, 1)
+		require.Equal(t, schedule.Matchers, schedules[0].Matchers)
+		require.Equal(t, schedule.TimeIntervals, schedules[0].TimeIntervals)
+
+		all, err := dbstore.ListAllSilenceSchedules(ctx)
+		require.NoError(t, err)
+		require.Len(t, all, 2)
+		require.Equal(t, time.Hour, all[1].Duration)
+
+		_, err = dbstore.GetSilenceSchedule(ctx, 2, "weekend")
+		require.ErrorIs(t, err, models.ErrSilenceScheduleNotFound)
+	})
+
+	t.Run("updates a silence schedule and its silence", func(t *testing.T) {
+		update := *schedule
+		update.Comment = "Weekend database maintenance"
+		update.Timezone = "Europe/Berlin"
+		require.NoError(t, dbstore.UpdateSilenceSchedule(ctx, &update))
+		require.NoError(t, dbstore.SetSilenceScheduleSilenceID(ctx, 1, "weekend", "silence-1"))
+
+		stored, err := dbstore.GetSilenceSchedule(ctx, 1, "weekend")
+		require.NoError(t, err)
+		require.Equal(t, "Weekend database maintenance", stored.Comment)
+		require.Equal(t, "Europe/Berlin", stored.Timezone)
+		require.Equal(t, "silence-1", stored.SilenceID)
+
+		update.UID = "missing"
+		require.ErrorIs(t, dbstore.UpdateSilenceSchedule(ctx, &update), models.ErrSilenceScheduleNotFound)
+	})
+
+	t.Run("deletes a silence schedule", func(t *testing.T) {
+		require.NoError(t, dbstore.DeleteSilenceSchedule(ctx, 1, "weekend"))
+		require.ErrorIs(t, dbstore.DeleteSilenceSchedule(ctx, 1, "weekend"), models.ErrSilenceScheduleNotFound)
+	})
+}
@@ -0,0 +1,133 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/alertmanager/pkg/labels"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/sqlstore"
+)
+
+// stateHistoryBatchSize is the number of rows read at once when the history is filtered by label matchers.
+const stateHistoryBatchSize = 1000
+
+// StateHistoryStore persists the transitions of alert instances between states.
+type StateHistoryStore interface {
+	SaveAlertStateHistory(ctx context.Context, entries []*models.AlertStateHistory) error
+	ListAlertStateHistory(ctx context.Context, query *models.ListAlertStateHistoryQuery) error
+	DeleteAlertStateHistoryOlderThan(ctx context.Context, olderThan time.Time) (int64, error)
+}
+
+// ProvideStateHistoryStore returns a StateHistoryStore for services outside of ngalert, such as cleanup.
+func ProvideStateHistoryStore(sqlStore *sqlstore.SQLStore) StateHistoryStore {
+	return &DBstore{SQLStore: sqlStore}
+}
+
+// SaveAlertStateHistory inserts the transitions.
+func (st DBstore) SaveAlertStateHistory(ctx context.Context, entries []*models.AlertStateHistory) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	return st.SQLStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		if _, err := sess.Insert(&entries); err != nil {
+			return fmt.Errorf("failed to insert alert state history: %w", err)
+		}
+		return nil
+	})
+}
+
+// ListAlertStateHistory returns a page of the transitions that match the query, most recent first.
+// Label matchers cannot be expressed in SQL, so when there are any the rows are read in batches and
+// filtered until the page is full.
+func (st DBstore) ListAlertStateHistory(ctx context.Context, query *models.ListAlertStateHistoryQuery) error {
+	if query.Limit <= 0 {
+		return fmt.Errorf("limit must be greater than 0")
+	}
+	page := query.Page
+	if page < 1 {
+		page = 1
+	}
+	skip := (page - 1) * query.Limit
+
+	return st.SQLStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		find := func(offset, limit int) ([]*models.AlertStateHistory, error) {
+			q := sess.Where("org_id = ?", query.OrgID)
+			if len(query.RuleUIDs) > 0 {
+				q = q.In("rule_uid", query.RuleUIDs)
+			}
+			if len(query.NamespaceUIDs) > 0 {
+				q = q.In("namespace_uid", query.NamespaceUIDs)
+			}
+			if len(query.ExcludeRuleUIDs) > 0 {
+				q = q.NotIn("rule_uid", query.ExcludeRuleUIDs)
+			}
+			if !query.From.IsZero() {
+				q = q.And("evaluated_at >= ?", query.From.UnixMilli())
+			}
+			if !query.To.IsZero() {
+				q = q.And("evaluated_at <= ?", query.To.UnixMilli())
+			}
+			var rows []*models.AlertStateHistory
+			err := q.Desc("evaluated_at", "id").Limit(limit, offset).Find(&rows)
+			return rows, err
+		}
+
+		if len(query.Matchers) == 0 {
+			rows, err := find(skip, query.Limit)
+			if err != nil {
+				return err
+			}
+			query.Result = rows
+			return nil
+		}
+
+		result := make([]*models.AlertStateHistory, 0, query.Limit)
+		for offset := 0; len(result) < query.Limit; offset += stateHistoryBatchSize {
+			rows, err := find(offset, stateHistoryBatchSize)
+			if err != nil {
+				return err
+			}
+			for _, row := range rows {
+				if len(result) == query.Limit {
+					break
+				}
+				if !matchesLabels(query.Matchers, row.Labels) {
+					continue
+				}
+				if skip > 0 {
+					skip--
+					continue
+				}
+				result = append(result, row)
+			}
+			if len(rows) < stateHistoryBatchSize {
+				break
+			}
+		}
+		query.Result = result
+		return nil
+	})
+}
+
+func matchesLabels(matchers labels.Matchers, lbs map[string]string) bool {
+	for _, m := range matchers {
+		if !m.Matches(lbs[m.Name]) {
+			return false
+		}
+	}
+	return true
+}
+
+// DeleteAlertStateHistoryOlderThan deletes the transitions that happened before olderThan and returns how many were deleted.
+func (st DBstore) DeleteAlertStateHistoryOlderThan(ctx context.Context, olderThan time.Time) (int64, error) {
+	var deleted int64
+	err := st.SQLStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		n, err := sess.Where("evaluated_at < ?", olderThan.UnixMilli()).Delete(&models.AlertStateHistory{})
+		deleted = n
+		return err
+	})
+	return deleted, err
+}
@@ -0,0 +1,124 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/tests"
+)
+
+func TestIntegrationAlertStateHistory(t *testing.T) {
+	ctx := context.Background()
+	_, dbstore := tests.SetupTestEnv(t, baseIntervalSeconds)
+
+	start := time.Unix(1000, 0).UTC()
+	var entries []*models.AlertStateHistory
+	for i := 0; i < 10; i++ {
+		host := "a"
+		if i%2 == 1 {
+			host = "b"
+		}
+		ruleUID := "rule-1"
+		if i >= 8 {
+			ruleUID = "rule-2"
+		}
+		entries = append(entries, &models.AlertStateHistory{
+			OrgID:         1,
+			RuleUID:       ruleUID,
+			NamespaceUID:  "folder-" + ruleUID,
+			RuleVersion:   int64(i),
+			Labels:        map[string]string{"host": host},
+			PreviousState: models.InstanceStateNormal,
+			State:         models.InstanceStateFiring,
+			Values:        map[string]*float64{"B": nil},
+			EvaluatedAt:   start.Add(time.Duration(i) * time.Minute).UnixMilli(),
+		})
+	}
+	entries = append(entries, &models.AlertStateHistory{
+		OrgID:       2,
+		RuleUID:     "rule-3",
+		Labels:      map[string]string{"host": "a"},
+		State:       models.InstanceStateFiring,
+		EvaluatedAt: start.UnixMilli(),
+	})
+	require.NoError(t, dbstore.SaveAlertStateHistory(ctx, entries))
+
+	versions := func(h []*models.AlertStateHistory) []int64 {
+		result := make([]int64, 0, len(h))
+		for _, e := range h {
+			result = append(result, e.RuleVersion)
+		}
+		return result
+	}
+
+	t.Run("should list the most recent transitions of the org first", func(t *testing.T) {
+		q := &models.ListAlertStateHistoryQuery{OrgID: 1, Limit: 3}
+		require.NoError(t, dbstore.ListAlertStateHistory(ctx, q))
+		require.Equal(t, []int64{9, 8, 7}, versions(q.Result))
+		require.Equal(t, map[string]string{"host": "b"}, q.Result[0].Labels)
+		require.Contains(t, q.Result[0].Values, "B")
+		require.Equal(t, start.Add(9*time.Minute).UnixMilli(), q.Result[0].EvaluatedAt)
+
+		q.Page = 4
+		require.NoError(t, dbstore.ListAlertStateHistory(ctx, q))
+		require.Equal(t, []int64{0}, versions(q.Result))
+	})
+
+	t.Run("should filter by rule and time range", func(t *testing.T) {
+		q := &models.ListAlertStateHistoryQuery{
+			OrgID:    1,
+			RuleUIDs: []string{"rule-1"},
+			From:     start.Add(2 * time.Minute),
+			To:       start.Add(8 * time.Minute),
+			Limit:    100,
+		}
+		require.NoError(t, dbstore.ListAlertStateHistory(ctx, q))
+		require.Equal(t, []int64{7, 6, 5, 4, 3, 2}, versions(q.Result))
+	})
+
+	t.Run("should filter by folder and excluded rules", func(t *testing.T) {
+		q := &models.ListAlertStateHistoryQuery{
+			OrgID:         1,
+			NamespaceUIDs: []string{"folder-rule-1", "folder-rule-2"},
+			Limit:         100,
+		}
+		require.NoError(t, dbstore.ListAlertStateHistory(ctx, q))
+		require.Len(t, q.Result, 10)
+
+		q.ExcludeRuleUIDs = []string{"rule-1"}
+		require.NoError(t, dbstore.ListAlertStateHistory(ctx, q))
+		require.Equal(t, []int64{9, 8}, versions(q.Result))
+
+		q.NamespaceUIDs = []string{"folder-rule-1"}
+		require.NoError(t, dbstore.ListAlertStateHistory(ctx, q))
+		require.Empty(t, q.Result)
+	})
+
+	t.Run("should filter and paginate by label matchers", func(t *testing.T) {
+		m, err := labels.NewMatcher(labels.MatchEqual, "host", "a")
+		require.NoError(t, err)
+		q := &models.ListAlertStateHistoryQuery{
+			OrgID:    1,
+			Matchers: labels.Matchers{m},
+			Limit:    2,
+			Page:     2,
+		}
+		require.NoError(t, dbstore.ListAlertStateHistory(ctx, q))
+		require.Equal(t, []int64{4, 2}, versions(q.Result))
+	})
+
+	t.Run("should delete transitions older than the given time", func(t *testing.T) {
+		deleted, err := dbstore.DeleteAlertStateHistoryOlderThan(ctx, start.Add(5*time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, int64(6), deleted)
+
+		q := &models.ListAlertStateHistoryQuery{OrgID: 1, Limit: 100}
+		require.NoError(t, dbstore.ListAlertStateHistory(ctx, q))
+		require.Equal(t, []int64{9, 8, 7, 6, 5}, versions(q.Result))
+	})
+}
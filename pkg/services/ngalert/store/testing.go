@@ -8,8 +8,10 @@ import (
 	"math/rand"
 	"net/http"
 	"net/http/httptest"
+	"sort"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/grafana/grafana/pkg/services/annotations"
 	"github.com/grafana/grafana/pkg/util"
@@ -253,6 +255,9 @@ func (f *FakeRuleStore) ListAlertRules(_ context.Context, q *models.ListAlertRul
 		if q.RuleGroup != "" && r.RuleGroup != q.RuleGroup {
 			continue
 		}
+		if len(q.RuleUIDs) > 0 && !stringInSlice(r.UID, q.RuleUIDs) {
+			continue
+		}
 		q.Result = append(q.Result, r)
 	}
 
@@ -381,6 +386,191 @@ func (f *FakeInstanceStore) DeleteAlertInstance(_ context.Context, _ int64, _, _
 	return nil
 }
 
+type FakeStateHistoryStore struct {
+	mtx     sync.Mutex
+	History []*models.AlertStateHistory
+}
+
+func (f *FakeStateHistoryStore) SaveAlertStateHistory(_ context.Context, entries []*models.AlertStateHistory) error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.History = append(f.History, entries...)
+	return nil
+}
+
+func (f *FakeStateHistoryStore) ListAlertStateHistory(_ context.Context, q *models.ListAlertStateHistoryQuery) error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	var result []*models.AlertStateHistory
+	for _, e := range f.History {
+		if e.OrgID != q.OrgID {
+			continue
+		}
+		if len(q.RuleUIDs) > 0 && !stringInSlice(e.RuleUID, q.RuleUIDs) {
+			continue
+		}
+		if len(q.NamespaceUIDs) > 0 && !stringInSlice(e.NamespaceUID, q.NamespaceUIDs) {
+			continue
+		}
+		if stringInSlice(e.RuleUID, q.ExcludeRuleUIDs) {
+			continue
+		}
+		result = append(result, e)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].EvaluatedAt > result[j].EvaluatedAt
+	})
+	q.Result = result
+	return nil
+}
+
+func stringInSlice(s string, list []string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *FakeStateHistoryStore) DeleteAlertStateHistoryOlderThan(_ context.Context, olderThan time.Time) (int64, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	kept := f.History[:0]
+	for _, e := range f.History {
+		if e.EvaluatedAt >= olderThan.UnixMilli() {
+			kept = append(kept, e)
+		}
+	}
+	deleted := int64(len(f.History) - len(kept))
+	f.History = kept
+	return deleted, nil
+}
+
+// GetHistory returns a copy of the saved transitions.
+func (f *FakeStateHistoryStore) GetHistory() []*models.AlertStateHistory {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	return append([]*models.AlertStateHistory{}, f.History...)
+}
+
+type FakeNotificationDeliveryStore struct {
+	mtx        sync.Mutex
+	lastID     int64
+	Deliveries []*models.NotificationDelivery
+}
+
+func (f *FakeNotificationDeliveryStore) SaveNotificationDelivery(_ context.Context, delivery *models.NotificationDelivery) error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	if delivery.ID == 0 {
+		f.lastID++
+		delivery.ID = f.lastID
+		cp := *delivery
+		f.Deliveries = append(f.Deliveries, &cp)
+		return nil
+	}
+	for i, d := range f.Deliveries {
+		if d.ID == delivery.ID {
+			cp := *delivery
+			f.Deliveries[i] = &cp
+			return nil
+		}
+	}
+	return models.ErrNotificationDeliveryNotFound
+}
+
+func (f *FakeNotificationDeliveryStore) GetNotificationDelivery(_ context.Context, orgID, id int64) (*models.NotificationDelivery, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	for _, d := range f.Deliveries {
+		if d.OrgID == orgID && d.ID == id {
+			cp := *d
+			return &cp, nil
+		}
+	}
+	return nil, models.ErrNotificationDeliveryNotFound
+}
+
+func (f *FakeNotificationDeliveryStore) ListNotificationDeliveries(_ context.Context, q *models.ListNotificationDeliveriesQuery) error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	var result []*models.NotificationDelivery
+	for _, d := range f.Deliveries {
+		if d.OrgID != q.OrgID {
+			continue
+		}
+		if q.Receiver != "" && d.Receiver != q.Receiver {
+			continue
+		}
+		if q.Status != "" && d.Status != q.Status {
+			continue
+		}
+		cp := *d
+		result = append(result, &cp)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].CreatedAt > result[j].CreatedAt
+	})
+	q.Result = result
+	return nil
+}
+
+func (f *FakeNotificationDeliveryStore) GetDueNotificationDeliveries(_ context.Context, orgID int64, now time.Time, limit int) ([]*models.NotificationDelivery, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	var result []*models.NotificationDelivery
+	for _, d := range f.Deliveries {
+		if len(result) == limit {
+			break
+		}
+		if d.OrgID == orgID && d.Status == models.NotificationDeliveryRetrying && d.NextAttemptAt <= now.UnixMilli() {
+			cp := *d
+			result = append(result, &cp)
+		}
+	}
+	return result, nil
+}
+
+func (f *FakeNotificationDeliveryStore) ClaimNotificationDelivery(_ context.Context, delivery *models.NotificationDelivery, until time.Time) (bool, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	for _, d := range f.Deliveries {
+		if d.ID == delivery.ID && d.Status == models.NotificationDeliveryRetrying && d.NextAttemptAt == delivery.NextAttemptAt {
+			d.NextAttemptAt = until.UnixMilli()
+			delivery.NextAttemptAt = d.NextAttemptAt
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *FakeNotificationDeliveryStore) DeleteNotificationDeliveriesOlderThan(_ context.Context, olderThan time.Time) (int64, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	kept := f.Deliveries[:0]
+	for _, d := range f.Deliveries {
+		if d.CreatedAt >= olderThan.UnixMilli() {
+			kept = append(kept, d)
+		}
+	}
+	deleted := int64(len(f.Deliveries) - len(kept))
+	f.Deliveries = kept
+	return deleted, nil
+}
+
+// GetDeliveries returns a copy of the saved deliveries.
+func (f *FakeNotificationDeliveryStore) GetDeliveries() []*models.NotificationDelivery {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	result := make([]*models.NotificationDelivery, 0, len(f.Deliveries))
+	for _, d := range f.Deliveries {
+		cp := *d
+		result = append(result, &cp)
+	}
+	return result
+}
+
 func NewFakeAdminConfigStore(t *testing.T) *FakeAdminConfigStore {
 	t.Helper()
 	return &FakeAdminConfigStore{Configs: map[int64]*models.AdminConfiguration{}}
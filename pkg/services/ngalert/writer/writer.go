@@ -0,0 +1,158 @@
+package writer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/prompb"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/live/remotewrite"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+// Writer writes the results of recording rules as samples of a metric.
+type Writer interface {
+	// Write writes one sample per series in frames at time t. The labels of a series are
+	// the labels of its field and extraLabels, and its name is the given metric name.
+	Write(ctx context.Context, name string, t time.Time, frames data.Frames, extraLabels map[string]string) error
+}
+
+// New returns a writer for the recording rules settings. If recording rules are disabled, the
+// returned writer drops all samples.
+func New(cfg setting.UnifiedAlertingRecordingRulesSettings, logger log.Logger) Writer {
+	if !cfg.Enabled {
+		return NoopWriter{}
+	}
+	return NewPrometheusWriter(cfg, logger)
+}
+
+// NoopWriter drops all samples.
+type NoopWriter struct{}
+
+func (NoopWriter) Write(context.Context, string, time.Time, data.Frames, map[string]string) error {
+	return nil
+}
+
+// PrometheusWriter writes samples to a Prometheus remote write endpoint.
+type PrometheusWriter struct {
+	url      string
+	username string
+	password string
+	client   *http.Client
+	logger   log.Logger
+}
+
+func NewPrometheusWriter(cfg setting.UnifiedAlertingRecordingRulesSettings, logger log.Logger) *PrometheusWriter {
+	return &PrometheusWriter{
+		url:      cfg.URL,
+		username: cfg.BasicAuthUsername,
+		password: cfg.BasicAuthPassword,
+		client:   &http.Client{Timeout: cfg.Timeout},
+		logger:   logger,
+	}
+}
+
+func (w *PrometheusWriter) Write(ctx context.Context, name string, t time.Time, frames data.Frames, extraLabels map[string]string) error {
+	series, err := TimeSeriesFromFrames(name, t, frames, extraLabels)
+	if err != nil {
+		return err
+	}
+	if len(series) == 0 {
+		w.logger.Debug("no samples to write", "metric", name)
+		return nil
+	}
+
+	body, err := remotewrite.TimeSeriesToBytes(series)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create remote write request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	if w.username != "" {
+		req.SetBasicAuth(w.username, w.password)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send remote write request: %w", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected response code %d from remote write endpoint", resp.StatusCode)
+	}
+	w.logger.Debug("samples written", "metric", name, "series", len(series))
+	return nil
+}
+
+// TimeSeriesFromFrames converts the numeric fields of frames to series with a single sample at time t.
+// The last non-null value of a field is used. Fields without a value are skipped.
+func TimeSeriesFromFrames(name string, t time.Time, frames data.Frames, extraLabels map[string]string) ([]prompb.TimeSeries, error) {
+	if !model.IsValidMetricName(model.LabelValue(name)) {
+		return nil, fmt.Errorf("invalid metric name %q", name)
+	}
+
+	var result []prompb.TimeSeries
+	for _, frame := range frames {
+		for _, field := range frame.Fields {
+			if !field.Type().Numeric() {
+				continue
+			}
+			value, ok := LastValue(field)
+			if !ok {
+				continue
+			}
+
+			lbls := make(map[string]string, len(field.Labels)+len(extraLabels)+1)
+			for k, v := range field.Labels {
+				lbls[k] = v
+			}
+			for k, v := range extraLabels {
+				lbls[k] = v
+			}
+			lbls[model.MetricNameLabel] = name
+
+			series := prompb.TimeSeries{
+				Labels:  make([]prompb.Label, 0, len(lbls)),
+				Samples: []prompb.Sample{{Value: value, Timestamp: t.UnixMilli()}},
+			}
+			for k, v := range lbls {
+				if !model.LabelName(k).IsValid() {
+					return nil, fmt.Errorf("invalid label name %q", k)
+				}
+				series.Labels = append(series.Labels, prompb.Label{Name: k, Value: v})
+			}
+			sort.Slice(series.Labels, func(i, j int) bool {
+				return series.Labels[i].Name < series.Labels[j].Name
+			})
+			result = append(result, series)
+		}
+	}
+	return result, nil
+}
+
+// LastValue returns the last non-null value of a numeric field.
+func LastValue(field *data.Field) (float64, bool) {
+	for i := field.Len() - 1; i >= 0; i-- {
+		if _, ok := field.ConcreteAt(i); !ok {
+			continue
+		}
+		f, err := field.FloatAt(i)
+		if err != nil {
+			continue
+		}
+		return f, true
+	}
+	return 0, false
+}
@@ -0,0 +1,85 @@
+package writer
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+func TestTimeSeriesFromFrames(t *testing.T) {
+	now := time.Unix(100, 0)
+	v := 1.5
+	frames := data.Frames{
+		data.NewFrame("", data.NewField("B", data.Labels{"host": "a"}, []*float64{&v})),
+		data.NewFrame("", data.NewField("B", data.Labels{"host": "b"}, []*float64{nil})),
+		data.NewFrame("", data.NewField("B", data.Labels{"host": "c"}, []int64{1, 2})),
+	}
+
+	series, err := TimeSeriesFromFrames("cpu:rate", now, frames, map[string]string{"team": "x"})
+	require.NoError(t, err)
+	require.Equal(t, []prompb.TimeSeries{
+		{
+			Labels:  []prompb.Label{{Name: "__name__", Value: "cpu:rate"}, {Name: "host", Value: "a"}, {Name: "team", Value: "x"}},
+			Samples: []prompb.Sample{{Value: 1.5, Timestamp: 100000}},
+		},
+		{
+			Labels:  []prompb.Label{{Name: "__name__", Value: "cpu:rate"}, {Name: "host", Value: "c"}, {Name: "team", Value: "x"}},
+			Samples: []prompb.Sample{{Value: 2, Timestamp: 100000}},
+		},
+	}, series)
+
+	_, err = TimeSeriesFromFrames("invalid-name", now, frames, nil)
+	require.Error(t, err)
+
+	_, err = TimeSeriesFromFrames("cpu", now, frames, map[string]string{"in-valid": "x"})
+	require.Error(t, err)
+}
+
+func TestPrometheusWriter(t *testing.T) {
+	var received prompb.WriteRequest
+	status := http.StatusNoContent
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		require.True(t, ok)
+		require.Equal(t, "user", user)
+		require.Equal(t, "pass", pass)
+		require.Equal(t, "snappy", r.Header.Get("Content-Encoding"))
+
+		b, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		b, err = snappy.Decode(nil, b)
+		require.NoError(t, err)
+		require.NoError(t, proto.Unmarshal(b, &received))
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+
+	w := New(setting.UnifiedAlertingRecordingRulesSettings{
+		Enabled:           true,
+		URL:               srv.URL,
+		BasicAuthUsername: "user",
+		BasicAuthPassword: "pass",
+		Timeout:           time.Second,
+	}, log.NewNopLogger())
+
+	v := 3.0
+	frames := data.Frames{data.NewFrame("", data.NewField("A", nil, []*float64{&v}))}
+	require.NoError(t, w.Write(context.Background(), "up", time.Unix(1, 0), frames, nil))
+	require.Len(t, received.Timeseries, 1)
+	require.Equal(t, []prompb.Sample{{Value: 3, Timestamp: 1000}}, received.Timeseries[0].Samples)
+
+	status = http.StatusBadRequest
+	require.Error(t, w.Write(context.Background(), "up", time.Unix(1, 0), frames, nil))
+}
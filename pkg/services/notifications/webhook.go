@@ -9,6 +9,7 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/grafana/grafana/pkg/util"
@@ -22,6 +23,49 @@ type Webhook struct {
 	HttpMethod  string
 	HttpHeader  map[string]string
 	ContentType string
+	Client      *http.Client
+}
+
+// webhookResponseBodyLimit is the number of bytes of a response body that are recorded by RecordWebhookResponse.
+const webhookResponseBodyLimit = 1024
+
+// WebhookResponse is the response to the last request sent with a context returned by WithWebhookResponse.
+type WebhookResponse struct {
+	mtx        sync.Mutex
+	statusCode int
+	body       string
+}
+
+// Get returns the status code and the truncated body of the response. The status code is 0 if no response was recorded.
+func (r *WebhookResponse) Get() (int, string) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.statusCode, r.body
+}
+
+type webhookResponseKey struct{}
+
+// WithWebhookResponse returns a context that records the response to requests sent with it, so that the caller
+// can tell what a notifier received from the remote service.
+func WithWebhookResponse(ctx context.Context) (context.Context, *WebhookResponse) {
+	r := &WebhookResponse{}
+	return context.WithValue(ctx, webhookResponseKey{}, r), r
+}
+
+// RecordWebhookResponse records the response to a request sent with ctx if it was returned by WithWebhookResponse.
+// The body is truncated to webhookResponseBodyLimit bytes.
+func RecordWebhookResponse(ctx context.Context, statusCode int, body []byte) {
+	r, ok := ctx.Value(webhookResponseKey{}).(*WebhookResponse)
+	if !ok {
+		return
+	}
+	if len(body) > webhookResponseBodyLimit {
+		body = body[:webhookResponseBodyLimit]
+	}
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.statusCode = statusCode
+	r.body = string(body)
 }
 
 var netTransport = &http.Transport{
@@ -39,6 +83,17 @@ var netClient = &http.Client{
 	Transport: netTransport,
 }
 
+// NewWebhookClient returns a client like the default client of webhooks that uses the given TLS configuration.
+// Create it once per configuration and reuse it, so that its connections are reused.
+func NewWebhookClient(cfg *tls.Config) *http.Client {
+	transport := netTransport.Clone()
+	transport.TLSClientConfig = cfg
+	return &http.Client{
+		Timeout:   netClient.Timeout,
+		Transport: transport,
+	}
+}
+
 func (ns *NotificationService) sendWebRequestSync(ctx context.Context, webhook *Webhook) error {
 	if webhook.HttpMethod == "" {
 		webhook.HttpMethod = http.MethodPost
@@ -70,7 +125,12 @@ func (ns *NotificationService) sendWebRequestSync(ctx context.Context, webhook *
 		request.Header.Set(k, v)
 	}
 
-	resp, err := netClient.Do(request)
+	client := netClient
+	if webhook.Client != nil {
+		client = webhook.Client
+	}
+
+	resp, err := client.Do(request)
 	if err != nil {
 		return err
 	}
@@ -82,6 +142,11 @@ func (ns *NotificationService) sendWebRequestSync(ctx context.Context, webhook *
 
 	if resp.StatusCode/100 == 2 {
 		ns.log.Debug("Webhook succeeded", "url", webhook.Url, "statuscode", resp.Status)
+		body, err := ioutil.ReadAll(io.LimitReader(resp.Body, webhookResponseBodyLimit))
+		if err != nil {
+			ns.log.Warn("Failed to read response body", "err", err)
+		}
+		RecordWebhookResponse(ctx, resp.StatusCode, body)
 		// flushing the body enables the transport to reuse the same connection
 		if _, err := io.Copy(ioutil.Discard, resp.Body); err != nil {
 			ns.log.Error("Failed to copy resp.Body to ioutil.Discard", "err", err)
@@ -93,6 +158,7 @@ func (ns *NotificationService) sendWebRequestSync(ctx context.Context, webhook *
 	if err != nil {
 		return err
 	}
+	RecordWebhookResponse(ctx, resp.StatusCode, body)
 
 	ns.log.Debug("Webhook failed", "url", webhook.Url, "statuscode", resp.Status, "body", string(body))
 	return fmt.Errorf("Webhook response status %v", resp.Status)
@@ -0,0 +1,66 @@
+package notifications
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/bus"
+)
+
+func TestSendWebRequestSyncRecordsResponse(t *testing.T) {
+	ns, _ := createSut(t, bus.New())
+
+	t.Run("records the response of a successful request", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{"status": "queued"}`))
+		}))
+		defer server.Close()
+
+		ctx, resp := WithWebhookResponse(context.Background())
+		require.NoError(t, ns.sendWebRequestSync(ctx, &Webhook{Url: server.URL, Body: "{}"}))
+		code, body := resp.Get()
+		require.Equal(t, http.StatusAccepted, code)
+		require.Equal(t, `{"status": "queued"}`, body)
+	})
+
+	t.Run("records the truncated response of a failed request", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(strings.Repeat("x", 2*webhookResponseBodyLimit)))
+		}))
+		defer server.Close()
+
+		ctx, resp := WithWebhookResponse(context.Background())
+		require.Error(t, ns.sendWebRequestSync(ctx, &Webhook{Url: server.URL, Body: "{}"}))
+		code, body := resp.Get()
+		require.Equal(t, http.StatusServiceUnavailable, code)
+		require.Len(t, body, webhookResponseBodyLimit)
+	})
+
+	t.Run("does nothing without a recorder in the context", func(t *testing.T) {
+		RecordWebhookResponse(context.Background(), http.StatusOK, []byte("ok"))
+	})
+}
+
+func TestSendWebRequestSyncTLSConfig(t *testing.T) {
+	ns, _ := createSut(t, bus.New())
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// The certificate of the test server is not trusted by the default configuration.
+	require.Error(t, ns.sendWebRequestSync(context.Background(), &Webhook{Url: server.URL, Body: "{}"}))
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+	require.NoError(t, ns.sendWebRequestSync(context.Background(), &Webhook{Url: server.URL, Body: "{}", Client: NewWebhookClient(&tls.Config{RootCAs: pool})}))
+}
@@ -5,13 +5,15 @@ import (
 
 	"github.com/grafana/grafana/pkg/models"
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/datasources"
 	"github.com/grafana/grafana/pkg/services/sqlstore"
 	"github.com/grafana/grafana/pkg/services/sqlstore/permissions"
 	"github.com/grafana/grafana/pkg/services/sqlstore/searchstore"
 )
 
-// ResourceFilter checks if a given a uid (resource identifier) check if we have the requested permission
-type ResourceFilter func(uid string) bool
+// ResourceFilter checks if a given a uid (resource identifier) of a kind check if we have the requested permission
+type ResourceFilter func(kind entityKind, uid string) bool
 
 // FutureAuthService eventually implemented by the security service
 type FutureAuthService interface {
@@ -71,7 +73,79 @@ func (a *simpleSQLAuthService) GetDashboardReadFilter(user *models.SignedInUser)
 		uids[rows[i].UID] = true
 	}
 
-	return func(uid string) bool {
-		return uids[uid]
+	datasourceUIDs, queryableDatasourceUIDs, err := a.getDatasourceUIDs(user)
+	if err != nil {
+		return nil, err
+	}
+	canReadAlertRules := a.getAlertRuleReadFilter(user)
+
+	return func(kind entityKind, uid string) bool {
+		switch kind {
+		case entityKindDashboard, entityKindFolder:
+			return uids[uid]
+		case entityKindDatasource:
+			return datasourceUIDs[uid]
+		case entityKindDatasourceQuery:
+			return queryableDatasourceUIDs[uid]
+		case entityKindAlertRule:
+			// The UID is the one of the folder of the alert rules.
+			return canReadAlertRules(uid)
+		case entityKindPlaylist:
+			// Playlists can be read by all members of an organization.
+			return true
+		default:
+			return false
+		}
 	}, err
 }
+
+// getAlertRuleReadFilter returns a function that checks if the user can read the alert rules in a folder.
+func (a *simpleSQLAuthService) getAlertRuleReadFilter(user *models.SignedInUser) func(folderUID string) bool {
+	if a.ac.IsDisabled() {
+		// Without access control, the alert rules can be read by all members of an organization.
+		return func(string) bool { return true }
+	}
+	return func(folderUID string) bool {
+		ok, err := a.ac.Evaluate(context.Background(), user, accesscontrol.EvalPermission(accesscontrol.ActionAlertingRuleRead, dashboards.ScopeFoldersProvider.GetResourceScopeUID(folderUID)))
+		return err == nil && ok
+	}
+}
+
+// getDatasourceUIDs returns the UIDs of the data sources whose settings the user can read, and the UIDs of the data
+// sources that the user can query.
+func (a *simpleSQLAuthService) getDatasourceUIDs(user *models.SignedInUser) (map[string]bool, map[string]bool, error) {
+	rows := make([]*dashIdQueryResult, 0)
+	err := a.sql.WithDbSession(context.Background(), func(sess *sqlstore.DBSession) error {
+		return sess.Table("data_source").
+			Where("org_id = ?", user.OrgId).
+			Cols("uid").
+			Find(&rows)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	readable := make(map[string]bool, len(rows))
+	queryable := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		if a.ac.IsDisabled() {
+			// Without access control, all members of an organization can query its data sources, and only admins
+			// can read their settings.
+			readable[row.UID] = user.HasRole(models.ROLE_ADMIN)
+			queryable[row.UID] = true
+			continue
+		}
+		scope := datasources.ScopeProvider.GetResourceScopeUID(row.UID)
+		ok, err := a.ac.Evaluate(context.Background(), user, accesscontrol.EvalPermission(datasources.ActionRead, scope))
+		if err != nil {
+			return nil, nil, err
+		}
+		readable[row.UID] = ok
+		ok, err = a.ac.Evaluate(context.Background(), user, accesscontrol.EvalPermission(datasources.ActionQuery, scope))
+		if err != nil {
+			return nil, nil, err
+		}
+		queryable[row.UID] = ok
+	}
+	return readable, queryable, nil
+}
@@ -17,26 +17,30 @@ import (
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/searchV2/extract"
 )
 
 const (
-	documentFieldUID         = "_id" // actually UID!! but bluge likes "_id"
-	documentFieldKind        = "kind"
-	documentFieldTag         = "tag"
-	documentFieldURL         = "url"
-	documentFieldName        = "name"
-	documentFieldName_sort   = "name_sort"
-	documentFieldName_ngram  = "name_ngram"
-	documentFieldDescription = "description"
-	documentFieldLocation    = "location" // parent path
-	documentFieldPanelType   = "panel_type"
-	documentFieldTransformer = "transformer"
-	documentFieldDSUID       = "ds_uid"
-	documentFieldDSType      = "ds_type"
+	documentFieldUID          = "_id" // actually UID!! but bluge likes "_id"
+	documentFieldKind         = "kind"
+	documentFieldTag          = "tag"
+	documentFieldURL          = "url"
+	documentFieldName         = "name"
+	documentFieldName_sort    = "name_sort"
+	documentFieldName_ngram   = "name_ngram"
+	documentFieldDescription  = "description"
+	documentFieldLocation     = "location" // parent path
+	documentFieldPanelType    = "panel_type"
+	documentFieldTransformer  = "transformer"
+	documentFieldDSUID        = "ds_uid"
+	documentFieldDSType       = "ds_type"
+	documentFieldDashboardUID = "dashboard_uid" // dashboards a library panel is connected to
+	documentFieldQuery        = "query"         // query text of panel targets
+	documentFieldVariable     = "variable"      // query text and definitions of dashboard variables
 )
 
-func initIndex(dashboards []dashboard, logger log.Logger, extendDoc ExtendDashboardFunc) (*bluge.Reader, *bluge.Writer, error) {
-	writer, err := bluge.OpenWriter(bluge.InMemoryOnlyConfig())
+func initIndex(config bluge.Config, dashboards []dashboard, entities []entity, logger log.Logger, extendDoc ExtendDashboardFunc) (*bluge.Reader, *bluge.Writer, error) {
+	writer, err := bluge.OpenWriter(config)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error opening writer: %v", err)
 	}
@@ -117,6 +121,14 @@ func initIndex(dashboards []dashboard, logger log.Logger, extendDoc ExtendDashbo
 			}
 		}
 	}
+
+	// Then alert rules, library panels, data sources and playlists.
+	for _, e := range entities {
+		batch.Insert(getEntityDoc(e))
+		if err := flushIfRequired(false); err != nil {
+			return nil, nil, err
+		}
+	}
 	if err := flushIfRequired(true); err != nil {
 		return nil, nil, err
 	}
@@ -160,6 +172,10 @@ func getNonFolderDashboardDoc(dash dashboard, location string) *bluge.Document {
 			SearchTermPositions())
 	}
 
+	for _, query := range dash.info.VarQueries {
+		doc.AddField(bluge.NewTextField(documentFieldVariable, query).SearchTermPositions())
+	}
+
 	for _, ds := range dash.info.Datasource {
 		if ds.UID != "" {
 			doc.AddField(bluge.NewKeywordField(documentFieldDSUID, ds.UID).
@@ -181,11 +197,17 @@ func getNonFolderDashboardDoc(dash dashboard, location string) *bluge.Document {
 func getDashboardPanelDocs(dash dashboard, location string) []*bluge.Document {
 	var docs []*bluge.Document
 	url := fmt.Sprintf("/d/%s/%s", dash.uid, dash.slug)
+	var panels []extract.PanelInfo
 	for _, panel := range dash.info.Panels {
 		if panel.Type == "row" {
-			continue // for now, we are excluding rows from the search index
+			// For now, we are excluding rows from the search index, but not the panels of collapsed rows.
+			panels = append(panels, panel.Collapsed...)
+			continue
 		}
+		panels = append(panels, panel)
+	}
 
+	for _, panel := range panels {
 		uid := dash.uid + "#" + strconv.FormatInt(panel.ID, 10)
 		purl := fmt.Sprintf("%s?viewPanel=%d", url, panel.ID)
 
@@ -198,6 +220,10 @@ func getDashboardPanelDocs(dash dashboard, location string) []*bluge.Document {
 			doc.AddField(bluge.NewKeywordField(documentFieldTransformer, xform).Aggregatable())
 		}
 
+		for _, query := range panel.Queries {
+			doc.AddField(bluge.NewTextField(documentFieldQuery, query).SearchTermPositions())
+		}
+
 		for _, ds := range panel.Datasource {
 			if ds.UID != "" {
 				doc.AddField(bluge.NewKeywordField(documentFieldDSUID, ds.UID).
@@ -218,6 +244,52 @@ func getDashboardPanelDocs(dash dashboard, location string) []*bluge.Document {
 	return docs
 }
 
+func getEntityDoc(e entity) *bluge.Document {
+	doc := newSearchDocument(entityDocID(e.kind, e.uid), e.name, e.description, e.url).
+		AddField(bluge.NewKeywordField(documentFieldKind, string(e.kind)).Aggregatable().StoreValue())
+
+	if e.location != "" {
+		doc.AddField(bluge.NewKeywordField(documentFieldLocation, e.location).Aggregatable().StoreValue())
+	}
+	if e.panelType != "" {
+		doc.AddField(bluge.NewKeywordField(documentFieldPanelType, e.panelType).Aggregatable().StoreValue())
+	}
+
+	for _, query := range e.queries {
+		doc.AddField(bluge.NewTextField(documentFieldQuery, query).SearchTermPositions())
+	}
+
+	for _, tag := range e.tags {
+		doc.AddField(bluge.NewKeywordField(documentFieldTag, tag).
+			StoreValue().
+			Aggregatable().
+			SearchTermPositions())
+	}
+
+	for _, ds := range e.datasource {
+		if ds.UID != "" {
+			doc.AddField(bluge.NewKeywordField(documentFieldDSUID, ds.UID).
+				StoreValue().
+				Aggregatable().
+				SearchTermPositions())
+		}
+		if ds.Type != "" {
+			doc.AddField(bluge.NewKeywordField(documentFieldDSType, ds.Type).
+				StoreValue().
+				Aggregatable().
+				SearchTermPositions())
+		}
+	}
+
+	for _, uid := range e.dashboards {
+		doc.AddField(bluge.NewKeywordField(documentFieldDashboardUID, uid).
+			StoreValue().
+			Aggregatable())
+	}
+
+	return doc
+}
+
 const ngramEdgeFilterMaxLength = 7
 
 var ngramIndexAnalyzer = &analysis.Analyzer{
@@ -344,7 +416,7 @@ func getDashboardLocation(reader *bluge.Reader, dashboardUID string) (string, bo
 	return dashboardLocation, found, err
 }
 
-//nolint: gocyclo
+// nolint: gocyclo
 func doSearchQuery(
 	ctx context.Context,
 	logger log.Logger,
@@ -353,6 +425,7 @@ func doSearchQuery(
 	q DashboardQuery,
 	extender QueryExtender,
 	appSubUrl string,
+	boosts map[string]float64,
 ) *backend.DataResponse {
 	response := &backend.DataResponse{}
 	header := &customMeta{}
@@ -406,28 +479,46 @@ func doSearchQuery(
 		hasConstraints = true
 	}
 
-	if q.Query == "*" || q.Query == "" {
+	// Field specific terms, such as query:"http_requests_total"
+	text, fieldQueries := parseFieldQueries(q.Query)
+	for _, fq := range fieldQueries {
+		fullQuery.AddMust(newFieldQuery(fq))
+		hasConstraints = true
+	}
+
+	if text == "*" || text == "" {
 		if !hasConstraints {
 			fullQuery.AddShould(bluge.NewMatchAllQuery())
 		}
 	} else {
 		// The actual se
 		bq := bluge.NewBooleanQuery().
-			AddShould(bluge.NewMatchPhraseQuery(q.Query).SetField(documentFieldName).SetBoost(6)).
-			AddShould(bluge.NewMatchPhraseQuery(q.Query).SetField(documentFieldDescription).SetBoost(3)).
-			AddShould(bluge.NewMatchQuery(q.Query).
+			AddShould(bluge.NewMatchPhraseQuery(text).SetField(documentFieldName).SetBoost(6)).
+			AddShould(bluge.NewMatchPhraseQuery(text).SetField(documentFieldDescription).SetBoost(3)).
+			AddShould(bluge.NewMatchQuery(text).
 				SetField(documentFieldName_ngram).
 				SetAnalyzer(ngramQueryAnalyzer).SetBoost(1))
 
-		if len(q.Query) > 4 {
-			bq.AddShould(bluge.NewFuzzyQuery(q.Query).SetField(documentFieldName)).SetBoost(1.5)
+		if len(text) > 4 {
+			bq.AddShould(bluge.NewFuzzyQuery(text).SetField(documentFieldName)).SetBoost(1.5)
 		}
-		if len(q.Query) > ngramEdgeFilterMaxLength && !strings.Contains(q.Query, " ") {
-			bq.AddShould(bluge.NewPrefixQuery(strings.ToLower(q.Query)).SetField(documentFieldName)).SetBoost(6)
+		if len(text) > ngramEdgeFilterMaxLength && !strings.Contains(text, " ") {
+			bq.AddShould(bluge.NewPrefixQuery(strings.ToLower(text)).SetField(documentFieldName)).SetBoost(6)
 		}
 		fullQuery.AddMust(bq)
 	}
 
+	// Usage ranking, boosts the frequently used dashboards without filtering any results
+	if len(boosts) > 0 {
+		bq := bluge.NewBooleanQuery()
+		for uid, boost := range boosts {
+			if boost > 0 {
+				bq.AddShould(bluge.NewTermQuery(uid).SetField(documentFieldUID).SetBoost(boost))
+			}
+		}
+		fullQuery.AddShould(bq)
+	}
+
 	limit := 50 // default view
 	if q.Limit > 0 {
 		limit = q.Limit
@@ -442,7 +533,8 @@ func doSearchQuery(
 	}
 	req.WithStandardAggregations()
 
-	if q.Sort != "" {
+	// Relevance is the default order by score, which includes the usage boosts
+	if q.Sort != "" && q.Sort != sortRelevance {
 		req.SortBy([]string{q.Sort})
 		header.SortBy = strings.TrimPrefix(q.Sort, "-")
 	}
@@ -0,0 +1,308 @@
+package searchV2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/searchV2/extract"
+	"github.com/grafana/grafana/pkg/services/sqlstore"
+)
+
+// indexedEntityKinds are the kinds of entities that are indexed besides dashboards, folders and panels.
+var indexedEntityKinds = []entityKind{entityKindAlertRule, entityKindLibraryPanel, entityKindDatasource, entityKindPlaylist}
+
+// expressionDatasourceUIDs are the UIDs that alert rule queries use for server side expressions.
+var expressionDatasourceUIDs = map[string]bool{"-100": true, "__expr__": true}
+
+type entityLoader interface {
+	// LoadEntities returns slice of entities of a kind. If uid is empty – then
+	// implementation must return all entities of the kind in an organization. If
+	// uid is not empty – then only return the entity with specified UID or empty
+	// slice if not found (this is required to apply partial update).
+	LoadEntities(ctx context.Context, orgID int64, kind entityKind, uid string) ([]entity, error)
+}
+
+// entity is an indexed object other than a dashboard, folder or panel.
+type entity struct {
+	kind        entityKind
+	uid         string
+	name        string
+	description string
+	url         string
+	location    string // folder UID of alert rules and library panels
+	tags        []string
+	panelType   string
+	queries     []string // query text of library panel targets
+	datasource  []extract.DataSourceRef
+	dashboards  []string // UIDs of the dashboards a library panel is connected to
+}
+
+// entityDocID returns the ID of the document of an entity. It is qualified by the kind of the entity
+// since UIDs are only unique within a kind.
+func entityDocID(kind entityKind, uid string) string {
+	return string(kind) + "/" + uid
+}
+
+type sqlEntityLoader struct {
+	sql    *sqlstore.SQLStore
+	logger log.Logger
+}
+
+func newSQLEntityLoader(sql *sqlstore.SQLStore) *sqlEntityLoader {
+	return &sqlEntityLoader{sql: sql, logger: log.New("sqlEntityLoader")}
+}
+
+func (l sqlEntityLoader) LoadEntities(ctx context.Context, orgID int64, kind entityKind, uid string) ([]entity, error) {
+	switch kind {
+	case entityKindAlertRule:
+		return l.loadAlertRules(ctx, orgID, uid)
+	case entityKindLibraryPanel:
+		return l.loadLibraryPanels(ctx, orgID, uid)
+	case entityKindDatasource:
+		return l.loadDatasources(ctx, orgID, uid)
+	case entityKindPlaylist:
+		return l.loadPlaylists(ctx, orgID, uid)
+	default:
+		return nil, fmt.Errorf("unsupported entity kind: %s", kind)
+	}
+}
+
+type alertRuleQueryResult struct {
+	UID          string `xorm:"uid"`
+	Title        string `xorm:"title"`
+	NamespaceUID string `xorm:"namespace_uid"`
+	RuleGroup    string `xorm:"rule_group"`
+	Labels       string `xorm:"labels"`
+	Data         string `xorm:"data"`
+}
+
+func (l sqlEntityLoader) loadAlertRules(ctx context.Context, orgID int64, uid string) ([]entity, error) {
+	lookup, err := loadDatasourceLookup(ctx, orgID, l.sql)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]*alertRuleQueryResult, 0)
+	err = l.sql.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		sess.Table("alert_rule").
+			Where("org_id = ?", orgID).
+			Cols("uid", "title", "namespace_uid", "rule_group", "labels", "data")
+		if uid != "" {
+			sess.Where("uid = ?", uid)
+		}
+		return sess.Find(&rows)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entities := make([]entity, 0, len(rows))
+	for _, row := range rows {
+		var labels map[string]string
+		if row.Labels != "" {
+			if err := json.Unmarshal([]byte(row.Labels), &labels); err != nil {
+				l.logger.Warn("Error indexing alert rule labels", "error", err, "ruleUid", row.UID)
+			}
+		}
+		// Labels are indexed as tags, so that they can be filtered and faceted like the tags of dashboards.
+		tags := make([]string, 0, len(labels))
+		for k, v := range labels {
+			tags = append(tags, k+"="+v)
+		}
+		sort.Strings(tags)
+
+		var queries []struct {
+			DatasourceUID string `json:"datasourceUid"`
+		}
+		if err := json.Unmarshal([]byte(row.Data), &queries); err != nil {
+			l.logger.Warn("Error indexing alert rule queries", "error", err, "ruleUid", row.UID)
+		}
+		var datasources []extract.DataSourceRef
+		for _, q := range queries {
+			if q.DatasourceUID == "" || expressionDatasourceUIDs[q.DatasourceUID] {
+				continue
+			}
+			ref := extract.DataSourceRef{UID: q.DatasourceUID}
+			if ds := lookup(&ref); ds != nil {
+				ref = *ds
+			}
+			datasources = append(datasources, ref)
+		}
+
+		entities = append(entities, entity{
+			kind:        entityKindAlertRule,
+			uid:         row.UID,
+			name:        row.Title,
+			description: row.RuleGroup,
+			url:         fmt.Sprintf("/alerting/grafana/%s/view", row.UID),
+			location:    row.NamespaceUID,
+			tags:        tags,
+			datasource:  datasources,
+		})
+	}
+	return entities, nil
+}
+
+type libraryPanelQueryResult struct {
+	ID          int64  `xorm:"id"`
+	UID         string `xorm:"uid"`
+	Name        string `xorm:"name"`
+	Description string `xorm:"description"`
+	Type        string `xorm:"type"`
+	Model       []byte `xorm:"model"`
+	FolderUID   string `xorm:"folder_uid"`
+}
+
+type libraryPanelConnectionQueryResult struct {
+	ElementID    int64  `xorm:"element_id"`
+	DashboardUID string `xorm:"dashboard_uid"`
+}
+
+func (l sqlEntityLoader) loadLibraryPanels(ctx context.Context, orgID int64, uid string) ([]entity, error) {
+	lookup, err := loadDatasourceLookup(ctx, orgID, l.sql)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]*libraryPanelQueryResult, 0)
+	connections := make([]*libraryPanelConnectionQueryResult, 0)
+	err = l.sql.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		sql := `SELECT le.id, le.uid, le.name, le.description, le.type, le.model, folder.uid AS folder_uid
+			FROM library_element AS le
+			LEFT JOIN dashboard AS folder ON folder.id = le.folder_id
+			WHERE le.org_id = ? AND le.kind = ?`
+		params := []interface{}{orgID, int64(models.PanelElement)}
+		if uid != "" {
+			sql += " AND le.uid = ?"
+			params = append(params, uid)
+		}
+		if err := sess.SQL(sql, params...).Find(&rows); err != nil {
+			return err
+		}
+
+		sql = `SELECT lec.element_id, dashboard.uid AS dashboard_uid
+			FROM ` + models.LibraryElementConnectionTableName + ` AS lec
+			INNER JOIN library_element AS le ON le.id = lec.element_id
+			INNER JOIN dashboard ON dashboard.id = lec.connection_id
+			WHERE le.org_id = ? AND le.kind = ?`
+		if uid != "" {
+			sql += " AND le.uid = ?"
+		}
+		return sess.SQL(sql, params...).Find(&connections)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dashboards := make(map[int64][]string, len(rows))
+	for _, c := range connections {
+		dashboards[c.ElementID] = append(dashboards[c.ElementID], c.DashboardUID)
+	}
+
+	entities := make([]entity, 0, len(rows))
+	for _, row := range rows {
+		var datasources []extract.DataSourceRef
+		var queries []string
+		panel, err := extract.ReadPanel(bytes.NewReader(row.Model), lookup)
+		if err != nil {
+			l.logger.Warn("Error indexing library panel model", "error", err, "libraryPanelUid", row.UID)
+		}
+		if panel != nil {
+			datasources = panel.Datasource
+			queries = panel.Queries
+		}
+
+		location := row.FolderUID
+		if location == "" {
+			location = "general"
+		}
+		entities = append(entities, entity{
+			kind:        entityKindLibraryPanel,
+			uid:         row.UID,
+			name:        row.Name,
+			description: row.Description,
+			url:         "/library-panels",
+			location:    location,
+			panelType:   row.Type,
+			queries:     queries,
+			datasource:  datasources,
+			dashboards:  dashboards[row.ID],
+		})
+	}
+	return entities, nil
+}
+
+func (l sqlEntityLoader) loadDatasources(ctx context.Context, orgID int64, uid string) ([]entity, error) {
+	rows := make([]*datasourceQueryResult, 0)
+	err := l.sql.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		sess.Table("data_source").
+			Where("org_id = ?", orgID).
+			Cols("uid", "name", "type", "is_default")
+		if uid != "" {
+			sess.Where("uid = ?", uid)
+		}
+		return sess.Find(&rows)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entities := make([]entity, 0, len(rows))
+	for _, row := range rows {
+		entities = append(entities, entity{
+			kind:       entityKindDatasource,
+			uid:        row.UID,
+			name:       row.Name,
+			url:        fmt.Sprintf("/datasources/edit/%s", row.UID),
+			datasource: []extract.DataSourceRef{{UID: row.UID, Type: row.Type}},
+		})
+	}
+	return entities, nil
+}
+
+type playlistQueryResult struct {
+	ID   int64  `xorm:"id"`
+	Name string `xorm:"name"`
+}
+
+// loadPlaylists loads the playlists of an organization. Playlists do not have UIDs, so their IDs are used instead.
+func (l sqlEntityLoader) loadPlaylists(ctx context.Context, orgID int64, uid string) ([]entity, error) {
+	var id int64
+	if uid != "" {
+		var err error
+		if id, err = strconv.ParseInt(uid, 10, 64); err != nil {
+			return []entity{}, nil
+		}
+	}
+
+	rows := make([]*playlistQueryResult, 0)
+	err := l.sql.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		sess.Table("playlist").
+			Where("org_id = ?", orgID).
+			Cols("id", "name")
+		if id > 0 {
+			sess.Where("id = ?", id)
+		}
+		return sess.Find(&rows)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entities := make([]entity, 0, len(rows))
+	for _, row := range rows {
+		entities = append(entities, entity{
+			kind: entityKindPlaylist,
+			uid:  strconv.FormatInt(row.ID, 10),
+			name: row.Name,
+			url:  fmt.Sprintf("/playlists/play/%d", row.ID),
+		})
+	}
+	return entities, nil
+}
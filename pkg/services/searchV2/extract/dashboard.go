@@ -113,9 +113,14 @@ func ReadDashboard(stream io.Reader, lookup DatasourceLookup) (*DashboardInfo, e
 				if sub == "list" {
 					for iter.ReadArray() {
 						for k := iter.ReadObject(); k != ""; k = iter.ReadObject() {
-							if k == "name" {
+							switch k {
+							case "name":
 								dash.TemplateVars = append(dash.TemplateVars, iter.ReadString())
-							} else {
+							case "query", "definition":
+								if query := readVariableQuery(iter); query != "" && !stringInSlice(query, dash.VarQueries) {
+									dash.VarQueries = append(dash.VarQueries, query)
+								}
+							default:
 								iter.Skip()
 							}
 						}
@@ -148,6 +153,43 @@ func ReadDashboard(stream io.Reader, lookup DatasourceLookup) (*DashboardInfo, e
 	return dash, iter.Error
 }
 
+// ReadPanel will take a byte stream of a panel model, such as the model of a library panel, and return panel info
+func ReadPanel(stream io.Reader, lookup DatasourceLookup) (*PanelInfo, error) {
+	iter := jsoniter.Parse(jsoniter.ConfigDefault, stream, 1024)
+	panel := readPanelInfo(iter, lookup)
+	return &panel, iter.Error
+}
+
+// readVariableQuery reads the query of a variable, which is either a string or an object with a query property
+func readVariableQuery(iter *jsoniter.Iterator) string {
+	switch iter.WhatIsNext() {
+	case jsoniter.StringValue:
+		return iter.ReadString()
+	case jsoniter.ObjectValue:
+		query := ""
+		for f := iter.ReadObject(); f != ""; f = iter.ReadObject() {
+			if f == "query" && iter.WhatIsNext() == jsoniter.StringValue {
+				query = iter.ReadString()
+			} else {
+				iter.Skip()
+			}
+		}
+		return query
+	default:
+		iter.Skip()
+		return ""
+	}
+}
+
+func stringInSlice(str string, slice []string) bool {
+	for _, s := range slice {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
+
 // will always return strings for now
 func readPanelInfo(iter *jsoniter.Iterator, lookup DatasourceLookup) PanelInfo {
 	panel := PanelInfo{}
@@ -232,6 +274,7 @@ func readPanelInfo(iter *jsoniter.Iterator, lookup DatasourceLookup) PanelInfo {
 	}
 
 	panel.Datasource = targets.GetDatasourceInfo()
+	panel.Queries = targets.queries
 
 	return panel
 }
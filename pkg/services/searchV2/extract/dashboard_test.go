@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -15,6 +16,7 @@ func TestReadDashboard(t *testing.T) {
 		"check-string-datasource-id",
 		"all-panels",
 		"panel-graph/graph-shared-tooltips",
+		"panel-queries",
 	}
 
 	// key will allow name or uid
@@ -64,3 +66,28 @@ func TestReadDashboard(t *testing.T) {
 		}
 	}
 }
+
+func TestReadPanel(t *testing.T) {
+	ds := func(ref *DataSourceRef) *DataSourceRef {
+		if ref == nil || ref.UID == "" {
+			return &DataSourceRef{
+				UID:  "default.uid",
+				Type: "default.type",
+			}
+		}
+		return ref
+	}
+
+	panel, err := ReadPanel(strings.NewReader(`{
+		"type": "timeseries",
+		"title": "CPU",
+		"description": "CPU usage",
+		"datasource": {"uid": "prom", "type": "prometheus"},
+		"targets": [{"refId": "A"}]
+	}`), ds)
+	require.NoError(t, err)
+	require.Equal(t, "timeseries", panel.Type)
+	require.Equal(t, "CPU", panel.Title)
+	require.Equal(t, "CPU usage", panel.Description)
+	require.Equal(t, []DataSourceRef{{UID: "prom", Type: "prometheus"}}, panel.Datasource)
+}
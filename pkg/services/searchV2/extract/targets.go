@@ -1,12 +1,24 @@
 package extract
 
 import (
+	"sort"
+
 	jsoniter "github.com/json-iterator/go"
 )
 
+// queryTextFields are the properties of a target that hold the query text of the common data sources.
+var queryTextFields = map[string]bool{
+	"expr":       true, // Prometheus, Loki
+	"rawSql":     true, // MySQL, PostgreSQL, MSSQL
+	"target":     true, // Graphite
+	"query":      true, // InfluxDB, Elasticsearch and others
+	"expression": true, // CloudWatch, server side expressions
+}
+
 type targetInfo struct {
-	lookup DatasourceLookup
-	uids   map[string]*DataSourceRef
+	lookup  DatasourceLookup
+	uids    map[string]*DataSourceRef
+	queries []string
 }
 
 func newTargetInfo(lookup DatasourceLookup) targetInfo {
@@ -23,6 +35,9 @@ func (s *targetInfo) GetDatasourceInfo() []DataSourceRef {
 		keys[i] = *v
 		i++
 	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].UID < keys[j].UID
+	})
 	return keys
 }
 
@@ -66,6 +81,13 @@ func (s *targetInfo) addTarget(iter *jsoniter.Iterator) {
 			iter.Skip()
 
 		default:
+			if queryTextFields[l1Field] && iter.WhatIsNext() == jsoniter.StringValue {
+				if query := iter.ReadString(); query != "" {
+					s.queries = append(s.queries, query)
+				}
+				continue
+			}
+
 			v := iter.Read()
 			logf("[Panel.TARGET] %s=%v\n", l1Field, v)
 		}
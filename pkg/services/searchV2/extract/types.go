@@ -16,6 +16,7 @@ type PanelInfo struct {
 	PluginVersion string          `json:"pluginVersion,omitempty"`
 	Datasource    []DataSourceRef `json:"datasource,omitempty"`  // UIDs
 	Transformer   []string        `json:"transformer,omitempty"` // ids of the transformation steps
+	Queries       []string        `json:"queries,omitempty"`     // query text of the targets
 
 	// Rows define panels as sub objects
 	Collapsed []PanelInfo `json:"collapsed,omitempty"`
@@ -27,6 +28,7 @@ type DashboardInfo struct {
 	Description   string          `json:"description,omitempty"`
 	Tags          []string        `json:"tags"`
 	TemplateVars  []string        `json:"templateVars,omitempty"` // the keys used
+	VarQueries    []string        `json:"varQueries,omitempty"`   // query text and definitions of the variables
 	Datasource    []DataSourceRef `json:"datasource,omitempty"`   // UIDs
 	Panels        []PanelInfo     `json:"panels"`                 // nesed documents
 	SchemaVersion int64           `json:"schemaVersion"`
@@ -2,6 +2,7 @@ package searchV2
 
 import (
 	"regexp"
+	"strings"
 
 	"github.com/blugelabs/bluge"
 	"github.com/blugelabs/bluge/search"
@@ -18,21 +19,33 @@ type PermissionFilter struct {
 type entityKind string
 
 const (
-	entityKindPanel     entityKind = "panel"
-	entityKindDashboard entityKind = "dashboard"
-	entityKindFolder    entityKind = "folder"
+	entityKindPanel        entityKind = "panel"
+	entityKindDashboard    entityKind = "dashboard"
+	entityKindFolder       entityKind = "folder"
+	entityKindAlertRule    entityKind = "alertrule"
+	entityKindLibraryPanel entityKind = "librarypanel"
+	entityKindDatasource   entityKind = "datasource"
+	entityKindPlaylist     entityKind = "playlist"
 )
 
+// entityKindDatasourceQuery is not indexed. The ResourceFilter is asked with it if the user can query a data source.
+const entityKindDatasourceQuery entityKind = "datasource:query"
+
 func (r entityKind) IsValid() bool {
-	return r == entityKindPanel || r == entityKindDashboard || r == entityKindFolder
+	switch r {
+	case entityKindPanel, entityKindDashboard, entityKindFolder,
+		entityKindAlertRule, entityKindLibraryPanel, entityKindDatasource, entityKindPlaylist:
+		return true
+	}
+	return false
 }
 
 func (r entityKind) supportsAuthzCheck() bool {
-	return r == entityKindPanel || r == entityKindDashboard || r == entityKindFolder
+	return r.IsValid()
 }
 
 var (
-	permissionFilterFields                 = []string{documentFieldUID, documentFieldKind}
+	permissionFilterFields                 = []string{documentFieldUID, documentFieldKind, documentFieldLocation, documentFieldDSUID}
 	panelIdFieldRegex                      = regexp.MustCompile(`^(.*)#([0-9]{1,4})$`)
 	panelIdFieldDashboardUidSubmatchIndex  = 1
 	panelIdFieldPanelIdSubmatchIndex       = 2
@@ -61,14 +74,12 @@ func (q *PermissionFilter) logAccessDecision(decision bool, kind interface{}, id
 	}
 }
 
-func (q *PermissionFilter) canAccess(kind entityKind, id string) bool {
+func (q *PermissionFilter) canAccess(kind entityKind, id string, location string, dsUIDs []string) bool {
 	if !kind.supportsAuthzCheck() {
 		q.logAccessDecision(false, kind, id, "entityDoesNotSupportAuthz")
 		return false
 	}
 
-	// TODO add `kind` to the `ResourceFilter` interface so that we can move the switch out of here
-	//
 	switch kind {
 	case entityKindFolder:
 		if id == "" {
@@ -77,7 +88,40 @@ func (q *PermissionFilter) canAccess(kind entityKind, id string) bool {
 		}
 		fallthrough
 	case entityKindDashboard:
-		decision := q.filter(id)
+		decision := q.filter(kind, id)
+		q.logAccessDecision(decision, kind, id, "resourceFilter")
+		return decision
+	case entityKindAlertRule:
+		// Alert rules can be read by those who can read their folder and the alert rules in it, and query all of
+		// their data sources.
+		if location != "general" && !q.filter(entityKindFolder, location) {
+			q.logAccessDecision(false, kind, id, "resourceFilter", "folderUid", location)
+			return false
+		}
+		if !q.filter(entityKindAlertRule, location) {
+			q.logAccessDecision(false, kind, id, "alertRuleReadFilter", "folderUid", location)
+			return false
+		}
+		for _, dsUID := range dsUIDs {
+			if !q.filter(entityKindDatasourceQuery, dsUID) {
+				q.logAccessDecision(false, kind, id, "datasourceQueryFilter", "datasourceUid", dsUID)
+				return false
+			}
+		}
+		q.logAccessDecision(true, kind, id, "resourceFilter", "folderUid", location)
+		return true
+	case entityKindLibraryPanel:
+		// Library panels can be read by those who can read their folder.
+		if location == "general" {
+			q.logAccessDecision(true, kind, id, "generalFolder")
+			return true
+		}
+		decision := q.filter(entityKindFolder, location)
+		q.logAccessDecision(decision, kind, id, "resourceFilter", "folderUid", location)
+		return decision
+	case entityKindDatasource, entityKindPlaylist:
+		uid := strings.TrimPrefix(id, string(kind)+"/")
+		decision := q.filter(kind, uid)
 		q.logAccessDecision(decision, kind, id, "resourceFilter")
 		return decision
 	case entityKindPanel:
@@ -90,7 +134,7 @@ func (q *PermissionFilter) canAccess(kind entityKind, id string) bool {
 		}
 
 		dashboardUid := matches[panelIdFieldDashboardUidSubmatchIndex]
-		decision := q.filter(dashboardUid)
+		decision := q.filter(entityKindDashboard, dashboardUid)
 
 		q.logAccessDecision(decision, kind, id, "resourceFilter", "dashboardUid", dashboardUid, "panelId", matches[panelIdFieldPanelIdSubmatchIndex])
 		return decision
@@ -108,12 +152,18 @@ func (q *PermissionFilter) Searcher(i search.Reader, options search.SearcherOpti
 
 	s, err := searcher.NewMatchAllSearcher(i, 1, similarity.ConstantScorer(1), options)
 	return searcher.NewFilteringSearcher(s, func(d *search.DocumentMatch) bool {
-		var kind, id string
+		var kind, id, location string
+		var dsUIDs []string
 		err := dvReader.VisitDocumentValues(d.Number, func(field string, term []byte) {
-			if field == documentFieldKind {
+			switch field {
+			case documentFieldKind:
 				kind = string(term)
-			} else if field == documentFieldUID {
+			case documentFieldUID:
 				id = string(term)
+			case documentFieldLocation:
+				location = string(term)
+			case documentFieldDSUID:
+				dsUIDs = append(dsUIDs, string(term))
 			}
 		})
 		if err != nil {
@@ -127,6 +177,6 @@ func (q *PermissionFilter) Searcher(i search.Reader, options search.SearcherOpti
 			return false
 		}
 
-		return q.canAccess(e, id)
+		return q.canAccess(e, id, location, dsUIDs)
 	}), err
 }
@@ -3,9 +3,11 @@ package searchV2
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"strings"
@@ -53,35 +55,56 @@ type dashboard struct {
 type dashboardIndex struct {
 	mu             sync.RWMutex
 	loader         dashboardLoader
+	entityLoader   entityLoader
 	perOrgReader   map[int64]*bluge.Reader // orgId -> bluge reader
 	perOrgWriter   map[int64]*bluge.Writer // orgId -> bluge writer
+	perOrgDir      map[int64]string        // orgId -> index directory inside indexPath
 	eventStore     eventStore
 	logger         log.Logger
 	buildSignals   chan int64
 	extender       DocumentExtender
 	folderIdLookup folderUIDLookup
+	indexPath      string // indexes are kept in memory only when empty
+	// fullReIndexInterval is how often the indexes are rebuilt from the database, never if 0.
+	fullReIndexInterval time.Duration
 }
 
-func newDashboardIndex(dashLoader dashboardLoader, evStore eventStore, extender DocumentExtender, folderIDs folderUIDLookup) *dashboardIndex {
+func newDashboardIndex(dashLoader dashboardLoader, entLoader entityLoader, evStore eventStore, extender DocumentExtender, folderIDs folderUIDLookup, indexPath string, fullReIndexInterval time.Duration) *dashboardIndex {
 	return &dashboardIndex{
-		loader:         dashLoader,
-		eventStore:     evStore,
-		perOrgReader:   map[int64]*bluge.Reader{},
-		perOrgWriter:   map[int64]*bluge.Writer{},
-		logger:         log.New("dashboardIndex"),
-		buildSignals:   make(chan int64),
-		extender:       extender,
-		folderIdLookup: folderIDs,
+		loader:              dashLoader,
+		entityLoader:        entLoader,
+		eventStore:          evStore,
+		perOrgReader:        map[int64]*bluge.Reader{},
+		perOrgWriter:        map[int64]*bluge.Writer{},
+		perOrgDir:           map[int64]string{},
+		logger:              log.New("dashboardIndex"),
+		buildSignals:        make(chan int64),
+		extender:            extender,
+		folderIdLookup:      folderIDs,
+		indexPath:           indexPath,
+		fullReIndexInterval: fullReIndexInterval,
 	}
 }
 
 func (i *dashboardIndex) run(ctx context.Context) error {
-	fullReIndexTicker := time.NewTicker(5 * time.Minute)
-	defer fullReIndexTicker.Stop()
+	var fullReIndex <-chan time.Time
+	if i.fullReIndexInterval > 0 {
+		fullReIndexTicker := time.NewTicker(i.fullReIndexInterval)
+		defer fullReIndexTicker.Stop()
+		fullReIndex = fullReIndexTicker.C
+	}
 
 	partialUpdateTicker := time.NewTicker(5 * time.Second)
 	defer partialUpdateTicker.Stop()
 
+	if i.indexPath != "" {
+		if err := os.MkdirAll(i.indexPath, 0750); err != nil {
+			i.logger.Error("Can't create search index directory, keeping index in memory", "indexPath", i.indexPath, "error", err)
+			i.indexPath = ""
+		}
+	}
+	defer i.closeIndexes()
+
 	var lastEventID int64
 	lastEvent, err := i.eventStore.GetLastEvent(ctx)
 	if err != nil {
@@ -111,8 +134,8 @@ func (i *dashboardIndex) run(ctx context.Context) error {
 				continue
 			}
 			i.mu.RUnlock()
-			_, _ = i.buildOrgIndex(ctx, orgID)
-		case <-fullReIndexTicker.C:
+			_, _ = i.loadOrgIndex(ctx, orgID)
+		case <-fullReIndex:
 			started := time.Now()
 			i.reIndexFromScratch(ctx)
 			i.logger.Info("Full re-indexing finished", "fullReIndexElapsed", time.Since(started))
@@ -130,7 +153,7 @@ func (i *dashboardIndex) buildInitialIndex(ctx context.Context) error {
 
 	// Build on start for orgID 1 but keep lazy for others.
 	started := time.Now()
-	numDashboards, err := i.buildOrgIndex(ctx, 1)
+	numDashboards, err := i.loadOrgIndex(ctx, 1)
 	if err != nil {
 		memCancel()
 		return fmt.Errorf("can't build dashboard search index for org ID 1: %w", err)
@@ -177,6 +200,16 @@ func (i *dashboardIndex) debugMemStats(ctx context.Context, frequency time.Durat
 }
 
 func (i *dashboardIndex) reportSizeOfIndexDiskBackup(orgID int64) {
+	if dir, ok := i.getOrgDir(orgID); ok {
+		size, err := dirSize(filepath.Join(i.indexPath, dir))
+		if err != nil {
+			i.logger.Error("can't calculate dir size", "error", err)
+			return
+		}
+		i.logger.Warn("Size of persisted index", "size", formatBytes(uint64(size)))
+		return
+	}
+
 	reader, _ := i.getOrgReader(orgID)
 
 	// create a temp directory to store the index
@@ -209,22 +242,144 @@ func (i *dashboardIndex) reportSizeOfIndexDiskBackup(orgID int64) {
 	i.logger.Warn("Size of index disk backup", "size", formatBytes(uint64(size)))
 }
 
+// loadOrgIndex opens the persisted index of an organization and brings it up to date, or builds
+// the index from scratch if there is no usable persisted index.
+func (i *dashboardIndex) loadOrgIndex(ctx context.Context, orgID int64) (int, error) {
+	if i.indexPath != "" {
+		started := time.Now()
+		numDashboards, ok, err := i.openOrgIndex(ctx, orgID)
+		if err != nil {
+			i.logger.Warn("Can't open persisted org index, rebuilding it", "orgId", orgID, "error", err)
+		} else if ok {
+			i.logger.Info("Opened persisted org index", "orgId", orgID, "elapsed", time.Since(started), "orgSearchDashboardCount", numDashboards)
+			return numDashboards, nil
+		}
+	}
+	return i.buildOrgIndex(ctx, orgID)
+}
+
+// openOrgIndex opens the persisted index of an organization and applies the entity events since its
+// checkpoint. It returns false if there is no persisted index, or if it can't be brought up to date.
+func (i *dashboardIndex) openOrgIndex(ctx context.Context, orgID int64) (int, bool, error) {
+	cp, err := readIndexCheckpoint(i.indexPath, orgID)
+	if err != nil || cp == nil {
+		return 0, false, err
+	}
+	if cp.Version != indexFormatVersion {
+		i.logger.Info("Persisted org index has an outdated format", "orgId", orgID, "version", cp.Version)
+		return 0, false, nil
+	}
+	if updated := time.Unix(cp.Updated, 0); time.Since(updated) > maxIndexCheckpointAge {
+		i.logger.Info("Persisted org index is too old to be updated", "orgId", orgID, "updated", updated)
+		return 0, false, nil
+	}
+	dir := filepath.Join(i.indexPath, cp.Dir)
+	if _, err := os.Stat(dir); err != nil {
+		return 0, false, fmt.Errorf("can't find persisted index: %w", err)
+	}
+
+	lastEvent, err := i.eventStore.GetLastEvent(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+	if (lastEvent == nil && cp.LastEventID > 0) || (lastEvent != nil && lastEvent.Id < cp.LastEventID) {
+		// Entity events were removed, for example since the database was restored from a backup.
+		i.logger.Info("Persisted org index is ahead of entity events", "orgId", orgID, "lastEventId", cp.LastEventID)
+		return 0, false, nil
+	}
+	events, err := i.eventStore.GetAllEventsAfter(ctx, cp.LastEventID)
+	if err != nil {
+		return 0, false, err
+	}
+
+	writer, err := bluge.OpenWriter(bluge.DefaultConfig(dir))
+	if err != nil {
+		return 0, false, fmt.Errorf("error opening writer: %w", err)
+	}
+	reader, err := writer.Reader()
+	if err != nil {
+		_ = writer.Close()
+		return 0, false, fmt.Errorf("error getting reader: %w", err)
+	}
+	i.setOrgIndex(orgID, reader, writer, cp.Dir)
+
+	lastEventID := cp.LastEventID
+	for _, e := range events {
+		if err := i.applyEventOnIndex(ctx, e); err != nil {
+			return 0, false, fmt.Errorf("can't apply event: %w", err)
+		}
+		lastEventID = e.Id
+	}
+	i.saveCheckpointWhenPersisted(orgID, writer, cp.Dir, lastEventID)
+
+	reader, _ = i.getOrgReader(orgID)
+	numDashboards, err := countDashboards(ctx, reader)
+	if err != nil {
+		return 0, false, err
+	}
+	if orgID == 1 {
+		go updateUsageStats(context.Background(), reader, i.logger)
+	}
+	return numDashboards, true, nil
+}
+
+func countDashboards(ctx context.Context, reader *bluge.Reader) (int, error) {
+	req := bluge.NewTopNSearch(0, bluge.NewTermQuery(string(entityKindDashboard)).SetField(documentFieldKind)).
+		WithStandardAggregations()
+	documentMatchIterator, err := reader.Search(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+	return int(documentMatchIterator.Aggregations().Count()), nil
+}
+
 func (i *dashboardIndex) buildOrgIndex(ctx context.Context, orgID int64) (int, error) {
 	started := time.Now()
 	ctx, cancel := context.WithTimeout(ctx, time.Minute)
 	defer cancel()
 
+	// Events from now on are replayed when opening the persisted index, so the
+	// ones applied while building the index don't get lost.
+	var lastEventID int64
+	if i.indexPath != "" {
+		lastEvent, err := i.eventStore.GetLastEvent(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("error loading last event: %w", err)
+		}
+		if lastEvent != nil {
+			lastEventID = lastEvent.Id
+		}
+	}
+
 	i.logger.Info("Start building org index", "orgId", orgID)
 	dashboards, err := i.loader.LoadDashboards(ctx, orgID, "")
 	if err != nil {
 		return 0, fmt.Errorf("error loading dashboards: %w", err)
 	}
+	var entities []entity
+	for _, kind := range indexedEntityKinds {
+		kindEntities, err := i.entityLoader.LoadEntities(ctx, orgID, kind, "")
+		if err != nil {
+			return 0, fmt.Errorf("error loading %s entities: %w", kind, err)
+		}
+		entities = append(entities, kindEntities...)
+	}
 	orgSearchIndexLoadTime := time.Since(started)
 	i.logger.Info("Finish loading org dashboards", "elapsed", orgSearchIndexLoadTime, "orgId", orgID)
 
+	config := bluge.InMemoryOnlyConfig()
+	var dir string
+	if i.indexPath != "" {
+		dir = newOrgIndexDir(orgID)
+		config = bluge.DefaultConfig(filepath.Join(i.indexPath, dir))
+	}
+
 	dashboardExtender := i.extender.GetDashboardExtender(orgID)
-	reader, writer, err := initIndex(dashboards, i.logger, dashboardExtender)
+	reader, writer, err := initIndex(config, dashboards, entities, i.logger, dashboardExtender)
 	if err != nil {
+		if dir != "" {
+			_ = os.RemoveAll(filepath.Join(i.indexPath, dir))
+		}
 		return 0, fmt.Errorf("error initializing index: %w", err)
 	}
 	orgSearchIndexTotalTime := time.Since(started)
@@ -235,8 +390,23 @@ func (i *dashboardIndex) buildOrgIndex(ctx context.Context, orgID int64) (int, e
 		"orgSearchIndexLoadTime", orgSearchIndexLoadTime,
 		"orgSearchIndexBuildTime", orgSearchIndexBuildTime,
 		"orgSearchIndexTotalTime", orgSearchIndexTotalTime,
-		"orgSearchDashboardCount", len(dashboards))
+		"orgSearchDashboardCount", len(dashboards),
+		"orgSearchEntityCount", len(entities))
 
+	i.setOrgIndex(orgID, reader, writer, dir)
+	if dir != "" {
+		i.saveCheckpointWhenPersisted(orgID, writer, dir, lastEventID)
+	}
+
+	if orgID == 1 {
+		go updateUsageStats(context.Background(), reader, i.logger)
+	}
+	return len(dashboards), nil
+}
+
+// setOrgIndex replaces the index of an organization, and removes the previous index directories once the
+// previous index is closed.
+func (i *dashboardIndex) setOrgIndex(orgID int64, reader *bluge.Reader, writer *bluge.Writer, dir string) {
 	i.mu.Lock()
 	if oldReader, ok := i.perOrgReader[orgID]; ok {
 		_ = oldReader.Close()
@@ -246,12 +416,100 @@ func (i *dashboardIndex) buildOrgIndex(ctx context.Context, orgID int64) (int, e
 	}
 	i.perOrgReader[orgID] = reader
 	i.perOrgWriter[orgID] = writer
+	if dir != "" {
+		i.perOrgDir[orgID] = dir
+	}
 	i.mu.Unlock()
 
-	if orgID == 1 {
-		go updateUsageStats(context.Background(), reader, i.logger)
+	if dir != "" {
+		if err := removeStaleOrgIndexDirs(i.indexPath, orgID, dir); err != nil {
+			i.logger.Warn("Can't remove previous org index", "orgId", orgID, "error", err)
+		}
 	}
-	return len(dashboards), nil
+}
+
+// closeIndexes closes the indexes of all organizations. Persisted indexes are flushed to disk first, since
+// closing a writer drops the changes that are not persisted yet.
+func (i *dashboardIndex) closeIndexes() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for orgID, reader := range i.perOrgReader {
+		_ = reader.Close()
+		delete(i.perOrgReader, orgID)
+	}
+	for orgID, writer := range i.perOrgWriter {
+		if i.indexPath != "" {
+			if err := waitPersisted(writer, indexPersistTimeout); err != nil {
+				i.logger.Warn("Can't flush org index", "orgId", orgID, "error", err)
+			}
+		}
+		if err := writer.Close(); err != nil {
+			i.logger.Warn("Can't close org index", "orgId", orgID, "error", err)
+		}
+		delete(i.perOrgWriter, orgID)
+	}
+}
+
+// saveCheckpoints records that the persisted indexes of all organizations reflect the entity events
+// up to lastEventID, once the changes made so far are persisted.
+func (i *dashboardIndex) saveCheckpoints(lastEventID int64) {
+	if i.indexPath == "" {
+		return
+	}
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	for orgID, dir := range i.perOrgDir {
+		if writer, ok := i.perOrgWriter[orgID]; ok {
+			i.saveCheckpointWhenPersisted(orgID, writer, dir, lastEventID)
+		}
+	}
+}
+
+// saveCheckpointWhenPersisted writes the checkpoint of the persisted index of an organization once the changes
+// made so far with the writer are on disk. Bluge persists batches in the background, so a checkpoint written
+// right away could skip events whose changes are lost on a crash. It is written when an empty batch is
+// persisted, because the batches before it are persisted with it or earlier.
+func (i *dashboardIndex) saveCheckpointWhenPersisted(orgID int64, writer *bluge.Writer, dir string, lastEventID int64) {
+	batch := bluge.NewBatch()
+	// The callback runs in the persister of the writer, which is waited for when closing the writer while
+	// holding i.mu, so it must not lock i.mu.
+	batch.SetPersistedCallback(func(err error) {
+		if err != nil {
+			i.logger.Error("Can't persist org index", "orgId", orgID, "error", err)
+			return
+		}
+		if err := writeIndexCheckpoint(i.indexPath, orgID, indexCheckpoint{Dir: dir, LastEventID: lastEventID}); err != nil {
+			i.logger.Error("Can't write org index checkpoint", "orgId", orgID, "error", err)
+		}
+	})
+	if err := writer.Batch(batch); err != nil {
+		i.logger.Error("Can't write org index checkpoint", "orgId", orgID, "error", err)
+	}
+}
+
+// waitPersisted waits until the changes made so far with the writer are on disk.
+func waitPersisted(writer *bluge.Writer, timeout time.Duration) error {
+	persisted := make(chan error, 1)
+	batch := bluge.NewBatch()
+	batch.SetPersistedCallback(func(err error) {
+		persisted <- err
+	})
+	if err := writer.Batch(batch); err != nil {
+		return err
+	}
+	select {
+	case err := <-persisted:
+		return err
+	case <-time.After(timeout):
+		return errors.New("timed out waiting for the index to be persisted")
+	}
+}
+
+func (i *dashboardIndex) getOrgDir(orgID int64) (string, bool) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	dir, ok := i.perOrgDir[orgID]
+	return dir, ok
 }
 
 func (i *dashboardIndex) getOrgReader(orgID int64) (*bluge.Reader, bool) {
@@ -304,6 +562,7 @@ func (i *dashboardIndex) applyIndexUpdates(ctx context.Context, lastEventID int6
 		lastEventID = e.Id
 	}
 	i.logger.Info("Index updates applied", "indexEventsAppliedElapsed", time.Since(started), "numEvents", len(events))
+	i.saveCheckpoints(lastEventID)
 	return lastEventID
 }
 
@@ -341,6 +600,10 @@ func (i *dashboardIndex) applyEvent(ctx context.Context, orgID int64, kind store
 	}
 	i.mu.Unlock()
 
+	if kind != store.EntityTypeDashboard && kind != store.EntityTypeFolder {
+		return i.applyEntityEvent(ctx, orgID, entityKind(kind), uid)
+	}
+
 	// Both dashboard and folder share same DB table.
 	dbDashboards, err := i.loader.LoadDashboards(ctx, orgID, uid)
 	if err != nil {
@@ -385,6 +648,66 @@ func (i *dashboardIndex) applyEvent(ctx context.Context, orgID int64, kind store
 	return nil
 }
 
+// applyEntityEvent updates the document of an entity other than a dashboard or folder, or removes it if the
+// entity does not exist anymore.
+func (i *dashboardIndex) applyEntityEvent(ctx context.Context, orgID int64, kind entityKind, uid string) error {
+	if !kind.IsValid() {
+		i.logger.Warn("unknown entity kind", "kind", kind, "uid", uid)
+		return nil
+	}
+
+	entities, err := i.entityLoader.LoadEntities(ctx, orgID, kind, uid)
+	if err != nil {
+		return err
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	writer, ok := i.perOrgWriter[orgID]
+	if !ok {
+		// Skip event for org not yet fully indexed.
+		return nil
+	}
+	reader, ok := i.perOrgReader[orgID]
+	if !ok {
+		// Skip event for org not yet fully indexed.
+		return nil
+	}
+
+	var newReader *bluge.Reader
+	if len(entities) == 0 {
+		newReader, err = i.removeEntity(ctx, writer, kind, uid)
+	} else {
+		newReader, err = i.updateEntity(ctx, writer, entities[0])
+	}
+	if err != nil {
+		return err
+	}
+	_ = reader.Close()
+	i.perOrgReader[orgID] = newReader
+	return nil
+}
+
+func (i *dashboardIndex) removeEntity(_ context.Context, writer *bluge.Writer, kind entityKind, uid string) (*bluge.Reader, error) {
+	batch := bluge.NewBatch()
+	batch.Delete(bluge.NewDocument(entityDocID(kind, uid)).ID())
+	if err := writer.Batch(batch); err != nil {
+		return nil, err
+	}
+	return writer.Reader()
+}
+
+func (i *dashboardIndex) updateEntity(_ context.Context, writer *bluge.Writer, e entity) (*bluge.Reader, error) {
+	doc := getEntityDoc(e)
+	batch := bluge.NewBatch()
+	batch.Update(doc.ID(), doc)
+	if err := writer.Batch(batch); err != nil {
+		return nil, err
+	}
+	return writer.Reader()
+}
+
 func (i *dashboardIndex) removeDashboard(_ context.Context, writer *bluge.Writer, reader *bluge.Reader, dashboardUID string) (*bluge.Reader, error) {
 	dashboardLocation, ok, err := getDashboardLocation(reader, dashboardUID)
 	if err != nil {
@@ -2,9 +2,12 @@ package searchV2
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 
@@ -25,13 +28,50 @@ func (t *testDashboardLoader) LoadDashboards(_ context.Context, _ int64, _ strin
 	return t.dashboards, nil
 }
 
+type testEntityLoader struct {
+	entities []entity
+}
+
+func (t *testEntityLoader) LoadEntities(_ context.Context, _ int64, kind entityKind, uid string) ([]entity, error) {
+	var entities []entity
+	for _, e := range t.entities {
+		if e.kind == kind && (uid == "" || e.uid == uid) {
+			entities = append(entities, e)
+		}
+	}
+	return entities, nil
+}
+
+type testEventStore struct {
+	events []*store.EntityEvent
+}
+
+func (t *testEventStore) OnEvent(_ store.EventHandler) {}
+
+func (t *testEventStore) GetLastEvent(_ context.Context) (*store.EntityEvent, error) {
+	if len(t.events) == 0 {
+		return nil, nil
+	}
+	return t.events[len(t.events)-1], nil
+}
+
+func (t *testEventStore) GetAllEventsAfter(_ context.Context, id int64) ([]*store.EntityEvent, error) {
+	var events []*store.EntityEvent
+	for _, e := range t.events {
+		if e.Id > id {
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
+
 var testLogger = log.New("index-test-logger")
 
-var testAllowAllFilter = func(uid string) bool {
+var testAllowAllFilter = func(kind entityKind, uid string) bool {
 	return true
 }
 
-var testDisallowAllFilter = func(uid string) bool {
+var testDisallowAllFilter = func(kind entityKind, uid string) bool {
 	return false
 }
 
@@ -43,15 +83,22 @@ func initTestIndexFromDashes(t *testing.T, dashboards []dashboard) (*dashboardIn
 }
 
 func initTestIndexFromDashesExtended(t *testing.T, dashboards []dashboard, extender DocumentExtender) (*dashboardIndex, *bluge.Reader, *bluge.Writer) {
+	t.Helper()
+	return initTestIndex(t, dashboards, nil, extender)
+}
+
+func initTestIndex(t *testing.T, dashboards []dashboard, entities []entity, extender DocumentExtender) (*dashboardIndex, *bluge.Reader, *bluge.Writer) {
 	t.Helper()
 	dashboardLoader := &testDashboardLoader{
 		dashboards: dashboards,
 	}
 	index := newDashboardIndex(
 		dashboardLoader,
+		&testEntityLoader{entities: entities},
 		&store.MockEntityEventsService{},
 		extender,
-		func(ctx context.Context, folderId int64) (string, error) { return "x", nil })
+		func(ctx context.Context, folderId int64) (string, error) { return "x", nil },
+		"", 0)
 	require.NotNil(t, index)
 	numDashboards, err := index.buildOrgIndex(context.Background(), testOrgID)
 	require.NoError(t, err)
@@ -70,7 +117,7 @@ func checkSearchResponse(t *testing.T, fileName string, reader *bluge.Reader, fi
 
 func checkSearchResponseExtended(t *testing.T, fileName string, reader *bluge.Reader, filter ResourceFilter, query DashboardQuery, extender QueryExtender) {
 	t.Helper()
-	resp := doSearchQuery(context.Background(), testLogger, reader, filter, query, extender, "/pfix")
+	resp := doSearchQuery(context.Background(), testLogger, reader, filter, query, extender, "/pfix", nil)
 	experimental.CheckGoldenJSONResponse(t, "testdata", fileName, resp, true)
 }
 
@@ -441,7 +488,7 @@ func TestDashboardIndex_Folders(t *testing.T) {
 		// TODO: golden file compare does not work here.
 		resp := doSearchQuery(context.Background(), testLogger, reader, testAllowAllFilter,
 			DashboardQuery{Query: "Dashboard in folder", Kind: []string{string(entityKindDashboard)}},
-			&NoopQueryExtender{}, "")
+			&NoopQueryExtender{}, "", nil)
 		custom, ok := resp.Frames[0].Meta.Custom.(*customMeta)
 		require.Equal(t, uint64(2), custom.Count)
 		require.True(t, ok, fmt.Sprintf("actual type: %T", resp.Frames[0].Meta.Custom))
@@ -462,7 +509,7 @@ func TestDashboardIndex_Folders(t *testing.T) {
 		require.NoError(t, err)
 		resp := doSearchQuery(context.Background(), testLogger, newReader, testAllowAllFilter,
 			DashboardQuery{Query: "Panel", Kind: []string{string(entityKindPanel)}},
-			&NoopQueryExtender{}, "")
+			&NoopQueryExtender{}, "", nil)
 		custom, ok := resp.Frames[0].Meta.Custom.(*customMeta)
 		require.True(t, ok)
 		require.Equal(t, uint64(1), custom.Count) // 1 panel which does not belong to dashboards in removed folder.
@@ -496,7 +543,7 @@ func TestDashboardIndex_Panels(t *testing.T) {
 		resp := doSearchQuery(
 			context.Background(), testLogger, reader, testAllowAllFilter,
 			DashboardQuery{Query: "Panel", Kind: []string{string(entityKindPanel)}},
-			&NoopQueryExtender{}, "")
+			&NoopQueryExtender{}, "", nil)
 		custom, ok := resp.Frames[0].Meta.Custom.(*customMeta)
 		require.True(t, ok, fmt.Sprintf("actual type: %T", resp.Frames[0].Meta.Custom))
 		require.Equal(t, uint64(2), custom.Count)
@@ -511,3 +558,414 @@ func TestDashboardIndex_Panels(t *testing.T) {
 		)
 	})
 }
+
+var testEntities = []entity{
+	{
+		kind:       entityKindAlertRule,
+		uid:        "rule-1",
+		name:       "High CPU",
+		location:   "1",
+		url:        "/alerting/grafana/rule-1/view",
+		tags:       []string{"team=ops"},
+		datasource: []extract.DataSourceRef{{UID: "prom", Type: "prometheus"}},
+	},
+	{
+		kind:     entityKindAlertRule,
+		uid:      "rule-2",
+		name:     "High memory",
+		location: "5",
+		url:      "/alerting/grafana/rule-2/view",
+		tags:     []string{"team=dev"},
+	},
+	{
+		kind:       entityKindLibraryPanel,
+		uid:        "lib-1",
+		name:       "CPU usage",
+		location:   "general",
+		url:        "/library-panels",
+		panelType:  "timeseries",
+		datasource: []extract.DataSourceRef{{UID: "prom", Type: "prometheus"}},
+		dashboards: []string{"2"},
+	},
+	{
+		kind:       entityKindDatasource,
+		uid:        "prom",
+		name:       "Prometheus",
+		url:        "/datasources/edit/prom",
+		datasource: []extract.DataSourceRef{{UID: "prom", Type: "prometheus"}},
+	},
+	{
+		kind: entityKindPlaylist,
+		uid:  "1",
+		name: "CPU playlist",
+		url:  "/playlists/play/1",
+	},
+}
+
+func searchTestCount(t *testing.T, reader *bluge.Reader, filter ResourceFilter, query DashboardQuery) (uint64, *data.Frame) {
+	t.Helper()
+	resp := doSearchQuery(context.Background(), testLogger, reader, filter, query, &NoopQueryExtender{}, "", nil)
+	require.NoError(t, resp.Error)
+	custom, ok := resp.Frames[0].Meta.Custom.(*customMeta)
+	require.True(t, ok, fmt.Sprintf("actual type: %T", resp.Frames[0].Meta.Custom))
+	return custom.Count, resp.Frames[0]
+}
+
+func TestDashboardIndex_Entities(t *testing.T) {
+	t.Run("entities-indexed", func(t *testing.T) {
+		_, reader, _ := initTestIndex(t, dashboardsWithFolders, testEntities, &NoopDocumentExtender{})
+		count, _ := searchTestCount(t, reader, testAllowAllFilter, DashboardQuery{Query: "cpu"})
+		require.Equal(t, uint64(3), count)
+
+		count, frame := searchTestCount(t, reader, testAllowAllFilter,
+			DashboardQuery{Query: "cpu", Kind: []string{string(entityKindAlertRule)}})
+		require.Equal(t, uint64(1), count)
+		require.Equal(t, "alertrule/rule-1", frame.Fields[1].At(0))
+	})
+	t.Run("entities-uids-do-not-collide", func(t *testing.T) {
+		// The playlist and the dashboard both have the UID "1".
+		_, reader, _ := initTestIndex(t, testDashboards, testEntities, &NoopDocumentExtender{})
+		count, _ := searchTestCount(t, reader, testAllowAllFilter, DashboardQuery{Query: "test"})
+		require.Equal(t, uint64(1), count)
+		count, _ = searchTestCount(t, reader, testAllowAllFilter,
+			DashboardQuery{Kind: []string{string(entityKindPlaylist)}})
+		require.Equal(t, uint64(1), count)
+	})
+	t.Run("entities-alert-rule-labels-as-tags", func(t *testing.T) {
+		_, reader, _ := initTestIndex(t, nil, testEntities, &NoopDocumentExtender{})
+		count, _ := searchTestCount(t, reader, testAllowAllFilter, DashboardQuery{Tags: []string{"team=ops"}})
+		require.Equal(t, uint64(1), count)
+	})
+	t.Run("entities-datasource", func(t *testing.T) {
+		_, reader, _ := initTestIndex(t, nil, testEntities, &NoopDocumentExtender{})
+		count, _ := searchTestCount(t, reader, testAllowAllFilter, DashboardQuery{Datasource: "prom"})
+		require.Equal(t, uint64(3), count)
+	})
+	t.Run("entities-connected-dashboards", func(t *testing.T) {
+		_, reader, _ := initTestIndex(t, nil, testEntities, &NoopDocumentExtender{})
+		resp := doSearchQuery(context.Background(), testLogger, reader, testAllowAllFilter,
+			DashboardQuery{Kind: []string{string(entityKindLibraryPanel)}, Facet: []FacetField{{Field: documentFieldDashboardUID}}},
+			&NoopQueryExtender{}, "", nil)
+		require.NoError(t, resp.Error)
+		require.Len(t, resp.Frames, 2)
+		require.Equal(t, "2", resp.Frames[1].Fields[0].At(0))
+	})
+	t.Run("entities-filtered-by-folder-access", func(t *testing.T) {
+		_, reader, _ := initTestIndex(t, nil, testEntities, &NoopDocumentExtender{})
+		filter := func(kind entityKind, uid string) bool {
+			return (kind == entityKindFolder || kind == entityKindAlertRule) && uid == "1" || kind == entityKindDatasourceQuery
+		}
+		count, frame := searchTestCount(t, reader, filter, DashboardQuery{Query: "high"})
+		require.Equal(t, uint64(1), count)
+		require.Equal(t, "alertrule/rule-1", frame.Fields[1].At(0))
+		// The library panel is in the general folder.
+		count, _ = searchTestCount(t, reader, filter, DashboardQuery{Kind: []string{string(entityKindLibraryPanel)}})
+		require.Equal(t, uint64(1), count)
+		// Data sources and playlists are checked by the filter itself.
+		count, _ = searchTestCount(t, reader, filter,
+			DashboardQuery{Kind: []string{string(entityKindDatasource), string(entityKindPlaylist)}})
+		require.Equal(t, uint64(0), count)
+	})
+	t.Run("entities-alert-rules-filtered-by-rule-and-datasource-access", func(t *testing.T) {
+		_, reader, _ := initTestIndex(t, nil, testEntities, &NoopDocumentExtender{})
+		query := DashboardQuery{Kind: []string{string(entityKindAlertRule)}}
+
+		// The folders can be read, but not the alert rules in them.
+		filter := func(kind entityKind, uid string) bool {
+			return kind != entityKindAlertRule
+		}
+		count, _ := searchTestCount(t, reader, filter, query)
+		require.Equal(t, uint64(0), count)
+
+		// The data source of rule-1 cannot be queried.
+		filter = func(kind entityKind, uid string) bool {
+			return kind != entityKindDatasourceQuery || uid != "prom"
+		}
+		count, frame := searchTestCount(t, reader, filter, query)
+		require.Equal(t, uint64(1), count)
+		require.Equal(t, "alertrule/rule-2", frame.Fields[1].At(0))
+	})
+	t.Run("entities-update-and-remove", func(t *testing.T) {
+		index, _, writer := initTestIndex(t, nil, testEntities, &NoopDocumentExtender{})
+		updated := testEntities[0]
+		updated.name = "Disk full"
+		reader, err := index.updateEntity(context.Background(), writer, updated)
+		require.NoError(t, err)
+		count, _ := searchTestCount(t, reader, testAllowAllFilter, DashboardQuery{Query: "disk"})
+		require.Equal(t, uint64(1), count)
+
+		reader, err = index.removeEntity(context.Background(), writer, entityKindAlertRule, "rule-1")
+		require.NoError(t, err)
+		count, _ = searchTestCount(t, reader, testAllowAllFilter, DashboardQuery{Kind: []string{string(entityKindAlertRule)}})
+		require.Equal(t, uint64(1), count)
+	})
+	t.Run("entities-removed-on-folder-removed", func(t *testing.T) {
+		index, reader, writer := initTestIndex(t, dashboardsWithFolders, testEntities, &NoopDocumentExtender{})
+		newReader, err := index.removeFolder(context.Background(), writer, reader, "1")
+		require.NoError(t, err)
+		count, _ := searchTestCount(t, newReader, testAllowAllFilter, DashboardQuery{Kind: []string{string(entityKindAlertRule)}})
+		require.Equal(t, uint64(1), count)
+	})
+}
+
+type countingDashboardLoader struct {
+	testDashboardLoader
+	fullLoads int
+}
+
+func (t *countingDashboardLoader) LoadDashboards(ctx context.Context, orgID int64, dashboardUID string) ([]dashboard, error) {
+	if dashboardUID == "" {
+		t.fullLoads++
+	}
+	return t.testDashboardLoader.LoadDashboards(ctx, orgID, dashboardUID)
+}
+
+func TestDashboardIndex_Persisted(t *testing.T) {
+	indexPath := t.TempDir()
+	ctx := context.Background()
+	folderLookup := func(ctx context.Context, folderId int64) (string, error) { return "x", nil }
+	events := &testEventStore{}
+	entities := &testEntityLoader{entities: testEntities[:1]}
+
+	newIndex := func(loader dashboardLoader) *dashboardIndex {
+		return newDashboardIndex(loader, entities, events, &NoopDocumentExtender{}, folderLookup, indexPath, 0)
+	}
+	searchCount := func(index *dashboardIndex, kind entityKind) uint64 {
+		reader, ok := index.getOrgReader(testOrgID)
+		require.True(t, ok)
+		count, _ := searchTestCount(t, reader, testAllowAllFilter, DashboardQuery{Kind: []string{string(kind)}})
+		return count
+	}
+
+	t.Run("built-when-not-persisted", func(t *testing.T) {
+		loader := &countingDashboardLoader{testDashboardLoader: testDashboardLoader{dashboards: testDashboards}}
+		index := newIndex(loader)
+		numDashboards, err := index.loadOrgIndex(ctx, testOrgID)
+		require.NoError(t, err)
+		require.Equal(t, len(testDashboards), numDashboards)
+		require.Equal(t, 1, loader.fullLoads)
+		index.closeIndexes()
+
+		cp, err := readIndexCheckpoint(indexPath, testOrgID)
+		require.NoError(t, err)
+		require.NotNil(t, cp)
+		require.Equal(t, int64(0), cp.LastEventID)
+	})
+
+	t.Run("opened-and-events-replayed", func(t *testing.T) {
+		entities.entities = testEntities[:2]
+		events.events = append(events.events, &store.EntityEvent{
+			Id:        1,
+			EventType: store.EntityEventTypeCreate,
+			EntityId:  store.CreateDatabaseEntityId("rule-2", testOrgID, store.EntityTypeAlertRule),
+		})
+
+		loader := &countingDashboardLoader{testDashboardLoader: testDashboardLoader{dashboards: testDashboards}}
+		index := newIndex(loader)
+		numDashboards, err := index.loadOrgIndex(ctx, testOrgID)
+		require.NoError(t, err)
+		require.Equal(t, 2, numDashboards)
+		require.Equal(t, 0, loader.fullLoads)
+		require.Equal(t, uint64(2), searchCount(index, entityKindDashboard))
+		require.Equal(t, uint64(2), searchCount(index, entityKindAlertRule))
+		index.closeIndexes()
+
+		cp, err := readIndexCheckpoint(indexPath, testOrgID)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), cp.LastEventID)
+	})
+
+	t.Run("rebuilt-when-events-missing", func(t *testing.T) {
+		// Events can't be replayed once removed, e.g. after restoring the database from a backup.
+		events.events = nil
+
+		loader := &countingDashboardLoader{testDashboardLoader: testDashboardLoader{dashboards: testDashboards[:1]}}
+		index := newIndex(loader)
+		numDashboards, err := index.loadOrgIndex(ctx, testOrgID)
+		require.NoError(t, err)
+		require.Equal(t, 1, numDashboards)
+		require.Equal(t, 1, loader.fullLoads)
+		index.closeIndexes()
+
+		dirs, err := filepath.Glob(filepath.Join(indexPath, "org-1-*"))
+		require.NoError(t, err)
+		require.Len(t, dirs, 1)
+	})
+
+	t.Run("rebuilt-when-format-outdated", func(t *testing.T) {
+		cp, err := readIndexCheckpoint(indexPath, testOrgID)
+		require.NoError(t, err)
+		b, err := json.Marshal(indexCheckpoint{Dir: cp.Dir, LastEventID: cp.LastEventID, Updated: time.Now().Unix(), Version: indexFormatVersion - 1})
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(checkpointPath(indexPath, testOrgID), b, 0600))
+
+		loader := &countingDashboardLoader{testDashboardLoader: testDashboardLoader{dashboards: testDashboards[:1]}}
+		index := newIndex(loader)
+		_, err = index.loadOrgIndex(ctx, testOrgID)
+		require.NoError(t, err)
+		require.Equal(t, 1, loader.fullLoads)
+		index.closeIndexes()
+
+		cp, err = readIndexCheckpoint(indexPath, testOrgID)
+		require.NoError(t, err)
+		require.Equal(t, indexFormatVersion, cp.Version)
+	})
+
+	t.Run("checkpoint-saved-once-persisted", func(t *testing.T) {
+		loader := &countingDashboardLoader{testDashboardLoader: testDashboardLoader{dashboards: testDashboards[:1]}}
+		index := newIndex(loader)
+		_, err := index.loadOrgIndex(ctx, testOrgID)
+		require.NoError(t, err)
+		writer, ok := index.getOrgWriter(testOrgID)
+		require.True(t, ok)
+
+		index.saveCheckpoints(5)
+		require.NoError(t, waitPersisted(writer, indexPersistTimeout))
+		cp, err := readIndexCheckpoint(indexPath, testOrgID)
+		require.NoError(t, err)
+		require.Equal(t, int64(5), cp.LastEventID)
+		index.closeIndexes()
+	})
+}
+
+var dashboardsWithQueries = []dashboard{
+	{
+		id:  1,
+		uid: "1",
+		info: &extract.DashboardInfo{
+			Title:      "API overview",
+			VarQueries: []string{"label_values(up, job)"},
+			Panels: []extract.PanelInfo{
+				{
+					ID:          1,
+					Title:       "Requests",
+					Queries:     []string{`sum(rate(http_requests_total{job="$job"}[5m]))`},
+					Transformer: []string{"organize"},
+				},
+				{
+					ID:      2,
+					Title:   "Orders",
+					Queries: []string{"SELECT created_at AS time, count(*) FROM orders GROUP BY 1"},
+				},
+				{
+					ID:    3,
+					Title: "Collapsed row",
+					Type:  "row",
+					Collapsed: []extract.PanelInfo{
+						{
+							ID:      4,
+							Title:   "Errors",
+							Queries: []string{`sum(rate(http_requests_total{code=~"5.."}[5m]))`},
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		id:  2,
+		uid: "2",
+		info: &extract.DashboardInfo{
+			Title: "http_requests_total",
+		},
+	},
+}
+
+func TestParseFieldQueries(t *testing.T) {
+	text, terms := parseFieldQueries(`errors query:"http_requests_total" transformer:organize query:job* api`)
+	require.Equal(t, "errors api", text)
+	require.Equal(t, []fieldQuery{
+		{field: documentFieldQuery, value: "http_requests_total"},
+		{field: documentFieldTransformer, value: "organize"},
+		{field: documentFieldQuery, value: "job*"},
+	}, terms)
+
+	text, terms = parseFieldQueries("http://localhost:3000 foo:bar")
+	require.Equal(t, "http://localhost:3000 foo:bar", text)
+	require.Empty(t, terms)
+}
+
+func TestDashboardIndex_QueryText(t *testing.T) {
+	_, reader, _ := initTestIndexFromDashes(t, dashboardsWithQueries)
+
+	t.Run("query-text-finds-panels", func(t *testing.T) {
+		count, frame := searchTestCount(t, reader, testAllowAllFilter, DashboardQuery{Query: `query:"http_requests_total"`})
+		require.Equal(t, uint64(2), count)
+		require.ElementsMatch(t, []interface{}{"1#1", "1#4"}, []interface{}{frame.Fields[1].At(0), frame.Fields[1].At(1)})
+	})
+	t.Run("query-text-phrase", func(t *testing.T) {
+		count, _ := searchTestCount(t, reader, testAllowAllFilter, DashboardQuery{Query: `query:"from orders"`})
+		require.Equal(t, uint64(1), count)
+	})
+	t.Run("query-text-prefix", func(t *testing.T) {
+		count, _ := searchTestCount(t, reader, testAllowAllFilter, DashboardQuery{Query: `query:http_req*`})
+		require.Equal(t, uint64(2), count)
+	})
+	t.Run("query-text-combined-with-name", func(t *testing.T) {
+		count, frame := searchTestCount(t, reader, testAllowAllFilter, DashboardQuery{Query: `query:"http_requests_total" errors`})
+		require.Equal(t, uint64(1), count)
+		require.Equal(t, "1#4", frame.Fields[1].At(0))
+	})
+	t.Run("variable", func(t *testing.T) {
+		count, frame := searchTestCount(t, reader, testAllowAllFilter, DashboardQuery{Query: `variable:"label_values"`})
+		require.Equal(t, uint64(1), count)
+		require.Equal(t, "1", frame.Fields[1].At(0))
+	})
+	t.Run("transformer", func(t *testing.T) {
+		count, _ := searchTestCount(t, reader, testAllowAllFilter, DashboardQuery{Query: "transformer:organize"})
+		require.Equal(t, uint64(1), count)
+	})
+	t.Run("query-text-respects-permissions", func(t *testing.T) {
+		count, _ := searchTestCount(t, reader, testDisallowAllFilter, DashboardQuery{Query: `query:"http_requests_total"`})
+		require.Equal(t, uint64(0), count)
+	})
+}
+
+var testRankedDashboards = []dashboard{
+	{
+		id:  1,
+		uid: "1",
+		info: &extract.DashboardInfo{
+			Title: "Archer Data",
+		},
+	},
+	{
+		id:  2,
+		uid: "2",
+		info: &extract.DashboardInfo{
+			Title: "Archer Sync",
+		},
+	},
+}
+
+func TestDashboardIndex_UsageBoosts(t *testing.T) {
+	_, reader, _ := initTestIndexFromDashes(t, testRankedDashboards)
+
+	search := func(t *testing.T, query DashboardQuery, boosts map[string]float64) *data.Frame {
+		t.Helper()
+		resp := doSearchQuery(context.Background(), testLogger, reader, testAllowAllFilter, query, &NoopQueryExtender{}, "", boosts)
+		require.NoError(t, resp.Error)
+		return resp.Frames[0]
+	}
+
+	t.Run("boosted-dashboard-ranks-first", func(t *testing.T) {
+		query := DashboardQuery{Query: "archer", Sort: sortRelevance}
+		frame := search(t, query, map[string]float64{"1": 10})
+		require.Equal(t, 2, frame.Rows())
+		require.Equal(t, "1", frame.Fields[1].At(0))
+
+		frame = search(t, query, map[string]float64{"2": 10})
+		require.Equal(t, 2, frame.Rows())
+		require.Equal(t, "2", frame.Fields[1].At(0))
+	})
+	t.Run("boosts-do-not-add-results", func(t *testing.T) {
+		frame := search(t, DashboardQuery{Query: "sync", Sort: sortRelevance}, map[string]float64{"1": 10})
+		require.Equal(t, 1, frame.Rows())
+		require.Equal(t, "2", frame.Fields[1].At(0))
+	})
+	t.Run("boosts-rank-empty-query", func(t *testing.T) {
+		frame := search(t, DashboardQuery{Sort: sortRelevance}, map[string]float64{"2": 10})
+		require.Equal(t, 2, frame.Rows())
+		require.Equal(t, "2", frame.Fields[1].At(0))
+	})
+}
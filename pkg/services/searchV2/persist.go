@@ -0,0 +1,96 @@
+package searchV2
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// maxIndexCheckpointAge is the maximum age of a persisted index which can be brought up to date by replaying
+// entity events. Entity events older than a day are deleted by the entity events service.
+const maxIndexCheckpointAge = 23 * time.Hour
+
+// indexPersistTimeout is how long closing the persisted indexes waits for the pending changes to be written to disk.
+const indexPersistTimeout = 10 * time.Second
+
+// indexFormatVersion is the version of the documents of the persisted indexes. It must be increased whenever
+// documents change, such as when fields or entity kinds are added, so that persisted indexes are rebuilt.
+const indexFormatVersion = 1
+
+// indexCheckpoint describes the persisted index of an organization, and the last entity event applied to it.
+type indexCheckpoint struct {
+	Dir         string `json:"dir"`
+	LastEventID int64  `json:"lastEventId"`
+	Updated     int64  `json:"updated"`
+	Version     int    `json:"version"`
+}
+
+func checkpointPath(indexPath string, orgID int64) string {
+	return filepath.Join(indexPath, fmt.Sprintf("org-%d.json", orgID))
+}
+
+// newOrgIndexDir returns a unique directory name for a new index of an organization. Every full re-index gets
+// a new directory, so that the previous index stays intact until the new one is complete.
+func newOrgIndexDir(orgID int64) string {
+	return fmt.Sprintf("org-%d-%d", orgID, time.Now().UnixNano())
+}
+
+// readIndexCheckpoint returns the checkpoint of an organization's persisted index, or nil if there is none.
+func readIndexCheckpoint(indexPath string, orgID int64) (*indexCheckpoint, error) {
+	// nolint:gosec
+	// We can ignore the gosec G304 warning since the path is built from configuration and the org ID.
+	data, err := ioutil.ReadFile(checkpointPath(indexPath, orgID))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	cp := &indexCheckpoint{}
+	if err := json.Unmarshal(data, cp); err != nil {
+		return nil, fmt.Errorf("invalid index checkpoint: %w", err)
+	}
+	if cp.Dir == "" || filepath.Base(cp.Dir) != cp.Dir {
+		return nil, fmt.Errorf("invalid index checkpoint directory: %q", cp.Dir)
+	}
+	return cp, nil
+}
+
+// writeIndexCheckpoint replaces the checkpoint of an organization's persisted index. The checkpoint is written
+// to a temporary file first, so that a crash never leaves a partially written checkpoint behind.
+func writeIndexCheckpoint(indexPath string, orgID int64, cp indexCheckpoint) error {
+	cp.Updated = time.Now().Unix()
+	cp.Version = indexFormatVersion
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+	path := checkpointPath(indexPath, orgID)
+	tmpPath := path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// removeStaleOrgIndexDirs removes the index directories of an organization other than the one in use. These
+// are left behind by full re-indexing, or by a re-index interrupted before its checkpoint was written.
+func removeStaleOrgIndexDirs(indexPath string, orgID int64, keep string) error {
+	dirs, err := filepath.Glob(filepath.Join(indexPath, fmt.Sprintf("org-%d-*", orgID)))
+	if err != nil {
+		return err
+	}
+	for _, dir := range dirs {
+		if filepath.Base(dir) == keep {
+			continue
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package searchV2
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/blugelabs/bluge"
+)
+
+// fieldQueryPattern matches the field specific terms of a query, such as `query:"http_requests_total"`,
+// `query:http_requests*` or `transformer:organize`.
+var fieldQueryPattern = regexp.MustCompile(`(?:^|\s)(query|variable|transformer):(?:"([^"]*)"|(\S+))`)
+
+// fieldQueryFields maps the fields of the query syntax to the document fields they search.
+var fieldQueryFields = map[string]string{
+	"query":       documentFieldQuery,
+	"variable":    documentFieldVariable,
+	"transformer": documentFieldTransformer,
+}
+
+type fieldQuery struct {
+	field string // document field
+	value string
+}
+
+// parseFieldQueries splits the field specific terms from a query. It returns the remaining free text
+// and the terms, which all need to match.
+func parseFieldQueries(query string) (string, []fieldQuery) {
+	var terms []fieldQuery
+	text := fieldQueryPattern.ReplaceAllStringFunc(query, func(match string) string {
+		m := fieldQueryPattern.FindStringSubmatch(match)
+		value := m[2]
+		if value == "" {
+			value = m[3]
+		}
+		if value != "" {
+			terms = append(terms, fieldQuery{field: fieldQueryFields[m[1]], value: value})
+		}
+		return " "
+	})
+	if len(terms) == 0 {
+		return query, nil
+	}
+	return strings.Join(strings.Fields(text), " "), terms
+}
+
+func newFieldQuery(q fieldQuery) bluge.Query {
+	if q.field == documentFieldTransformer {
+		// Transformer IDs are indexed as keywords.
+		return bluge.NewTermQuery(q.value).SetField(q.field)
+	}
+	if prefix := strings.TrimSuffix(q.value, "*"); prefix != q.value && !strings.ContainsAny(prefix, " \t") {
+		return bluge.NewPrefixQuery(strings.ToLower(prefix)).SetField(q.field)
+	}
+	return bluge.NewMatchPhraseQuery(q.value).SetField(q.field)
+}
@@ -0,0 +1,249 @@
+package searchV2
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/grafana/grafana/pkg/infra/localcache"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/sqlstore"
+)
+
+// sortRelevance is the DashboardQuery.Sort value that ranks results by text score combined with
+// the usage of the dashboards.
+const sortRelevance = "relevance"
+
+const (
+	// maxRankedDashboards limits the number of dashboards boosted by each usage signal.
+	maxRankedDashboards = 100
+
+	viewsWeight      = 1.0
+	starsWeight      = 2.0
+	lastViewedWeight = 3.0
+	// lastViewedHalfLife is the time after which the boost of a dashboard viewed by the user is halved.
+	lastViewedHalfLife = 7 * 24 * time.Hour
+
+	usageBoostsCacheTTL = time.Minute
+	// viewsFlushInterval is how often the views counted in memory are written to the database.
+	viewsFlushInterval = 10 * time.Second
+)
+
+// dashboardView counts the views of a dashboard by a user.
+type dashboardView struct {
+	ID           int64  `xorm:"pk autoincr 'id'"`
+	OrgID        int64  `xorm:"org_id"`
+	DashboardUID string `xorm:"dashboard_uid"`
+	UserID       int64  `xorm:"user_id"`
+	Views        int64  `xorm:"views"`
+	LastViewed   int64  `xorm:"last_viewed"`
+}
+
+func (dashboardView) TableName() string {
+	return "dashboard_view"
+}
+
+type dashboardUsage struct {
+	views      int64     // by all users
+	stars      int64     // by all users
+	lastViewed time.Time // by the current user
+}
+
+// boost returns the score added to a dashboard for its usage.
+func (u dashboardUsage) boost(now time.Time) float64 {
+	boost := viewsWeight*math.Log1p(float64(u.views)) + starsWeight*math.Log1p(float64(u.stars))
+	if !u.lastViewed.IsZero() {
+		age := now.Sub(u.lastViewed)
+		boost += lastViewedWeight * math.Pow(0.5, float64(age)/float64(lastViewedHalfLife))
+	}
+	return boost
+}
+
+type dashboardViewKey struct {
+	orgID        int64
+	dashboardUID string
+	userID       int64
+}
+
+type usageRanking struct {
+	sql    *sqlstore.SQLStore
+	cache  *localcache.CacheService
+	logger log.Logger
+
+	mu           sync.Mutex
+	pendingViews map[dashboardViewKey]*dashboardView
+}
+
+func newUsageRanking(sql *sqlstore.SQLStore) *usageRanking {
+	return &usageRanking{
+		sql:          sql,
+		cache:        localcache.New(usageBoostsCacheTTL, 2*usageBoostsCacheTTL),
+		logger:       log.New("searchV2.ranking"),
+		pendingViews: map[dashboardViewKey]*dashboardView{},
+	}
+}
+
+// run writes the views counted in memory to the database periodically, and once more when ctx is done.
+func (r *usageRanking) run(ctx context.Context) error {
+	ticker := time.NewTicker(viewsFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			r.flushViews(ctx)
+		case <-ctx.Done():
+			r.flushViews(context.Background())
+			return nil
+		}
+	}
+}
+
+// recordView counts a view of a dashboard by a user. Views are counted in memory, so that loading a
+// dashboard does not wait for the database, and written to the database by run.
+func (r *usageRanking) recordView(orgID int64, userID int64, dashboardUID string) {
+	key := dashboardViewKey{orgID: orgID, dashboardUID: dashboardUID, userID: userID}
+	now := time.Now().Unix()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if v, ok := r.pendingViews[key]; ok {
+		v.Views++
+		v.LastViewed = now
+		return
+	}
+	r.pendingViews[key] = &dashboardView{
+		OrgID:        orgID,
+		DashboardUID: dashboardUID,
+		UserID:       userID,
+		Views:        1,
+		LastViewed:   now,
+	}
+}
+
+// flushViews writes the views counted in memory to the database. Views that can't be written are lost,
+// they only rank the search results.
+func (r *usageRanking) flushViews(ctx context.Context) {
+	r.mu.Lock()
+	pending := r.pendingViews
+	r.pendingViews = map[dashboardViewKey]*dashboardView{}
+	r.mu.Unlock()
+
+	for _, v := range pending {
+		if err := r.addViews(ctx, v); err != nil {
+			r.logger.Warn("Failed to record dashboard views", "orgId", v.OrgID, "uid", v.DashboardUID, "error", err)
+		}
+	}
+}
+
+func (r *usageRanking) addViews(ctx context.Context, v *dashboardView) error {
+	update := func(sess *sqlstore.DBSession) (bool, error) {
+		res, err := sess.Exec("UPDATE dashboard_view SET views = views + ?, last_viewed = ? WHERE org_id = ? AND dashboard_uid = ? AND user_id = ?",
+			v.Views, v.LastViewed, v.OrgID, v.DashboardUID, v.UserID)
+		if err != nil {
+			return false, err
+		}
+		affected, err := res.RowsAffected()
+		return affected > 0, err
+	}
+
+	return r.sql.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		if updated, err := update(sess); err != nil || updated {
+			return err
+		}
+		_, err := sess.Insert(&dashboardView{
+			OrgID:        v.OrgID,
+			DashboardUID: v.DashboardUID,
+			UserID:       v.UserID,
+			Views:        v.Views,
+			LastViewed:   v.LastViewed,
+		})
+		if err != nil && r.sql.Dialect.IsUniqueConstraintViolation(err) {
+			// Another instance recorded the first view of the dashboard concurrently.
+			_, err = update(sess)
+		}
+		return err
+	})
+}
+
+// getBoosts returns the boosts of the most viewed and starred dashboards of an organization, and
+// of the dashboards last viewed by the user, keyed by dashboard UID.
+func (r *usageRanking) getBoosts(ctx context.Context, orgID int64, userID int64) (map[string]float64, error) {
+	key := fmt.Sprintf("%d/%d", orgID, userID)
+	if boosts, ok := r.cache.Get(key); ok {
+		return boosts.(map[string]float64), nil
+	}
+
+	usage, err := r.getUsage(ctx, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	boosts := make(map[string]float64, len(usage))
+	for uid, u := range usage {
+		boosts[uid] = u.boost(now)
+	}
+	r.cache.Set(key, boosts, usageBoostsCacheTTL)
+	return boosts, nil
+}
+
+type dashboardCountQueryResult struct {
+	DashboardUID string `xorm:"dashboard_uid"`
+	Count        int64  `xorm:"count"`
+}
+
+func (r *usageRanking) getUsage(ctx context.Context, orgID int64, userID int64) (map[string]*dashboardUsage, error) {
+	usage := make(map[string]*dashboardUsage)
+	get := func(uid string) *dashboardUsage {
+		u, ok := usage[uid]
+		if !ok {
+			u = &dashboardUsage{}
+			usage[uid] = u
+		}
+		return u
+	}
+
+	views := make([]*dashboardCountQueryResult, 0)
+	stars := make([]*dashboardCountQueryResult, 0)
+	lastViewed := make([]*dashboardView, 0)
+	err := r.sql.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		err := sess.SQL(`SELECT dashboard_uid, SUM(views) AS count FROM dashboard_view
+			WHERE org_id = ? GROUP BY dashboard_uid ORDER BY count DESC`+r.sql.Dialect.Limit(maxRankedDashboards),
+			orgID).Find(&views)
+		if err != nil {
+			return err
+		}
+
+		err = sess.SQL(`SELECT dashboard.uid AS dashboard_uid, COUNT(*) AS count FROM star
+			INNER JOIN dashboard ON dashboard.id = star.dashboard_id
+			WHERE dashboard.org_id = ? GROUP BY dashboard.uid ORDER BY count DESC`+r.sql.Dialect.Limit(maxRankedDashboards),
+			orgID).Find(&stars)
+		if err != nil {
+			return err
+		}
+
+		if userID == 0 {
+			// Views of anonymous users are counted, but they don't have a history of their own.
+			return nil
+		}
+		return sess.Where("org_id = ? AND user_id = ?", orgID, userID).
+			Desc("last_viewed").
+			Limit(maxRankedDashboards).
+			Find(&lastViewed)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range views {
+		get(v.DashboardUID).views = v.Count
+	}
+	for _, s := range stars {
+		get(s.DashboardUID).stars = s.Count
+	}
+	for _, v := range lastViewed {
+		get(v.DashboardUID).lastViewed = time.Unix(v.LastViewed, 0)
+	}
+	return usage, nil
+}
@@ -0,0 +1,61 @@
+package searchV2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana/pkg/services/sqlstore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationUsageRanking(t *testing.T) {
+	ranking := newUsageRanking(sqlstore.InitTestDB(t))
+	ctx := context.Background()
+
+	ranking.recordView(1, 1, "a")
+	ranking.recordView(1, 1, "a")
+	ranking.recordView(1, 2, "a")
+	ranking.flushViews(ctx)
+	ranking.recordView(1, 2, "b")
+	ranking.recordView(1, 1, "a")
+	ranking.recordView(2, 1, "c")
+	ranking.flushViews(ctx)
+
+	t.Run("views are counted per dashboard and user", func(t *testing.T) {
+		usage, err := ranking.getUsage(ctx, 1, 1)
+		require.NoError(t, err)
+		require.Len(t, usage, 2)
+		require.Equal(t, int64(4), usage["a"].views)
+		require.False(t, usage["a"].lastViewed.IsZero())
+		require.Equal(t, int64(1), usage["b"].views)
+		require.True(t, usage["b"].lastViewed.IsZero())
+	})
+
+	t.Run("views are written when flushed", func(t *testing.T) {
+		ranking.recordView(3, 1, "d")
+		usage, err := ranking.getUsage(ctx, 3, 1)
+		require.NoError(t, err)
+		require.Empty(t, usage)
+
+		ranking.flushViews(ctx)
+		usage, err = ranking.getUsage(ctx, 3, 1)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), usage["d"].views)
+	})
+
+	t.Run("boosts are scoped to the organization", func(t *testing.T) {
+		boosts, err := ranking.getBoosts(ctx, 2, 1)
+		require.NoError(t, err)
+		require.Len(t, boosts, 1)
+		require.Greater(t, boosts["c"], 0.0)
+	})
+}
+
+func TestDashboardUsageBoost(t *testing.T) {
+	now := time.Now()
+	require.Equal(t, 0.0, dashboardUsage{}.boost(now))
+	require.Greater(t, dashboardUsage{views: 10}.boost(now), dashboardUsage{views: 1}.boost(now))
+	require.Greater(t, dashboardUsage{stars: 1}.boost(now), dashboardUsage{views: 1}.boost(now))
+	require.InDelta(t, lastViewedWeight/2, dashboardUsage{lastViewed: now.Add(-lastViewedHalfLife)}.boost(now), 0.001)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/grafana/grafana/pkg/setting"
 
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"golang.org/x/sync/errgroup"
 )
 
 type StandardSearchService struct {
@@ -27,6 +28,7 @@ type StandardSearchService struct {
 	logger         log.Logger
 	dashboardIndex *dashboardIndex
 	extender       DashboardIndexExtender
+	ranking        *usageRanking
 }
 
 func ProvideService(cfg *setting.Cfg, sql *sqlstore.SQLStore, entityEventStore store.EntityEventsService, ac accesscontrol.AccessControl) SearchService {
@@ -41,12 +43,16 @@ func ProvideService(cfg *setting.Cfg, sql *sqlstore.SQLStore, entityEventStore s
 		},
 		dashboardIndex: newDashboardIndex(
 			newSQLDashboardLoader(sql),
+			newSQLEntityLoader(sql),
 			entityEventStore,
 			extender.GetDocumentExtender(),
 			newFolderIDLookup(sql),
+			cfg.Search.IndexPath,
+			cfg.Search.FullReindexInterval,
 		),
 		logger:   log.New("searchV2"),
 		extender: extender,
+		ranking:  newUsageRanking(sql),
 	}
 	return s
 }
@@ -59,7 +65,14 @@ func (s *StandardSearchService) IsDisabled() bool {
 }
 
 func (s *StandardSearchService) Run(ctx context.Context) error {
-	return s.dashboardIndex.run(ctx)
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		return s.dashboardIndex.run(ctx)
+	})
+	g.Go(func() error {
+		return s.ranking.run(ctx)
+	})
+	return g.Wait()
 }
 
 func (s *StandardSearchService) RegisterDashboardIndexExtender(ext DashboardIndexExtender) {
@@ -137,5 +150,21 @@ func (s *StandardSearchService) DoDashboardQuery(ctx context.Context, user *back
 		return rsp
 	}
 
-	return doSearchQuery(ctx, s.logger, reader, filter, q, s.extender.GetQueryExtender(q), s.cfg.AppSubURL)
+	var boosts map[string]float64
+	if q.Sort == sortRelevance {
+		boosts, err = s.ranking.getBoosts(ctx, orgID, signedInUser.UserId)
+		if err != nil {
+			// Rank by text score only
+			s.logger.Warn("Error while loading dashboard usage", "error", err, "orgId", orgID)
+		}
+	}
+
+	return doSearchQuery(ctx, s.logger, reader, filter, q, s.extender.GetQueryExtender(q), s.cfg.AppSubURL, boosts)
+}
+
+func (s *StandardSearchService) RecordDashboardView(orgID int64, userID int64, dashboardUID string) {
+	if s.IsDisabled() {
+		return
+	}
+	s.ranking.recordView(orgID, userID, dashboardUID)
 }
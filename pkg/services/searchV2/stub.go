@@ -33,6 +33,10 @@ func (s *stubSearchService) RegisterDashboardIndexExtender(ext DashboardIndexExt
 	// noop
 }
 
+func (s *stubSearchService) RecordDashboardView(orgID int64, userID int64, dashboardUID string) {
+	// noop
+}
+
 func (s *stubSearchService) Run(_ context.Context) error {
 	return nil
 }
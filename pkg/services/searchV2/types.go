@@ -14,9 +14,9 @@ type FacetField struct {
 }
 
 type DashboardQuery struct {
-	Query        string       `json:"query"`
+	Query        string       `json:"query"`              // text, and terms such as query:"up", variable:"label_values" or transformer:organize
 	Location     string       `json:"location,omitempty"` // parent folder ID
-	Sort         string       `json:"sort,omitempty"`     // field ASC/DESC
+	Sort         string       `json:"sort,omitempty"`     // field ASC/DESC, or "relevance" to rank by usage
 	Datasource   string       `json:"ds_uid,omitempty"`   // "datasource" collides with the JSON value at the same leel :()
 	Tags         []string     `json:"tags,omitempty"`
 	Kind         []string     `json:"kind,omitempty"`
@@ -34,4 +34,5 @@ type SearchService interface {
 	registry.BackgroundService
 	DoDashboardQuery(ctx context.Context, user *backend.User, orgId int64, query DashboardQuery) *backend.DataResponse
 	RegisterDashboardIndexExtender(ext DashboardIndexExtender)
+	RecordDashboardView(orgID int64, userID int64, dashboardUID string)
 }
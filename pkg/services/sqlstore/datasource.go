@@ -117,13 +117,18 @@ func (ss *SQLStore) DeleteDataSource(ctx context.Context, cmd *models.DeleteData
 		}
 
 		// Publish data source deletion event
-		sess.publishAfterCommit(&events.DataSourceDeleted{
+		deleted := &events.DataSourceDeleted{
 			Timestamp: time.Now(),
 			Name:      cmd.Name,
 			ID:        cmd.ID,
 			UID:       cmd.UID,
 			OrgID:     cmd.OrgID,
-		})
+		}
+		if ds != nil {
+			// the command may reference the data source by ID or name only
+			deleted.Name, deleted.ID, deleted.UID = ds.Name, ds.Id, ds.Uid
+		}
+		sess.publishAfterCommit(deleted)
 
 		return nil
 	})
@@ -262,9 +267,28 @@ func (ss *SQLStore) UpdateDataSource(ctx context.Context, cmd *models.UpdateData
 		}
 
 		err = updateIsDefaultFlag(ds, sess)
+		if err != nil {
+			return err
+		}
 
 		cmd.Result = ds
-		return err
+
+		uid := cmd.Uid
+		if uid == "" {
+			// an empty uid is not updated, so look up the stored one
+			if _, err := sess.Table("data_source").Where("id=? AND org_id=?", ds.Id, ds.OrgId).Cols("uid").Get(&uid); err != nil {
+				return err
+			}
+		}
+		sess.publishAfterCommit(&events.DataSourceUpdated{
+			Timestamp: time.Now(),
+			Name:      cmd.Name,
+			ID:        cmd.Id,
+			UID:       uid,
+			OrgID:     cmd.OrgId,
+		})
+
+		return nil
 	})
 }
 
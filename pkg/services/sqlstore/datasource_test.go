@@ -204,6 +204,37 @@ func TestIntegrationDataAccess(t *testing.T) {
 			err := sqlStore.UpdateDataSource(context.Background(), cmd)
 			require.NoError(t, err)
 		})
+
+		t.Run("fires an event with the stored uid when the datasource is updated", func(t *testing.T) {
+			sqlStore := InitTestDB(t)
+			ds := initDatasource(sqlStore)
+
+			var updated *events.DataSourceUpdated
+			bus.AddEventListener(func(ctx context.Context, e *events.DataSourceUpdated) error {
+				updated = e
+				return nil
+			})
+
+			cmd := &models.UpdateDataSourceCommand{
+				Id:     ds.Id,
+				OrgId:  10,
+				Name:   "nisse",
+				Type:   models.DS_GRAPHITE,
+				Access: models.DS_ACCESS_PROXY,
+				Url:    "http://test",
+			}
+
+			err := sqlStore.UpdateDataSource(context.Background(), cmd)
+			require.NoError(t, err)
+
+			require.Eventually(t, func() bool {
+				return assert.NotNil(t, updated)
+			}, time.Second, time.Millisecond)
+
+			require.Equal(t, ds.Id, updated.ID)
+			require.Equal(t, ds.Uid, updated.UID)
+			require.Equal(t, int64(10), updated.OrgID)
+		})
 	})
 
 	t.Run("DeleteDataSourceById", func(t *testing.T) {
@@ -261,6 +292,27 @@ func TestIntegrationDataAccess(t *testing.T) {
 		require.Equal(t, "nisse-uid", deleted.UID)
 	})
 
+	t.Run("fires an event with the uid when the datasource is deleted by name", func(t *testing.T) {
+		sqlStore := InitTestDB(t)
+		ds := initDatasource(sqlStore)
+
+		var deleted *events.DataSourceDeleted
+		bus.AddEventListener(func(ctx context.Context, e *events.DataSourceDeleted) error {
+			deleted = e
+			return nil
+		})
+
+		err := sqlStore.DeleteDataSource(context.Background(), &models.DeleteDataSourceCommand{Name: ds.Name, OrgID: ds.OrgId})
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool {
+			return assert.NotNil(t, deleted)
+		}, time.Second, time.Millisecond)
+
+		require.Equal(t, ds.Id, deleted.ID)
+		require.Equal(t, ds.Uid, deleted.UID)
+	})
+
 	t.Run("DeleteDataSourceByName", func(t *testing.T) {
 		sqlStore := InitTestDB(t)
 		ds := initDatasource(sqlStore)
@@ -0,0 +1,24 @@
+package migrations
+
+import . "github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+
+func addDashboardViewMigrations(mg *Migrator) {
+	dashboardViewV1 := Table{
+		Name: "dashboard_view",
+		Columns: []*Column{
+			{Name: "id", Type: DB_BigInt, Nullable: false, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: DB_BigInt, Nullable: false},
+			{Name: "dashboard_uid", Type: DB_NVarchar, Length: 40, Nullable: false},
+			{Name: "user_id", Type: DB_BigInt, Nullable: false},
+			{Name: "views", Type: DB_BigInt, Nullable: false},
+			{Name: "last_viewed", Type: DB_BigInt, Nullable: false},
+		},
+		Indices: []*Index{
+			{Cols: []string{"org_id", "dashboard_uid", "user_id"}, Type: UniqueIndex},
+			{Cols: []string{"org_id", "user_id", "last_viewed"}},
+		},
+	}
+
+	mg.AddMigration("create dashboard_view table v1", NewAddTableMigration(dashboardViewV1))
+	addTableIndicesMigrations(mg, "v1", dashboardViewV1)
+}
@@ -27,6 +27,14 @@ func AddTablesMigrations(mg *migrator.Migrator) {
 	AddProvisioningMigrations(mg)
 
 	AddAlertImageMigrations(mg)
+
+	AddAlertStateHistoryMigrations(mg)
+
+	AddNotificationDeliveryMigrations(mg)
+
+	AddAlertRuleTemplateMigrations(mg)
+
+	AddSilenceScheduleMigrations(mg)
 }
 
 // AddAlertDefinitionMigrations should not be modified.
@@ -241,6 +249,16 @@ func AddAlertRuleMigrations(mg *migrator.Migrator, defaultIntervalSeconds int64)
 			Cols: []string{"org_id", "dashboard_uid", "panel_id"},
 		},
 	))
+
+	mg.AddMigration("add rule_group_idx column to alert_rule", migrator.NewAddColumnMigration(
+		migrator.Table{Name: "alert_rule"},
+		&migrator.Column{Name: "rule_group_idx", Type: migrator.DB_Int, Nullable: false, Default: "1"},
+	))
+
+	mg.AddMigration("add record column to alert_rule", migrator.NewAddColumnMigration(
+		migrator.Table{Name: "alert_rule"},
+		&migrator.Column{Name: "record", Type: migrator.DB_Text, Nullable: true},
+	))
 }
 
 func AddAlertRuleVersionMigrations(mg *migrator.Migrator) {
@@ -284,6 +302,10 @@ func AddAlertRuleVersionMigrations(mg *migrator.Migrator) {
 
 	// add labels column
 	mg.AddMigration("add column labels to alert_rule_version", migrator.NewAddColumnMigration(alertRuleVersion, &migrator.Column{Name: "labels", Type: migrator.DB_Text, Nullable: true}))
+
+	mg.AddMigration("add rule_group_idx column to alert_rule_version", migrator.NewAddColumnMigration(alertRuleVersion, &migrator.Column{Name: "rule_group_idx", Type: migrator.DB_Int, Nullable: false, Default: "1"}))
+
+	mg.AddMigration("add record column to alert_rule_version", migrator.NewAddColumnMigration(alertRuleVersion, &migrator.Column{Name: "record", Type: migrator.DB_Text, Nullable: true}))
 }
 
 func AddAlertmanagerConfigMigrations(mg *migrator.Migrator) {
@@ -317,6 +339,10 @@ func AddAlertmanagerConfigMigrations(mg *migrator.Migrator) {
 	mg.AddMigration("add configuration_hash column to alert_configuration", migrator.NewAddColumnMigration(alertConfiguration, &migrator.Column{
 		Name: "configuration_hash", Type: migrator.DB_Varchar, Nullable: false, Default: "'not-yet-calculated'", Length: 32,
 	}))
+
+	mg.AddMigration("add created_by column to alert_configuration", migrator.NewAddColumnMigration(alertConfiguration, &migrator.Column{
+		Name: "created_by", Type: migrator.DB_NVarchar, Nullable: false, Default: "''", Length: 190,
+	}))
 }
 
 func AddAlertAdminConfigMigrations(mg *migrator.Migrator) {
@@ -379,3 +405,138 @@ func AddAlertImageMigrations(mg *migrator.Migrator) {
 	mg.AddMigration("create alert_image table", migrator.NewAddTableMigration(imageTable))
 	mg.AddMigration("add unique index on token to alert_image table", migrator.NewAddIndexMigration(imageTable, imageTable.Indices[0]))
 }
+
+func AddAlertStateHistoryMigrations(mg *migrator.Migrator) {
+	stateHistoryTable := migrator.Table{
+		Name: "alert_state_history",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "rule_uid", Type: migrator.DB_NVarchar, Length: 40, Nullable: false},
+			{Name: "rule_version", Type: migrator.DB_BigInt, Nullable: false},
+			// The folder of the rule authorizes access to the history, including the history of deleted rules.
+			{Name: "namespace_uid", Type: migrator.DB_NVarchar, Length: 40, Nullable: false},
+			{Name: "labels", Type: migrator.DB_Text, Nullable: false},
+			{Name: "labels_hash", Type: migrator.DB_NVarchar, Length: 190, Nullable: false},
+			{Name: "previous_state", Type: migrator.DB_NVarchar, Length: 40, Nullable: false},
+			{Name: "previous_reason", Type: migrator.DB_NVarchar, Length: 190, Nullable: true},
+			{Name: "state", Type: migrator.DB_NVarchar, Length: 40, Nullable: false},
+			{Name: "reason", Type: migrator.DB_NVarchar, Length: 190, Nullable: true},
+			{Name: "eval_values", Type: migrator.DB_Text, Nullable: true},
+			{Name: "evaluated_at", Type: migrator.DB_BigInt, Nullable: false},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"org_id", "rule_uid", "evaluated_at"}, Type: migrator.IndexType},
+			{Cols: []string{"org_id", "evaluated_at"}, Type: migrator.IndexType},
+			{Cols: []string{"evaluated_at"}, Type: migrator.IndexType},
+			{Cols: []string{"org_id", "namespace_uid", "evaluated_at"}, Type: migrator.IndexType},
+		},
+	}
+	mg.AddMigration("create alert_state_history table", migrator.NewAddTableMigration(stateHistoryTable))
+	mg.AddMigration("add index on org_id, rule_uid and evaluated_at to alert_state_history table", migrator.NewAddIndexMigration(stateHistoryTable, stateHistoryTable.Indices[0]))
+	mg.AddMigration("add index on org_id and evaluated_at to alert_state_history table", migrator.NewAddIndexMigration(stateHistoryTable, stateHistoryTable.Indices[1]))
+	mg.AddMigration("add index on evaluated_at to alert_state_history table", migrator.NewAddIndexMigration(stateHistoryTable, stateHistoryTable.Indices[2]))
+	mg.AddMigration("add index on org_id, namespace_uid and evaluated_at to alert_state_history table", migrator.NewAddIndexMigration(stateHistoryTable, stateHistoryTable.Indices[3]))
+}
+
+func AddNotificationDeliveryMigrations(mg *migrator.Migrator) {
+	deliveryTable := migrator.Table{
+		Name: "alert_notification_delivery",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "receiver", Type: migrator.DB_NVarchar, Length: 190, Nullable: false},
+			{Name: "integration_uid", Type: migrator.DB_NVarchar, Length: 40, Nullable: false},
+			{Name: "integration_type", Type: migrator.DB_NVarchar, Length: 190, Nullable: false},
+			{Name: "integration_index", Type: migrator.DB_Int, Nullable: false},
+			{Name: "group_key", Type: migrator.DB_Text, Nullable: false},
+			{Name: "group_labels", Type: migrator.DB_Text, Nullable: true},
+			{Name: "alert_fingerprints", Type: migrator.DB_Text, Nullable: true},
+			{Name: "alerts", Type: migrator.DB_MediumText, Nullable: true},
+			{Name: "status", Type: migrator.DB_NVarchar, Length: 40, Nullable: false},
+			{Name: "attempts", Type: migrator.DB_Int, Nullable: false},
+			{Name: "error", Type: migrator.DB_Text, Nullable: true},
+			{Name: "response_code", Type: migrator.DB_Int, Nullable: false},
+			{Name: "response_body", Type: migrator.DB_Text, Nullable: true},
+			{Name: "created_at", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "last_attempt_at", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "next_attempt_at", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "replay_of", Type: migrator.DB_BigInt, Nullable: false},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"org_id", "created_at"}, Type: migrator.IndexType},
+			{Cols: []string{"org_id", "status", "next_attempt_at"}, Type: migrator.IndexType},
+			{Cols: []string{"created_at"}, Type: migrator.IndexType},
+		},
+	}
+	mg.AddMigration("create alert_notification_delivery table", migrator.NewAddTableMigration(deliveryTable))
+	mg.AddMigration("add index on org_id and created_at to alert_notification_delivery table", migrator.NewAddIndexMigration(deliveryTable, deliveryTable.Indices[0]))
+	mg.AddMigration("add index on org_id, status and next_attempt_at to alert_notification_delivery table", migrator.NewAddIndexMigration(deliveryTable, deliveryTable.Indices[1]))
+	mg.AddMigration("add index on created_at to alert_notification_delivery table", migrator.NewAddIndexMigration(deliveryTable, deliveryTable.Indices[2]))
+}
+
+func AddAlertRuleTemplateMigrations(mg *migrator.Migrator) {
+	templateTable := migrator.Table{
+		Name: "alert_rule_template",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "uid", Type: migrator.DB_NVarchar, Length: 40, Nullable: false},
+			{Name: "title", Type: migrator.DB_NVarchar, Length: 190, Nullable: false},
+			{Name: "description", Type: migrator.DB_Text, Nullable: true},
+			{Name: "parameters", Type: migrator.DB_Text, Nullable: true},
+			{Name: "rule", Type: migrator.DB_MediumText, Nullable: false},
+			{Name: "version", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "updated", Type: migrator.DB_DateTime, Nullable: false},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"org_id", "uid"}, Type: migrator.UniqueIndex},
+		},
+	}
+	mg.AddMigration("create alert_rule_template table", migrator.NewAddTableMigration(templateTable))
+	mg.AddMigration("add unique index on org_id and uid to alert_rule_template table", migrator.NewAddIndexMigration(templateTable, templateTable.Indices[0]))
+
+	instanceTable := migrator.Table{
+		Name: "alert_rule_template_instance",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "template_uid", Type: migrator.DB_NVarchar, Length: 40, Nullable: false},
+			{Name: "rule_uid", Type: migrator.DB_NVarchar, Length: 40, Nullable: false},
+			{Name: "parameters", Type: migrator.DB_Text, Nullable: true},
+			{Name: "template_version", Type: migrator.DB_BigInt, Nullable: false},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"org_id", "rule_uid"}, Type: migrator.UniqueIndex},
+			{Cols: []string{"org_id", "template_uid"}, Type: migrator.IndexType},
+		},
+	}
+	mg.AddMigration("create alert_rule_template_instance table", migrator.NewAddTableMigration(instanceTable))
+	mg.AddMigration("add unique index on org_id and rule_uid to alert_rule_template_instance table", migrator.NewAddIndexMigration(instanceTable, instanceTable.Indices[0]))
+	mg.AddMigration("add index on org_id and template_uid to alert_rule_template_instance table", migrator.NewAddIndexMigration(instanceTable, instanceTable.Indices[1]))
+}
+
+func AddSilenceScheduleMigrations(mg *migrator.Migrator) {
+	scheduleTable := migrator.Table{
+		Name: "silence_schedule",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "uid", Type: migrator.DB_NVarchar, Length: 40, Nullable: false},
+			{Name: "comment", Type: migrator.DB_Text, Nullable: true},
+			{Name: "created_by", Type: migrator.DB_NVarchar, Length: 190, Nullable: false},
+			{Name: "matchers", Type: migrator.DB_Text, Nullable: false},
+			{Name: "cron", Type: migrator.DB_NVarchar, Length: 190, Nullable: true},
+			{Name: "duration", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "time_intervals", Type: migrator.DB_Text, Nullable: true},
+			{Name: "timezone", Type: migrator.DB_NVarchar, Length: 60, Nullable: true},
+			{Name: "silence_id", Type: migrator.DB_NVarchar, Length: 40, Nullable: true},
+			{Name: "updated", Type: migrator.DB_DateTime, Nullable: false},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"org_id", "uid"}, Type: migrator.UniqueIndex},
+		},
+	}
+	mg.AddMigration("create silence_schedule table", migrator.NewAddTableMigration(scheduleTable))
+	mg.AddMigration("add unique index on org_id and uid to silence_schedule table", migrator.NewAddIndexMigration(scheduleTable, scheduleTable.Indices[0]))
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/grafana/grafana/pkg/bus"
+	"github.com/grafana/grafana/pkg/events"
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/registry"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
@@ -23,8 +25,12 @@ const (
 type EntityType string
 
 const (
-	EntityTypeDashboard EntityType = "dashboard"
-	EntityTypeFolder    EntityType = "folder"
+	EntityTypeDashboard    EntityType = "dashboard"
+	EntityTypeFolder       EntityType = "folder"
+	EntityTypeAlertRule    EntityType = "alertrule"
+	EntityTypeLibraryPanel EntityType = "librarypanel"
+	EntityTypeDatasource   EntityType = "datasource"
+	EntityTypePlaylist     EntityType = "playlist"
 )
 
 // CreateDatabaseEntityId creates entityId for entities stored in the existing SQL tables
@@ -68,17 +74,23 @@ type EntityEventsService interface {
 	deleteEventsOlderThan(ctx context.Context, duration time.Duration) error
 }
 
-func ProvideEntityEventsService(cfg *setting.Cfg, sqlStore *sqlstore.SQLStore, features featuremgmt.FeatureToggles) EntityEventsService {
+func ProvideEntityEventsService(cfg *setting.Cfg, sqlStore *sqlstore.SQLStore, bus bus.Bus, features featuremgmt.FeatureToggles) EntityEventsService {
 	if !features.IsEnabled(featuremgmt.FlagPanelTitleSearch) {
 		return &dummyEntityEventsService{}
 	}
 
-	return &entityEventService{
+	e := &entityEventService{
 		sql:           sqlStore,
 		features:      features,
 		log:           log.New("entity-events"),
 		eventHandlers: make([]EventHandler, 0),
 	}
+	// data sources are written by the API as well as by file provisioning,
+	// so their events are taken from the data source store.
+	bus.AddEventListener(e.handleDataSourceCreated)
+	bus.AddEventListener(e.handleDataSourceUpdated)
+	bus.AddEventListener(e.handleDataSourceDeleted)
+	return e
 }
 
 type entityEventService struct {
@@ -104,6 +116,34 @@ func (e *entityEventService) SaveEvent(ctx context.Context, cmd SaveEventCmd) er
 	return e.broadcastEvent(ctx, entityEvent)
 }
 
+func (e *entityEventService) handleDataSourceCreated(ctx context.Context, evt *events.DataSourceCreated) error {
+	e.saveDataSourceEvent(ctx, evt.OrgID, evt.UID, EntityEventTypeCreate)
+	return nil
+}
+
+func (e *entityEventService) handleDataSourceUpdated(ctx context.Context, evt *events.DataSourceUpdated) error {
+	e.saveDataSourceEvent(ctx, evt.OrgID, evt.UID, EntityEventTypeUpdate)
+	return nil
+}
+
+func (e *entityEventService) handleDataSourceDeleted(ctx context.Context, evt *events.DataSourceDeleted) error {
+	e.saveDataSourceEvent(ctx, evt.OrgID, evt.UID, EntityEventTypeDelete)
+	return nil
+}
+
+// saveDataSourceEvent does not fail the handler as the data source change is already committed.
+func (e *entityEventService) saveDataSourceEvent(ctx context.Context, orgID int64, uid string, eventType EntityEventType) {
+	if uid == "" {
+		return
+	}
+	if err := e.SaveEvent(ctx, SaveEventCmd{
+		EntityId:  CreateDatabaseEntityId(uid, orgID, EntityTypeDatasource),
+		EventType: eventType,
+	}); err != nil {
+		e.log.Warn("failed to save data source entity event", "uid", uid, "error", err)
+	}
+}
+
 func (e *entityEventService) broadcastEvent(ctx context.Context, event *EntityEvent) error {
 	for _, h := range e.eventHandlers {
 		err := h(ctx, event)
@@ -438,6 +438,8 @@ type Cfg struct {
 
 	DashboardPreviews DashboardPreviewsSettings
 
+	Search SearchSettings
+
 	// Access Control
 	RBACEnabled         bool
 	RBACPermissionCache bool
@@ -1009,6 +1011,7 @@ func (cfg *Cfg) Load(args CommandLineArgs) error {
 	cfg.readDataSourcesSettings()
 
 	cfg.DashboardPreviews = readDashboardPreviewsSettings(iniFile)
+	cfg.Search = readSearchSettings(iniFile, cfg.DataPath)
 
 	if VerifyEmailEnabled && !cfg.Smtp.Enabled {
 		cfg.Logger.Warn("require_email_validation is enabled but smtp is disabled")
@@ -0,0 +1,34 @@
+package setting
+
+import (
+	"path/filepath"
+	"time"
+
+	"gopkg.in/ini.v1"
+)
+
+type SearchSettings struct {
+	// IndexPath is the directory the search index is persisted to. The index is kept in memory only when empty.
+	IndexPath string
+	// FullReindexInterval is how often the search index is rebuilt from the database. It is never rebuilt if 0.
+	FullReindexInterval time.Duration
+}
+
+func readSearchSettings(iniFile *ini.File, dataPath string) SearchSettings {
+	s := SearchSettings{}
+
+	searchSection := iniFile.Section("search")
+	if !searchSection.Key("persist_index").MustBool(false) {
+		s.FullReindexInterval = searchSection.Key("full_reindex_interval").MustDuration(5 * time.Minute)
+		return s
+	}
+
+	s.IndexPath = searchSection.Key("index_path").MustString("")
+	if s.IndexPath == "" {
+		s.IndexPath = filepath.Join(dataPath, "search")
+	}
+	// A persisted index is kept up to date by the entity events, also across restarts, so rebuilding it is
+	// only a safety net.
+	s.FullReindexInterval = searchSection.Key("full_reindex_interval").MustDuration(24 * time.Hour)
+	return s
+}
@@ -51,6 +51,18 @@ const (
 	screenshotsDefaultCapture               = false
 	screenshotsDefaultMaxConcurrent         = 5
 	screenshotsDefaultUploadImageStorage    = false
+	stateHistoryDefaultEnabled              = true
+	stateHistoryDefaultRetention            = 30 * 24 * time.Hour
+	deliveryLogDefaultEnabled               = true
+	deliveryLogDefaultRetention             = 7 * 24 * time.Hour
+	deliveryLogDefaultRetryMaxAttempts      = 0
+	deliveryLogDefaultRetryInitialBackoff   = 30 * time.Second
+	deliveryLogDefaultRetryMaxBackoff       = 10 * time.Minute
+	recordingRulesDefaultEnabled            = false
+	recordingRulesDefaultTimeout            = 10 * time.Second
+	enrichmentDefaultEnabled                = false
+	enrichmentDefaultSource                 = "static"
+	enrichmentDefaultRefreshInterval        = 5 * time.Minute
 	// SchedulerBaseInterval base interval of the scheduler. Controls how often the scheduler fetches database for new changes as well as schedules evaluation of a rule
 	// changing this value is discouraged because this could cause existing alert definition
 	// with intervals that are not exactly divided by this number not to be evaluated
@@ -68,6 +80,7 @@ type UnifiedAlertingSettings struct {
 	HAPeerTimeout                  time.Duration
 	HAGossipInterval               time.Duration
 	HAPushPullInterval             time.Duration
+	HAShardRuleEvaluation          bool
 	MaxAttempts                    int64
 	MinInterval                    time.Duration
 	EvaluationTimeout              time.Duration
@@ -81,6 +94,10 @@ type UnifiedAlertingSettings struct {
 	// DefaultRuleEvaluationInterval default interval between evaluations of a rule.
 	DefaultRuleEvaluationInterval time.Duration
 	Screenshots                   UnifiedAlertingScreenshotSettings
+	StateHistory                  UnifiedAlertingStateHistorySettings
+	DeliveryLog                   UnifiedAlertingDeliveryLogSettings
+	RecordingRules                UnifiedAlertingRecordingRulesSettings
+	Enrichment                    UnifiedAlertingEnrichmentSettings
 }
 
 type UnifiedAlertingScreenshotSettings struct {
@@ -89,6 +106,54 @@ type UnifiedAlertingScreenshotSettings struct {
 	UploadExternalImageStorage bool
 }
 
+type UnifiedAlertingStateHistorySettings struct {
+	Enabled bool
+	// Retention is how long transitions are kept before they are deleted. Zero means forever.
+	Retention time.Duration
+}
+
+type UnifiedAlertingDeliveryLogSettings struct {
+	Enabled bool
+	// Retention is how long deliveries are kept before they are deleted. Zero means forever.
+	Retention time.Duration
+	// RetryMaxAttempts is how many times a delivery that failed with a transient error is attempted
+	// by the retry queue. Zero disables the retry queue.
+	RetryMaxAttempts    int
+	RetryInitialBackoff time.Duration
+	RetryMaxBackoff     time.Duration
+}
+
+type UnifiedAlertingRecordingRulesSettings struct {
+	Enabled bool
+	// URL is the Prometheus remote write endpoint the results of recording rules are written to.
+	URL               string
+	BasicAuthUsername string
+	BasicAuthPassword string
+	Timeout           time.Duration
+}
+
+type UnifiedAlertingEnrichmentSettings struct {
+	Enabled bool
+	// Source is where the lookup table is read from: static, file or datasource.
+	Source string
+	// MatchLabels are the labels of the alerts whose values select the rows of the lookup table.
+	MatchLabels []string
+	// LabelColumns are the columns of the lookup table that are added as labels rather than annotations.
+	LabelColumns []string
+	// Overwrite allows the lookup table to replace the labels and annotations that the alerts already have.
+	Overwrite       bool
+	RefreshInterval time.Duration
+	// Static is the lookup table as a JSON array of objects.
+	Static string
+	// FilePath is the path of a CSV or JSON file in the Grafana storage.
+	FilePath string
+	// OrgID is the organization whose alerts are enriched, and of the file or the data source.
+	OrgID         int64
+	DatasourceUID string
+	// Query is the JSON model of the data source query, such as {"rawSql": "SELECT ...", "format": "table"}.
+	Query string
+}
+
 // IsEnabled returns true if UnifiedAlertingSettings.Enabled is either nil or true.
 // It hides the implementation details of the Enabled and simplifies its usage.
 func (u *UnifiedAlertingSettings) IsEnabled() bool {
@@ -182,6 +247,7 @@ func (cfg *Cfg) ReadUnifiedAlertingSettings(iniFile *ini.File) error {
 	if err != nil {
 		return err
 	}
+	uaCfg.HAShardRuleEvaluation = ua.Key("ha_shard_rule_evaluation").MustBool(false)
 	uaCfg.HAListenAddr = ua.Key("ha_listen_address").MustString(alertmanagerDefaultClusterAddr)
 	uaCfg.HAAdvertiseAddr = ua.Key("ha_advertise_address").MustString("")
 	peers := ua.Key("ha_peers").MustString("")
@@ -265,10 +331,114 @@ func (cfg *Cfg) ReadUnifiedAlertingSettings(iniFile *ini.File) error {
 	uaCfgScreenshots.UploadExternalImageStorage = screenshots.Key("upload_external_image_storage").MustBool(screenshotsDefaultUploadImageStorage)
 	uaCfg.Screenshots = uaCfgScreenshots
 
+	stateHistory := iniFile.Section("unified_alerting.state_history")
+	uaCfg.StateHistory.Enabled = stateHistory.Key("enabled").MustBool(stateHistoryDefaultEnabled)
+	uaCfg.StateHistory.Retention, err = gtime.ParseDuration(valueAsString(stateHistory, "retention", stateHistoryDefaultRetention.String()))
+	if err != nil {
+		return err
+	}
+	if uaCfg.StateHistory.Retention < 0 {
+		return fmt.Errorf("value of setting 'retention' in section 'unified_alerting.state_history' must not be negative")
+	}
+
+	deliveryLog := iniFile.Section("unified_alerting.delivery_log")
+	uaCfg.DeliveryLog.Enabled = deliveryLog.Key("enabled").MustBool(deliveryLogDefaultEnabled)
+	uaCfg.DeliveryLog.Retention, err = gtime.ParseDuration(valueAsString(deliveryLog, "retention", deliveryLogDefaultRetention.String()))
+	if err != nil {
+		return err
+	}
+	if uaCfg.DeliveryLog.Retention < 0 {
+		return fmt.Errorf("value of setting 'retention' in section 'unified_alerting.delivery_log' must not be negative")
+	}
+	uaCfg.DeliveryLog.RetryMaxAttempts = deliveryLog.Key("retry_max_attempts").MustInt(deliveryLogDefaultRetryMaxAttempts)
+	if uaCfg.DeliveryLog.RetryMaxAttempts < 0 {
+		return fmt.Errorf("value of setting 'retry_max_attempts' in section 'unified_alerting.delivery_log' must not be negative")
+	}
+	uaCfg.DeliveryLog.RetryInitialBackoff, err = gtime.ParseDuration(valueAsString(deliveryLog, "retry_initial_backoff", deliveryLogDefaultRetryInitialBackoff.String()))
+	if err != nil {
+		return err
+	}
+	uaCfg.DeliveryLog.RetryMaxBackoff, err = gtime.ParseDuration(valueAsString(deliveryLog, "retry_max_backoff", deliveryLogDefaultRetryMaxBackoff.String()))
+	if err != nil {
+		return err
+	}
+	if uaCfg.DeliveryLog.RetryInitialBackoff <= 0 || uaCfg.DeliveryLog.RetryMaxBackoff < uaCfg.DeliveryLog.RetryInitialBackoff {
+		return fmt.Errorf("value of setting 'retry_initial_backoff' in section 'unified_alerting.delivery_log' must be positive and not greater than 'retry_max_backoff'")
+	}
+
+	recordingRules := iniFile.Section("unified_alerting.recording_rules")
+	uaCfg.RecordingRules.Enabled = ownKeyAsBool(recordingRules, "enabled", recordingRulesDefaultEnabled)
+	uaCfg.RecordingRules.URL = valueAsString(recordingRules, "url", "")
+	uaCfg.RecordingRules.BasicAuthUsername = valueAsString(recordingRules, "basic_auth_username", "")
+	uaCfg.RecordingRules.BasicAuthPassword = valueAsString(recordingRules, "basic_auth_password", "")
+	uaCfg.RecordingRules.Timeout, err = gtime.ParseDuration(valueAsString(recordingRules, "timeout", recordingRulesDefaultTimeout.String()))
+	if err != nil {
+		return err
+	}
+	if uaCfg.RecordingRules.Enabled && uaCfg.RecordingRules.URL == "" {
+		return fmt.Errorf("setting 'url' in section 'unified_alerting.recording_rules' is required when recording rules are enabled")
+	}
+
+	enrichment := iniFile.Section("unified_alerting.enrichment")
+	uaCfg.Enrichment.Enabled = ownKeyAsBool(enrichment, "enabled", enrichmentDefaultEnabled)
+	uaCfg.Enrichment.Source = valueAsString(enrichment, "source", enrichmentDefaultSource)
+	uaCfg.Enrichment.MatchLabels = util.SplitString(valueAsString(enrichment, "match_labels", ""))
+	uaCfg.Enrichment.LabelColumns = util.SplitString(valueAsString(enrichment, "label_columns", ""))
+	uaCfg.Enrichment.Overwrite = enrichment.Key("overwrite").MustBool(false)
+	uaCfg.Enrichment.RefreshInterval, err = gtime.ParseDuration(valueAsString(enrichment, "refresh_interval", enrichmentDefaultRefreshInterval.String()))
+	if err != nil {
+		return err
+	}
+	uaCfg.Enrichment.Static = valueAsString(enrichment, "static", "")
+	uaCfg.Enrichment.FilePath = valueAsString(enrichment, "file_path", "")
+	uaCfg.Enrichment.OrgID = enrichment.Key("org_id").MustInt64(1)
+	uaCfg.Enrichment.DatasourceUID = valueAsString(enrichment, "datasource_uid", "")
+	uaCfg.Enrichment.Query = valueAsString(enrichment, "query", "")
+	if uaCfg.Enrichment.Enabled {
+		if err := validateEnrichmentSettings(uaCfg.Enrichment); err != nil {
+			return fmt.Errorf("invalid section 'unified_alerting.enrichment': %w", err)
+		}
+	}
+
 	cfg.UnifiedAlerting = uaCfg
 	return nil
 }
 
+func validateEnrichmentSettings(cfg UnifiedAlertingEnrichmentSettings) error {
+	if len(cfg.MatchLabels) == 0 {
+		return errors.New("setting 'match_labels' is required")
+	}
+	if cfg.RefreshInterval <= 0 {
+		return errors.New("value of setting 'refresh_interval' must be positive")
+	}
+	switch cfg.Source {
+	case "static":
+		if cfg.Static == "" {
+			return errors.New("setting 'static' is required by the static source")
+		}
+	case "file":
+		if cfg.FilePath == "" {
+			return errors.New("setting 'file_path' is required by the file source")
+		}
+	case "datasource":
+		if cfg.DatasourceUID == "" || cfg.Query == "" {
+			return errors.New("settings 'datasource_uid' and 'query' are required by the datasource source")
+		}
+	default:
+		return fmt.Errorf("unknown source %q, expected static, file or datasource", cfg.Source)
+	}
+	return nil
+}
+
 func GetAlertmanagerDefaultConfiguration() string {
 	return alertmanagerDefaultConfiguration
 }
+
+// ownKeyAsBool reads a boolean key of the section. Unlike section.Key, it does not fall back to the parent
+// section, so that e.g. [unified_alerting.recording_rules] does not inherit enabled from [unified_alerting].
+func ownKeyAsBool(section *ini.Section, keyName string, defaultValue bool) bool {
+	if _, ok := section.KeysHash()[keyName]; !ok {
+		return defaultValue
+	}
+	return section.Key(keyName).MustBool(defaultValue)
+}
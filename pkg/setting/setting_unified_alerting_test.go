@@ -266,3 +266,44 @@ func TestMinInterval(t *testing.T) {
 		})
 	}
 }
+
+func TestEnrichmentSettings(t *testing.T) {
+	t.Run("is not enabled by the enabled setting of unified alerting", func(t *testing.T) {
+		f := ini.Empty()
+		cfg := NewCfg()
+		cfg.IsFeatureToggleEnabled = func(key string) bool { return false }
+		unifiedAlertingSec, err := f.NewSection("unified_alerting")
+		require.NoError(t, err)
+		_, err = unifiedAlertingSec.NewKey("enabled", "true")
+		require.NoError(t, err)
+		_, err = f.NewSection("unified_alerting.enrichment")
+		require.NoError(t, err)
+
+		require.NoError(t, cfg.ReadUnifiedAlertingSettings(f))
+		require.False(t, cfg.UnifiedAlerting.Enrichment.Enabled)
+	})
+
+	t.Run("reads the enrichment section", func(t *testing.T) {
+		f := ini.Empty()
+		cfg := NewCfg()
+		cfg.IsFeatureToggleEnabled = func(key string) bool { return false }
+		enrichmentSec, err := f.NewSection("unified_alerting.enrichment")
+		require.NoError(t, err)
+		for k, v := range map[string]string{
+			"enabled":       "true",
+			"match_labels":  "service, env",
+			"label_columns": "team",
+			"org_id":        "2",
+			"static":        `[{"service": "api", "env": "prod", "team": "backend"}]`,
+		} {
+			_, err = enrichmentSec.NewKey(k, v)
+			require.NoError(t, err)
+		}
+
+		require.NoError(t, cfg.ReadUnifiedAlertingSettings(f))
+		require.True(t, cfg.UnifiedAlerting.Enrichment.Enabled)
+		require.Equal(t, []string{"service", "env"}, cfg.UnifiedAlerting.Enrichment.MatchLabels)
+		require.Equal(t, []string{"team"}, cfg.UnifiedAlerting.Enrichment.LabelColumns)
+		require.Equal(t, int64(2), cfg.UnifiedAlerting.Enrichment.OrgID)
+	})
+}
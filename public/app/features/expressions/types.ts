@@ -23,6 +23,15 @@ export const reducerTypes: Array<SelectableValue<string>> = [
   { value: ReducerID.sum, label: 'Sum', description: 'Get the sum of all values' },
   { value: ReducerID.count, label: 'Count', description: 'Get the number of values' },
   { value: ReducerID.last, label: 'Last', description: 'Get the last value' },
+  { value: 'first', label: 'First', description: 'Get the first value' },
+  { value: 'median', label: 'Median', description: 'Get the median value' },
+  { value: 'p95', label: '95th %', description: 'Get the 95th percentile, any pN such as p99.9 is also supported' },
+  { value: 'p99', label: '99th %', description: 'Get the 99th percentile, any pN such as p99.9 is also supported' },
+  { value: 'stddev', label: 'Standard deviation', description: 'Get the standard deviation of all values' },
+  { value: 'variance', label: 'Variance', description: 'Get the variance of all values' },
+  { value: 'range', label: 'Range', description: 'Get the difference between the maximum and minimum values' },
+  { value: 'diff', label: 'Difference', description: 'Get the difference between the last and first values' },
+  { value: 'count_non_null', label: 'Count non-null', description: 'Get the number of values that are not null' },
 ];
 
 export enum ReducerMode {